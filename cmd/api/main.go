@@ -16,6 +16,8 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/account"
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/config"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/elasticsearch"
@@ -23,7 +25,10 @@ import (
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/files"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/websocket"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction"
+	tranDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	transactionInfra "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/infrastructure"
 	"github.com/AguilaMike/Stori_Challenge_Go/pkg/api"
 	"github.com/AguilaMike/Stori_Challenge_Go/pkg/web"
 )
@@ -68,11 +73,51 @@ func main() {
 	}
 	emailSender := email.NewSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword)
 
+	merchantService, err := merchant.SetupMerchantDomain()
+	if err != nil {
+		log.Fatalf("Failed to set up merchant dictionary: %v", err)
+	}
+
 	accountService := account.SetupAccountDomain(pgDB, esClient, nc)
-	transactionService := transaction.SetupTransactionDomain(pgDB, esClient, nc, connGrpc, emailSender)
+	transactionService := transaction.SetupTransactionDomain(pgDB, esClient, nc, connGrpc, emailSender, merchantService, cfg.AuthorizationWindow, !cfg.SummaryFromTransactions)
+	ledgerService := transaction.SetupLedgerDomain(pgDB, nc)
+	transferService := transaction.SetupTransferDomain(pgDB, esClient, nc)
+	correctionService := transaction.SetupCorrectionDomain(pgDB, nc, merchantService)
+	refundService := transaction.SetupRefundDomain(pgDB, nc, cfg.RefundMatchWindow)
+	splitService := transaction.SetupSplitDomain(pgDB, nc)
+	annotationService := transaction.SetupAnnotationDomain(pgDB, nc)
+	installmentService := transaction.SetupInstallmentDomain(pgDB, nc)
+	accrualService := transaction.SetupAccrualDomain(pgDB, nc, accountDomain.AccrualPolicy{
+		APR:      cfg.CardAPR,
+		DayCount: cfg.CardDayCount,
+		LateFee:  cfg.LateFee,
+	})
+	blobStore, err := files.NewLocalBlobStore(cfg.BlobDir)
+	if err != nil {
+		log.Fatalf("Failed to set up blob store: %v", err)
+	}
+	disputeService := transaction.SetupDisputeDomain(pgDB, nc, blobStore, connGrpc, emailSender, tranDomain.DisputePolicy{
+		ProvisionalCreditAfter: cfg.DisputeCreditAfter,
+		ResolutionWindow:       cfg.DisputeDeadline,
+	})
+	rewardService, err := transaction.SetupRewardDomain(pgDB, nc, transactionInfra.NewJSONRewardProgramSource())
+	if err != nil {
+		log.Fatalf("Failed to set up rewards program: %v", err)
+	}
+	subscriptionService := transaction.SetupSubscriptionDomain(pgDB, nc)
+	budgetService := transaction.SetupBudgetDomain(pgDB, nc, connGrpc, emailSender)
+	goalService := transaction.SetupGoalDomain(pgDB, nc)
+	forecastService := transaction.SetupForecastDomain(pgDB, nc)
+	anomalyService := transaction.SetupAnomalyDomain(pgDB, nc, connGrpc, emailSender, tranDomain.AnomalyPolicy{
+		ZScore:          cfg.AnomalyZScore,
+		BurstWindow:     cfg.AnomalyBurstWindow,
+		BurstCount:      cfg.AnomalyBurstCount,
+		DuplicateWindow: cfg.AnomalyDuplicate,
+	})
+	analyticsService := analytics.SetupAnalyticsDomain(esClient, nc)
 
 	// Set up API HTTP router
-	apiMux := api.SetupHTTPRoutes(accountService, transactionService)
+	apiMux := api.SetupHTTPRoutes(accountService, transactionService, ledgerService, transferService, correctionService, refundService, splitService, annotationService, accrualService, installmentService, rewardService, disputeService, subscriptionService, anomalyService, budgetService, goalService, forecastService, merchantService, analyticsService, cfg.AdminAPIKey, nc)
 
 	// Configurar rutas web
 	templateDir := filepath.Join("web", "templates")
@@ -85,6 +130,9 @@ func main() {
 
 	// Inicializar el servicio de WebSocket
 	wsService := websocket.NewWebSocketService()
+	if err := wsService.Deliver(nc); err != nil {
+		log.Fatalf("Failed to subscribe to WebSocket updates: %v", err)
+	}
 
 	webMux, err := web.SetupWebRoutes(accountService, fileUploadService, wsService, pgDB, esClient, nc.GetConnection(), templateDir, staticDir)
 	if err != nil {
@@ -110,7 +158,7 @@ func main() {
 	}()
 
 	// Set up gRPC server
-	grpcServer := api.SetupGRPCServer(accountService, transactionService)
+	grpcServer := api.SetupGRPCServer(accountService, transactionService, transferService, correctionService, refundService, splitService, annotationService, disputeService, goalService, ledgerService, analyticsService, cfg.AdminAPIKey)
 
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
@@ -14,12 +14,19 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/account"
+	accountApp "github.com/AguilaMike/Stori_Challenge_Go/internal/account/application"
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/config"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/elasticsearch"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/email"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/files"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/websocket"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant"
+	merchantDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/infrastructure"
@@ -28,6 +35,41 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// authorizationExpiryInterval is how often pending authorizations older than
+// the authorization window are expired.
+const authorizationExpiryInterval = time.Hour
+
+// accrualCatchUpDays is how many past days each scheduled accrual run covers,
+// so that a missed run is caught up by the next one.
+const accrualCatchUpDays = 7
+
+// installmentPostingInterval is how often installments that fell due are
+// billed.
+const installmentPostingInterval = time.Hour
+
+// rewardInterval is how often purchases that have not earned points yet,
+// such as those sent through the API, earn them and voided purchases give
+// them back.
+const rewardInterval = time.Hour
+
+// disputeDeadlineInterval is how often disputes are checked for provisional
+// credits that fell due and resolution deadlines that passed.
+const disputeDeadlineInterval = time.Hour
+
+// subscriptionDetectionInterval is how often every account is scanned for
+// recurring charges, so that missing charges raise an alert even without a
+// new import.
+const subscriptionDetectionInterval = 24 * time.Hour
+
+// forecastInterval is how often every account with recent activity is
+// forecast again.
+const forecastInterval = 24 * time.Hour
+
+// summaryProjectionFlushInterval is how often the months of the monthly
+// summary projection still queued by writes are recomputed, for the
+// transaction events the worker missed.
+const summaryProjectionFlushInterval = time.Minute
+
 func main() {
 	// Load configuration
 	cfg, err := config.LoadConfig(".")
@@ -69,8 +111,43 @@ func main() {
 	transactionRepo := infrastructure.NewPostgresTransactionRepository(pgDB, nc)
 	transactionQueryRepo := infrastructure.NewElasticsearchTransactionRepository(esClient, nc, "transactions")
 
+	merchantService, err := merchant.SetupMerchantDomain()
+	if err != nil {
+		log.Fatalf("Failed to set up merchant dictionary: %v", err)
+	}
+
 	// Initialize service
-	transactionService := application.NewTransactionService(transactionRepo, transactionQueryRepo, connGrpc, emailSender)
+	transactionService := application.NewTransactionService(transactionRepo, transactionQueryRepo, connGrpc, emailSender, merchantService, cfg.AuthorizationWindow, !cfg.SummaryFromTransactions)
+	accountService := account.SetupAccountDomain(pgDB, esClient, nc)
+	refundService := transaction.SetupRefundDomain(pgDB, nc, cfg.RefundMatchWindow)
+	installmentService := transaction.SetupInstallmentDomain(pgDB, nc)
+	accrualService := transaction.SetupAccrualDomain(pgDB, nc, accountDomain.AccrualPolicy{
+		APR:      cfg.CardAPR,
+		DayCount: cfg.CardDayCount,
+		LateFee:  cfg.LateFee,
+	})
+	blobStore, err := files.NewLocalBlobStore(cfg.BlobDir)
+	if err != nil {
+		log.Fatalf("Failed to set up blob store: %v", err)
+	}
+	disputeService := transaction.SetupDisputeDomain(pgDB, nc, blobStore, connGrpc, emailSender, domain.DisputePolicy{
+		ProvisionalCreditAfter: cfg.DisputeCreditAfter,
+		ResolutionWindow:       cfg.DisputeDeadline,
+	})
+	rewardService, err := transaction.SetupRewardDomain(pgDB, nc, infrastructure.NewJSONRewardProgramSource())
+	if err != nil {
+		log.Fatalf("Failed to set up rewards program: %v", err)
+	}
+	subscriptionService := transaction.SetupSubscriptionDomain(pgDB, nc)
+	projectionService := transaction.SetupSummaryProjectionDomain(pgDB, nc)
+	forecastService := transaction.SetupForecastDomain(pgDB, nc)
+	budgetService := transaction.SetupBudgetDomain(pgDB, nc, connGrpc, emailSender)
+	anomalyService := transaction.SetupAnomalyDomain(pgDB, nc, connGrpc, emailSender, domain.AnomalyPolicy{
+		ZScore:          cfg.AnomalyZScore,
+		BurstWindow:     cfg.AnomalyBurstWindow,
+		BurstCount:      cfg.AnomalyBurstCount,
+		DuplicateWindow: cfg.AnomalyDuplicate,
+	})
 
 	// Initialize NATS client
 	natsClient, err := nats.NewNatsClient(cfg.NatsURL)
@@ -79,15 +156,48 @@ func main() {
 	}
 	defer natsClient.Close()
 
-	// Initialize WebSocket service
-	wsService := websocket.NewWebSocketService()
+	// Browsers connect to the API; relay the updates there over NATS
+	wsService := websocket.NewRelay(natsClient)
 
 	// Set up your worker logic here
-	err = setupWorkerTasks(natsClient, transactionService, wsService)
+	err = setupWorkerTasks(natsClient, transactionService, accountService, refundService, accrualService, rewardService, disputeService, subscriptionService, anomalyService, budgetService, projectionService, forecastService, wsService, cfg.ImportEmailLifetime)
 	if err != nil {
 		log.Fatalf("Failed to set up worker tasks: %v", err)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go runPeriodically(ctx, cfg.ReconcileInterval, func() {
+		reconcileBalances(ctx, accountService)
+	})
+	go runPeriodically(ctx, authorizationExpiryInterval, func() {
+		expireAuthorizations(ctx, transactionService)
+	})
+	go runPeriodically(ctx, installmentPostingInterval, func() {
+		postDueInstallments(ctx, installmentService)
+	})
+	go runPeriodically(ctx, cfg.AccrualInterval, func() {
+		yesterday := time.Now().UTC().AddDate(0, 0, -1)
+		runAccruals(ctx, accrualService, yesterday.AddDate(0, 0, 1-accrualCatchUpDays), yesterday)
+	})
+	go runPeriodically(ctx, rewardInterval, func() {
+		processRewards(ctx, rewardService)
+	})
+	go runPeriodically(ctx, disputeDeadlineInterval, func() {
+		processDisputeDeadlines(ctx, disputeService)
+	})
+	go runPeriodically(ctx, subscriptionDetectionInterval, func() {
+		detectSubscriptions(ctx, subscriptionService)
+	})
+	go runPeriodically(ctx, forecastInterval, func() {
+		runForecasts(ctx, forecastService)
+	})
+	go runPeriodically(ctx, summaryProjectionFlushInterval, func() {
+		if _, err := projectionService.Flush(ctx); err != nil {
+			log.Printf("Error refreshing summary projection: %v", err)
+		}
+	})
+
 	log.Println("Worker started successfully")
 
 	// Wait for interrupt signal to gracefully shut down the worker
@@ -101,7 +211,18 @@ func main() {
 func setupWorkerTasks(
 	natsClient *nats.NatsClient,
 	transactionService *application.TransactionService,
-	wsService *websocket.WebSocketService) error {
+	accountService *accountApp.AccountService,
+	refundService *application.RefundService,
+	accrualService *application.AccrualService,
+	rewardService *application.RewardService,
+	disputeService *application.DisputeService,
+	subscriptionService *application.SubscriptionService,
+	anomalyService *application.AnomalyService,
+	budgetService *application.BudgetService,
+	projectionService *application.SummaryProjectionService,
+	forecastService *application.ForecastService,
+	wsService *websocket.Relay,
+	lifetimeSummary bool) error {
 
 	_, err := natsClient.Subscribe("transaction.file.uploaded", func(data []byte) {
 		var fileInfo struct {
@@ -116,83 +237,512 @@ func setupWorkerTasks(
 			return
 		}
 
+		fileImport := domain.NewFileImport(fileInfo.UserID, fileInfo.FileName, int64(base64.StdEncoding.DecodedLen(len(fileInfo.FileContent))))
+
 		// Decodificar el contenido del archivo
 		decodedContent, err := base64.StdEncoding.DecodeString(fileInfo.FileContent)
 		if err != nil {
 			log.Printf("Error decoding file content: %v", err)
+			fileImport.Fail(err)
+			publishFileImport(natsClient, fileImport)
 			return
 		}
+		fileImport.FileSize = int64(len(decodedContent))
 
-		transactions, err := processTransactionFile(decodedContent, fileInfo.FileName, fileInfo.UserID)
+		transactions, rejected, err := processTransactionFile(decodedContent, fileInfo.FileName, fileInfo.UserID)
 		if err != nil {
 			log.Printf("Error processing transaction file: %v", err)
+			fileImport.Fail(err)
+			publishFileImport(natsClient, fileImport)
 			return
 		}
+		fileImport.RowCount = len(transactions) + len(rejected)
+		fileImport.RejectedCount = len(rejected)
 
 		ctx := context.Background()
-		err = transactionService.CreateBulkTransactions(ctx, transactions)
+		transactions, err = transactionService.ImportTransactions(ctx, fileImport, transactions)
 		if err != nil {
 			log.Printf("Error saving transactions: %v", err)
+			fileImport.Fail(err)
+			publishFileImport(natsClient, fileImport)
 			return
 		}
+		fileImport.Succeed(len(transactions))
+		publishFileImport(natsClient, fileImport)
 
-		summary, err := transactionService.GetTransactionSummary(ctx, fileInfo.UserID)
-		if err != nil {
-			log.Printf("Error getting transaction summary: %v", err)
-			return
+		if _, err := refundService.MatchRefunds(ctx, transactions); err != nil {
+			log.Printf("Error matching refunds: %v", err)
 		}
 
-		err = transactionService.SendSummaryEmail(ctx, summary, fileInfo.UserID)
-		if err != nil {
-			log.Printf("Error sending summary email: %v", err)
-			return
+		if _, err := rewardService.EarnRewards(ctx, transactions); err != nil {
+			log.Printf("Error earning rewards: %v", err)
+		}
+
+		if _, err := subscriptionService.Detect(ctx, fileInfo.UserID, time.Now().UTC()); err != nil {
+			log.Printf("Error detecting subscriptions: %v", err)
+		}
+
+		// The summary email is sent next; do not wait for the events
+		if err := projectionService.Refresh(ctx, transactions); err != nil {
+			log.Printf("Error refreshing summary projection: %v", err)
+		}
+
+		if _, err := forecastService.Run(ctx, fileInfo.UserID, time.Now().UTC()); err != nil {
+			log.Printf("Error forecasting account: %v", err)
+		}
+
+		summary := domain.SummarizeImport(fileImport, rejected, transactions)
+		if lifetimeSummary {
+			summary.Lifetime, err = transactionService.GetTransactionSummary(ctx, fileInfo.UserID, domain.SummaryOptions{})
+			if err != nil {
+				log.Printf("Error getting transaction summary: %v", err)
+			}
 		}
 
 		// Enviar actualización a través de WebSocket
 		updateMessage, _ := json.Marshal(map[string]interface{}{
-			"type":    "transaction_update",
+			"type":    "import_summary",
 			"summary": summary,
 		})
 		wsService.SendUpdate(fileInfo.UserID.String(), updateMessage)
 
+		err = transactionService.SendImportEmail(ctx, summary)
+		if err != nil {
+			log.Printf("Error sending import email: %v", err)
+			return
+		}
+
 		log.Printf("Successfully processed file %s for user %s", fileInfo.FileName, fileInfo.UserID)
 	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(merchantDomain.NormalizeRequestedEvent, func(data []byte) {
+		updated, err := transactionService.NormalizeMerchants(context.Background())
+		if err != nil {
+			log.Printf("Error normalizing merchants: %v", err)
+			return
+		}
+		log.Printf("Merchant normalization finished, %d transactions updated", updated)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.RefundMatchRequestedEvent, func(data []byte) {
+		linked, err := refundService.MatchAllRefunds(context.Background())
+		if err != nil {
+			log.Printf("Error matching refunds: %v", err)
+			return
+		}
+		log.Printf("Refund matching finished, %d refunds linked", linked)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(accountDomain.ReconcileRequestedEvent, func(data []byte) {
+		reconcileBalances(context.Background(), accountService)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(accountDomain.AccrualRunRequestedEvent, func(data []byte) {
+		var request struct {
+			From time.Time `json:"from"`
+			To   time.Time `json:"to"`
+		}
+		if err := json.Unmarshal(data, &request); err != nil {
+			log.Printf("Error unmarshaling accrual run request: %v", err)
+			return
+		}
+		runAccruals(context.Background(), accrualService, request.From, request.To)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.ForecastRunRequestedEvent, func(data []byte) {
+		var request struct {
+			AccountID uuid.UUID `json:"account_id"`
+		}
+		if err := json.Unmarshal(data, &request); err != nil {
+			log.Printf("Error unmarshaling forecast request: %v", err)
+			return
+		}
+		if _, err := forecastService.Run(context.Background(), request.AccountID, time.Now().UTC()); err != nil {
+			log.Printf("Error forecasting account: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.TransactionStatusChangedEvent, func(data []byte) {
+		var change domain.StatusChange
+		if err := json.Unmarshal(data, &change); err != nil {
+			log.Printf("Error unmarshaling status change: %v", err)
+			return
+		}
+
+		updateMessage, _ := json.Marshal(map[string]interface{}{
+			"type":   "transaction_status",
+			"change": change,
+		})
+		wsService.SendUpdate(change.AccountID.String(), updateMessage)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.DisputeUpdatedEvent, func(data []byte) {
+		var change domain.DisputeChange
+		if err := json.Unmarshal(data, &change); err != nil {
+			log.Printf("Error unmarshaling dispute change: %v", err)
+			return
+		}
+
+		updateMessage, _ := json.Marshal(map[string]interface{}{
+			"type":   "dispute_update",
+			"change": change,
+		})
+		wsService.SendUpdate(change.Dispute.AccountID.String(), updateMessage)
+
+		if err := disputeService.SendDisputeEmail(context.Background(), &change); err != nil {
+			log.Printf("Error sending dispute email: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.SubscriptionAlertCreatedEvent, func(data []byte) {
+		var alert domain.SubscriptionAlert
+		if err := json.Unmarshal(data, &alert); err != nil {
+			log.Printf("Error unmarshaling subscription alert: %v", err)
+			return
+		}
+
+		updateMessage, _ := json.Marshal(map[string]interface{}{
+			"type":  "subscription_alert",
+			"alert": alert,
+		})
+		wsService.SendUpdate(alert.AccountID.String(), updateMessage)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.TransactionCreatedEvent, func(data []byte) {
+		var transaction domain.Transaction
+		if err := json.Unmarshal(data, &transaction); err != nil {
+			log.Printf("Error unmarshaling transaction: %v", err)
+			return
+		}
+
+		ctx := context.Background()
+		if err := projectionService.Apply(ctx, &transaction); err != nil {
+			log.Printf("Error refreshing summary projection: %v", err)
+		}
+		if _, err := anomalyService.Score(ctx, &transaction); err != nil {
+			log.Printf("Error scoring transaction: %v", err)
+		}
+		if _, err := budgetService.Track(ctx, &transaction, time.Now().UTC()); err != nil {
+			log.Printf("Error tracking budgets: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// Settlements and amendments can raise the spend of the month, and
+	// splits move it across categories
+	for _, subject := range []string{domain.TransactionUpdatedEvent, domain.TransactionSplitEvent} {
+		_, err = natsClient.Subscribe(subject, func(data []byte) {
+			var transaction domain.Transaction
+			if err := json.Unmarshal(data, &transaction); err != nil {
+				log.Printf("Error unmarshaling transaction: %v", err)
+				return
+			}
+
+			ctx := context.Background()
+			if err := projectionService.Apply(ctx, &transaction); err != nil {
+				log.Printf("Error refreshing summary projection: %v", err)
+			}
+			if _, err := budgetService.Track(ctx, &transaction, time.Now().UTC()); err != nil {
+				log.Printf("Error tracking budgets: %v", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = natsClient.Subscribe(domain.SummaryRebuildRequestedEvent, func(data []byte) {
+		refreshed, err := projectionService.Rebuild(context.Background())
+		if err != nil {
+			log.Printf("Error rebuilding summary projection: %v", err)
+			return
+		}
+		log.Printf("Summary projection rebuilt, %d months refreshed", refreshed)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.TransactionAlertCreatedEvent, func(data []byte) {
+		var alert domain.TransactionAlert
+		if err := json.Unmarshal(data, &alert); err != nil {
+			log.Printf("Error unmarshaling transaction alert: %v", err)
+			return
+		}
+
+		updateMessage, _ := json.Marshal(map[string]interface{}{
+			"type":  "transaction_alert",
+			"alert": alert,
+		})
+		wsService.SendUpdate(alert.AccountID.String(), updateMessage)
+
+		if err := anomalyService.SendAlertEmail(context.Background(), &alert); err != nil {
+			log.Printf("Error sending transaction alert email: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.TransactionAlertReviewedEvent, func(data []byte) {
+		var alert domain.TransactionAlert
+		if err := json.Unmarshal(data, &alert); err != nil {
+			log.Printf("Error unmarshaling transaction alert: %v", err)
+			return
+		}
+
+		updateMessage, _ := json.Marshal(map[string]interface{}{
+			"type":  "transaction_alert_reviewed",
+			"alert": alert,
+		})
+		wsService.SendUpdate(alert.AccountID.String(), updateMessage)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.BudgetAlertCreatedEvent, func(data []byte) {
+		var alert domain.BudgetAlert
+		if err := json.Unmarshal(data, &alert); err != nil {
+			log.Printf("Error unmarshaling budget alert: %v", err)
+			return
+		}
+
+		updateMessage, _ := json.Marshal(map[string]interface{}{
+			"type":  "budget_alert",
+			"alert": alert,
+		})
+		wsService.SendUpdate(alert.AccountID.String(), updateMessage)
+
+		if err := budgetService.SendAlertEmail(context.Background(), &alert); err != nil {
+			log.Printf("Error sending budget alert email: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = natsClient.Subscribe(domain.TransferCompletedEvent, func(data []byte) {
+		var transfer domain.Transfer
+		if err := json.Unmarshal(data, &transfer); err != nil {
+			log.Printf("Error unmarshaling transfer: %v", err)
+			return
+		}
+
+		// Notify both sides of the transfer
+		updateMessage, _ := json.Marshal(map[string]interface{}{
+			"type":     "transfer_completed",
+			"transfer": transfer,
+		})
+		wsService.SendUpdate(transfer.SourceAccountID.String(), updateMessage)
+		wsService.SendUpdate(transfer.DestinationAccountID.String(), updateMessage)
+	})
 
 	return err
 }
 
-func processTransactionFile(content []byte, filename string, userID uuid.UUID) ([]*domain.Transaction, error) {
+// runPeriodically runs job every interval until ctx is cancelled. A zero
+// interval disables the job.
+func runPeriodically(ctx context.Context, interval time.Duration, job func()) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job()
+		}
+	}
+}
+
+func reconcileBalances(ctx context.Context, accountService *accountApp.AccountService) {
+	drifts, err := accountService.ReconcileBalances(ctx)
+	if err != nil {
+		log.Printf("Error reconciling account balances: %v", err)
+		return
+	}
+	log.Printf("Balance reconciliation finished, %d accounts corrected", len(drifts))
+}
+
+func expireAuthorizations(ctx context.Context, transactionService *application.TransactionService) {
+	expired, err := transactionService.ExpireAuthorizations(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("Error expiring authorizations: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("Authorization expiry finished, %d authorizations expired", expired)
+	}
+}
+
+func postDueInstallments(ctx context.Context, installmentService *application.InstallmentService) {
+	posted, err := installmentService.PostDueInstallments(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("Error posting installments: %v", err)
+		return
+	}
+	if posted > 0 {
+		log.Printf("Installment posting finished, %d installments posted", posted)
+	}
+}
+
+func runAccruals(ctx context.Context, accrualService *application.AccrualService, from, to time.Time) {
+	run, err := accrualService.RunAccruals(ctx, from, to, false)
+	if err != nil {
+		log.Printf("Error running accruals: %v", err)
+		return
+	}
+	log.Printf("Accrual run finished, %d accruals posted, %d already posted, %d mismatched",
+		run.Created, run.Existing, run.Mismatched)
+}
+
+func processRewards(ctx context.Context, rewardService *application.RewardService) {
+	earned, err := rewardService.EarnPending(ctx)
+	if err != nil {
+		log.Printf("Error earning rewards: %v", err)
+	}
+	reversed, err := rewardService.ClawBack(ctx)
+	if err != nil {
+		log.Printf("Error reversing rewards: %v", err)
+	}
+	if earned > 0 || reversed > 0 {
+		log.Printf("Rewards run finished, %d points earned, %d purchases reversed", earned, reversed)
+	}
+}
+
+func processDisputeDeadlines(ctx context.Context, disputeService *application.DisputeService) {
+	credited, resolved, err := disputeService.ProcessDeadlines(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("Error processing dispute deadlines: %v", err)
+	}
+	if credited > 0 || resolved > 0 {
+		log.Printf("Dispute deadlines processed, %d provisional credits granted, %d disputes resolved", credited, resolved)
+	}
+}
+
+func detectSubscriptions(ctx context.Context, subscriptionService *application.SubscriptionService) {
+	raised, err := subscriptionService.DetectAll(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("Error detecting subscriptions: %v", err)
+	}
+	if raised > 0 {
+		log.Printf("Subscription detection finished, %d alerts raised", raised)
+	}
+}
+
+func runForecasts(ctx context.Context, forecastService *application.ForecastService) {
+	forecast, err := forecastService.RunAll(ctx, time.Now().UTC())
+	if err != nil {
+		log.Printf("Error forecasting accounts: %v", err)
+	}
+	if forecast > 0 {
+		log.Printf("Forecast run finished, %d accounts forecast", forecast)
+	}
+}
+
+// publishFileImport reports the outcome of an import for the platform
+// analytics.
+func publishFileImport(natsClient *nats.NatsClient, fileImport *domain.FileImport) {
+	if err := natsClient.Publish(domain.FileImportedEvent, fileImport); err != nil {
+		log.Printf("Error publishing file import: %v", err)
+	}
+}
+
+// processTransactionFile reads the transactions of a file and the rows
+// that could not be read.
+func processTransactionFile(content []byte, filename string, userID uuid.UUID) ([]*domain.Transaction, []domain.RejectedRow, error) {
 	reader := csv.NewReader(strings.NewReader(string(content)))
+	reader.FieldsPerRecord = -1 // Description and status columns are optional
 	records, err := reader.ReadAll()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	fileHash := domain.HashFile(content)
 	var transactions []*domain.Transaction
+	var rejected []domain.RejectedRow
 	for i, record := range records {
 		if i == 0 {
 			continue // Skip header
 		}
-		if len(record) != 2 {
-			log.Printf("Skipping invalid record: %v", record)
+		reject := func(reason string) {
+			log.Printf("Skipping record %d: %s", i+1, reason)
+			rejected = append(rejected, domain.RejectedRow{Line: i + 1, Reason: reason})
+		}
+		if len(record) < 2 || len(record) > 4 {
+			reject(fmt.Sprintf("expected 2 to 4 columns, got %d", len(record)))
 			continue // Skip invalid records
 		}
 
 		date, err := time.Parse("2006-01-02", record[0])
 		if err != nil {
-			log.Printf("Invalid date format: %v", err)
+			reject(fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", record[0]))
 			continue // Skip invalid dates
 		}
 
 		amount, err := strconv.ParseFloat(record[1], 64)
 		if err != nil {
-			log.Printf("Invalid amount: %v", err)
+			reject(fmt.Sprintf("invalid amount %q", record[1]))
 			continue // Skip invalid amounts
 		}
 
-		transaction := domain.NewTransaction(userID, amount, filename, date)
+		description := ""
+		if len(record) >= 3 {
+			description = strings.TrimSpace(record[2])
+		}
+
+		status := domain.StatusPosted
+		if len(record) == 4 && strings.TrimSpace(record[3]) != "" {
+			status = strings.ToLower(strings.TrimSpace(record[3]))
+		}
+
+		var transaction *domain.Transaction
+		switch status {
+		case domain.StatusPosted:
+			transaction = domain.NewTransaction(userID, amount, description, filename, date)
+		case domain.StatusPending:
+			transaction = domain.NewAuthorization(userID, amount, description, filename, date)
+		default:
+			reject(fmt.Sprintf("invalid status %q", status))
+			continue // Skip invalid statuses
+		}
+		transaction.ImportKey = domain.ImportKey(fileHash, i+1)
 		transactions = append(transactions, transaction)
 	}
 
-	return transactions, nil
+	return transactions, rejected, nil
 }
@@ -2,6 +2,9 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -9,6 +12,8 @@ import (
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/account/ports"
 )
 
+const maxStatementCycles = 24
+
 type AccountService struct {
 	repo  ports.AccountRepository
 	query ports.AccountQueryRepository
@@ -30,6 +35,19 @@ func (s *AccountService) CreateAccount(ctx context.Context, nickname, email stri
 	return account, nil
 }
 
+// CreateCreditCardAccount creates a card account with the given credit
+// terms.
+func (s *AccountService) CreateCreditCardAccount(ctx context.Context, nickname, email string, terms domain.CardTerms) (*domain.Account, error) {
+	account, err := domain.NewCreditCardAccount(nickname, email, terms)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
 func (s *AccountService) GetAccount(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
 	return s.query.GetByID(ctx, id)
 }
@@ -49,3 +67,88 @@ func (s *AccountService) ListAccounts(ctx context.Context, limit, offset int64)
 func (s *AccountService) SearchAccounts(ctx context.Context, query string) ([]*domain.Account, error) {
 	return s.query.Search(ctx, query)
 }
+
+// GetCurrentStatement returns the statement of the last cycle the card
+// account closed, or nil for other accounts.
+func (s *AccountService) GetCurrentStatement(ctx context.Context, account *domain.Account) (*domain.CardStatement, error) {
+	if !account.IsCreditCard() {
+		return nil, nil
+	}
+	return s.statement(ctx, account, account.LastClosingDate(time.Now()))
+}
+
+// GetStatements returns the last cycles closed by a card account, newest
+// first.
+func (s *AccountService) GetStatements(ctx context.Context, id uuid.UUID, cycles int) ([]*domain.CardStatement, error) {
+	if cycles < 1 || cycles > maxStatementCycles {
+		return nil, domain.ErrInvalidCycleRequest
+	}
+
+	account, err := s.query.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, domain.ErrAccountNotFound
+	}
+	if !account.IsCreditCard() {
+		return nil, domain.ErrNotCreditCard
+	}
+
+	closing := account.LastClosingDate(time.Now())
+	statements := make([]*domain.CardStatement, 0, cycles)
+	for i := 0; i < cycles; i++ {
+		statement, err := s.statement(ctx, account, closing.AddDate(0, -i, 0))
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+func (s *AccountService) statement(ctx context.Context, account *domain.Account, closing time.Time) (*domain.CardStatement, error) {
+	start, end := account.StatementPeriod(closing)
+	activity, err := s.repo.GetStatementActivity(ctx, account.ID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement activity: %w", err)
+	}
+	return account.NewStatement(closing, activity), nil
+}
+
+// GetInstallmentBalance returns what is left to bill of the account's
+// installment plans.
+func (s *AccountService) GetInstallmentBalance(ctx context.Context, id uuid.UUID) (float64, error) {
+	return s.repo.GetInstallmentBalance(ctx, id)
+}
+
+// GetBalanceDrifts compares each stored balance with the sum of the account
+// ledger postings without modifying anything.
+func (s *AccountService) GetBalanceDrifts(ctx context.Context) ([]*domain.BalanceDrift, error) {
+	return s.repo.ListBalanceDrifts(ctx)
+}
+
+// ReconcileBalances recomputes every drifted balance from the ledger and
+// returns the drift that was corrected. Each account is checked again and
+// set under its row lock, so postings committed since the drift was listed
+// are kept.
+func (s *AccountService) ReconcileBalances(ctx context.Context) ([]*domain.BalanceDrift, error) {
+	candidates, err := s.repo.ListBalanceDrifts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list balance drifts: %w", err)
+	}
+
+	drifts := make([]*domain.BalanceDrift, 0, len(candidates))
+	for _, c := range candidates {
+		d, err := s.repo.ReconcileBalance(ctx, c.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconcile account %s: %w", c.AccountID, err)
+		}
+		if d == nil {
+			continue
+		}
+		log.Printf("Balance drift on account %s: stored %.2f, ledger %.2f (drift %.2f)", d.AccountID, d.Balance, d.LedgerBalance, d.Drift)
+		drifts = append(drifts, d)
+	}
+	return drifts, nil
+}
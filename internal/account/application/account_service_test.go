@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/account/ports"
+)
+
+// fakeAccountRepository lists the candidates of reconcile and answers each
+// ReconcileBalance with what the locked re-check found.
+type fakeAccountRepository struct {
+	ports.AccountRepository
+	candidates []*domain.BalanceDrift
+	reconciled map[uuid.UUID]*domain.BalanceDrift
+	err        error
+	calls      []uuid.UUID
+}
+
+func (r *fakeAccountRepository) ListBalanceDrifts(ctx context.Context) ([]*domain.BalanceDrift, error) {
+	return r.candidates, nil
+}
+
+func (r *fakeAccountRepository) ReconcileBalance(ctx context.Context, id uuid.UUID) (*domain.BalanceDrift, error) {
+	r.calls = append(r.calls, id)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.reconciled[id], nil
+}
+
+func TestReconcileBalances(t *testing.T) {
+	drifted, settled := uuid.New(), uuid.New()
+	candidates := []*domain.BalanceDrift{
+		{AccountID: drifted, Balance: 110, LedgerBalance: 100, Drift: 10},
+		{AccountID: settled, Balance: 50, LedgerBalance: 40, Drift: 10},
+	}
+
+	tests := []struct {
+		name       string
+		reconciled map[uuid.UUID]*domain.BalanceDrift
+		err        error
+		want       []uuid.UUID
+		wantErr    bool
+	}{
+		{
+			name: "corrects every drifted account",
+			reconciled: map[uuid.UUID]*domain.BalanceDrift{
+				drifted: {AccountID: drifted, Balance: 110, LedgerBalance: 100, Drift: 10},
+				settled: {AccountID: settled, Balance: 50, LedgerBalance: 40, Drift: 10},
+			},
+			want: []uuid.UUID{drifted, settled},
+		},
+		{
+			// A posting committed after the listing brought the balance
+			// back in line with the ledger
+			name: "skips accounts that no longer drift",
+			reconciled: map[uuid.UUID]*domain.BalanceDrift{
+				drifted: {AccountID: drifted, Balance: 110, LedgerBalance: 100, Drift: 10},
+			},
+			want: []uuid.UUID{drifted},
+		},
+		{
+			name:    "stops on the first error",
+			err:     errors.New("connection reset"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAccountRepository{candidates: candidates, reconciled: tt.reconciled, err: tt.err}
+			service := NewAccountService(repo, nil)
+
+			drifts, err := service.ReconcileBalances(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(repo.calls) != 1 {
+					t.Fatalf("reconciled %d accounts after the error, want 1", len(repo.calls))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(repo.calls) != len(candidates) {
+				t.Fatalf("reconciled %d accounts, want every candidate", len(repo.calls))
+			}
+			if len(drifts) != len(tt.want) {
+				t.Fatalf("got %d drifts, want %d", len(drifts), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if drifts[i].AccountID != id {
+					t.Errorf("drift %d is for account %s, want %s", i, drifts[i].AccountID, id)
+				}
+			}
+		})
+	}
+}
@@ -7,15 +7,33 @@ import (
 )
 
 type Account struct {
-	ID        uuid.UUID
-	Nickname  string
-	Email     string
-	Balance   float64
-	CreatedAt int64
-	UpdatedAt int64
-	Active    bool
+	ID                  uuid.UUID
+	Nickname            string
+	Email               string
+	Balance             float64
+	Type                string // "debit" or "credit_card"
+	CreditLimit         float64
+	StatementClosingDay int // day of the month the card statement closes
+	PaymentDueDays      int // days from the closing date to the payment due date
+	CreatedAt           int64
+	UpdatedAt           int64
+	Active              bool
 }
 
+// BalanceDrift reports an account whose stored balance differs from the sum
+// of its ledger postings.
+type BalanceDrift struct {
+	AccountID     uuid.UUID
+	Balance       float64
+	LedgerBalance float64
+	Drift         float64
+}
+
+const (
+	AccountUpdatedEvent     = "account.updated"
+	ReconcileRequestedEvent = "account.reconcile.requested"
+)
+
 func NewAccount(nickname, email string) *Account {
 	now := time.Now().UTC().Unix()
 	return &Account{
@@ -23,6 +41,7 @@ func NewAccount(nickname, email string) *Account {
 		Nickname:  nickname,
 		Email:     email,
 		Balance:   0,
+		Type:      AccountTypeDebit,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Active:    true,
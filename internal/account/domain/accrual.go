@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AccrualInterest = "interest"
+	AccrualLateFee  = "late_fee"
+
+	DayCountActual365    = "actual/365"
+	DayCountActual360    = "actual/360"
+	DayCountActualActual = "actual/actual"
+
+	AccrualRunRequestedEvent = "account.accruals.run.requested"
+
+	// maxAccrualRangeDays bounds a single run so that a typo in a date range
+	// does not recompute years of history.
+	maxAccrualRangeDays = 366
+)
+
+var (
+	ErrInvalidAPR          = errors.New("APR must be zero or positive")
+	ErrInvalidDayCount     = errors.New("day count must be actual/365, actual/360 or actual/actual")
+	ErrInvalidLateFee      = errors.New("late fee must be zero or positive")
+	ErrInvalidAccrualRange = errors.New("accrual range must be ordered, end before today and span at most 366 days")
+	ErrAccrualPosted       = errors.New("accrual already posted")
+)
+
+// AccrualPolicy holds the pricing used to accrue interest and late fees.
+type AccrualPolicy struct {
+	APR      float64 // annual rate, e.g. 0.6 for 60%
+	DayCount string
+	LateFee  float64
+}
+
+func (p AccrualPolicy) Validate() error {
+	if p.APR < 0 || math.IsNaN(p.APR) || math.IsInf(p.APR, 0) {
+		return ErrInvalidAPR
+	}
+	if p.LateFee < 0 || math.IsNaN(p.LateFee) || math.IsInf(p.LateFee, 0) {
+		return ErrInvalidLateFee
+	}
+	switch p.DayCount {
+	case DayCountActual365, DayCountActual360, DayCountActualActual:
+		return nil
+	default:
+		return ErrInvalidDayCount
+	}
+}
+
+// DailyRate is the share of the APR charged for day under the day-count
+// convention.
+func (p AccrualPolicy) DailyRate(day time.Time) float64 {
+	switch p.DayCount {
+	case DayCountActual360:
+		return p.APR / 360
+	case DayCountActualActual:
+		year := day.Year()
+		days := time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC).Sub(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)).Hours() / 24
+		return p.APR / days
+	default:
+		return p.APR / 365
+	}
+}
+
+// ValidateAccrualRange checks that [from, to] are whole days before today.
+func ValidateAccrualRange(from, to, today time.Time) error {
+	if to.Before(from) || !to.Before(today) || to.Sub(from).Hours()/24 >= maxAccrualRangeDays {
+		return ErrInvalidAccrualRange
+	}
+	return nil
+}
+
+// DailyActivity is the posted activity of an account on one value date.
+type DailyActivity struct {
+	Date    time.Time
+	Debits  float64 // negative
+	Credits float64
+}
+
+// AccountActivity is the posted activity of an account by value date. It is
+// the only input of the accrual calculation, which keeps runs reproducible.
+type AccountActivity struct {
+	Days []DailyActivity
+}
+
+// Add records an amount posted on day, e.g. a charge accrued during a run.
+func (a *AccountActivity) Add(day time.Time, amount float64) {
+	activity := DailyActivity{Date: day}
+	if amount < 0 {
+		activity.Debits = amount
+	} else {
+		activity.Credits = amount
+	}
+	a.Days = append(a.Days, activity)
+}
+
+// BalanceBefore is the account balance at the start of day.
+func (a *AccountActivity) BalanceBefore(day time.Time) float64 {
+	var balance float64
+	for _, d := range a.Days {
+		if d.Date.Before(day) {
+			balance += d.Debits + d.Credits
+		}
+	}
+	return balance
+}
+
+// CreditsBetween sums the credits with a value date in [start, end).
+func (a *AccountActivity) CreditsBetween(start, end time.Time) float64 {
+	var credits float64
+	for _, d := range a.Days {
+		if !d.Date.Before(start) && d.Date.Before(end) {
+			credits += d.Credits
+		}
+	}
+	return credits
+}
+
+// Statement rebuilds the activity of the cycle [start, end).
+func (a *AccountActivity) Statement(start, end time.Time) StatementActivity {
+	activity := StatementActivity{OpeningBalance: a.BalanceBefore(start)}
+	for _, d := range a.Days {
+		if !d.Date.Before(start) && d.Date.Before(end) {
+			activity.Debits += d.Debits
+			activity.Credits += d.Credits
+		}
+	}
+	return activity
+}
+
+// Accrual is an interest charge or late fee assessed on a card account.
+type Accrual struct {
+	ID            uuid.UUID
+	AccountID     uuid.UUID
+	Kind          string // "interest" or "late_fee"
+	AccrualDate   time.Time
+	StatementDate time.Time // closing date of the statement that triggered it
+	Basis         float64   // balance charged interest, or unpaid minimum payment
+	DailyRate     float64   // zero for late fees
+	Amount        float64   // positive charge
+	TransactionID uuid.UUID
+	CreatedAt     int64
+}
+
+// AccrualLine reports one accrual of a run. Existing accruals are not posted
+// again; PostedAmount differs from Amount when the recomputation no longer
+// matches what was posted.
+type AccrualLine struct {
+	Accrual      *Accrual
+	Existing     bool
+	PostedAmount float64
+}
+
+// Mismatched reports whether an existing accrual was posted with a different
+// amount than the one recomputed now.
+func (l AccrualLine) Mismatched() bool {
+	return l.Existing && math.Round(l.PostedAmount*100) != math.Round(l.Accrual.Amount*100)
+}
+
+type AccrualRun struct {
+	From       time.Time
+	To         time.Time
+	DryRun     bool
+	Lines      []AccrualLine
+	Created    int
+	Existing   int
+	Mismatched int
+}
+
+// Accrue computes the charges of a card account for day:
+//
+//   - a late fee the day after a due date when the payments received since
+//     the statement closed are below its minimum payment;
+//   - daily interest on the balance owed at the start of the day while the
+//     last statement due was not paid in full by its due date.
+func (a *Account) Accrue(policy AccrualPolicy, activity *AccountActivity, day time.Time) []*Accrual {
+	if !a.IsCreditCard() || a.StatementClosingDay == 0 {
+		return nil
+	}
+
+	// Find the last statement whose due date has passed
+	closing := a.LastClosingDate(day)
+	for !closing.AddDate(0, 0, a.PaymentDueDays).Before(day) {
+		closing = closing.AddDate(0, -1, 0)
+	}
+	start, end := a.StatementPeriod(closing)
+	statement := a.NewStatement(closing, activity.Statement(start, end))
+	dueDate := statement.DueDate
+	paid := roundCents(activity.CreditsBetween(end, dueDate.AddDate(0, 0, 1)))
+
+	var accruals []*Accrual
+	if dueDate.AddDate(0, 0, 1).Equal(day) && policy.LateFee > 0 && paid < statement.MinimumPayment {
+		accruals = append(accruals, a.newAccrual(AccrualLateFee, day, closing, statement.MinimumPayment-paid, 0, policy.LateFee))
+	}
+
+	if statement.StatementBalance > 0 && paid < statement.StatementBalance {
+		owed := roundCents(-activity.BalanceBefore(day))
+		rate := policy.DailyRate(day)
+		if interest := roundCents(owed * rate); interest > 0 {
+			accruals = append(accruals, a.newAccrual(AccrualInterest, day, closing, owed, rate, interest))
+		}
+	}
+	return accruals
+}
+
+func (a *Account) newAccrual(kind string, day, statementDate time.Time, basis, rate, amount float64) *Accrual {
+	return &Accrual{
+		ID:            uuid.New(),
+		AccountID:     a.ID,
+		Kind:          kind,
+		AccrualDate:   day,
+		StatementDate: statementDate,
+		Basis:         roundCents(basis),
+		DailyRate:     rate,
+		Amount:        roundCents(amount),
+		CreatedAt:     time.Now().UTC().Unix(),
+	}
+}
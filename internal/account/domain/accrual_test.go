@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDailyRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy AccrualPolicy
+		day    time.Time
+		want   float64
+	}{
+		{name: "actual/365", policy: AccrualPolicy{APR: 0.365, DayCount: DayCountActual365}, day: date(2024, 3, 1), want: 0.001},
+		{name: "actual/360", policy: AccrualPolicy{APR: 0.36, DayCount: DayCountActual360}, day: date(2024, 3, 1), want: 0.001},
+		{name: "actual/actual in a leap year", policy: AccrualPolicy{APR: 0.366, DayCount: DayCountActualActual}, day: date(2024, 12, 31), want: 0.001},
+		{name: "actual/actual in a common year", policy: AccrualPolicy{APR: 0.365, DayCount: DayCountActualActual}, day: date(2023, 1, 1), want: 0.001},
+		{name: "no APR", policy: AccrualPolicy{DayCount: DayCountActual360}, day: date(2024, 3, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.DailyRate(tt.day); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("DailyRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccrue(t *testing.T) {
+	policy := AccrualPolicy{APR: 0.365, DayCount: DayCountActual365, LateFee: 350}
+
+	// The cycle from February 11 to March 10 closes with 1000 owed, due on
+	// March 30 with a minimum payment of 100
+	purchase := DailyActivity{Date: date(2024, 2, 20), Debits: -1000}
+	payment := func(amount float64) DailyActivity {
+		return DailyActivity{Date: date(2024, 3, 20), Credits: amount}
+	}
+
+	type accrual struct {
+		kind   string
+		basis  float64
+		amount float64
+	}
+	tests := []struct {
+		name     string
+		debit    bool
+		activity []DailyActivity
+		day      time.Time
+		want     []accrual
+	}{
+		{
+			name:     "nothing paid the day after the due date",
+			activity: []DailyActivity{purchase},
+			day:      date(2024, 3, 31),
+			want:     []accrual{{AccrualLateFee, 100, 350}, {AccrualInterest, 1000, 1}},
+		},
+		{
+			name:     "minimum paid",
+			activity: []DailyActivity{purchase, payment(150)},
+			day:      date(2024, 3, 31),
+			want:     []accrual{{AccrualInterest, 850, 0.85}},
+		},
+		{
+			name:     "paid in full",
+			activity: []DailyActivity{purchase, payment(1000)},
+			day:      date(2024, 3, 31),
+		},
+		{
+			name:     "interest keeps accruing after the due date",
+			activity: []DailyActivity{purchase},
+			day:      date(2024, 4, 5),
+			want:     []accrual{{AccrualInterest, 1000, 1}},
+		},
+		{
+			name:     "before the due date",
+			activity: []DailyActivity{purchase},
+			day:      date(2024, 3, 25),
+		},
+		{
+			name:     "debit account",
+			debit:    true,
+			activity: []DailyActivity{purchase},
+			day:      date(2024, 3, 31),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := NewCreditCardAccount("card", "user@stori.mx", CardTerms{
+				CreditLimit: 5000, StatementClosingDay: 10, PaymentDueDays: 20,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.debit {
+				account = NewAccount("debit", "user@stori.mx")
+			}
+
+			accruals := account.Accrue(policy, &AccountActivity{Days: tt.activity}, tt.day)
+			if len(accruals) != len(tt.want) {
+				t.Fatalf("got %d accruals, want %d", len(accruals), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := accruals[i]
+				if got.Kind != want.kind || got.Basis != want.basis || got.Amount != want.amount {
+					t.Errorf("accrual %d = %s of %.2f on %.2f, want %s of %.2f on %.2f", i, got.Kind, got.Amount, got.Basis, want.kind, want.amount, want.basis)
+				}
+				if !got.StatementDate.Equal(date(2024, 3, 10)) {
+					t.Errorf("accrual %d for the statement of %s, want 2024-03-10", i, got.StatementDate.Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AccountTypeDebit      = "debit"
+	AccountTypeCreditCard = "credit_card"
+
+	DefaultPaymentDueDays = 20
+
+	// The minimum payment is a share of the statement balance with a floor,
+	// never more than the balance itself.
+	minimumPaymentRate  = 0.05
+	minimumPaymentFloor = 100.0
+
+	// Closing days are limited to 28 so that every month has one.
+	maxStatementClosingDay = 28
+	maxPaymentDueDays      = 60
+)
+
+var (
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrInvalidAccountType  = errors.New("account type must be debit or credit_card")
+	ErrInvalidCreditLimit  = errors.New("credit limit must be greater than zero")
+	ErrInvalidClosingDay   = errors.New("statement closing day must be between 1 and 28")
+	ErrInvalidPaymentDays  = errors.New("payment due days must be between 1 and 60")
+	ErrNotCreditCard       = errors.New("account is not a credit card")
+	ErrInvalidCycleRequest = errors.New("the number of cycles must be between 1 and 24")
+)
+
+// CardTerms are the credit conditions of a card account.
+type CardTerms struct {
+	CreditLimit         float64
+	StatementClosingDay int
+	PaymentDueDays      int
+}
+
+// StatementActivity is the posted activity of an account around a cycle:
+// the balance before it starts and its debits and credits.
+type StatementActivity struct {
+	OpeningBalance float64
+	Debits         float64 // negative
+	Credits        float64
+}
+
+// CardStatement is one closed billing cycle of a card account. Amounts
+// owed are positive.
+type CardStatement struct {
+	AccountID        uuid.UUID
+	PeriodStart      time.Time
+	ClosingDate      time.Time
+	DueDate          time.Time
+	OpeningBalance   float64
+	Purchases        float64
+	Payments         float64
+	StatementBalance float64
+	MinimumPayment   float64
+}
+
+// NewCreditCardAccount creates a card account. A zero PaymentDueDays uses
+// the default.
+func NewCreditCardAccount(nickname, email string, terms CardTerms) (*Account, error) {
+	if terms.PaymentDueDays == 0 {
+		terms.PaymentDueDays = DefaultPaymentDueDays
+	}
+	if terms.CreditLimit <= 0 || math.IsNaN(terms.CreditLimit) || math.IsInf(terms.CreditLimit, 0) {
+		return nil, ErrInvalidCreditLimit
+	}
+	if terms.StatementClosingDay < 1 || terms.StatementClosingDay > maxStatementClosingDay {
+		return nil, ErrInvalidClosingDay
+	}
+	if terms.PaymentDueDays < 1 || terms.PaymentDueDays > maxPaymentDueDays {
+		return nil, ErrInvalidPaymentDays
+	}
+
+	account := NewAccount(nickname, email)
+	account.Type = AccountTypeCreditCard
+	account.CreditLimit = terms.CreditLimit
+	account.StatementClosingDay = terms.StatementClosingDay
+	account.PaymentDueDays = terms.PaymentDueDays
+	return account, nil
+}
+
+// IsCreditCard reports whether the account is a card account. Accounts
+// created before account types existed are debit accounts.
+func (a *Account) IsCreditCard() bool {
+	return a.Type == AccountTypeCreditCard
+}
+
+// AvailableCredit is the part of the credit limit not used by the current
+// balance. A balance in the customer's favour adds to it.
+func (a *Account) AvailableCredit() float64 {
+	if !a.IsCreditCard() {
+		return 0
+	}
+	return math.Max(0, roundCents(a.CreditLimit+a.Balance))
+}
+
+// LastClosingDate is the closing date of the most recent cycle closed at
+// now. A cycle closes at the end of its closing day.
+func (a *Account) LastClosingDate(now time.Time) time.Time {
+	now = now.UTC()
+	closing := time.Date(now.Year(), now.Month(), a.StatementClosingDay, 0, 0, 0, 0, time.UTC)
+	if now.Before(closing.AddDate(0, 0, 1)) {
+		closing = closing.AddDate(0, -1, 0)
+	}
+	return closing
+}
+
+// NextClosingDate is the closing date of the cycle open at now.
+func (a *Account) NextClosingDate(now time.Time) time.Time {
+	return a.LastClosingDate(now).AddDate(0, 1, 0)
+}
+
+// CycleStart is the first day of the billing cycle containing date. Debit
+// accounts use calendar months.
+func (a *Account) CycleStart(date time.Time) time.Time {
+	if !a.IsCreditCard() || a.StatementClosingDay == 0 {
+		date = date.UTC()
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return a.LastClosingDate(date).AddDate(0, 0, 1)
+}
+
+// StatementPeriod returns the half-open range of value dates covered by the
+// cycle closing on closing.
+func (a *Account) StatementPeriod(closing time.Time) (start, end time.Time) {
+	return closing.AddDate(0, -1, 1), closing.AddDate(0, 0, 1)
+}
+
+// NewStatement builds the statement of the cycle closing on closing from its
+// activity.
+func (a *Account) NewStatement(closing time.Time, activity StatementActivity) *CardStatement {
+	start, _ := a.StatementPeriod(closing)
+	balance := roundCents(-(activity.OpeningBalance + activity.Debits + activity.Credits))
+
+	return &CardStatement{
+		AccountID:        a.ID,
+		PeriodStart:      start,
+		ClosingDate:      closing,
+		DueDate:          closing.AddDate(0, 0, a.PaymentDueDays),
+		OpeningBalance:   roundCents(-activity.OpeningBalance),
+		Purchases:        roundCents(-activity.Debits),
+		Payments:         roundCents(activity.Credits),
+		StatementBalance: balance,
+		MinimumPayment:   minimumPayment(balance),
+	}
+}
+
+func minimumPayment(balance float64) float64 {
+	if balance <= 0 {
+		return 0
+	}
+	return math.Min(balance, math.Max(minimumPaymentFloor, roundCents(balance*minimumPaymentRate)))
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -3,7 +3,9 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -15,25 +17,31 @@ import (
 
 type PostgresAccountRepository struct {
 	queries *sqlc.Queries
+	db      *sql.DB
 	nats    *nats.NatsClient
 }
 
 func NewPostgresAccountRepository(db *sql.DB, nc *nats.NatsClient) ports.AccountRepository {
 	return &PostgresAccountRepository{
 		queries: sqlc.New(db),
+		db:      db,
 		nats:    nc,
 	}
 }
 
 func (r *PostgresAccountRepository) Create(ctx context.Context, account *domain.Account) error {
 	_, err := r.queries.CreateAccount(ctx, sqlc.CreateAccountParams{
-		ID:        account.ID,
-		Nickname:  account.Nickname,
-		Email:     account.Email,
-		Balance:   strconv.FormatFloat(account.Balance, 'f', -1, 64),
-		CreatedAt: account.CreatedAt,
-		UpdatedAt: account.UpdatedAt,
-		Active:    account.Active,
+		ID:                  account.ID,
+		Nickname:            account.Nickname,
+		Email:               account.Email,
+		Balance:             strconv.FormatFloat(account.Balance, 'f', -1, 64),
+		CreatedAt:           account.CreatedAt,
+		UpdatedAt:           account.UpdatedAt,
+		Active:              account.Active,
+		Type:                account.Type,
+		CreditLimit:         strconv.FormatFloat(account.CreditLimit, 'f', 2, 64),
+		StatementClosingDay: int32(account.StatementClosingDay),
+		PaymentDueDays:      int32(account.PaymentDueDays),
 	})
 	if err != nil {
 		return err
@@ -70,6 +78,145 @@ func (r *PostgresAccountRepository) Delete(ctx context.Context, id uuid.UUID) er
 	return r.publishEvent("account.deleted", map[string]string{"id": id.String()})
 }
 
+func (r *PostgresAccountRepository) ListBalanceDrifts(ctx context.Context) ([]*domain.BalanceDrift, error) {
+	rows, err := r.queries.ListAccountBalanceDrifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := make([]*domain.BalanceDrift, 0, len(rows))
+	for _, row := range rows {
+		balance, err := strconv.ParseFloat(row.Balance, 64)
+		if err != nil {
+			return nil, err
+		}
+		ledgerBalance, err := strconv.ParseFloat(row.LedgerBalance, 64)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, &domain.BalanceDrift{
+			AccountID:     row.ID,
+			Balance:       balance,
+			LedgerBalance: ledgerBalance,
+			Drift:         balance - ledgerBalance,
+		})
+	}
+	return drifts, nil
+}
+
+// ReconcileBalance sets the balance of the account to the sum of its ledger
+// postings while holding the account row, so no posting can commit between
+// the read and the write. It returns nil when nothing drifted.
+func (r *PostgresAccountRepository) ReconcileBalance(ctx context.Context, id uuid.UUID) (*domain.BalanceDrift, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	locked, err := qtx.GetAccountForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := strconv.ParseFloat(locked.Balance, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := qtx.ReconcileAccountBalance(ctx, sqlc.ReconcileAccountBalanceParams{
+		ID:        id,
+		UpdatedAt: time.Now().UTC().Unix(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	account, err := toDomainAccount(row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Publish event to NATS
+	if err := r.publishEvent(domain.AccountUpdatedEvent, account); err != nil {
+		return nil, err
+	}
+	return &domain.BalanceDrift{
+		AccountID:     id,
+		Balance:       balance,
+		LedgerBalance: account.Balance,
+		Drift:         balance - account.Balance,
+	}, nil
+}
+
+// GetStatementActivity sums the posted, non-voided transactions of the
+// account before start and between start and end.
+func (r *PostgresAccountRepository) GetStatementActivity(ctx context.Context, id uuid.UUID, start, end time.Time) (domain.StatementActivity, error) {
+	row, err := r.queries.GetAccountStatementActivity(ctx, sqlc.GetAccountStatementActivityParams{
+		AccountID:   id,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	})
+	if err != nil {
+		return domain.StatementActivity{}, err
+	}
+
+	var activity domain.StatementActivity
+	if activity.OpeningBalance, err = strconv.ParseFloat(row.OpeningBalance, 64); err != nil {
+		return domain.StatementActivity{}, err
+	}
+	if activity.Debits, err = strconv.ParseFloat(row.Debits, 64); err != nil {
+		return domain.StatementActivity{}, err
+	}
+	if activity.Credits, err = strconv.ParseFloat(row.Credits, 64); err != nil {
+		return domain.StatementActivity{}, err
+	}
+	return activity, nil
+}
+
+// GetInstallmentBalance sums the installments of the account's plans that
+// are not billed yet.
+func (r *PostgresAccountRepository) GetInstallmentBalance(ctx context.Context, id uuid.UUID) (float64, error) {
+	remaining, err := r.queries.GetAccountInstallmentBalance(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(remaining, 64)
+}
+
+func toDomainAccount(row sqlc.Account) (*domain.Account, error) {
+	balance, err := strconv.ParseFloat(row.Balance, 64)
+	if err != nil {
+		return nil, err
+	}
+	creditLimit, err := strconv.ParseFloat(row.CreditLimit, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Account{
+		ID:                  row.ID,
+		Nickname:            row.Nickname,
+		Email:               row.Email,
+		Balance:             balance,
+		Type:                row.Type,
+		CreditLimit:         creditLimit,
+		StatementClosingDay: int(row.StatementClosingDay),
+		PaymentDueDays:      int(row.PaymentDueDays),
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           row.UpdatedAt,
+		Active:              row.Active,
+	}, nil
+}
+
 func (r *PostgresAccountRepository) publishEvent(subject string, payload interface{}) error {
 	return r.nats.Publish(subject, payload)
 }
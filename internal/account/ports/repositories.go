@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,6 +13,10 @@ type AccountRepository interface {
 	Create(ctx context.Context, account *domain.Account) error
 	Update(ctx context.Context, account *domain.Account) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	ListBalanceDrifts(ctx context.Context) ([]*domain.BalanceDrift, error)
+	ReconcileBalance(ctx context.Context, id uuid.UUID) (*domain.BalanceDrift, error)
+	GetStatementActivity(ctx context.Context, id uuid.UUID, start, end time.Time) (domain.StatementActivity, error)
+	GetInstallmentBalance(ctx context.Context, id uuid.UUID) (float64, error)
 }
 
 type AccountQueryRepository interface {
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/ports"
+)
+
+// AnalyticsService aggregates the activity of every account for operations.
+type AnalyticsService struct {
+	repo ports.AnalyticsRepository
+}
+
+func NewAnalyticsService(repo ports.AnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{repo: repo}
+}
+
+// GetActivity returns the active accounts and the transaction volume and
+// value, in total and per day, from from to to, excluded.
+func (s *AnalyticsService) GetActivity(ctx context.Context, from, to time.Time) (*domain.Activity, error) {
+	if err := domain.ValidateRange(from, to); err != nil {
+		return nil, err
+	}
+	activity, err := s.repo.GetActivity(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate activity: %w", err)
+	}
+	return activity, nil
+}
+
+// GetImportStats returns the success rate and file sizes of the imports
+// processed from from to to, excluded.
+func (s *AnalyticsService) GetImportStats(ctx context.Context, from, to time.Time) (*domain.ImportStats, error) {
+	if err := domain.ValidateRange(from, to); err != nil {
+		return nil, err
+	}
+	stats, err := s.repo.GetImportStats(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate imports: %w", err)
+	}
+	return stats, nil
+}
+
+// GetBalanceDistribution returns how the current balances of the active
+// accounts spread.
+func (s *AnalyticsService) GetBalanceDistribution(ctx context.Context) (*domain.BalanceDistribution, error) {
+	distribution, err := s.repo.GetBalanceDistribution(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate balances: %w", err)
+	}
+	return distribution, nil
+}
+
+// ListTopMerchants returns the merchants with the most purchases across all
+// accounts from from to to, excluded.
+func (s *AnalyticsService) ListTopMerchants(ctx context.Context, from, to time.Time, limit int) ([]domain.TopMerchant, error) {
+	if err := domain.ValidateRange(from, to); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > domain.MaxTopMerchants {
+		return nil, domain.ErrInvalidTopLimit
+	}
+	merchants, err := s.repo.ListTopMerchants(ctx, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate merchants: %w", err)
+	}
+	return merchants, nil
+}
@@ -0,0 +1,14 @@
+package analytics
+
+import (
+	"github.com/olivere/elastic/v7"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/infrastructure"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+)
+
+func SetupAnalyticsDomain(esClient *elastic.Client, nc *nats.NatsClient) *application.AnalyticsService {
+	repo := infrastructure.NewElasticsearchAnalyticsRepository(esClient, nc, "transactions", "accounts", "imports")
+	return application.NewAnalyticsService(repo)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// FileImportedEvent is published by the worker after each import, with
+	// the fields of Import.
+	FileImportedEvent = "transaction.file.imported"
+
+	ImportSucceeded = "succeeded"
+	ImportFailed    = "failed"
+
+	// MaxRangeDays bounds the range of the platform analytics.
+	MaxRangeDays = 366
+
+	DefaultTopMerchants = 10
+	MaxTopMerchants     = 100
+)
+
+// BalanceBoundaries split the balances of the accounts into the buckets of
+// their distribution; the first and last buckets are open.
+var BalanceBoundaries = []float64{0, 1000, 5000, 10000, 50000, 100000}
+
+// BalancePercentiles are the percentiles of the balance distribution.
+var BalancePercentiles = []float64{25, 50, 75, 90, 99}
+
+var (
+	ErrInvalidRange    = errors.New("analytics range must end after it starts")
+	ErrRangeTooLong    = fmt.Errorf("analytics range spans more than %d days", MaxRangeDays)
+	ErrInvalidTopLimit = fmt.Errorf("limit must be between 1 and %d", MaxTopMerchants)
+)
+
+// Import is the outcome of processing an uploaded file, as reported by the
+// worker.
+type Import struct {
+	ID               uuid.UUID
+	AccountID        uuid.UUID
+	FileName         string
+	FileSize         int64
+	Status           string
+	TransactionCount int
+	Error            string `json:",omitempty"`
+	ProcessedAt      time.Time
+}
+
+// Activity is the transaction activity of all accounts from From to To,
+// excluded. Pending, reversed, expired and voided transactions are left
+// out; debits are negative.
+type Activity struct {
+	From             time.Time
+	To               time.Time
+	ActiveAccounts   int64 // accounts with at least one transaction
+	TransactionCount int64
+	TotalCredit      float64
+	TotalDebit       float64
+	Days             []DailyActivity // every day of the range
+}
+
+// DailyActivity is the transaction volume and value of a day.
+type DailyActivity struct {
+	Date             time.Time
+	ActiveAccounts   int64
+	TransactionCount int64
+	Credit           float64
+	Debit            float64
+}
+
+// ImportStats sums up the imports processed from From to To, excluded.
+type ImportStats struct {
+	From             time.Time
+	To               time.Time
+	Total            int64
+	Succeeded        int64
+	Failed           int64
+	SuccessRate      float64 // share of succeeded imports, 0 without imports
+	AverageFileSize  float64 // bytes
+	TotalFileSize    int64
+	TransactionCount int64 // imported by the succeeded imports
+}
+
+// BalanceDistribution is how the balances of the active accounts spread.
+type BalanceDistribution struct {
+	AccountCount int64
+	Total        float64
+	Average      float64
+	Min          float64
+	Max          float64
+	Percentiles  []BalancePercentile
+	Buckets      []BalanceBucket
+}
+
+type BalancePercentile struct {
+	Percentile float64
+	Balance    float64
+}
+
+// BalanceBucket counts the accounts with a balance from From, included, to
+// To, excluded. A nil bound is open.
+type BalanceBucket struct {
+	From         *float64
+	To           *float64
+	AccountCount int64
+}
+
+// TopMerchant is the spend at a merchant across all accounts; Total is
+// negative.
+type TopMerchant struct {
+	Merchant         string
+	TransactionCount int64
+	AccountCount     int64
+	Total            float64
+}
+
+// ValidateRange checks an analytics range of whole days.
+func ValidateRange(from, to time.Time) error {
+	if !to.After(from) {
+		return ErrInvalidRange
+	}
+	if to.Sub(from) > MaxRangeDays*24*time.Hour {
+		return ErrRangeTooLong
+	}
+	return nil
+}
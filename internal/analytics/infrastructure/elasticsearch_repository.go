@@ -0,0 +1,301 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/ports"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+)
+
+const esDateFormat = "yyyy-MM-dd"
+
+// unsettledStatuses are the statuses of the transactions that never moved
+// money, or not yet.
+var unsettledStatuses = []interface{}{"pending", "reversed", "expired"}
+
+// ElasticsearchAnalyticsRepository aggregates the read models of all the
+// accounts. It also indexes the imports reported by the worker.
+type ElasticsearchAnalyticsRepository struct {
+	client       *elastic.Client
+	transactions string
+	accounts     string
+	imports      string
+	nats         *nats.NatsClient
+}
+
+func NewElasticsearchAnalyticsRepository(client *elastic.Client, nc *nats.NatsClient, transactions, accounts, imports string) ports.AnalyticsRepository {
+	repo := &ElasticsearchAnalyticsRepository{
+		client:       client,
+		transactions: transactions,
+		accounts:     accounts,
+		imports:      imports,
+		nats:         nc,
+	}
+	repo.subscribeToEvents()
+	return repo
+}
+
+func (r *ElasticsearchAnalyticsRepository) subscribeToEvents() {
+	r.nats.Subscribe(domain.FileImportedEvent, r.handleFileImported)
+}
+
+func (r *ElasticsearchAnalyticsRepository) handleFileImported(data []byte) {
+	var fileImport domain.Import
+	if err := json.Unmarshal(data, &fileImport); err != nil {
+		log.Printf("Error unmarshaling file import: %v", err)
+		return
+	}
+
+	_, err := r.client.Index().
+		Index(r.imports).
+		Id(fileImport.ID.String()).
+		BodyJson(fileImport).
+		Do(context.Background())
+	if err != nil {
+		log.Printf("Error indexing file import: %v", err)
+	}
+}
+
+// settledQuery matches the transactions of every account from from to to,
+// excluded, that moved money, in their current version.
+func settledQuery(from, to time.Time) *elastic.BoolQuery {
+	return elastic.NewBoolQuery().
+		Filter(elastic.NewRangeQuery("InputDate").Gte(from).Lt(to)).
+		MustNot(
+			elastic.NewTermQuery("Voided", true),
+			elastic.NewExistsQuery("SupersededBy"),
+			elastic.NewTermsQuery("Status.keyword", unsettledStatuses...),
+		)
+}
+
+func (r *ElasticsearchAnalyticsRepository) GetActivity(ctx context.Context, from, to time.Time) (*domain.Activity, error) {
+	days := elastic.NewDateHistogramAggregation().
+		Field("InputDate").
+		CalendarInterval("day").
+		Format(esDateFormat).
+		MinDocCount(0).
+		ExtendedBounds(from.Format(time.DateOnly), to.AddDate(0, 0, -1).Format(time.DateOnly))
+	for name, agg := range volumeAggregations() {
+		days.SubAggregation(name, agg)
+	}
+
+	search := r.client.Search().
+		Index(r.transactions).
+		Query(settledQuery(from, to)).
+		Size(0).
+		TrackTotalHits(true).
+		Aggregation("days", days)
+	for name, agg := range volumeAggregations() {
+		search.Aggregation(name, agg)
+	}
+	result, err := search.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error executing Elasticsearch query: %v", err)
+	}
+
+	activity := &domain.Activity{
+		From:             from,
+		To:               to,
+		TransactionCount: result.TotalHits(),
+	}
+	activity.ActiveAccounts, activity.TotalCredit, activity.TotalDebit = volume(result.Aggregations)
+
+	histogram, ok := result.Aggregations.DateHistogram("days")
+	if !ok {
+		return activity, nil
+	}
+	activity.Days = make([]domain.DailyActivity, 0, len(histogram.Buckets))
+	for _, bucket := range histogram.Buckets {
+		day := domain.DailyActivity{
+			Date:             time.UnixMilli(int64(bucket.Key)).UTC(),
+			TransactionCount: bucket.DocCount,
+		}
+		day.ActiveAccounts, day.Credit, day.Debit = volume(bucket.Aggregations)
+		activity.Days = append(activity.Days, day)
+	}
+	return activity, nil
+}
+
+// volumeAggregations count the active accounts and sum the credits and
+// debits of the transactions they run on.
+func volumeAggregations() map[string]elastic.Aggregation {
+	return map[string]elastic.Aggregation{
+		"accounts": elastic.NewCardinalityAggregation().Field("AccountID.keyword"),
+		"credit": elastic.NewFilterAggregation().
+			Filter(elastic.NewRangeQuery("Amount").Gt(0)).
+			SubAggregation("value", elastic.NewSumAggregation().Field("Amount")),
+		"debit": elastic.NewFilterAggregation().
+			Filter(elastic.NewRangeQuery("Amount").Lt(0)).
+			SubAggregation("value", elastic.NewSumAggregation().Field("Amount")),
+	}
+}
+
+// volume reads the aggregations of volumeAggregations.
+func volume(aggs elastic.Aggregations) (accounts int64, credit, debit float64) {
+	if metric, ok := aggs.Cardinality("accounts"); ok && metric.Value != nil {
+		accounts = int64(*metric.Value)
+	}
+	if bucket, ok := aggs.Filter("credit"); ok {
+		credit = sumValue(bucket.Aggregations, "value")
+	}
+	if bucket, ok := aggs.Filter("debit"); ok {
+		debit = sumValue(bucket.Aggregations, "value")
+	}
+	return accounts, roundCents(credit), roundCents(debit)
+}
+
+func (r *ElasticsearchAnalyticsRepository) GetImportStats(ctx context.Context, from, to time.Time) (*domain.ImportStats, error) {
+	result, err := r.client.Search().
+		Index(r.imports).
+		Query(elastic.NewRangeQuery("ProcessedAt").Gte(from).Lt(to)).
+		Size(0).
+		TrackTotalHits(true).
+		Aggregation("status", elastic.NewTermsAggregation().Field("Status.keyword")).
+		Aggregation("size", elastic.NewStatsAggregation().Field("FileSize")).
+		Aggregation("transactions", elastic.NewFilterAggregation().
+			Filter(elastic.NewTermQuery("Status.keyword", domain.ImportSucceeded)).
+			SubAggregation("value", elastic.NewSumAggregation().Field("TransactionCount"))).
+		Do(ctx)
+	if err != nil {
+		// Nothing was imported yet
+		if elastic.IsNotFound(err) {
+			return &domain.ImportStats{From: from, To: to}, nil
+		}
+		return nil, fmt.Errorf("error executing Elasticsearch query: %v", err)
+	}
+
+	stats := &domain.ImportStats{From: from, To: to, Total: result.TotalHits()}
+	if terms, ok := result.Aggregations.Terms("status"); ok {
+		for _, bucket := range terms.Buckets {
+			switch bucket.Key {
+			case domain.ImportSucceeded:
+				stats.Succeeded = bucket.DocCount
+			case domain.ImportFailed:
+				stats.Failed = bucket.DocCount
+			}
+		}
+	}
+	if stats.Total > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(stats.Total)
+	}
+	if size, ok := result.Aggregations.Stats("size"); ok {
+		if size.Avg != nil {
+			stats.AverageFileSize = roundCents(*size.Avg)
+		}
+		if size.Sum != nil {
+			stats.TotalFileSize = int64(*size.Sum)
+		}
+	}
+	if bucket, ok := result.Aggregations.Filter("transactions"); ok {
+		stats.TransactionCount = int64(sumValue(bucket.Aggregations, "value"))
+	}
+	return stats, nil
+}
+
+func (r *ElasticsearchAnalyticsRepository) GetBalanceDistribution(ctx context.Context) (*domain.BalanceDistribution, error) {
+	buckets := elastic.NewRangeAggregation().Field("Balance")
+	bounds := domain.BalanceBoundaries
+	buckets.AddUnboundedFrom(bounds[0])
+	for i := 1; i < len(bounds); i++ {
+		buckets.AddRange(bounds[i-1], bounds[i])
+	}
+	buckets.AddUnboundedTo(bounds[len(bounds)-1])
+
+	result, err := r.client.Search().
+		Index(r.accounts).
+		Query(elastic.NewTermQuery("Active", true)).
+		Size(0).
+		Aggregation("stats", elastic.NewStatsAggregation().Field("Balance")).
+		Aggregation("percentiles", elastic.NewPercentilesAggregation().Field("Balance").Percentiles(domain.BalancePercentiles...)).
+		Aggregation("buckets", buckets).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error executing Elasticsearch query: %v", err)
+	}
+
+	distribution := &domain.BalanceDistribution{}
+	if stats, ok := result.Aggregations.Stats("stats"); ok {
+		distribution.AccountCount = stats.Count
+		if stats.Count > 0 {
+			distribution.Total = roundCents(*stats.Sum)
+			distribution.Average = roundCents(*stats.Avg)
+			distribution.Min = roundCents(*stats.Min)
+			distribution.Max = roundCents(*stats.Max)
+		}
+	}
+	if percentiles, ok := result.Aggregations.Percentiles("percentiles"); ok && distribution.AccountCount > 0 {
+		for _, p := range domain.BalancePercentiles {
+			distribution.Percentiles = append(distribution.Percentiles, domain.BalancePercentile{
+				Percentile: p,
+				Balance:    roundCents(percentiles.Values[fmt.Sprintf("%.1f", p)]),
+			})
+		}
+	}
+	if ranges, ok := result.Aggregations.Range("buckets"); ok {
+		for _, bucket := range ranges.Buckets {
+			distribution.Buckets = append(distribution.Buckets, domain.BalanceBucket{
+				From:         bucket.From,
+				To:           bucket.To,
+				AccountCount: bucket.DocCount,
+			})
+		}
+	}
+	return distribution, nil
+}
+
+func (r *ElasticsearchAnalyticsRepository) ListTopMerchants(ctx context.Context, from, to time.Time, limit int) ([]domain.TopMerchant, error) {
+	query := settledQuery(from, to).
+		Filter(elastic.NewRangeQuery("Amount").Lt(0), elastic.NewExistsQuery("Merchant")).
+		MustNot(elastic.NewTermQuery("Merchant.keyword", ""))
+
+	result, err := r.client.Search().
+		Index(r.transactions).
+		Query(query).
+		Size(0).
+		Aggregation("merchants", elastic.NewTermsAggregation().
+			Field("Merchant.keyword").
+			Size(limit).
+			SubAggregation("accounts", elastic.NewCardinalityAggregation().Field("AccountID.keyword")).
+			SubAggregation("total", elastic.NewSumAggregation().Field("Amount"))).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error executing Elasticsearch query: %v", err)
+	}
+
+	terms, ok := result.Aggregations.Terms("merchants")
+	if !ok {
+		return nil, nil
+	}
+	merchants := make([]domain.TopMerchant, 0, len(terms.Buckets))
+	for _, bucket := range terms.Buckets {
+		merchant := domain.TopMerchant{
+			Merchant:         fmt.Sprint(bucket.Key),
+			TransactionCount: bucket.DocCount,
+			Total:            roundCents(sumValue(bucket.Aggregations, "total")),
+		}
+		if metric, ok := bucket.Aggregations.Cardinality("accounts"); ok && metric.Value != nil {
+			merchant.AccountCount = int64(*metric.Value)
+		}
+		merchants = append(merchants, merchant)
+	}
+	return merchants, nil
+}
+
+func sumValue(aggs elastic.Aggregations, name string) float64 {
+	if metric, ok := aggs.Sum(name); ok && metric.Value != nil {
+		return *metric.Value
+	}
+	return 0
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/domain"
+)
+
+type AnalyticsRepository interface {
+	GetActivity(ctx context.Context, from, to time.Time) (*domain.Activity, error)
+	GetImportStats(ctx context.Context, from, to time.Time) (*domain.ImportStats, error)
+	GetBalanceDistribution(ctx context.Context) (*domain.BalanceDistribution, error)
+	ListTopMerchants(ctx context.Context, from, to time.Time, limit int) ([]domain.TopMerchant, error)
+}
@@ -2,29 +2,47 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	DatabaseURL      string `mapstructure:"DATABASE_URL"`
-	DatabaseHost     string `mapstructure:"DATABASE_HOST"`
-	DatabasePort     string `mapstructure:"DATABASE_PORT"`
-	DatabaseUser     string `mapstructure:"DATABASE_USER"`
-	DatabasePassword string `mapstructure:"DATABASE_PASSWORD"`
-	DatabaseName     string `mapstructure:"DATABASE_NAME"`
-	DatabaseSllMode  string `mapstructure:"DATABASE_SSLMODE"`
-	DatabaseMaxOpen  int    `mapstructure:"DATABASE_MAX_CONNECTIONS"`
-	DatabaseMaxIdle  int    `mapstructure:"DATABASE_MAX_IDLE_CONNECTIONS"`
-	ElasticsearchURL string `mapstructure:"ELASTICSEARCH_URL"`
-	NatsURL          string `mapstructure:"NATS_URL"`
-	SMTPHost         string `mapstructure:"SMTP_HOST"`
-	SMTPPort         string `mapstructure:"SMTP_PORT"`
-	SMTPUser         string `mapstructure:"SMTP_USER"`
-	SMTPPassword     string `mapstructure:"SMTP_PASSWORD"`
-	APIPort          string `mapstructure:"API_PORT"`
-	GRPCPort         string `mapstructure:"GRPC_PORT"`
-	DOMAIN           string `mapstructure:"DOMAIN"`
+	DatabaseURL             string        `mapstructure:"DATABASE_URL"`
+	DatabaseHost            string        `mapstructure:"DATABASE_HOST"`
+	DatabasePort            string        `mapstructure:"DATABASE_PORT"`
+	DatabaseUser            string        `mapstructure:"DATABASE_USER"`
+	DatabasePassword        string        `mapstructure:"DATABASE_PASSWORD"`
+	DatabaseName            string        `mapstructure:"DATABASE_NAME"`
+	DatabaseSllMode         string        `mapstructure:"DATABASE_SSLMODE"`
+	DatabaseMaxOpen         int           `mapstructure:"DATABASE_MAX_CONNECTIONS"`
+	DatabaseMaxIdle         int           `mapstructure:"DATABASE_MAX_IDLE_CONNECTIONS"`
+	ElasticsearchURL        string        `mapstructure:"ELASTICSEARCH_URL"`
+	NatsURL                 string        `mapstructure:"NATS_URL"`
+	SMTPHost                string        `mapstructure:"SMTP_HOST"`
+	SMTPPort                string        `mapstructure:"SMTP_PORT"`
+	SMTPUser                string        `mapstructure:"SMTP_USER"`
+	SMTPPassword            string        `mapstructure:"SMTP_PASSWORD"`
+	APIPort                 string        `mapstructure:"API_PORT"`
+	GRPCPort                string        `mapstructure:"GRPC_PORT"`
+	DOMAIN                  string        `mapstructure:"DOMAIN"`
+	ReconcileInterval       time.Duration `mapstructure:"RECONCILE_INTERVAL"`
+	RefundMatchWindow       time.Duration `mapstructure:"REFUND_MATCH_WINDOW"`
+	AuthorizationWindow     time.Duration `mapstructure:"AUTHORIZATION_WINDOW"`
+	CardAPR                 float64       `mapstructure:"CARD_APR"`
+	CardDayCount            string        `mapstructure:"CARD_DAY_COUNT"`
+	LateFee                 float64       `mapstructure:"LATE_FEE"`
+	AccrualInterval         time.Duration `mapstructure:"ACCRUAL_INTERVAL"`
+	BlobDir                 string        `mapstructure:"BLOB_DIR"`
+	DisputeCreditAfter      time.Duration `mapstructure:"DISPUTE_PROVISIONAL_CREDIT_AFTER"`
+	DisputeDeadline         time.Duration `mapstructure:"DISPUTE_RESOLUTION_WINDOW"`
+	AnomalyZScore           float64       `mapstructure:"ANOMALY_ZSCORE"`
+	AnomalyBurstWindow      time.Duration `mapstructure:"ANOMALY_BURST_WINDOW"`
+	AnomalyBurstCount       int           `mapstructure:"ANOMALY_BURST_COUNT"`
+	AnomalyDuplicate        time.Duration `mapstructure:"ANOMALY_DUPLICATE_WINDOW"`
+	SummaryFromTransactions bool          `mapstructure:"SUMMARY_FROM_TRANSACTIONS"`
+	AdminAPIKey             string        `mapstructure:"ADMIN_API_KEY"`
+	ImportEmailLifetime     bool          `mapstructure:"IMPORT_EMAIL_LIFETIME_SUMMARY"`
 }
 
 func (v *Config) GetConnectionString() string {
@@ -7,24 +7,62 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (id, nickname, email, balance, created_at, updated_at, active)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, nickname, email, balance, created_at, updated_at, active
+const adjustAccountBalance = `-- name: AdjustAccountBalance :one
+UPDATE accounts
+SET balance = balance + $2, updated_at = $3
+WHERE id = $1
+RETURNING id, nickname, email, balance, created_at, updated_at, active, type, credit_limit, statement_closing_day, payment_due_days
 `
 
-type CreateAccountParams struct {
+type AdjustAccountBalanceParams struct {
 	ID        uuid.UUID `json:"id"`
-	Nickname  string    `json:"nickname"`
-	Email     string    `json:"email"`
 	Balance   string    `json:"balance"`
-	CreatedAt int64     `json:"created_at"`
 	UpdatedAt int64     `json:"updated_at"`
-	Active    bool      `json:"active"`
+}
+
+func (q *Queries) AdjustAccountBalance(ctx context.Context, arg AdjustAccountBalanceParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, adjustAccountBalance, arg.ID, arg.Balance, arg.UpdatedAt)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Nickname,
+		&i.Email,
+		&i.Balance,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Active,
+		&i.Type,
+		&i.CreditLimit,
+		&i.StatementClosingDay,
+		&i.PaymentDueDays,
+	)
+	return i, err
+}
+
+const createAccount = `-- name: CreateAccount :one
+INSERT INTO accounts (id, nickname, email, balance, created_at, updated_at, active,
+    type, credit_limit, statement_closing_day, payment_due_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, nickname, email, balance, created_at, updated_at, active, type, credit_limit, statement_closing_day, payment_due_days
+`
+
+type CreateAccountParams struct {
+	ID                  uuid.UUID `json:"id"`
+	Nickname            string    `json:"nickname"`
+	Email               string    `json:"email"`
+	Balance             string    `json:"balance"`
+	CreatedAt           int64     `json:"created_at"`
+	UpdatedAt           int64     `json:"updated_at"`
+	Active              bool      `json:"active"`
+	Type                string    `json:"type"`
+	CreditLimit         string    `json:"credit_limit"`
+	StatementClosingDay int32     `json:"statement_closing_day"`
+	PaymentDueDays      int32     `json:"payment_due_days"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
@@ -36,6 +74,10 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.Active,
+		arg.Type,
+		arg.CreditLimit,
+		arg.StatementClosingDay,
+		arg.PaymentDueDays,
 	)
 	var i Account
 	err := row.Scan(
@@ -46,6 +88,10 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Active,
+		&i.Type,
+		&i.CreditLimit,
+		&i.StatementClosingDay,
+		&i.PaymentDueDays,
 	)
 	return i, err
 }
@@ -62,7 +108,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, nickname, email, balance, created_at, updated_at, active FROM accounts
+SELECT id, nickname, email, balance, created_at, updated_at, active, type, credit_limit, statement_closing_day, payment_due_days FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -77,12 +123,114 @@ func (q *Queries) GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Active,
+		&i.Type,
+		&i.CreditLimit,
+		&i.StatementClosingDay,
+		&i.PaymentDueDays,
+	)
+	return i, err
+}
+
+const getAccountForUpdate = `-- name: GetAccountForUpdate :one
+SELECT id, nickname, email, balance, created_at, updated_at, active, type, credit_limit, statement_closing_day, payment_due_days FROM accounts
+WHERE id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetAccountForUpdate(ctx context.Context, id uuid.UUID) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountForUpdate, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Nickname,
+		&i.Email,
+		&i.Balance,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Active,
+		&i.Type,
+		&i.CreditLimit,
+		&i.StatementClosingDay,
+		&i.PaymentDueDays,
 	)
 	return i, err
 }
 
+const getAccountStatementActivity = `-- name: GetAccountStatementActivity :one
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE input_date < $1), 0)::numeric AS opening_balance,
+    COALESCE(SUM(amount) FILTER (WHERE input_date >= $1 AND amount < 0), 0)::numeric AS debits,
+    COALESCE(SUM(amount) FILTER (WHERE input_date >= $1 AND amount > 0), 0)::numeric AS credits
+FROM transactions
+WHERE account_id = $2
+    AND status = 'posted'
+    AND NOT voided AND superseded_by IS NULL
+    AND input_date < $3
+`
+
+type GetAccountStatementActivityParams struct {
+	PeriodStart time.Time `json:"period_start"`
+	AccountID   uuid.UUID `json:"account_id"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+type GetAccountStatementActivityRow struct {
+	OpeningBalance string `json:"opening_balance"`
+	Debits         string `json:"debits"`
+	Credits        string `json:"credits"`
+}
+
+func (q *Queries) GetAccountStatementActivity(ctx context.Context, arg GetAccountStatementActivityParams) (GetAccountStatementActivityRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountStatementActivity, arg.PeriodStart, arg.AccountID, arg.PeriodEnd)
+	var i GetAccountStatementActivityRow
+	err := row.Scan(&i.OpeningBalance, &i.Debits, &i.Credits)
+	return i, err
+}
+
+const listAccountBalanceDrifts = `-- name: ListAccountBalanceDrifts :many
+SELECT
+    a.id,
+    a.balance,
+    COALESCE(SUM(p.amount), 0)::numeric AS ledger_balance
+FROM accounts a
+LEFT JOIN ledger_accounts la ON la.account_id = a.id
+LEFT JOIN postings p ON p.ledger_account_id = la.id
+GROUP BY a.id, a.balance
+HAVING a.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY a.id
+`
+
+type ListAccountBalanceDriftsRow struct {
+	ID            uuid.UUID `json:"id"`
+	Balance       string    `json:"balance"`
+	LedgerBalance string    `json:"ledger_balance"`
+}
+
+func (q *Queries) ListAccountBalanceDrifts(ctx context.Context) ([]ListAccountBalanceDriftsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountBalanceDrifts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountBalanceDriftsRow{}
+	for rows.Next() {
+		var i ListAccountBalanceDriftsRow
+		if err := rows.Scan(&i.ID, &i.Balance, &i.LedgerBalance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, nickname, email, balance, created_at, updated_at, active FROM accounts
+SELECT id, nickname, email, balance, created_at, updated_at, active, type, credit_limit, statement_closing_day, payment_due_days FROM accounts
 WHERE active = true
 ORDER BY created_at
 LIMIT $1 OFFSET $2
@@ -110,6 +258,10 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Active,
+			&i.Type,
+			&i.CreditLimit,
+			&i.StatementClosingDay,
+			&i.PaymentDueDays,
 		); err != nil {
 			return nil, err
 		}
@@ -124,11 +276,63 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
+const lockAccountForShare = `-- name: LockAccountForShare :exec
+SELECT id FROM accounts
+WHERE id = $1
+FOR SHARE
+`
+
+// Waits for the balance changes of the account in flight and holds off the
+// next ones.
+func (q *Queries) LockAccountForShare(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, lockAccountForShare, id)
+	return err
+}
+
+const reconcileAccountBalance = `-- name: ReconcileAccountBalance :one
+UPDATE accounts a
+SET balance = ledger.balance, updated_at = $1
+FROM (
+    SELECT COALESCE(SUM(p.amount), 0)::numeric AS balance
+    FROM ledger_accounts la
+    JOIN postings p ON p.ledger_account_id = la.id
+    WHERE la.account_id = $2
+) ledger
+WHERE a.id = $2 AND a.balance <> ledger.balance
+RETURNING a.id, a.nickname, a.email, a.balance, a.created_at, a.updated_at, a.active, a.type, a.credit_limit, a.statement_closing_day, a.payment_due_days
+`
+
+type ReconcileAccountBalanceParams struct {
+	UpdatedAt int64     `json:"updated_at"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// Sets the balance to the sum of the account ledger postings in a single
+// statement; no row is returned when they already agree.
+func (q *Queries) ReconcileAccountBalance(ctx context.Context, arg ReconcileAccountBalanceParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, reconcileAccountBalance, arg.UpdatedAt, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Nickname,
+		&i.Email,
+		&i.Balance,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Active,
+		&i.Type,
+		&i.CreditLimit,
+		&i.StatementClosingDay,
+		&i.PaymentDueDays,
+	)
+	return i, err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET nickname = $2, email = $3, balance = $4, updated_at = $5, active = $6
 WHERE id = $1
-RETURNING id, nickname, email, balance, created_at, updated_at, active
+RETURNING id, nickname, email, balance, created_at, updated_at, active, type, credit_limit, statement_closing_day, payment_due_days
 `
 
 type UpdateAccountParams struct {
@@ -158,6 +362,10 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Active,
+		&i.Type,
+		&i.CreditLimit,
+		&i.StatementClosingDay,
+		&i.PaymentDueDays,
 	)
 	return i, err
 }
@@ -0,0 +1,198 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: accrual.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createCardAccrual = `-- name: CreateCardAccrual :one
+INSERT INTO card_accruals (id, account_id, kind, accrual_date, statement_date, basis, daily_rate, amount, transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (account_id, kind, accrual_date) DO NOTHING
+RETURNING id, account_id, kind, accrual_date, statement_date, basis, daily_rate, amount, transaction_id, created_at
+`
+
+type CreateCardAccrualParams struct {
+	ID            uuid.UUID `json:"id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Kind          string    `json:"kind"`
+	AccrualDate   time.Time `json:"accrual_date"`
+	StatementDate time.Time `json:"statement_date"`
+	Basis         string    `json:"basis"`
+	DailyRate     string    `json:"daily_rate"`
+	Amount        string    `json:"amount"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+func (q *Queries) CreateCardAccrual(ctx context.Context, arg CreateCardAccrualParams) (CardAccrual, error) {
+	row := q.db.QueryRowContext(ctx, createCardAccrual,
+		arg.ID,
+		arg.AccountID,
+		arg.Kind,
+		arg.AccrualDate,
+		arg.StatementDate,
+		arg.Basis,
+		arg.DailyRate,
+		arg.Amount,
+		arg.TransactionID,
+		arg.CreatedAt,
+	)
+	var i CardAccrual
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Kind,
+		&i.AccrualDate,
+		&i.StatementDate,
+		&i.Basis,
+		&i.DailyRate,
+		&i.Amount,
+		&i.TransactionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountDailyActivity = `-- name: ListAccountDailyActivity :many
+SELECT
+    date_trunc('day', input_date)::timestamp AS day,
+    COALESCE(SUM(amount) FILTER (WHERE amount < 0), 0)::numeric AS debits,
+    COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0)::numeric AS credits
+FROM transactions
+WHERE account_id = $1
+    AND status = 'posted'
+    AND NOT voided AND superseded_by IS NULL
+    AND input_date < $2
+GROUP BY 1
+ORDER BY 1
+`
+
+type ListAccountDailyActivityParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	InputDate time.Time `json:"input_date"`
+}
+
+type ListAccountDailyActivityRow struct {
+	Day     time.Time `json:"day"`
+	Debits  string    `json:"debits"`
+	Credits string    `json:"credits"`
+}
+
+func (q *Queries) ListAccountDailyActivity(ctx context.Context, arg ListAccountDailyActivityParams) ([]ListAccountDailyActivityRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountDailyActivity, arg.AccountID, arg.InputDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountDailyActivityRow{}
+	for rows.Next() {
+		var i ListAccountDailyActivityRow
+		if err := rows.Scan(&i.Day, &i.Debits, &i.Credits); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardAccruals = `-- name: ListCardAccruals :many
+SELECT id, account_id, kind, accrual_date, statement_date, basis, daily_rate, amount, transaction_id, created_at FROM card_accruals
+WHERE account_id = $1
+    AND accrual_date BETWEEN $2 AND $3
+ORDER BY accrual_date, kind
+`
+
+type ListCardAccrualsParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	FromDate  time.Time `json:"from_date"`
+	ToDate    time.Time `json:"to_date"`
+}
+
+func (q *Queries) ListCardAccruals(ctx context.Context, arg ListCardAccrualsParams) ([]CardAccrual, error) {
+	rows, err := q.db.QueryContext(ctx, listCardAccruals, arg.AccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CardAccrual{}
+	for rows.Next() {
+		var i CardAccrual
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Kind,
+			&i.AccrualDate,
+			&i.StatementDate,
+			&i.Basis,
+			&i.DailyRate,
+			&i.Amount,
+			&i.TransactionID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCreditCardAccounts = `-- name: ListCreditCardAccounts :many
+SELECT id, nickname, email, balance, created_at, updated_at, active, type, credit_limit, statement_closing_day, payment_due_days FROM accounts
+WHERE type = 'credit_card' AND active = true
+ORDER BY id
+`
+
+func (q *Queries) ListCreditCardAccounts(ctx context.Context) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listCreditCardAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Nickname,
+			&i.Email,
+			&i.Balance,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Active,
+			&i.Type,
+			&i.CreditLimit,
+			&i.StatementClosingDay,
+			&i.PaymentDueDays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,423 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: anomaly.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const countDebitsBetween = `-- name: CountDebitsBetween :one
+SELECT COUNT(*)::bigint FROM transactions
+WHERE account_id = $1
+  AND type = 'debit'
+  AND amount < 0
+  AND voided = false AND superseded_by IS NULL
+  AND id <> $2
+  AND authorized_at BETWEEN $3 AND $4
+`
+
+type CountDebitsBetweenParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	ExcludeID uuid.UUID `json:"exclude_id"`
+	Since     time.Time `json:"since"`
+	Until     time.Time `json:"until"`
+}
+
+func (q *Queries) CountDebitsBetween(ctx context.Context, arg CountDebitsBetweenParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDebitsBetween,
+		arg.AccountID,
+		arg.ExcludeID,
+		arg.Since,
+		arg.Until,
+	)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countMerchantHistory = `-- name: CountMerchantHistory :one
+SELECT COUNT(*)::bigint FROM transactions
+WHERE account_id = $1
+  AND merchant = $2
+  AND voided = false AND superseded_by IS NULL
+  AND authorized_at < $3
+`
+
+type CountMerchantHistoryParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Merchant  string    `json:"merchant"`
+	Before    time.Time `json:"before"`
+}
+
+func (q *Queries) CountMerchantHistory(ctx context.Context, arg CountMerchantHistoryParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMerchantHistory, arg.AccountID, arg.Merchant, arg.Before)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createTransactionAlert = `-- name: CreateTransactionAlert :one
+INSERT INTO transaction_alerts (id, transaction_id, account_id, score, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (transaction_id) DO NOTHING
+RETURNING id, transaction_id, account_id, score, status, reviewed_by, review_note, reviewed_at, created_at, updated_at
+`
+
+type CreateTransactionAlertParams struct {
+	ID            uuid.UUID `json:"id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Score         string    `json:"score"`
+	Status        string    `json:"status"`
+	CreatedAt     int64     `json:"created_at"`
+	UpdatedAt     int64     `json:"updated_at"`
+}
+
+func (q *Queries) CreateTransactionAlert(ctx context.Context, arg CreateTransactionAlertParams) (TransactionAlert, error) {
+	row := q.db.QueryRowContext(ctx, createTransactionAlert,
+		arg.ID,
+		arg.TransactionID,
+		arg.AccountID,
+		arg.Score,
+		arg.Status,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TransactionAlert
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.AccountID,
+		&i.Score,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createTransactionAlertReason = `-- name: CreateTransactionAlertReason :exec
+INSERT INTO transaction_alert_reasons (alert_id, code, weight, detail)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateTransactionAlertReasonParams struct {
+	AlertID uuid.UUID `json:"alert_id"`
+	Code    string    `json:"code"`
+	Weight  string    `json:"weight"`
+	Detail  string    `json:"detail"`
+}
+
+func (q *Queries) CreateTransactionAlertReason(ctx context.Context, arg CreateTransactionAlertReasonParams) error {
+	_, err := q.db.ExecContext(ctx, createTransactionAlertReason,
+		arg.AlertID,
+		arg.Code,
+		arg.Weight,
+		arg.Detail,
+	)
+	return err
+}
+
+const getAnomalyAmountStats = `-- name: GetAnomalyAmountStats :one
+SELECT COUNT(*)::bigint AS debit_count,
+       COALESCE(AVG(-amount), 0)::float8 AS mean,
+       COALESCE(STDDEV_POP(-amount), 0)::float8 AS stddev
+FROM transactions
+WHERE account_id = $1
+  AND type = 'debit'
+  AND amount < 0
+  AND voided = false AND superseded_by IS NULL
+  AND status <> 'expired'
+  AND authorized_at >= $2
+  AND authorized_at < $3
+`
+
+type GetAnomalyAmountStatsParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Since     time.Time `json:"since"`
+	Before    time.Time `json:"before"`
+}
+
+type GetAnomalyAmountStatsRow struct {
+	DebitCount int64   `json:"debit_count"`
+	Mean       float64 `json:"mean"`
+	Stddev     float64 `json:"stddev"`
+}
+
+func (q *Queries) GetAnomalyAmountStats(ctx context.Context, arg GetAnomalyAmountStatsParams) (GetAnomalyAmountStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getAnomalyAmountStats, arg.AccountID, arg.Since, arg.Before)
+	var i GetAnomalyAmountStatsRow
+	err := row.Scan(&i.DebitCount, &i.Mean, &i.Stddev)
+	return i, err
+}
+
+const getTransactionAlert = `-- name: GetTransactionAlert :one
+SELECT id, transaction_id, account_id, score, status, reviewed_by, review_note, reviewed_at, created_at, updated_at FROM transaction_alerts
+WHERE id = $1
+`
+
+func (q *Queries) GetTransactionAlert(ctx context.Context, id uuid.UUID) (TransactionAlert, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionAlert, id)
+	var i TransactionAlert
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.AccountID,
+		&i.Score,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTransactionAlertForUpdate = `-- name: GetTransactionAlertForUpdate :one
+SELECT id, transaction_id, account_id, score, status, reviewed_by, review_note, reviewed_at, created_at, updated_at FROM transaction_alerts
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetTransactionAlertForUpdate(ctx context.Context, id uuid.UUID) (TransactionAlert, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionAlertForUpdate, id)
+	var i TransactionAlert
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.AccountID,
+		&i.Score,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listDuplicateCharges = `-- name: ListDuplicateCharges :many
+SELECT id FROM transactions
+WHERE account_id = $1
+  AND amount = $2
+  AND merchant = $3
+  AND description = $4
+  AND voided = false AND superseded_by IS NULL
+  AND id <> $5
+  AND authorized_at BETWEEN $6 AND $7
+ORDER BY authorized_at, id
+`
+
+type ListDuplicateChargesParams struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	Amount      string    `json:"amount"`
+	Merchant    string    `json:"merchant"`
+	Description string    `json:"description"`
+	ExcludeID   uuid.UUID `json:"exclude_id"`
+	Since       time.Time `json:"since"`
+	Until       time.Time `json:"until"`
+}
+
+func (q *Queries) ListDuplicateCharges(ctx context.Context, arg ListDuplicateChargesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listDuplicateCharges,
+		arg.AccountID,
+		arg.Amount,
+		arg.Merchant,
+		arg.Description,
+		arg.ExcludeID,
+		arg.Since,
+		arg.Until,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionAlertReasons = `-- name: ListTransactionAlertReasons :many
+SELECT alert_id, code, weight, detail FROM transaction_alert_reasons
+WHERE alert_id = $1
+ORDER BY weight DESC, code
+`
+
+func (q *Queries) ListTransactionAlertReasons(ctx context.Context, alertID uuid.UUID) ([]TransactionAlertReason, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionAlertReasons, alertID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransactionAlertReason{}
+	for rows.Next() {
+		var i TransactionAlertReason
+		if err := rows.Scan(
+			&i.AlertID,
+			&i.Code,
+			&i.Weight,
+			&i.Detail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionAlertsByAccount = `-- name: ListTransactionAlertsByAccount :many
+SELECT id, transaction_id, account_id, score, status, reviewed_by, review_note, reviewed_at, created_at, updated_at FROM transaction_alerts
+WHERE account_id = $1
+ORDER BY created_at DESC, id
+`
+
+func (q *Queries) ListTransactionAlertsByAccount(ctx context.Context, accountID uuid.UUID) ([]TransactionAlert, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionAlertsByAccount, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransactionAlert{}
+	for rows.Next() {
+		var i TransactionAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.AccountID,
+			&i.Score,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionAlertsByStatus = `-- name: ListTransactionAlertsByStatus :many
+SELECT id, transaction_id, account_id, score, status, reviewed_by, review_note, reviewed_at, created_at, updated_at FROM transaction_alerts
+WHERE status = $1
+ORDER BY score DESC, created_at, id
+LIMIT $3 OFFSET $2
+`
+
+type ListTransactionAlertsByStatusParams struct {
+	Status string `json:"status"`
+	Offset int64  `json:"offset"`
+	Limit  int64  `json:"limit"`
+}
+
+func (q *Queries) ListTransactionAlertsByStatus(ctx context.Context, arg ListTransactionAlertsByStatusParams) ([]TransactionAlert, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionAlertsByStatus, arg.Status, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransactionAlert{}
+	for rows.Next() {
+		var i TransactionAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.AccountID,
+			&i.Score,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewTransactionAlert = `-- name: ReviewTransactionAlert :one
+UPDATE transaction_alerts
+SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = $5, updated_at = $6
+WHERE id = $1
+RETURNING id, transaction_id, account_id, score, status, reviewed_by, review_note, reviewed_at, created_at, updated_at
+`
+
+type ReviewTransactionAlertParams struct {
+	ID         uuid.UUID `json:"id"`
+	Status     string    `json:"status"`
+	ReviewedBy string    `json:"reviewed_by"`
+	ReviewNote string    `json:"review_note"`
+	ReviewedAt int64     `json:"reviewed_at"`
+	UpdatedAt  int64     `json:"updated_at"`
+}
+
+func (q *Queries) ReviewTransactionAlert(ctx context.Context, arg ReviewTransactionAlertParams) (TransactionAlert, error) {
+	row := q.db.QueryRowContext(ctx, reviewTransactionAlert,
+		arg.ID,
+		arg.Status,
+		arg.ReviewedBy,
+		arg.ReviewNote,
+		arg.ReviewedAt,
+		arg.UpdatedAt,
+	)
+	var i TransactionAlert
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.AccountID,
+		&i.Score,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,220 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: budget.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createBudgetAlert = `-- name: CreateBudgetAlert :one
+INSERT INTO budget_alerts (id, budget_id, account_id, month, threshold, spent, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (budget_id, month, threshold) DO NOTHING
+RETURNING id, budget_id, account_id, month, threshold, spent, created_at
+`
+
+type CreateBudgetAlertParams struct {
+	ID        uuid.UUID `json:"id"`
+	BudgetID  uuid.UUID `json:"budget_id"`
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+	Threshold int32     `json:"threshold"`
+	Spent     string    `json:"spent"`
+	CreatedAt int64     `json:"created_at"`
+}
+
+func (q *Queries) CreateBudgetAlert(ctx context.Context, arg CreateBudgetAlertParams) (BudgetAlert, error) {
+	row := q.db.QueryRowContext(ctx, createBudgetAlert,
+		arg.ID,
+		arg.BudgetID,
+		arg.AccountID,
+		arg.Month,
+		arg.Threshold,
+		arg.Spent,
+		arg.CreatedAt,
+	)
+	var i BudgetAlert
+	err := row.Scan(
+		&i.ID,
+		&i.BudgetID,
+		&i.AccountID,
+		&i.Month,
+		&i.Threshold,
+		&i.Spent,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteBudget = `-- name: DeleteBudget :exec
+DELETE FROM budgets
+WHERE id = $1
+`
+
+func (q *Queries) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteBudget, id)
+	return err
+}
+
+const deleteBudgetAlerts = `-- name: DeleteBudgetAlerts :exec
+DELETE FROM budget_alerts
+WHERE budget_id = $1
+`
+
+func (q *Queries) DeleteBudgetAlerts(ctx context.Context, budgetID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteBudgetAlerts, budgetID)
+	return err
+}
+
+const getBudget = `-- name: GetBudget :one
+SELECT id, account_id, category, amount, created_at, updated_at FROM budgets
+WHERE id = $1
+`
+
+func (q *Queries) GetBudget(ctx context.Context, id uuid.UUID) (Budget, error) {
+	row := q.db.QueryRowContext(ctx, getBudget, id)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Category,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listBudgetsByAccount = `-- name: ListBudgetsByAccount :many
+SELECT id, account_id, category, amount, created_at, updated_at FROM budgets
+WHERE account_id = $1
+ORDER BY category
+`
+
+func (q *Queries) ListBudgetsByAccount(ctx context.Context, accountID uuid.UUID) ([]Budget, error) {
+	rows, err := q.db.QueryContext(ctx, listBudgetsByAccount, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Budget{}
+	for rows.Next() {
+		var i Budget
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Category,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategorySpend = `-- name: ListCategorySpend :many
+SELECT (CASE WHEN COALESCE(s.category, t.category) = '' THEN 'uncategorized'
+             ELSE COALESCE(s.category, t.category) END)::text AS category,
+       SUM(-COALESCE(s.amount, t.amount))::float8 AS spent
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = $1
+  AND t.amount < 0
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.type NOT IN ('installment', 'installment_conversion')
+  AND t.input_date >= $2
+  AND t.input_date < $3
+GROUP BY 1
+ORDER BY 1
+`
+
+type ListCategorySpendParams struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	MonthStart time.Time `json:"month_start"`
+	MonthEnd   time.Time `json:"month_end"`
+}
+
+type ListCategorySpendRow struct {
+	Category string  `json:"category"`
+	Spent    float64 `json:"spent"`
+}
+
+// Spend per category as the summary counts it: posted purchases and
+// charges, split across their categories, without installment movements.
+func (q *Queries) ListCategorySpend(ctx context.Context, arg ListCategorySpendParams) ([]ListCategorySpendRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCategorySpend, arg.AccountID, arg.MonthStart, arg.MonthEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCategorySpendRow{}
+	for rows.Next() {
+		var i ListCategorySpendRow
+		if err := rows.Scan(&i.Category, &i.Spent); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertBudget = `-- name: UpsertBudget :one
+INSERT INTO budgets (id, account_id, category, amount, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (account_id, category) DO UPDATE
+SET amount = EXCLUDED.amount,
+    updated_at = EXCLUDED.updated_at
+RETURNING id, account_id, category, amount, created_at, updated_at
+`
+
+type UpsertBudgetParams struct {
+	ID        uuid.UUID `json:"id"`
+	AccountID uuid.UUID `json:"account_id"`
+	Category  string    `json:"category"`
+	Amount    string    `json:"amount"`
+	CreatedAt int64     `json:"created_at"`
+	UpdatedAt int64     `json:"updated_at"`
+}
+
+func (q *Queries) UpsertBudget(ctx context.Context, arg UpsertBudgetParams) (Budget, error) {
+	row := q.db.QueryRowContext(ctx, upsertBudget,
+		arg.ID,
+		arg.AccountID,
+		arg.Category,
+		arg.Amount,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Category,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,346 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: correction.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const copyTransactionSplits = `-- name: CopyTransactionSplits :exec
+INSERT INTO transaction_splits (id, transaction_id, position, amount, category, note, created_at)
+SELECT gen_random_uuid(), $1, s.position, s.amount, s.category, s.note, s.created_at
+FROM transaction_splits s
+WHERE s.transaction_id = $2
+`
+
+type CopyTransactionSplitsParams struct {
+	ToID   uuid.UUID `json:"to_id"`
+	FromID uuid.UUID `json:"from_id"`
+}
+
+func (q *Queries) CopyTransactionSplits(ctx context.Context, arg CopyTransactionSplitsParams) error {
+	_, err := q.db.ExecContext(ctx, copyTransactionSplits, arg.ToID, arg.FromID)
+	return err
+}
+
+const copyTransactionTags = `-- name: CopyTransactionTags :exec
+INSERT INTO transaction_tags (transaction_id, tag, created_at)
+SELECT $1, t.tag, t.created_at
+FROM transaction_tags t
+WHERE t.transaction_id = $2
+`
+
+type CopyTransactionTagsParams struct {
+	ToID   uuid.UUID `json:"to_id"`
+	FromID uuid.UUID `json:"from_id"`
+}
+
+func (q *Queries) CopyTransactionTags(ctx context.Context, arg CopyTransactionTagsParams) error {
+	_, err := q.db.ExecContext(ctx, copyTransactionTags, arg.ToID, arg.FromID)
+	return err
+}
+
+const createTransactionCorrection = `-- name: CreateTransactionCorrection :one
+INSERT INTO transaction_corrections (id, transaction_id, kind, reason, actor, previous_amount, new_amount, previous_description, new_description, previous_category, new_category, journal_entry_id, created_at, version_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, transaction_id, kind, reason, actor, previous_amount, new_amount, previous_description, new_description, previous_category, new_category, journal_entry_id, created_at, version_id
+`
+
+type CreateTransactionCorrectionParams struct {
+	ID                  uuid.UUID     `json:"id"`
+	TransactionID       uuid.UUID     `json:"transaction_id"`
+	Kind                string        `json:"kind"`
+	Reason              string        `json:"reason"`
+	Actor               string        `json:"actor"`
+	PreviousAmount      string        `json:"previous_amount"`
+	NewAmount           string        `json:"new_amount"`
+	PreviousDescription string        `json:"previous_description"`
+	NewDescription      string        `json:"new_description"`
+	PreviousCategory    string        `json:"previous_category"`
+	NewCategory         string        `json:"new_category"`
+	JournalEntryID      uuid.NullUUID `json:"journal_entry_id"`
+	CreatedAt           int64         `json:"created_at"`
+	VersionID           uuid.NullUUID `json:"version_id"`
+}
+
+func (q *Queries) CreateTransactionCorrection(ctx context.Context, arg CreateTransactionCorrectionParams) (TransactionCorrection, error) {
+	row := q.db.QueryRowContext(ctx, createTransactionCorrection,
+		arg.ID,
+		arg.TransactionID,
+		arg.Kind,
+		arg.Reason,
+		arg.Actor,
+		arg.PreviousAmount,
+		arg.NewAmount,
+		arg.PreviousDescription,
+		arg.NewDescription,
+		arg.PreviousCategory,
+		arg.NewCategory,
+		arg.JournalEntryID,
+		arg.CreatedAt,
+		arg.VersionID,
+	)
+	var i TransactionCorrection
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.Kind,
+		&i.Reason,
+		&i.Actor,
+		&i.PreviousAmount,
+		&i.NewAmount,
+		&i.PreviousDescription,
+		&i.NewDescription,
+		&i.PreviousCategory,
+		&i.NewCategory,
+		&i.JournalEntryID,
+		&i.CreatedAt,
+		&i.VersionID,
+	)
+	return i, err
+}
+
+const createTransactionVersion = `-- name: CreateTransactionVersion :one
+INSERT INTO transactions (id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
+`
+
+type CreateTransactionVersionParams struct {
+	ID                uuid.UUID     `json:"id"`
+	AccountID         uuid.UUID     `json:"account_id"`
+	Amount            string        `json:"amount"`
+	Type              string        `json:"type"`
+	InputFileID       string        `json:"input_file_id"`
+	InputDate         time.Time     `json:"input_date"`
+	CreatedAt         int64         `json:"created_at"`
+	Description       string        `json:"description"`
+	Merchant          string        `json:"merchant"`
+	Category          string        `json:"category"`
+	TransferID        uuid.NullUUID `json:"transfer_id"`
+	Voided            bool          `json:"voided"`
+	UpdatedAt         int64         `json:"updated_at"`
+	ReversalOf        uuid.NullUUID `json:"reversal_of"`
+	ReversalKind      string        `json:"reversal_kind"`
+	Status            string        `json:"status"`
+	AuthorizedAt      time.Time     `json:"authorized_at"`
+	PostedAt          sql.NullTime  `json:"posted_at"`
+	Note              string        `json:"note"`
+	InstallmentPlanID uuid.NullUUID `json:"installment_plan_id"`
+	Supersedes        uuid.NullUUID `json:"supersedes"`
+}
+
+func (q *Queries) CreateTransactionVersion(ctx context.Context, arg CreateTransactionVersionParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, createTransactionVersion,
+		arg.ID,
+		arg.AccountID,
+		arg.Amount,
+		arg.Type,
+		arg.InputFileID,
+		arg.InputDate,
+		arg.CreatedAt,
+		arg.Description,
+		arg.Merchant,
+		arg.Category,
+		arg.TransferID,
+		arg.Voided,
+		arg.UpdatedAt,
+		arg.ReversalOf,
+		arg.ReversalKind,
+		arg.Status,
+		arg.AuthorizedAt,
+		arg.PostedAt,
+		arg.Note,
+		arg.InstallmentPlanID,
+		arg.Supersedes,
+	)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Type,
+		&i.InputFileID,
+		&i.InputDate,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
+	)
+	return i, err
+}
+
+const listTransactionCorrections = `-- name: ListTransactionCorrections :many
+SELECT id, transaction_id, kind, reason, actor, previous_amount, new_amount, previous_description, new_description, previous_category, new_category, journal_entry_id, created_at, version_id FROM transaction_corrections
+WHERE transaction_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListTransactionCorrections(ctx context.Context, transactionID uuid.UUID) ([]TransactionCorrection, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionCorrections, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransactionCorrection{}
+	for rows.Next() {
+		var i TransactionCorrection
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.Kind,
+			&i.Reason,
+			&i.Actor,
+			&i.PreviousAmount,
+			&i.NewAmount,
+			&i.PreviousDescription,
+			&i.NewDescription,
+			&i.PreviousCategory,
+			&i.NewCategory,
+			&i.JournalEntryID,
+			&i.CreatedAt,
+			&i.VersionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markTransactionSuperseded = `-- name: MarkTransactionSuperseded :execrows
+UPDATE transactions
+SET superseded_by = $1
+WHERE id = $2 AND superseded_by IS NULL
+`
+
+type MarkTransactionSupersededParams struct {
+	SupersededBy uuid.NullUUID `json:"superseded_by"`
+	ID           uuid.UUID     `json:"id"`
+}
+
+// The only change ever made to a corrected row.
+func (q *Queries) MarkTransactionSuperseded(ctx context.Context, arg MarkTransactionSupersededParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markTransactionSuperseded, arg.SupersededBy, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const moveTransactionDisputes = `-- name: MoveTransactionDisputes :exec
+UPDATE disputes
+SET transaction_id = $1
+WHERE transaction_id = $2
+`
+
+type MoveTransactionDisputesParams struct {
+	ToID   uuid.UUID `json:"to_id"`
+	FromID uuid.UUID `json:"from_id"`
+}
+
+func (q *Queries) MoveTransactionDisputes(ctx context.Context, arg MoveTransactionDisputesParams) error {
+	_, err := q.db.ExecContext(ctx, moveTransactionDisputes, arg.ToID, arg.FromID)
+	return err
+}
+
+const moveTransactionReversals = `-- name: MoveTransactionReversals :many
+UPDATE transactions
+SET reversal_of = $1
+WHERE reversal_of = $2
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
+`
+
+type MoveTransactionReversalsParams struct {
+	ToID   uuid.NullUUID `json:"to_id"`
+	FromID uuid.NullUUID `json:"from_id"`
+}
+
+// Refunds and dispute movements follow the current version of what they
+// reverse.
+func (q *Queries) MoveTransactionReversals(ctx context.Context, arg MoveTransactionReversalsParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, moveTransactionReversals, arg.ToID, arg.FromID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveTransactionRewardEntries = `-- name: MoveTransactionRewardEntries :exec
+UPDATE reward_entries
+SET transaction_id = $1
+WHERE transaction_id = $2
+`
+
+type MoveTransactionRewardEntriesParams struct {
+	ToID   uuid.UUID `json:"to_id"`
+	FromID uuid.UUID `json:"from_id"`
+}
+
+func (q *Queries) MoveTransactionRewardEntries(ctx context.Context, arg MoveTransactionRewardEntriesParams) error {
+	_, err := q.db.ExecContext(ctx, moveTransactionRewardEntries, arg.ToID, arg.FromID)
+	return err
+}
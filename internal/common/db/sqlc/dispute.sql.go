@@ -0,0 +1,478 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: dispute.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createDispute = `-- name: CreateDispute :one
+INSERT INTO disputes (id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, credit_transaction_id, reversal_transaction_id, created_at, updated_at
+`
+
+type CreateDisputeParams struct {
+	ID                   uuid.UUID `json:"id"`
+	AccountID            uuid.UUID `json:"account_id"`
+	TransactionID        uuid.UUID `json:"transaction_id"`
+	Amount               string    `json:"amount"`
+	ReasonCode           string    `json:"reason_code"`
+	Description          string    `json:"description"`
+	Status               string    `json:"status"`
+	ProvisionalCreditDue time.Time `json:"provisional_credit_due"`
+	Deadline             time.Time `json:"deadline"`
+	CreatedAt            int64     `json:"created_at"`
+	UpdatedAt            int64     `json:"updated_at"`
+}
+
+func (q *Queries) CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, createDispute,
+		arg.ID,
+		arg.AccountID,
+		arg.TransactionID,
+		arg.Amount,
+		arg.ReasonCode,
+		arg.Description,
+		arg.Status,
+		arg.ProvisionalCreditDue,
+		arg.Deadline,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.TransactionID,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Description,
+		&i.Status,
+		&i.ProvisionalCreditDue,
+		&i.Deadline,
+		&i.CreditTransactionID,
+		&i.ReversalTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createDisputeAttachment = `-- name: CreateDisputeAttachment :one
+INSERT INTO dispute_attachments (id, dispute_id, file_name, content_type, size, blob_key, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, dispute_id, file_name, content_type, size, blob_key, created_at
+`
+
+type CreateDisputeAttachmentParams struct {
+	ID          uuid.UUID `json:"id"`
+	DisputeID   uuid.UUID `json:"dispute_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	BlobKey     string    `json:"blob_key"`
+	CreatedAt   int64     `json:"created_at"`
+}
+
+func (q *Queries) CreateDisputeAttachment(ctx context.Context, arg CreateDisputeAttachmentParams) (DisputeAttachment, error) {
+	row := q.db.QueryRowContext(ctx, createDisputeAttachment,
+		arg.ID,
+		arg.DisputeID,
+		arg.FileName,
+		arg.ContentType,
+		arg.Size,
+		arg.BlobKey,
+		arg.CreatedAt,
+	)
+	var i DisputeAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.DisputeID,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.BlobKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createDisputeEvent = `-- name: CreateDisputeEvent :exec
+INSERT INTO dispute_events (id, dispute_id, from_status, to_status, note, transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateDisputeEventParams struct {
+	ID            uuid.UUID     `json:"id"`
+	DisputeID     uuid.UUID     `json:"dispute_id"`
+	FromStatus    string        `json:"from_status"`
+	ToStatus      string        `json:"to_status"`
+	Note          string        `json:"note"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+	CreatedAt     int64         `json:"created_at"`
+}
+
+func (q *Queries) CreateDisputeEvent(ctx context.Context, arg CreateDisputeEventParams) error {
+	_, err := q.db.ExecContext(ctx, createDisputeEvent,
+		arg.ID,
+		arg.DisputeID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Note,
+		arg.TransactionID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const getDispute = `-- name: GetDispute :one
+SELECT id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, credit_transaction_id, reversal_transaction_id, created_at, updated_at FROM disputes
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetDispute(ctx context.Context, id uuid.UUID) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, getDispute, id)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.TransactionID,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Description,
+		&i.Status,
+		&i.ProvisionalCreditDue,
+		&i.Deadline,
+		&i.CreditTransactionID,
+		&i.ReversalTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getDisputeAttachment = `-- name: GetDisputeAttachment :one
+SELECT id, dispute_id, file_name, content_type, size, blob_key, created_at FROM dispute_attachments
+WHERE id = $1 AND dispute_id = $2 LIMIT 1
+`
+
+type GetDisputeAttachmentParams struct {
+	ID        uuid.UUID `json:"id"`
+	DisputeID uuid.UUID `json:"dispute_id"`
+}
+
+func (q *Queries) GetDisputeAttachment(ctx context.Context, arg GetDisputeAttachmentParams) (DisputeAttachment, error) {
+	row := q.db.QueryRowContext(ctx, getDisputeAttachment, arg.ID, arg.DisputeID)
+	var i DisputeAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.DisputeID,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.BlobKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDisputeForUpdate = `-- name: GetDisputeForUpdate :one
+SELECT id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, credit_transaction_id, reversal_transaction_id, created_at, updated_at FROM disputes
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetDisputeForUpdate(ctx context.Context, id uuid.UUID) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, getDisputeForUpdate, id)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.TransactionID,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Description,
+		&i.Status,
+		&i.ProvisionalCreditDue,
+		&i.Deadline,
+		&i.CreditTransactionID,
+		&i.ReversalTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOpenDisputeByTransaction = `-- name: GetOpenDisputeByTransaction :one
+SELECT id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, credit_transaction_id, reversal_transaction_id, created_at, updated_at FROM disputes
+WHERE transaction_id = $1 AND status NOT IN ('won', 'lost')
+LIMIT 1
+`
+
+func (q *Queries) GetOpenDisputeByTransaction(ctx context.Context, transactionID uuid.UUID) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, getOpenDisputeByTransaction, transactionID)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.TransactionID,
+		&i.Amount,
+		&i.ReasonCode,
+		&i.Description,
+		&i.Status,
+		&i.ProvisionalCreditDue,
+		&i.Deadline,
+		&i.CreditTransactionID,
+		&i.ReversalTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listDisputeAttachments = `-- name: ListDisputeAttachments :many
+SELECT id, dispute_id, file_name, content_type, size, blob_key, created_at FROM dispute_attachments
+WHERE dispute_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListDisputeAttachments(ctx context.Context, disputeID uuid.UUID) ([]DisputeAttachment, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputeAttachments, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DisputeAttachment{}
+	for rows.Next() {
+		var i DisputeAttachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.DisputeID,
+			&i.FileName,
+			&i.ContentType,
+			&i.Size,
+			&i.BlobKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisputeEvents = `-- name: ListDisputeEvents :many
+SELECT id, dispute_id, from_status, to_status, note, transaction_id, created_at FROM dispute_events
+WHERE dispute_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListDisputeEvents(ctx context.Context, disputeID uuid.UUID) ([]DisputeEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputeEvents, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DisputeEvent{}
+	for rows.Next() {
+		var i DisputeEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.DisputeID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Note,
+			&i.TransactionID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisputesByAccount = `-- name: ListDisputesByAccount :many
+SELECT id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, credit_transaction_id, reversal_transaction_id, created_at, updated_at FROM disputes
+WHERE account_id = $1
+ORDER BY created_at DESC, id
+`
+
+func (q *Queries) ListDisputesByAccount(ctx context.Context, accountID uuid.UUID) ([]Dispute, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputesByAccount, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Dispute{}
+	for rows.Next() {
+		var i Dispute
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionID,
+			&i.Amount,
+			&i.ReasonCode,
+			&i.Description,
+			&i.Status,
+			&i.ProvisionalCreditDue,
+			&i.Deadline,
+			&i.CreditTransactionID,
+			&i.ReversalTransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisputesDueForCredit = `-- name: ListDisputesDueForCredit :many
+SELECT id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, credit_transaction_id, reversal_transaction_id, created_at, updated_at FROM disputes
+WHERE status IN ('opened', 'under_review') AND provisional_credit_due <= $1
+ORDER BY provisional_credit_due, id
+LIMIT $2
+`
+
+type ListDisputesDueForCreditParams struct {
+	ProvisionalCreditDue time.Time `json:"provisional_credit_due"`
+	Limit                int64     `json:"limit"`
+}
+
+func (q *Queries) ListDisputesDueForCredit(ctx context.Context, arg ListDisputesDueForCreditParams) ([]Dispute, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputesDueForCredit, arg.ProvisionalCreditDue, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Dispute{}
+	for rows.Next() {
+		var i Dispute
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionID,
+			&i.Amount,
+			&i.ReasonCode,
+			&i.Description,
+			&i.Status,
+			&i.ProvisionalCreditDue,
+			&i.Deadline,
+			&i.CreditTransactionID,
+			&i.ReversalTransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisputesPastDeadline = `-- name: ListDisputesPastDeadline :many
+SELECT id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, credit_transaction_id, reversal_transaction_id, created_at, updated_at FROM disputes
+WHERE status NOT IN ('won', 'lost') AND deadline < $1
+ORDER BY deadline, id
+LIMIT $2
+`
+
+type ListDisputesPastDeadlineParams struct {
+	Deadline time.Time `json:"deadline"`
+	Limit    int64     `json:"limit"`
+}
+
+func (q *Queries) ListDisputesPastDeadline(ctx context.Context, arg ListDisputesPastDeadlineParams) ([]Dispute, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputesPastDeadline, arg.Deadline, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Dispute{}
+	for rows.Next() {
+		var i Dispute
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionID,
+			&i.Amount,
+			&i.ReasonCode,
+			&i.Description,
+			&i.Status,
+			&i.ProvisionalCreditDue,
+			&i.Deadline,
+			&i.CreditTransactionID,
+			&i.ReversalTransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateDispute = `-- name: UpdateDispute :exec
+UPDATE disputes
+SET status = $2, credit_transaction_id = $3, reversal_transaction_id = $4, updated_at = $5
+WHERE id = $1
+`
+
+type UpdateDisputeParams struct {
+	ID                    uuid.UUID     `json:"id"`
+	Status                string        `json:"status"`
+	CreditTransactionID   uuid.NullUUID `json:"credit_transaction_id"`
+	ReversalTransactionID uuid.NullUUID `json:"reversal_transaction_id"`
+	UpdatedAt             int64         `json:"updated_at"`
+}
+
+func (q *Queries) UpdateDispute(ctx context.Context, arg UpdateDisputeParams) error {
+	_, err := q.db.ExecContext(ctx, updateDispute,
+		arg.ID,
+		arg.Status,
+		arg.CreditTransactionID,
+		arg.ReversalTransactionID,
+		arg.UpdatedAt,
+	)
+	return err
+}
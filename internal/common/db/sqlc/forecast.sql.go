@@ -0,0 +1,306 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: forecast.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createForecastItem = `-- name: CreateForecastItem :exec
+INSERT INTO cash_flow_forecast_items (account_id, day, name, category, cadence, amount)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateForecastItemParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Day       time.Time `json:"day"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Cadence   string    `json:"cadence"`
+	Amount    string    `json:"amount"`
+}
+
+func (q *Queries) CreateForecastItem(ctx context.Context, arg CreateForecastItemParams) error {
+	_, err := q.db.ExecContext(ctx, createForecastItem,
+		arg.AccountID,
+		arg.Day,
+		arg.Name,
+		arg.Category,
+		arg.Cadence,
+		arg.Amount,
+	)
+	return err
+}
+
+const createForecastPoint = `-- name: CreateForecastPoint :exec
+INSERT INTO cash_flow_forecast_points (account_id, day, expected, low, high, recurring, baseline)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateForecastPointParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Day       time.Time `json:"day"`
+	Expected  string    `json:"expected"`
+	Low       string    `json:"low"`
+	High      string    `json:"high"`
+	Recurring string    `json:"recurring"`
+	Baseline  string    `json:"baseline"`
+}
+
+func (q *Queries) CreateForecastPoint(ctx context.Context, arg CreateForecastPointParams) error {
+	_, err := q.db.ExecContext(ctx, createForecastPoint,
+		arg.AccountID,
+		arg.Day,
+		arg.Expected,
+		arg.Low,
+		arg.High,
+		arg.Recurring,
+		arg.Baseline,
+	)
+	return err
+}
+
+const deleteForecastItems = `-- name: DeleteForecastItems :exec
+DELETE FROM cash_flow_forecast_items
+WHERE account_id = $1
+`
+
+func (q *Queries) DeleteForecastItems(ctx context.Context, accountID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteForecastItems, accountID)
+	return err
+}
+
+const deleteForecastPoints = `-- name: DeleteForecastPoints :exec
+DELETE FROM cash_flow_forecast_points
+WHERE account_id = $1
+`
+
+func (q *Queries) DeleteForecastPoints(ctx context.Context, accountID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteForecastPoints, accountID)
+	return err
+}
+
+const getForecast = `-- name: GetForecast :one
+SELECT account_id, as_of, starting_balance, daily_income, daily_spend, daily_deviation, generated_at FROM cash_flow_forecasts
+WHERE account_id = $1
+`
+
+func (q *Queries) GetForecast(ctx context.Context, accountID uuid.UUID) (CashFlowForecast, error) {
+	row := q.db.QueryRowContext(ctx, getForecast, accountID)
+	var i CashFlowForecast
+	err := row.Scan(
+		&i.AccountID,
+		&i.AsOf,
+		&i.StartingBalance,
+		&i.DailyIncome,
+		&i.DailySpend,
+		&i.DailyDeviation,
+		&i.GeneratedAt,
+	)
+	return i, err
+}
+
+const listForecastAccounts = `-- name: ListForecastAccounts :many
+SELECT DISTINCT t.account_id FROM transactions t
+JOIN accounts a ON a.id = t.account_id
+WHERE a.active = true AND t.status = 'posted' AND t.voided = false AND t.superseded_by IS NULL AND t.input_date >= $1
+ORDER BY t.account_id
+`
+
+func (q *Queries) ListForecastAccounts(ctx context.Context, inputDate time.Time) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listForecastAccounts, inputDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var account_id uuid.UUID
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listForecastHistory = `-- name: ListForecastHistory :many
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
+WHERE account_id = $1
+  AND status = 'posted'
+  AND voided = false AND superseded_by IS NULL
+  AND input_date >= $2
+ORDER BY input_date, created_at, id
+`
+
+type ListForecastHistoryParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	InputDate time.Time `json:"input_date"`
+}
+
+func (q *Queries) ListForecastHistory(ctx context.Context, arg ListForecastHistoryParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listForecastHistory, arg.AccountID, arg.InputDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listForecastItems = `-- name: ListForecastItems :many
+SELECT id, account_id, day, name, category, cadence, amount FROM cash_flow_forecast_items
+WHERE account_id = $1
+ORDER BY day, id
+`
+
+func (q *Queries) ListForecastItems(ctx context.Context, accountID uuid.UUID) ([]CashFlowForecastItem, error) {
+	rows, err := q.db.QueryContext(ctx, listForecastItems, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CashFlowForecastItem{}
+	for rows.Next() {
+		var i CashFlowForecastItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Day,
+			&i.Name,
+			&i.Category,
+			&i.Cadence,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listForecastPoints = `-- name: ListForecastPoints :many
+SELECT account_id, day, expected, low, high, recurring, baseline FROM cash_flow_forecast_points
+WHERE account_id = $1
+ORDER BY day
+`
+
+func (q *Queries) ListForecastPoints(ctx context.Context, accountID uuid.UUID) ([]CashFlowForecastPoint, error) {
+	rows, err := q.db.QueryContext(ctx, listForecastPoints, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CashFlowForecastPoint{}
+	for rows.Next() {
+		var i CashFlowForecastPoint
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Day,
+			&i.Expected,
+			&i.Low,
+			&i.High,
+			&i.Recurring,
+			&i.Baseline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertForecast = `-- name: UpsertForecast :exec
+INSERT INTO cash_flow_forecasts (account_id, as_of, starting_balance, daily_income, daily_spend, daily_deviation, generated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id) DO UPDATE
+SET as_of = EXCLUDED.as_of,
+    starting_balance = EXCLUDED.starting_balance,
+    daily_income = EXCLUDED.daily_income,
+    daily_spend = EXCLUDED.daily_spend,
+    daily_deviation = EXCLUDED.daily_deviation,
+    generated_at = EXCLUDED.generated_at
+`
+
+type UpsertForecastParams struct {
+	AccountID       uuid.UUID `json:"account_id"`
+	AsOf            time.Time `json:"as_of"`
+	StartingBalance string    `json:"starting_balance"`
+	DailyIncome     string    `json:"daily_income"`
+	DailySpend      string    `json:"daily_spend"`
+	DailyDeviation  string    `json:"daily_deviation"`
+	GeneratedAt     int64     `json:"generated_at"`
+}
+
+func (q *Queries) UpsertForecast(ctx context.Context, arg UpsertForecastParams) error {
+	_, err := q.db.ExecContext(ctx, upsertForecast,
+		arg.AccountID,
+		arg.AsOf,
+		arg.StartingBalance,
+		arg.DailyIncome,
+		arg.DailySpend,
+		arg.DailyDeviation,
+		arg.GeneratedAt,
+	)
+	return err
+}
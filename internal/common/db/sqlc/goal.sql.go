@@ -0,0 +1,197 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: goal.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createSavingsGoal = `-- name: CreateSavingsGoal :one
+INSERT INTO savings_goals (id, account_id, name, target_amount, target_date, category, start_date, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, account_id, name, target_amount, target_date, category, start_date, created_at, updated_at
+`
+
+type CreateSavingsGoalParams struct {
+	ID           uuid.UUID `json:"id"`
+	AccountID    uuid.UUID `json:"account_id"`
+	Name         string    `json:"name"`
+	TargetAmount string    `json:"target_amount"`
+	TargetDate   time.Time `json:"target_date"`
+	Category     string    `json:"category"`
+	StartDate    time.Time `json:"start_date"`
+	CreatedAt    int64     `json:"created_at"`
+	UpdatedAt    int64     `json:"updated_at"`
+}
+
+func (q *Queries) CreateSavingsGoal(ctx context.Context, arg CreateSavingsGoalParams) (SavingsGoal, error) {
+	row := q.db.QueryRowContext(ctx, createSavingsGoal,
+		arg.ID,
+		arg.AccountID,
+		arg.Name,
+		arg.TargetAmount,
+		arg.TargetDate,
+		arg.Category,
+		arg.StartDate,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i SavingsGoal
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Name,
+		&i.TargetAmount,
+		&i.TargetDate,
+		&i.Category,
+		&i.StartDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteSavingsGoal = `-- name: DeleteSavingsGoal :exec
+DELETE FROM savings_goals
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSavingsGoal(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteSavingsGoal, id)
+	return err
+}
+
+const getGoalCategoryContributions = `-- name: GetGoalCategoryContributions :one
+SELECT COALESCE(SUM(-COALESCE(s.amount, t.amount)), 0)::float8 AS saved,
+       COALESCE(SUM(-COALESCE(s.amount, t.amount)) FILTER (WHERE t.input_date >= $1), 0)::float8 AS recent
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = $2
+  AND COALESCE(s.category, t.category) = $3
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.input_date >= $4
+`
+
+type GetGoalCategoryContributionsParams struct {
+	RecentSince time.Time `json:"recent_since"`
+	AccountID   uuid.UUID `json:"account_id"`
+	Category    string    `json:"category"`
+	Since       time.Time `json:"since"`
+}
+
+type GetGoalCategoryContributionsRow struct {
+	Saved  float64 `json:"saved"`
+	Recent float64 `json:"recent"`
+}
+
+// Money moved into a goal through its category: debits of the category,
+// split across categories, less the credits taken back out of it.
+func (q *Queries) GetGoalCategoryContributions(ctx context.Context, arg GetGoalCategoryContributionsParams) (GetGoalCategoryContributionsRow, error) {
+	row := q.db.QueryRowContext(ctx, getGoalCategoryContributions,
+		arg.RecentSince,
+		arg.AccountID,
+		arg.Category,
+		arg.Since,
+	)
+	var i GetGoalCategoryContributionsRow
+	err := row.Scan(&i.Saved, &i.Recent)
+	return i, err
+}
+
+const getGoalNetSavings = `-- name: GetGoalNetSavings :one
+SELECT COALESCE(SUM(amount), 0)::float8 AS saved,
+       COALESCE(SUM(amount) FILTER (WHERE input_date >= $1), 0)::float8 AS recent
+FROM transactions
+WHERE account_id = $2
+  AND status = 'posted'
+  AND voided = false AND superseded_by IS NULL
+  AND input_date >= $3
+`
+
+type GetGoalNetSavingsParams struct {
+	RecentSince time.Time `json:"recent_since"`
+	AccountID   uuid.UUID `json:"account_id"`
+	Since       time.Time `json:"since"`
+}
+
+type GetGoalNetSavingsRow struct {
+	Saved  float64 `json:"saved"`
+	Recent float64 `json:"recent"`
+}
+
+// Net flow of the account since a goal started, in total and since the
+// start of the window its contribution rate is measured over.
+func (q *Queries) GetGoalNetSavings(ctx context.Context, arg GetGoalNetSavingsParams) (GetGoalNetSavingsRow, error) {
+	row := q.db.QueryRowContext(ctx, getGoalNetSavings, arg.RecentSince, arg.AccountID, arg.Since)
+	var i GetGoalNetSavingsRow
+	err := row.Scan(&i.Saved, &i.Recent)
+	return i, err
+}
+
+const getSavingsGoal = `-- name: GetSavingsGoal :one
+SELECT id, account_id, name, target_amount, target_date, category, start_date, created_at, updated_at FROM savings_goals
+WHERE id = $1
+`
+
+func (q *Queries) GetSavingsGoal(ctx context.Context, id uuid.UUID) (SavingsGoal, error) {
+	row := q.db.QueryRowContext(ctx, getSavingsGoal, id)
+	var i SavingsGoal
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Name,
+		&i.TargetAmount,
+		&i.TargetDate,
+		&i.Category,
+		&i.StartDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listSavingsGoalsByAccount = `-- name: ListSavingsGoalsByAccount :many
+SELECT id, account_id, name, target_amount, target_date, category, start_date, created_at, updated_at FROM savings_goals
+WHERE account_id = $1
+ORDER BY target_date, name
+`
+
+func (q *Queries) ListSavingsGoalsByAccount(ctx context.Context, accountID uuid.UUID) ([]SavingsGoal, error) {
+	rows, err := q.db.QueryContext(ctx, listSavingsGoalsByAccount, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SavingsGoal{}
+	for rows.Next() {
+		var i SavingsGoal
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Name,
+			&i.TargetAmount,
+			&i.TargetDate,
+			&i.Category,
+			&i.StartDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
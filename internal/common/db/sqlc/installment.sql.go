@@ -0,0 +1,363 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: installment.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createInstallment = `-- name: CreateInstallment :exec
+INSERT INTO installments (id, plan_id, number, due_date, principal, interest, amount)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateInstallmentParams struct {
+	ID        uuid.UUID `json:"id"`
+	PlanID    uuid.UUID `json:"plan_id"`
+	Number    int32     `json:"number"`
+	DueDate   time.Time `json:"due_date"`
+	Principal string    `json:"principal"`
+	Interest  string    `json:"interest"`
+	Amount    string    `json:"amount"`
+}
+
+func (q *Queries) CreateInstallment(ctx context.Context, arg CreateInstallmentParams) error {
+	_, err := q.db.ExecContext(ctx, createInstallment,
+		arg.ID,
+		arg.PlanID,
+		arg.Number,
+		arg.DueDate,
+		arg.Principal,
+		arg.Interest,
+		arg.Amount,
+	)
+	return err
+}
+
+const createInstallmentPlan = `-- name: CreateInstallmentPlan :one
+INSERT INTO installment_plans (id, account_id, transaction_id, conversion_transaction_id, principal, installment_count, annual_rate, installment_amount, total_interest, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, account_id, transaction_id, conversion_transaction_id, principal, installment_count, annual_rate, installment_amount, total_interest, status, created_at, updated_at
+`
+
+type CreateInstallmentPlanParams struct {
+	ID                      uuid.UUID `json:"id"`
+	AccountID               uuid.UUID `json:"account_id"`
+	TransactionID           uuid.UUID `json:"transaction_id"`
+	ConversionTransactionID uuid.UUID `json:"conversion_transaction_id"`
+	Principal               string    `json:"principal"`
+	InstallmentCount        int32     `json:"installment_count"`
+	AnnualRate              string    `json:"annual_rate"`
+	InstallmentAmount       string    `json:"installment_amount"`
+	TotalInterest           string    `json:"total_interest"`
+	Status                  string    `json:"status"`
+	CreatedAt               int64     `json:"created_at"`
+	UpdatedAt               int64     `json:"updated_at"`
+}
+
+func (q *Queries) CreateInstallmentPlan(ctx context.Context, arg CreateInstallmentPlanParams) (InstallmentPlan, error) {
+	row := q.db.QueryRowContext(ctx, createInstallmentPlan,
+		arg.ID,
+		arg.AccountID,
+		arg.TransactionID,
+		arg.ConversionTransactionID,
+		arg.Principal,
+		arg.InstallmentCount,
+		arg.AnnualRate,
+		arg.InstallmentAmount,
+		arg.TotalInterest,
+		arg.Status,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i InstallmentPlan
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.TransactionID,
+		&i.ConversionTransactionID,
+		&i.Principal,
+		&i.InstallmentCount,
+		&i.AnnualRate,
+		&i.InstallmentAmount,
+		&i.TotalInterest,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAccountInstallmentBalance = `-- name: GetAccountInstallmentBalance :one
+SELECT COALESCE(SUM(i.amount), 0)::numeric AS remaining
+FROM installments i
+JOIN installment_plans p ON p.id = i.plan_id
+WHERE p.account_id = $1 AND i.transaction_id IS NULL
+`
+
+func (q *Queries) GetAccountInstallmentBalance(ctx context.Context, accountID uuid.UUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, getAccountInstallmentBalance, accountID)
+	var remaining string
+	err := row.Scan(&remaining)
+	return remaining, err
+}
+
+const getInstallmentPlan = `-- name: GetInstallmentPlan :one
+SELECT id, account_id, transaction_id, conversion_transaction_id, principal, installment_count, annual_rate, installment_amount, total_interest, status, created_at, updated_at FROM installment_plans
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetInstallmentPlan(ctx context.Context, id uuid.UUID) (InstallmentPlan, error) {
+	row := q.db.QueryRowContext(ctx, getInstallmentPlan, id)
+	var i InstallmentPlan
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.TransactionID,
+		&i.ConversionTransactionID,
+		&i.Principal,
+		&i.InstallmentCount,
+		&i.AnnualRate,
+		&i.InstallmentAmount,
+		&i.TotalInterest,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getInstallmentPlanForUpdate = `-- name: GetInstallmentPlanForUpdate :one
+SELECT id, account_id, transaction_id, conversion_transaction_id, principal, installment_count, annual_rate, installment_amount, total_interest, status, created_at, updated_at FROM installment_plans
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetInstallmentPlanForUpdate(ctx context.Context, id uuid.UUID) (InstallmentPlan, error) {
+	row := q.db.QueryRowContext(ctx, getInstallmentPlanForUpdate, id)
+	var i InstallmentPlan
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.TransactionID,
+		&i.ConversionTransactionID,
+		&i.Principal,
+		&i.InstallmentCount,
+		&i.AnnualRate,
+		&i.InstallmentAmount,
+		&i.TotalInterest,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listDueInstallments = `-- name: ListDueInstallments :many
+SELECT id, plan_id, number, due_date, principal, interest, amount, transaction_id FROM installments
+WHERE transaction_id IS NULL AND due_date <= $1
+ORDER BY due_date, plan_id, number
+LIMIT $2
+`
+
+type ListDueInstallmentsParams struct {
+	DueDate time.Time `json:"due_date"`
+	Limit   int64     `json:"limit"`
+}
+
+func (q *Queries) ListDueInstallments(ctx context.Context, arg ListDueInstallmentsParams) ([]Installment, error) {
+	rows, err := q.db.QueryContext(ctx, listDueInstallments, arg.DueDate, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Installment{}
+	for rows.Next() {
+		var i Installment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlanID,
+			&i.Number,
+			&i.DueDate,
+			&i.Principal,
+			&i.Interest,
+			&i.Amount,
+			&i.TransactionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInstallmentPlansByAccount = `-- name: ListInstallmentPlansByAccount :many
+SELECT id, account_id, transaction_id, conversion_transaction_id, principal, installment_count, annual_rate, installment_amount, total_interest, status, created_at, updated_at FROM installment_plans
+WHERE account_id = $1
+ORDER BY created_at DESC, id
+`
+
+func (q *Queries) ListInstallmentPlansByAccount(ctx context.Context, accountID uuid.UUID) ([]InstallmentPlan, error) {
+	rows, err := q.db.QueryContext(ctx, listInstallmentPlansByAccount, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InstallmentPlan{}
+	for rows.Next() {
+		var i InstallmentPlan
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionID,
+			&i.ConversionTransactionID,
+			&i.Principal,
+			&i.InstallmentCount,
+			&i.AnnualRate,
+			&i.InstallmentAmount,
+			&i.TotalInterest,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlanInstallments = `-- name: ListPlanInstallments :many
+SELECT id, plan_id, number, due_date, principal, interest, amount, transaction_id FROM installments
+WHERE plan_id = $1
+ORDER BY number
+`
+
+func (q *Queries) ListPlanInstallments(ctx context.Context, planID uuid.UUID) ([]Installment, error) {
+	rows, err := q.db.QueryContext(ctx, listPlanInstallments, planID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Installment{}
+	for rows.Next() {
+		var i Installment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlanID,
+			&i.Number,
+			&i.DueDate,
+			&i.Principal,
+			&i.Interest,
+			&i.Amount,
+			&i.TransactionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markInstallmentPosted = `-- name: MarkInstallmentPosted :execrows
+UPDATE installments
+SET transaction_id = $2
+WHERE id = $1 AND transaction_id IS NULL
+`
+
+type MarkInstallmentPostedParams struct {
+	ID            uuid.UUID     `json:"id"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+}
+
+func (q *Queries) MarkInstallmentPosted(ctx context.Context, arg MarkInstallmentPostedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markInstallmentPosted, arg.ID, arg.TransactionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setTransactionInstallmentPlan = `-- name: SetTransactionInstallmentPlan :one
+UPDATE transactions
+SET installment_plan_id = $2, updated_at = $3
+WHERE id = $1
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
+`
+
+type SetTransactionInstallmentPlanParams struct {
+	ID                uuid.UUID     `json:"id"`
+	InstallmentPlanID uuid.NullUUID `json:"installment_plan_id"`
+	UpdatedAt         int64         `json:"updated_at"`
+}
+
+func (q *Queries) SetTransactionInstallmentPlan(ctx context.Context, arg SetTransactionInstallmentPlanParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, setTransactionInstallmentPlan, arg.ID, arg.InstallmentPlanID, arg.UpdatedAt)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Type,
+		&i.InputFileID,
+		&i.InputDate,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
+	)
+	return i, err
+}
+
+const updateInstallmentPlanStatus = `-- name: UpdateInstallmentPlanStatus :exec
+UPDATE installment_plans
+SET status = $2, updated_at = $3
+WHERE id = $1
+`
+
+type UpdateInstallmentPlanStatusParams struct {
+	ID        uuid.UUID `json:"id"`
+	Status    string    `json:"status"`
+	UpdatedAt int64     `json:"updated_at"`
+}
+
+func (q *Queries) UpdateInstallmentPlanStatus(ctx context.Context, arg UpdateInstallmentPlanStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateInstallmentPlanStatus, arg.ID, arg.Status, arg.UpdatedAt)
+	return err
+}
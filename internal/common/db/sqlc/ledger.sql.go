@@ -0,0 +1,439 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: ledger.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createJournalEntry = `-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (id, transaction_id, description, effective_date, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, transaction_id, description, effective_date, created_at
+`
+
+type CreateJournalEntryParams struct {
+	ID            uuid.UUID     `json:"id"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+	Description   string        `json:"description"`
+	EffectiveDate time.Time     `json:"effective_date"`
+	CreatedAt     int64         `json:"created_at"`
+}
+
+func (q *Queries) CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error) {
+	row := q.db.QueryRowContext(ctx, createJournalEntry,
+		arg.ID,
+		arg.TransactionID,
+		arg.Description,
+		arg.EffectiveDate,
+		arg.CreatedAt,
+	)
+	var i JournalEntry
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.Description,
+		&i.EffectiveDate,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPosting = `-- name: CreatePosting :one
+INSERT INTO postings (id, journal_entry_id, ledger_account_id, amount, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, journal_entry_id, ledger_account_id, amount, created_at
+`
+
+type CreatePostingParams struct {
+	ID              uuid.UUID `json:"id"`
+	JournalEntryID  uuid.UUID `json:"journal_entry_id"`
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	Amount          string    `json:"amount"`
+	CreatedAt       int64     `json:"created_at"`
+}
+
+func (q *Queries) CreatePosting(ctx context.Context, arg CreatePostingParams) (Posting, error) {
+	row := q.db.QueryRowContext(ctx, createPosting,
+		arg.ID,
+		arg.JournalEntryID,
+		arg.LedgerAccountID,
+		arg.Amount,
+		arg.CreatedAt,
+	)
+	var i Posting
+	err := row.Scan(
+		&i.ID,
+		&i.JournalEntryID,
+		&i.LedgerAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteDailyBalancesFrom = `-- name: DeleteDailyBalancesFrom :exec
+DELETE FROM daily_balances
+WHERE ledger_account_id = $1
+    AND day >= $2::date
+`
+
+type DeleteDailyBalancesFromParams struct {
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	FromDate        time.Time `json:"from_date"`
+}
+
+func (q *Queries) DeleteDailyBalancesFrom(ctx context.Context, arg DeleteDailyBalancesFromParams) error {
+	_, err := q.db.ExecContext(ctx, deleteDailyBalancesFrom, arg.LedgerAccountID, arg.FromDate)
+	return err
+}
+
+const ensureLedgerAccount = `-- name: EnsureLedgerAccount :one
+INSERT INTO ledger_accounts (id, code, name, type, account_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (code) DO UPDATE SET code = EXCLUDED.code
+RETURNING id, code, name, type, account_id, created_at
+`
+
+type EnsureLedgerAccountParams struct {
+	ID        uuid.UUID     `json:"id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	AccountID uuid.NullUUID `json:"account_id"`
+	CreatedAt int64         `json:"created_at"`
+}
+
+func (q *Queries) EnsureLedgerAccount(ctx context.Context, arg EnsureLedgerAccountParams) (LedgerAccount, error) {
+	row := q.db.QueryRowContext(ctx, ensureLedgerAccount,
+		arg.ID,
+		arg.Code,
+		arg.Name,
+		arg.Type,
+		arg.AccountID,
+		arg.CreatedAt,
+	)
+	var i LedgerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.Type,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLedgerAccountByCode = `-- name: GetLedgerAccountByCode :one
+SELECT id, code, name, type, account_id, created_at FROM ledger_accounts
+WHERE code = $1 LIMIT 1
+`
+
+func (q *Queries) GetLedgerAccountByCode(ctx context.Context, code string) (LedgerAccount, error) {
+	row := q.db.QueryRowContext(ctx, getLedgerAccountByCode, code)
+	var i LedgerAccount
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.Type,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLedgerBalanceBefore = `-- name: GetLedgerBalanceBefore :one
+SELECT COALESCE(SUM(p.amount), 0)::numeric AS balance
+FROM postings p
+JOIN journal_entries je ON je.id = p.journal_entry_id
+WHERE p.ledger_account_id = $1 AND je.effective_date < $2
+`
+
+type GetLedgerBalanceBeforeParams struct {
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	EffectiveDate   time.Time `json:"effective_date"`
+}
+
+func (q *Queries) GetLedgerBalanceBefore(ctx context.Context, arg GetLedgerBalanceBeforeParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLedgerBalanceBefore, arg.LedgerAccountID, arg.EffectiveDate)
+	var balance string
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getTrialBalance = `-- name: GetTrialBalance :many
+SELECT
+    la.id,
+    la.code,
+    la.name,
+    la.type,
+    COALESCE(SUM(p.amount) FILTER (WHERE je.effective_date <= $1::timestamp), 0)::numeric AS balance
+FROM ledger_accounts la
+LEFT JOIN postings p ON p.ledger_account_id = la.id
+LEFT JOIN journal_entries je ON je.id = p.journal_entry_id
+GROUP BY la.id, la.code, la.name, la.type
+ORDER BY la.code
+`
+
+type GetTrialBalanceRow struct {
+	ID      uuid.UUID `json:"id"`
+	Code    string    `json:"code"`
+	Name    string    `json:"name"`
+	Type    string    `json:"type"`
+	Balance string    `json:"balance"`
+}
+
+func (q *Queries) GetTrialBalance(ctx context.Context, asOf time.Time) ([]GetTrialBalanceRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTrialBalance, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTrialBalanceRow{}
+	for rows.Next() {
+		var i GetTrialBalanceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.Type,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDailyBalances = `-- name: ListDailyBalances :many
+SELECT
+    day,
+    credits::float8 AS credits,
+    debits::float8 AS debits,
+    closing_balance::float8 AS closing_balance,
+    posting_count
+FROM daily_balances
+WHERE ledger_account_id = $1
+    AND day >= $2::date
+    AND day < $3::date
+ORDER BY day
+`
+
+type ListDailyBalancesParams struct {
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	FromDate        time.Time `json:"from_date"`
+	ToDate          time.Time `json:"to_date"`
+}
+
+type ListDailyBalancesRow struct {
+	Day            time.Time `json:"day"`
+	Credits        float64   `json:"credits"`
+	Debits         float64   `json:"debits"`
+	ClosingBalance float64   `json:"closing_balance"`
+	PostingCount   int32     `json:"posting_count"`
+}
+
+func (q *Queries) ListDailyBalances(ctx context.Context, arg ListDailyBalancesParams) ([]ListDailyBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDailyBalances, arg.LedgerAccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDailyBalancesRow{}
+	for rows.Next() {
+		var i ListDailyBalancesRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Credits,
+			&i.Debits,
+			&i.ClosingBalance,
+			&i.PostingCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLedgerDailyTotals = `-- name: ListLedgerDailyTotals :many
+SELECT
+    date_trunc('day', je.effective_date)::timestamp AS day,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0)::float8 AS credits,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.amount < 0), 0)::float8 AS debits,
+    COUNT(*) AS posting_count
+FROM postings p
+JOIN journal_entries je ON je.id = p.journal_entry_id
+WHERE p.ledger_account_id = $1
+    AND je.effective_date >= $2
+    AND je.effective_date < $3
+GROUP BY 1
+ORDER BY 1
+`
+
+type ListLedgerDailyTotalsParams struct {
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	FromDate        time.Time `json:"from_date"`
+	ToDate          time.Time `json:"to_date"`
+}
+
+type ListLedgerDailyTotalsRow struct {
+	Day          time.Time `json:"day"`
+	Credits      float64   `json:"credits"`
+	Debits       float64   `json:"debits"`
+	PostingCount int64     `json:"posting_count"`
+}
+
+func (q *Queries) ListLedgerDailyTotals(ctx context.Context, arg ListLedgerDailyTotalsParams) ([]ListLedgerDailyTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLedgerDailyTotals, arg.LedgerAccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLedgerDailyTotalsRow{}
+	for rows.Next() {
+		var i ListLedgerDailyTotalsRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Credits,
+			&i.Debits,
+			&i.PostingCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLedgerPostings = `-- name: ListLedgerPostings :many
+SELECT
+    p.id,
+    p.journal_entry_id,
+    je.transaction_id,
+    je.description,
+    je.effective_date,
+    p.amount
+FROM postings p
+JOIN journal_entries je ON je.id = p.journal_entry_id
+WHERE p.ledger_account_id = $1
+    AND je.effective_date >= $2
+    AND je.effective_date < $3
+ORDER BY je.effective_date, p.created_at, p.id
+`
+
+type ListLedgerPostingsParams struct {
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	FromDate        time.Time `json:"from_date"`
+	ToDate          time.Time `json:"to_date"`
+}
+
+type ListLedgerPostingsRow struct {
+	ID             uuid.UUID     `json:"id"`
+	JournalEntryID uuid.UUID     `json:"journal_entry_id"`
+	TransactionID  uuid.NullUUID `json:"transaction_id"`
+	Description    string        `json:"description"`
+	EffectiveDate  time.Time     `json:"effective_date"`
+	Amount         string        `json:"amount"`
+}
+
+func (q *Queries) ListLedgerPostings(ctx context.Context, arg ListLedgerPostingsParams) ([]ListLedgerPostingsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLedgerPostings, arg.LedgerAccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLedgerPostingsRow{}
+	for rows.Next() {
+		var i ListLedgerPostingsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.JournalEntryID,
+			&i.TransactionID,
+			&i.Description,
+			&i.EffectiveDate,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockLedgerAccount = `-- name: LockLedgerAccount :exec
+SELECT id FROM ledger_accounts
+WHERE id = $1
+FOR UPDATE
+`
+
+// Serializes the filling of the cached daily balances of an internal ledger
+// account.
+func (q *Queries) LockLedgerAccount(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, lockLedgerAccount, id)
+	return err
+}
+
+const upsertDailyBalance = `-- name: UpsertDailyBalance :exec
+INSERT INTO daily_balances (ledger_account_id, day, credits, debits, closing_balance, posting_count)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (ledger_account_id, day) DO UPDATE
+SET credits = EXCLUDED.credits,
+    debits = EXCLUDED.debits,
+    closing_balance = EXCLUDED.closing_balance,
+    posting_count = EXCLUDED.posting_count
+`
+
+type UpsertDailyBalanceParams struct {
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	Day             time.Time `json:"day"`
+	Credits         string    `json:"credits"`
+	Debits          string    `json:"debits"`
+	ClosingBalance  string    `json:"closing_balance"`
+	PostingCount    int32     `json:"posting_count"`
+}
+
+func (q *Queries) UpsertDailyBalance(ctx context.Context, arg UpsertDailyBalanceParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDailyBalance,
+		arg.LedgerAccountID,
+		arg.Day,
+		arg.Credits,
+		arg.Debits,
+		arg.ClosingBalance,
+		arg.PostingCount,
+	)
+	return err
+}
@@ -5,27 +5,370 @@
 package sqlc
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
+	ID                  uuid.UUID `json:"id"`
+	Nickname            string    `json:"nickname"`
+	Email               string    `json:"email"`
+	Balance             string    `json:"balance"`
+	CreatedAt           int64     `json:"created_at"`
+	UpdatedAt           int64     `json:"updated_at"`
+	Active              bool      `json:"active"`
+	Type                string    `json:"type"`
+	CreditLimit         string    `json:"credit_limit"`
+	StatementClosingDay int32     `json:"statement_closing_day"`
+	PaymentDueDays      int32     `json:"payment_due_days"`
+}
+
+type Budget struct {
 	ID        uuid.UUID `json:"id"`
-	Nickname  string    `json:"nickname"`
-	Email     string    `json:"email"`
-	Balance   string    `json:"balance"`
+	AccountID uuid.UUID `json:"account_id"`
+	Category  string    `json:"category"`
+	Amount    string    `json:"amount"`
 	CreatedAt int64     `json:"created_at"`
 	UpdatedAt int64     `json:"updated_at"`
-	Active    bool      `json:"active"`
 }
 
-type Transaction struct {
+type BudgetAlert struct {
+	ID        uuid.UUID `json:"id"`
+	BudgetID  uuid.UUID `json:"budget_id"`
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+	Threshold int32     `json:"threshold"`
+	Spent     string    `json:"spent"`
+	CreatedAt int64     `json:"created_at"`
+}
+
+type CardAccrual struct {
+	ID            uuid.UUID `json:"id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Kind          string    `json:"kind"`
+	AccrualDate   time.Time `json:"accrual_date"`
+	StatementDate time.Time `json:"statement_date"`
+	Basis         string    `json:"basis"`
+	DailyRate     string    `json:"daily_rate"`
+	Amount        string    `json:"amount"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+type CashFlowForecast struct {
+	AccountID       uuid.UUID `json:"account_id"`
+	AsOf            time.Time `json:"as_of"`
+	StartingBalance string    `json:"starting_balance"`
+	DailyIncome     string    `json:"daily_income"`
+	DailySpend      string    `json:"daily_spend"`
+	DailyDeviation  string    `json:"daily_deviation"`
+	GeneratedAt     int64     `json:"generated_at"`
+}
+
+type CashFlowForecastItem struct {
+	ID        int64     `json:"id"`
+	AccountID uuid.UUID `json:"account_id"`
+	Day       time.Time `json:"day"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Cadence   string    `json:"cadence"`
+	Amount    string    `json:"amount"`
+}
+
+type CashFlowForecastPoint struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Day       time.Time `json:"day"`
+	Expected  string    `json:"expected"`
+	Low       string    `json:"low"`
+	High      string    `json:"high"`
+	Recurring string    `json:"recurring"`
+	Baseline  string    `json:"baseline"`
+}
+
+type DailyBalance struct {
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	Day             time.Time `json:"day"`
+	Credits         string    `json:"credits"`
+	Debits          string    `json:"debits"`
+	ClosingBalance  string    `json:"closing_balance"`
+	PostingCount    int32     `json:"posting_count"`
+}
+
+type Dispute struct {
+	ID                    uuid.UUID     `json:"id"`
+	AccountID             uuid.UUID     `json:"account_id"`
+	TransactionID         uuid.UUID     `json:"transaction_id"`
+	Amount                string        `json:"amount"`
+	ReasonCode            string        `json:"reason_code"`
+	Description           string        `json:"description"`
+	Status                string        `json:"status"`
+	ProvisionalCreditDue  time.Time     `json:"provisional_credit_due"`
+	Deadline              time.Time     `json:"deadline"`
+	CreditTransactionID   uuid.NullUUID `json:"credit_transaction_id"`
+	ReversalTransactionID uuid.NullUUID `json:"reversal_transaction_id"`
+	CreatedAt             int64         `json:"created_at"`
+	UpdatedAt             int64         `json:"updated_at"`
+}
+
+type DisputeAttachment struct {
 	ID          uuid.UUID `json:"id"`
-	AccountID   uuid.UUID `json:"account_id"`
-	Amount      string    `json:"amount"`
-	Type        string    `json:"type"`
-	InputFileID string    `json:"input_file_id"`
-	InputDate   time.Time `json:"input_date"`
+	DisputeID   uuid.UUID `json:"dispute_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	BlobKey     string    `json:"blob_key"`
 	CreatedAt   int64     `json:"created_at"`
 }
+
+type DisputeEvent struct {
+	ID            uuid.UUID     `json:"id"`
+	DisputeID     uuid.UUID     `json:"dispute_id"`
+	FromStatus    string        `json:"from_status"`
+	ToStatus      string        `json:"to_status"`
+	Note          string        `json:"note"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+	CreatedAt     int64         `json:"created_at"`
+}
+
+type ImportRow struct {
+	AccountID     uuid.UUID `json:"account_id"`
+	ImportKey     string    `json:"import_key"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+type Installment struct {
+	ID            uuid.UUID     `json:"id"`
+	PlanID        uuid.UUID     `json:"plan_id"`
+	Number        int32         `json:"number"`
+	DueDate       time.Time     `json:"due_date"`
+	Principal     string        `json:"principal"`
+	Interest      string        `json:"interest"`
+	Amount        string        `json:"amount"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+}
+
+type InstallmentPlan struct {
+	ID                      uuid.UUID `json:"id"`
+	AccountID               uuid.UUID `json:"account_id"`
+	TransactionID           uuid.UUID `json:"transaction_id"`
+	ConversionTransactionID uuid.UUID `json:"conversion_transaction_id"`
+	Principal               string    `json:"principal"`
+	InstallmentCount        int32     `json:"installment_count"`
+	AnnualRate              string    `json:"annual_rate"`
+	InstallmentAmount       string    `json:"installment_amount"`
+	TotalInterest           string    `json:"total_interest"`
+	Status                  string    `json:"status"`
+	CreatedAt               int64     `json:"created_at"`
+	UpdatedAt               int64     `json:"updated_at"`
+}
+
+type JournalEntry struct {
+	ID            uuid.UUID     `json:"id"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+	Description   string        `json:"description"`
+	EffectiveDate time.Time     `json:"effective_date"`
+	CreatedAt     int64         `json:"created_at"`
+}
+
+type LedgerAccount struct {
+	ID        uuid.UUID     `json:"id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	AccountID uuid.NullUUID `json:"account_id"`
+	CreatedAt int64         `json:"created_at"`
+}
+
+type MonthlyAccountSummary struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	Month        time.Time `json:"month"`
+	TotalCount   int32     `json:"total_count"`
+	Amount       string    `json:"amount"`
+	CreditCount  int32     `json:"credit_count"`
+	Credits      string    `json:"credits"`
+	DebitCount   int32     `json:"debit_count"`
+	Debits       string    `json:"debits"`
+	RefundCount  int32     `json:"refund_count"`
+	Refunds      string    `json:"refunds"`
+	Installments string    `json:"installments"`
+	RefreshedAt  int64     `json:"refreshed_at"`
+}
+
+type MonthlyCategorySummary struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+	Category  string    `json:"category"`
+	Total     string    `json:"total"`
+	Count     int32     `json:"count"`
+}
+
+type MonthlyMerchantSummary struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+	Merchant  string    `json:"merchant"`
+	Category  string    `json:"category"`
+	Total     string    `json:"total"`
+	Count     int32     `json:"count"`
+}
+
+type Posting struct {
+	ID              uuid.UUID `json:"id"`
+	JournalEntryID  uuid.UUID `json:"journal_entry_id"`
+	LedgerAccountID uuid.UUID `json:"ledger_account_id"`
+	Amount          string    `json:"amount"`
+	CreatedAt       int64     `json:"created_at"`
+}
+
+type RewardEntry struct {
+	ID            uuid.UUID `json:"id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Kind          string    `json:"kind"`
+	Points        int64     `json:"points"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Rule          string    `json:"rule"`
+	CycleStart    time.Time `json:"cycle_start"`
+	EffectiveDate time.Time `json:"effective_date"`
+	Description   string    `json:"description"`
+	CreatedAt     int64     `json:"created_at"`
+	Basis         string    `json:"basis"`
+}
+
+type SavingsGoal struct {
+	ID           uuid.UUID `json:"id"`
+	AccountID    uuid.UUID `json:"account_id"`
+	Name         string    `json:"name"`
+	TargetAmount string    `json:"target_amount"`
+	TargetDate   time.Time `json:"target_date"`
+	Category     string    `json:"category"`
+	StartDate    time.Time `json:"start_date"`
+	CreatedAt    int64     `json:"created_at"`
+	UpdatedAt    int64     `json:"updated_at"`
+}
+
+type StaleSummaryMonth struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+	Version   int64     `json:"version"`
+	MarkedAt  int64     `json:"marked_at"`
+}
+
+type Subscription struct {
+	ID               uuid.UUID `json:"id"`
+	AccountID        uuid.UUID `json:"account_id"`
+	Merchant         string    `json:"merchant"`
+	Category         string    `json:"category"`
+	Cadence          string    `json:"cadence"`
+	AverageAmount    string    `json:"average_amount"`
+	LastAmount       string    `json:"last_amount"`
+	LastChargeDate   time.Time `json:"last_charge_date"`
+	NextExpectedDate time.Time `json:"next_expected_date"`
+	ChargeCount      int32     `json:"charge_count"`
+	Status           string    `json:"status"`
+	CreatedAt        int64     `json:"created_at"`
+	UpdatedAt        int64     `json:"updated_at"`
+}
+
+type SubscriptionAlert struct {
+	ID             uuid.UUID `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	AccountID      uuid.UUID `json:"account_id"`
+	Kind           string    `json:"kind"`
+	ChargeDate     time.Time `json:"charge_date"`
+	Amount         string    `json:"amount"`
+	PreviousAmount string    `json:"previous_amount"`
+	Message        string    `json:"message"`
+	CreatedAt      int64     `json:"created_at"`
+}
+
+type Transaction struct {
+	ID                uuid.UUID     `json:"id"`
+	AccountID         uuid.UUID     `json:"account_id"`
+	Amount            string        `json:"amount"`
+	Type              string        `json:"type"`
+	InputFileID       string        `json:"input_file_id"`
+	InputDate         time.Time     `json:"input_date"`
+	CreatedAt         int64         `json:"created_at"`
+	Description       string        `json:"description"`
+	Merchant          string        `json:"merchant"`
+	Category          string        `json:"category"`
+	TransferID        uuid.NullUUID `json:"transfer_id"`
+	Voided            bool          `json:"voided"`
+	UpdatedAt         int64         `json:"updated_at"`
+	ReversalOf        uuid.NullUUID `json:"reversal_of"`
+	ReversalKind      string        `json:"reversal_kind"`
+	Status            string        `json:"status"`
+	AuthorizedAt      time.Time     `json:"authorized_at"`
+	PostedAt          sql.NullTime  `json:"posted_at"`
+	Note              string        `json:"note"`
+	InstallmentPlanID uuid.NullUUID `json:"installment_plan_id"`
+	Supersedes        uuid.NullUUID `json:"supersedes"`
+	SupersededBy      uuid.NullUUID `json:"superseded_by"`
+}
+
+type TransactionAlert struct {
+	ID            uuid.UUID `json:"id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Score         string    `json:"score"`
+	Status        string    `json:"status"`
+	ReviewedBy    string    `json:"reviewed_by"`
+	ReviewNote    string    `json:"review_note"`
+	ReviewedAt    int64     `json:"reviewed_at"`
+	CreatedAt     int64     `json:"created_at"`
+	UpdatedAt     int64     `json:"updated_at"`
+}
+
+type TransactionAlertReason struct {
+	AlertID uuid.UUID `json:"alert_id"`
+	Code    string    `json:"code"`
+	Weight  string    `json:"weight"`
+	Detail  string    `json:"detail"`
+}
+
+type TransactionCorrection struct {
+	ID                  uuid.UUID     `json:"id"`
+	TransactionID       uuid.UUID     `json:"transaction_id"`
+	Kind                string        `json:"kind"`
+	Reason              string        `json:"reason"`
+	Actor               string        `json:"actor"`
+	PreviousAmount      string        `json:"previous_amount"`
+	NewAmount           string        `json:"new_amount"`
+	PreviousDescription string        `json:"previous_description"`
+	NewDescription      string        `json:"new_description"`
+	PreviousCategory    string        `json:"previous_category"`
+	NewCategory         string        `json:"new_category"`
+	JournalEntryID      uuid.NullUUID `json:"journal_entry_id"`
+	CreatedAt           int64         `json:"created_at"`
+	VersionID           uuid.NullUUID `json:"version_id"`
+}
+
+type TransactionSplit struct {
+	ID            uuid.UUID `json:"id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Position      int32     `json:"position"`
+	Amount        string    `json:"amount"`
+	Category      string    `json:"category"`
+	Note          string    `json:"note"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+type TransactionTag struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Tag           string    `json:"tag"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+type Transfer struct {
+	ID                   uuid.UUID      `json:"id"`
+	SourceAccountID      uuid.UUID      `json:"source_account_id"`
+	DestinationAccountID uuid.UUID      `json:"destination_account_id"`
+	Amount               string         `json:"amount"`
+	Description          string         `json:"description"`
+	IdempotencyKey       sql.NullString `json:"idempotency_key"`
+	DebitTransactionID   uuid.UUID      `json:"debit_transaction_id"`
+	CreditTransactionID  uuid.UUID      `json:"credit_transaction_id"`
+	CreatedAt            int64          `json:"created_at"`
+}
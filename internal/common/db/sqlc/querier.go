@@ -6,20 +6,214 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddTransactionTag(ctx context.Context, arg AddTransactionTagParams) error
+	AdjustAccountBalance(ctx context.Context, arg AdjustAccountBalanceParams) (Account, error)
+	CopyTransactionSplits(ctx context.Context, arg CopyTransactionSplitsParams) error
+	CopyTransactionTags(ctx context.Context, arg CopyTransactionTagsParams) error
+	CountDebitsBetween(ctx context.Context, arg CountDebitsBetweenParams) (int64, error)
+	CountMerchantHistory(ctx context.Context, arg CountMerchantHistoryParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateBudgetAlert(ctx context.Context, arg CreateBudgetAlertParams) (BudgetAlert, error)
+	CreateCardAccrual(ctx context.Context, arg CreateCardAccrualParams) (CardAccrual, error)
+	CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, error)
+	CreateDisputeAttachment(ctx context.Context, arg CreateDisputeAttachmentParams) (DisputeAttachment, error)
+	CreateDisputeEvent(ctx context.Context, arg CreateDisputeEventParams) error
+	CreateForecastItem(ctx context.Context, arg CreateForecastItemParams) error
+	CreateForecastPoint(ctx context.Context, arg CreateForecastPointParams) error
+	CreateImportRow(ctx context.Context, arg CreateImportRowParams) error
+	CreateInstallment(ctx context.Context, arg CreateInstallmentParams) error
+	CreateInstallmentPlan(ctx context.Context, arg CreateInstallmentPlanParams) (InstallmentPlan, error)
+	CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error)
+	CreatePosting(ctx context.Context, arg CreatePostingParams) (Posting, error)
+	CreateRewardEntry(ctx context.Context, arg CreateRewardEntryParams) (RewardEntry, error)
+	CreateSavingsGoal(ctx context.Context, arg CreateSavingsGoalParams) (SavingsGoal, error)
+	CreateSubscriptionAlert(ctx context.Context, arg CreateSubscriptionAlertParams) (SubscriptionAlert, error)
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
+	CreateTransactionAlert(ctx context.Context, arg CreateTransactionAlertParams) (TransactionAlert, error)
+	CreateTransactionAlertReason(ctx context.Context, arg CreateTransactionAlertReasonParams) error
+	CreateTransactionCorrection(ctx context.Context, arg CreateTransactionCorrectionParams) (TransactionCorrection, error)
+	CreateTransactionSplit(ctx context.Context, arg CreateTransactionSplitParams) (TransactionSplit, error)
+	CreateTransactionVersion(ctx context.Context, arg CreateTransactionVersionParams) (Transaction, error)
+	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
 	DeleteAccount(ctx context.Context, id uuid.UUID) error
+	DeleteBudget(ctx context.Context, id uuid.UUID) error
+	DeleteBudgetAlerts(ctx context.Context, budgetID uuid.UUID) error
+	DeleteDailyBalancesFrom(ctx context.Context, arg DeleteDailyBalancesFromParams) error
+	DeleteForecastItems(ctx context.Context, accountID uuid.UUID) error
+	DeleteForecastPoints(ctx context.Context, accountID uuid.UUID) error
+	DeleteMonthlyCategorySummary(ctx context.Context, arg DeleteMonthlyCategorySummaryParams) error
+	DeleteMonthlyMerchantSummary(ctx context.Context, arg DeleteMonthlyMerchantSummaryParams) error
+	DeleteSavingsGoal(ctx context.Context, id uuid.UUID) error
+	// Dequeues a refreshed month unless it was written to since the refresh
+	// read its version.
+	DeleteStaleSummaryMonth(ctx context.Context, arg DeleteStaleSummaryMonthParams) error
+	DeleteTransactionSplits(ctx context.Context, transactionID uuid.UUID) error
+	DeleteTransactionTags(ctx context.Context, transactionID uuid.UUID) error
+	EnsureLedgerAccount(ctx context.Context, arg EnsureLedgerAccountParams) (LedgerAccount, error)
+	ExpirePendingTransactions(ctx context.Context, arg ExpirePendingTransactionsParams) ([]Transaction, error)
 	GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
+	GetAccountForUpdate(ctx context.Context, id uuid.UUID) (Account, error)
+	GetAccountInstallmentBalance(ctx context.Context, accountID uuid.UUID) (string, error)
+	GetAccountStatementActivity(ctx context.Context, arg GetAccountStatementActivityParams) (GetAccountStatementActivityRow, error)
+	GetAnomalyAmountStats(ctx context.Context, arg GetAnomalyAmountStatsParams) (GetAnomalyAmountStatsRow, error)
+	GetBudget(ctx context.Context, id uuid.UUID) (Budget, error)
+	GetDispute(ctx context.Context, id uuid.UUID) (Dispute, error)
+	GetDisputeAttachment(ctx context.Context, arg GetDisputeAttachmentParams) (DisputeAttachment, error)
+	GetDisputeForUpdate(ctx context.Context, id uuid.UUID) (Dispute, error)
+	GetForecast(ctx context.Context, accountID uuid.UUID) (CashFlowForecast, error)
+	// Money moved into a goal through its category: debits of the category,
+	// split across categories, less the credits taken back out of it.
+	GetGoalCategoryContributions(ctx context.Context, arg GetGoalCategoryContributionsParams) (GetGoalCategoryContributionsRow, error)
+	// Net flow of the account since a goal started, in total and since the
+	// start of the window its contribution rate is measured over.
+	GetGoalNetSavings(ctx context.Context, arg GetGoalNetSavingsParams) (GetGoalNetSavingsRow, error)
+	GetInstallmentPlan(ctx context.Context, id uuid.UUID) (InstallmentPlan, error)
+	GetInstallmentPlanForUpdate(ctx context.Context, id uuid.UUID) (InstallmentPlan, error)
+	GetLedgerAccountByCode(ctx context.Context, code string) (LedgerAccount, error)
+	GetLedgerBalanceBefore(ctx context.Context, arg GetLedgerBalanceBeforeParams) (string, error)
+	GetOpenDisputeByTransaction(ctx context.Context, transactionID uuid.UUID) (Dispute, error)
+	GetRewardBalance(ctx context.Context, accountID uuid.UUID) (GetRewardBalanceRow, error)
+	GetSavingsGoal(ctx context.Context, id uuid.UUID) (SavingsGoal, error)
+	GetStaleSummaryMonthVersion(ctx context.Context, arg GetStaleSummaryMonthVersionParams) (int64, error)
 	GetTransaction(ctx context.Context, id uuid.UUID) (Transaction, error)
-	GetTransactionSummary(ctx context.Context, accountID uuid.UUID) (GetTransactionSummaryRow, error)
+	GetTransactionAlert(ctx context.Context, id uuid.UUID) (TransactionAlert, error)
+	GetTransactionAlertForUpdate(ctx context.Context, id uuid.UUID) (TransactionAlert, error)
+	GetTransactionForUpdate(ctx context.Context, id uuid.UUID) (Transaction, error)
+	GetTransfer(ctx context.Context, id uuid.UUID) (Transfer, error)
+	GetTransferByIdempotencyKey(ctx context.Context, idempotencyKey sql.NullString) (Transfer, error)
+	GetTrialBalance(ctx context.Context, asOf time.Time) ([]GetTrialBalanceRow, error)
+	// Recomputes the spend per category of an account and month, as
+	// ListSummaryCategories does.
+	InsertMonthlyCategorySummary(ctx context.Context, arg InsertMonthlyCategorySummaryParams) error
+	// Recomputes the spend per merchant of an account and month, as
+	// ListSummaryMerchants does.
+	InsertMonthlyMerchantSummary(ctx context.Context, arg InsertMonthlyMerchantSummaryParams) error
+	LinkTransactionReversal(ctx context.Context, arg LinkTransactionReversalParams) (Transaction, error)
+	ListAccountBalanceDrifts(ctx context.Context) ([]ListAccountBalanceDriftsRow, error)
+	ListAccountDailyActivity(ctx context.Context, arg ListAccountDailyActivityParams) ([]ListAccountDailyActivityRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListBudgetsByAccount(ctx context.Context, accountID uuid.UUID) ([]Budget, error)
+	ListCardAccruals(ctx context.Context, arg ListCardAccrualsParams) ([]CardAccrual, error)
+	// Spend per category as the summary counts it: posted purchases and
+	// charges, split across their categories, without installment movements.
+	ListCategorySpend(ctx context.Context, arg ListCategorySpendParams) ([]ListCategorySpendRow, error)
+	ListCreditCardAccounts(ctx context.Context) ([]Account, error)
+	ListDailyBalances(ctx context.Context, arg ListDailyBalancesParams) ([]ListDailyBalancesRow, error)
+	ListDisputeAttachments(ctx context.Context, disputeID uuid.UUID) ([]DisputeAttachment, error)
+	ListDisputeEvents(ctx context.Context, disputeID uuid.UUID) ([]DisputeEvent, error)
+	ListDisputesByAccount(ctx context.Context, accountID uuid.UUID) ([]Dispute, error)
+	ListDisputesDueForCredit(ctx context.Context, arg ListDisputesDueForCreditParams) ([]Dispute, error)
+	ListDisputesPastDeadline(ctx context.Context, arg ListDisputesPastDeadlineParams) ([]Dispute, error)
+	ListDueInstallments(ctx context.Context, arg ListDueInstallmentsParams) ([]Installment, error)
+	ListDuplicateCharges(ctx context.Context, arg ListDuplicateChargesParams) ([]uuid.UUID, error)
+	ListForecastAccounts(ctx context.Context, inputDate time.Time) ([]uuid.UUID, error)
+	ListForecastHistory(ctx context.Context, arg ListForecastHistoryParams) ([]Transaction, error)
+	ListForecastItems(ctx context.Context, accountID uuid.UUID) ([]CashFlowForecastItem, error)
+	ListForecastPoints(ctx context.Context, accountID uuid.UUID) ([]CashFlowForecastPoint, error)
+	// The keys among import_keys of rows the account already imported.
+	ListImportedKeys(ctx context.Context, arg ListImportedKeysParams) ([]string, error)
+	ListInstallmentPlansByAccount(ctx context.Context, accountID uuid.UUID) ([]InstallmentPlan, error)
+	ListLedgerDailyTotals(ctx context.Context, arg ListLedgerDailyTotalsParams) ([]ListLedgerDailyTotalsRow, error)
+	ListLedgerPostings(ctx context.Context, arg ListLedgerPostingsParams) ([]ListLedgerPostingsRow, error)
+	ListPendingAuthorizations(ctx context.Context, arg ListPendingAuthorizationsParams) ([]Transaction, error)
+	ListPlanInstallments(ctx context.Context, planID uuid.UUID) ([]Installment, error)
+	ListProjectedCategories(ctx context.Context, arg ListProjectedCategoriesParams) ([]ListProjectedCategoriesRow, error)
+	// The merchants with the most spend of every month, up to merchant_limit
+	// per month.
+	ListProjectedMerchants(ctx context.Context, arg ListProjectedMerchantsParams) ([]ListProjectedMerchantsRow, error)
+	ListProjectedMonths(ctx context.Context, arg ListProjectedMonthsParams) ([]ListProjectedMonthsRow, error)
+	ListRefundCandidates(ctx context.Context, arg ListRefundCandidatesParams) ([]ListRefundCandidatesRow, error)
+	// Earned points of purchases whose standing spend changed since: voided,
+	// amended, refunded or won in a dispute. Dispute credits only count once the
+	// dispute is won. The points kept are floored as RewardClawback.Reversal
+	// does, so that a purchase drops out of the list once reversed.
+	ListRewardClawbacks(ctx context.Context, arg ListRewardClawbacksParams) ([]ListRewardClawbacksRow, error)
+	ListRewardCycleTotals(ctx context.Context, arg ListRewardCycleTotalsParams) ([]ListRewardCycleTotalsRow, error)
+	ListRewardEntries(ctx context.Context, arg ListRewardEntriesParams) ([]RewardEntry, error)
+	ListRewardMonths(ctx context.Context, accountID uuid.UUID) ([]ListRewardMonthsRow, error)
+	ListSavingsGoalsByAccount(ctx context.Context, accountID uuid.UUID) ([]SavingsGoal, error)
+	ListStaleSummaryMonths(ctx context.Context, limit int64) ([]ListStaleSummaryMonthsRow, error)
+	ListSubscriptionAccounts(ctx context.Context, inputDate time.Time) ([]uuid.UUID, error)
+	ListSubscriptionAlerts(ctx context.Context, arg ListSubscriptionAlertsParams) ([]SubscriptionAlert, error)
+	ListSubscriptionCandidates(ctx context.Context, arg ListSubscriptionCandidatesParams) ([]Transaction, error)
+	ListSubscriptionsByAccount(ctx context.Context, accountID uuid.UUID) ([]Subscription, error)
+	// Spend per month and category, split across the categories of split
+	// transactions.
+	ListSummaryCategories(ctx context.Context, arg ListSummaryCategoriesParams) ([]ListSummaryCategoriesRow, error)
+	// Totals per day of the transactions a summary covers: those of the
+	// account in the range, neither voided nor superseded by a correction,
+	// posted or optionally pending, carrying every tag of the search and
+	// containing its text in the description, merchant, category or note.
+	// Every summary query selects them the same way. Installment movements
+	// only count towards the balance and the installments billed, as the
+	// purchase already counts as spend; refunds give back spend instead of
+	// counting as credits.
+	ListSummaryDays(ctx context.Context, arg ListSummaryDaysParams) ([]ListSummaryDaysRow, error)
+	// The merchants with the most spend of every month, up to merchant_limit
+	// per month.
+	ListSummaryMerchants(ctx context.Context, arg ListSummaryMerchantsParams) ([]ListSummaryMerchantsRow, error)
+	// Every account and month with transactions or already projected, to
+	// rebuild the projection.
+	ListSummaryProjectionMonths(ctx context.Context) ([]ListSummaryProjectionMonthsRow, error)
+	ListSummaryTagTotals(ctx context.Context, arg ListSummaryTagTotalsParams) ([]ListSummaryTagTotalsRow, error)
+	// A page of the transactions of a summary, newest first.
+	ListSummaryTransactions(ctx context.Context, arg ListSummaryTransactionsParams) ([]Transaction, error)
+	ListTransactionAlertReasons(ctx context.Context, alertID uuid.UUID) ([]TransactionAlertReason, error)
+	ListTransactionAlertsByAccount(ctx context.Context, accountID uuid.UUID) ([]TransactionAlert, error)
+	ListTransactionAlertsByStatus(ctx context.Context, arg ListTransactionAlertsByStatusParams) ([]TransactionAlert, error)
+	ListTransactionCorrections(ctx context.Context, transactionID uuid.UUID) ([]TransactionCorrection, error)
+	ListTransactionSplits(ctx context.Context, transactionID uuid.UUID) ([]TransactionSplit, error)
+	ListTransactionTags(ctx context.Context, transactionID uuid.UUID) ([]string, error)
+	ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error)
 	ListTransactionsByAccount(ctx context.Context, arg ListTransactionsByAccountParams) ([]Transaction, error)
+	ListUnlinkedCredits(ctx context.Context, arg ListUnlinkedCreditsParams) ([]Transaction, error)
+	ListUnrewardedDebits(ctx context.Context, arg ListUnrewardedDebitsParams) ([]Transaction, error)
+	// Waits for the balance changes of the account in flight and holds off the
+	// next ones.
+	LockAccountForShare(ctx context.Context, id uuid.UUID) error
+	// Serializes the filling of the cached daily balances of an internal ledger
+	// account.
+	LockLedgerAccount(ctx context.Context, id uuid.UUID) error
+	LockRewardEntry(ctx context.Context, id uuid.UUID) error
+	MarkInstallmentPosted(ctx context.Context, arg MarkInstallmentPostedParams) (int64, error)
+	// Queues a month for the next flush, in the database transaction of the
+	// write that changed it.
+	MarkSummaryMonthStale(ctx context.Context, arg MarkSummaryMonthStaleParams) error
+	// The only change ever made to a corrected row.
+	MarkTransactionSuperseded(ctx context.Context, arg MarkTransactionSupersededParams) (int64, error)
+	MoveTransactionDisputes(ctx context.Context, arg MoveTransactionDisputesParams) error
+	// Refunds and dispute movements follow the current version of what they
+	// reverse.
+	MoveTransactionReversals(ctx context.Context, arg MoveTransactionReversalsParams) ([]Transaction, error)
+	MoveTransactionRewardEntries(ctx context.Context, arg MoveTransactionRewardEntriesParams) error
+	// Sets the balance to the sum of the account ledger postings in a single
+	// statement; no row is returned when they already agree.
+	ReconcileAccountBalance(ctx context.Context, arg ReconcileAccountBalanceParams) (Account, error)
+	// Recomputes the totals of an account and month from its posted,
+	// current, non-voided transactions, the same way ListSummaryDays does. A month left
+	// without transactions keeps a row of zeros.
+	RefreshMonthlyAccountSummary(ctx context.Context, arg RefreshMonthlyAccountSummaryParams) error
+	ReviewTransactionAlert(ctx context.Context, arg ReviewTransactionAlertParams) (TransactionAlert, error)
+	SetTransactionInstallmentPlan(ctx context.Context, arg SetTransactionInstallmentPlanParams) (Transaction, error)
+	SettleTransaction(ctx context.Context, arg SettleTransactionParams) (Transaction, error)
+	SumTransactionRefunds(ctx context.Context, reversalOf uuid.NullUUID) (string, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateDispute(ctx context.Context, arg UpdateDisputeParams) error
+	UpdateInstallmentPlanStatus(ctx context.Context, arg UpdateInstallmentPlanStatusParams) error
+	UpdateTransactionMerchant(ctx context.Context, arg UpdateTransactionMerchantParams) error
+	UpdateTransactionNote(ctx context.Context, arg UpdateTransactionNoteParams) error
+	UpdateTransactionStatus(ctx context.Context, arg UpdateTransactionStatusParams) (Transaction, error)
+	UpsertBudget(ctx context.Context, arg UpsertBudgetParams) (Budget, error)
+	UpsertDailyBalance(ctx context.Context, arg UpsertDailyBalanceParams) error
+	UpsertForecast(ctx context.Context, arg UpsertForecastParams) error
+	UpsertSubscription(ctx context.Context, arg UpsertSubscriptionParams) (Subscription, error)
 }
 
 var _ Querier = (*Queries)(nil)
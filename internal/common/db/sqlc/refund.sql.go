@@ -0,0 +1,224 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: refund.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const linkTransactionReversal = `-- name: LinkTransactionReversal :one
+UPDATE transactions
+SET reversal_of = $2, reversal_kind = $3, updated_at = $4
+WHERE id = $1
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
+`
+
+type LinkTransactionReversalParams struct {
+	ID           uuid.UUID     `json:"id"`
+	ReversalOf   uuid.NullUUID `json:"reversal_of"`
+	ReversalKind string        `json:"reversal_kind"`
+	UpdatedAt    int64         `json:"updated_at"`
+}
+
+func (q *Queries) LinkTransactionReversal(ctx context.Context, arg LinkTransactionReversalParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, linkTransactionReversal,
+		arg.ID,
+		arg.ReversalOf,
+		arg.ReversalKind,
+		arg.UpdatedAt,
+	)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Type,
+		&i.InputFileID,
+		&i.InputDate,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
+	)
+	return i, err
+}
+
+const listRefundCandidates = `-- name: ListRefundCandidates :many
+SELECT t.id, t.account_id, t.amount, t.type, t.input_file_id, t.input_date, t.created_at, t.description, t.merchant, t.category, t.transfer_id, t.voided, t.updated_at, t.reversal_of, t.reversal_kind, t.status, t.authorized_at, t.posted_at, t.note, t.installment_plan_id, t.supersedes, t.superseded_by, COALESCE(r.refunded, 0)::numeric AS refunded
+FROM transactions t
+LEFT JOIN (
+    SELECT reversal_of, SUM(amount) AS refunded
+    FROM transactions
+    WHERE reversal_of IS NOT NULL AND voided = false AND superseded_by IS NULL
+    GROUP BY reversal_of
+) r ON r.reversal_of = t.id
+WHERE t.account_id = $1
+  AND t.merchant = $2
+  AND t.type = 'debit'
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.transfer_id IS NULL
+  AND t.installment_plan_id IS NULL
+  AND t.input_date BETWEEN $3 AND $4
+ORDER BY t.input_date DESC, t.created_at DESC
+`
+
+type ListRefundCandidatesParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Merchant  string    `json:"merchant"`
+	FromDate  time.Time `json:"from_date"`
+	ToDate    time.Time `json:"to_date"`
+}
+
+type ListRefundCandidatesRow struct {
+	Transaction Transaction `json:"transaction"`
+	Refunded    string      `json:"refunded"`
+}
+
+func (q *Queries) ListRefundCandidates(ctx context.Context, arg ListRefundCandidatesParams) ([]ListRefundCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRefundCandidates,
+		arg.AccountID,
+		arg.Merchant,
+		arg.FromDate,
+		arg.ToDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRefundCandidatesRow{}
+	for rows.Next() {
+		var i ListRefundCandidatesRow
+		if err := rows.Scan(
+			&i.Transaction.ID,
+			&i.Transaction.AccountID,
+			&i.Transaction.Amount,
+			&i.Transaction.Type,
+			&i.Transaction.InputFileID,
+			&i.Transaction.InputDate,
+			&i.Transaction.CreatedAt,
+			&i.Transaction.Description,
+			&i.Transaction.Merchant,
+			&i.Transaction.Category,
+			&i.Transaction.TransferID,
+			&i.Transaction.Voided,
+			&i.Transaction.UpdatedAt,
+			&i.Transaction.ReversalOf,
+			&i.Transaction.ReversalKind,
+			&i.Transaction.Status,
+			&i.Transaction.AuthorizedAt,
+			&i.Transaction.PostedAt,
+			&i.Transaction.Note,
+			&i.Transaction.InstallmentPlanID,
+			&i.Transaction.Supersedes,
+			&i.Transaction.SupersededBy,
+			&i.Refunded,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnlinkedCredits = `-- name: ListUnlinkedCredits :many
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
+WHERE type = 'credit'
+  AND status = 'posted'
+  AND merchant <> ''
+  AND reversal_of IS NULL
+  AND transfer_id IS NULL
+  AND voided = false AND superseded_by IS NULL
+  AND (created_at, id) > ($1::bigint, $2::uuid)
+ORDER BY created_at, id
+LIMIT $3
+`
+
+type ListUnlinkedCreditsParams struct {
+	AfterCreatedAt int64     `json:"after_created_at"`
+	AfterID        uuid.UUID `json:"after_id"`
+	Limit          int64     `json:"limit"`
+}
+
+func (q *Queries) ListUnlinkedCredits(ctx context.Context, arg ListUnlinkedCreditsParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listUnlinkedCredits, arg.AfterCreatedAt, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumTransactionRefunds = `-- name: SumTransactionRefunds :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS refunded
+FROM transactions
+WHERE reversal_of = $1 AND voided = false AND superseded_by IS NULL
+`
+
+func (q *Queries) SumTransactionRefunds(ctx context.Context, reversalOf uuid.NullUUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, sumTransactionRefunds, reversalOf)
+	var refunded string
+	err := row.Scan(&refunded)
+	return refunded, err
+}
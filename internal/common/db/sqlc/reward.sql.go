@@ -0,0 +1,383 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: reward.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createRewardEntry = `-- name: CreateRewardEntry :one
+INSERT INTO reward_entries (id, account_id, kind, points, transaction_id, rule, cycle_start, effective_date, description, created_at, basis)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (transaction_id, kind) WHERE kind <> 'reversal' DO NOTHING
+RETURNING id, account_id, kind, points, transaction_id, rule, cycle_start, effective_date, description, created_at, basis
+`
+
+type CreateRewardEntryParams struct {
+	ID            uuid.UUID `json:"id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Kind          string    `json:"kind"`
+	Points        int64     `json:"points"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Rule          string    `json:"rule"`
+	CycleStart    time.Time `json:"cycle_start"`
+	EffectiveDate time.Time `json:"effective_date"`
+	Description   string    `json:"description"`
+	CreatedAt     int64     `json:"created_at"`
+	Basis         string    `json:"basis"`
+}
+
+func (q *Queries) CreateRewardEntry(ctx context.Context, arg CreateRewardEntryParams) (RewardEntry, error) {
+	row := q.db.QueryRowContext(ctx, createRewardEntry,
+		arg.ID,
+		arg.AccountID,
+		arg.Kind,
+		arg.Points,
+		arg.TransactionID,
+		arg.Rule,
+		arg.CycleStart,
+		arg.EffectiveDate,
+		arg.Description,
+		arg.CreatedAt,
+		arg.Basis,
+	)
+	var i RewardEntry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Kind,
+		&i.Points,
+		&i.TransactionID,
+		&i.Rule,
+		&i.CycleStart,
+		&i.EffectiveDate,
+		&i.Description,
+		&i.CreatedAt,
+		&i.Basis,
+	)
+	return i, err
+}
+
+const getRewardBalance = `-- name: GetRewardBalance :one
+SELECT
+    COALESCE(SUM(points), 0)::bigint AS balance,
+    COALESCE(SUM(points) FILTER (WHERE kind IN ('earn', 'reversal')), 0)::bigint AS earned,
+    COALESCE(-SUM(points) FILTER (WHERE kind = 'redeem'), 0)::bigint AS redeemed
+FROM reward_entries
+WHERE account_id = $1
+`
+
+type GetRewardBalanceRow struct {
+	Balance  int64 `json:"balance"`
+	Earned   int64 `json:"earned"`
+	Redeemed int64 `json:"redeemed"`
+}
+
+func (q *Queries) GetRewardBalance(ctx context.Context, accountID uuid.UUID) (GetRewardBalanceRow, error) {
+	row := q.db.QueryRowContext(ctx, getRewardBalance, accountID)
+	var i GetRewardBalanceRow
+	err := row.Scan(&i.Balance, &i.Earned, &i.Redeemed)
+	return i, err
+}
+
+const listRewardClawbacks = `-- name: ListRewardClawbacks :many
+WITH standing AS (
+    SELECT
+        e.id,
+        CASE WHEN t.voided THEN 0 ELSE GREATEST(0, LEAST(e.basis, -t.amount - COALESCE((
+            SELECT SUM(r.amount) FROM transactions r
+            WHERE r.reversal_of = t.id AND r.voided = false AND r.superseded_by IS NULL
+              AND (r.reversal_kind <> 'dispute' OR EXISTS (
+                  SELECT 1 FROM disputes d
+                  WHERE d.credit_transaction_id = r.id AND d.status = 'won'
+              ))
+        ), 0))) END::numeric AS retained,
+        COALESCE((
+            SELECT SUM(x.points) FROM reward_entries x
+            WHERE x.transaction_id = e.transaction_id AND x.kind = 'reversal'
+        ), 0)::bigint AS reversed
+    FROM reward_entries e
+    JOIN transactions t ON t.id = e.transaction_id
+    WHERE e.kind = 'earn'
+      AND e.points > 0
+      AND e.basis > 0
+      AND ($2::uuid IS NULL OR e.id = $2)
+)
+SELECT e.id, e.account_id, e.kind, e.points, e.transaction_id, e.rule, e.cycle_start, e.effective_date, e.description, e.created_at, e.basis, s.retained, s.reversed
+FROM standing s
+JOIN reward_entries e ON e.id = s.id
+WHERE e.points * ROUND(s.retained * 100)::bigint / ROUND(e.basis * 100)::bigint - e.points <> s.reversed
+ORDER BY e.created_at, e.id
+LIMIT $1
+`
+
+type ListRewardClawbacksParams struct {
+	Limit   int64         `json:"limit"`
+	EntryID uuid.NullUUID `json:"entry_id"`
+}
+
+type ListRewardClawbacksRow struct {
+	RewardEntry RewardEntry `json:"reward_entry"`
+	Retained    string      `json:"retained"`
+	Reversed    int64       `json:"reversed"`
+}
+
+// Earned points of purchases whose standing spend changed since: voided,
+// amended, refunded or won in a dispute. Dispute credits only count once the
+// dispute is won. The points kept are floored as RewardClawback.Reversal
+// does, so that a purchase drops out of the list once reversed.
+func (q *Queries) ListRewardClawbacks(ctx context.Context, arg ListRewardClawbacksParams) ([]ListRewardClawbacksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRewardClawbacks, arg.Limit, arg.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRewardClawbacksRow{}
+	for rows.Next() {
+		var i ListRewardClawbacksRow
+		if err := rows.Scan(
+			&i.RewardEntry.ID,
+			&i.RewardEntry.AccountID,
+			&i.RewardEntry.Kind,
+			&i.RewardEntry.Points,
+			&i.RewardEntry.TransactionID,
+			&i.RewardEntry.Rule,
+			&i.RewardEntry.CycleStart,
+			&i.RewardEntry.EffectiveDate,
+			&i.RewardEntry.Description,
+			&i.RewardEntry.CreatedAt,
+			&i.RewardEntry.Basis,
+			&i.Retained,
+			&i.Reversed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRewardCycleTotals = `-- name: ListRewardCycleTotals :many
+SELECT rule, COALESCE(SUM(points), 0)::bigint AS points
+FROM reward_entries
+WHERE account_id = $1 AND cycle_start = $2 AND kind = 'earn'
+GROUP BY rule
+`
+
+type ListRewardCycleTotalsParams struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	CycleStart time.Time `json:"cycle_start"`
+}
+
+type ListRewardCycleTotalsRow struct {
+	Rule   string `json:"rule"`
+	Points int64  `json:"points"`
+}
+
+func (q *Queries) ListRewardCycleTotals(ctx context.Context, arg ListRewardCycleTotalsParams) ([]ListRewardCycleTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRewardCycleTotals, arg.AccountID, arg.CycleStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRewardCycleTotalsRow{}
+	for rows.Next() {
+		var i ListRewardCycleTotalsRow
+		if err := rows.Scan(&i.Rule, &i.Points); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRewardEntries = `-- name: ListRewardEntries :many
+SELECT id, account_id, kind, points, transaction_id, rule, cycle_start, effective_date, description, created_at, basis FROM reward_entries
+WHERE account_id = $1
+ORDER BY effective_date DESC, created_at DESC, id
+LIMIT $2 OFFSET $3
+`
+
+type ListRewardEntriesParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Limit     int64     `json:"limit"`
+	Offset    int64     `json:"offset"`
+}
+
+func (q *Queries) ListRewardEntries(ctx context.Context, arg ListRewardEntriesParams) ([]RewardEntry, error) {
+	rows, err := q.db.QueryContext(ctx, listRewardEntries, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RewardEntry{}
+	for rows.Next() {
+		var i RewardEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Kind,
+			&i.Points,
+			&i.TransactionID,
+			&i.Rule,
+			&i.CycleStart,
+			&i.EffectiveDate,
+			&i.Description,
+			&i.CreatedAt,
+			&i.Basis,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRewardMonths = `-- name: ListRewardMonths :many
+SELECT
+    date_trunc('month', effective_date)::timestamp AS month,
+    COALESCE(SUM(points) FILTER (WHERE kind IN ('earn', 'reversal')), 0)::bigint AS earned,
+    COALESCE(-SUM(points) FILTER (WHERE kind = 'redeem'), 0)::bigint AS redeemed
+FROM reward_entries
+WHERE account_id = $1
+GROUP BY 1
+ORDER BY 1
+`
+
+type ListRewardMonthsRow struct {
+	Month    time.Time `json:"month"`
+	Earned   int64     `json:"earned"`
+	Redeemed int64     `json:"redeemed"`
+}
+
+func (q *Queries) ListRewardMonths(ctx context.Context, accountID uuid.UUID) ([]ListRewardMonthsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRewardMonths, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRewardMonthsRow{}
+	for rows.Next() {
+		var i ListRewardMonthsRow
+		if err := rows.Scan(&i.Month, &i.Earned, &i.Redeemed); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnrewardedDebits = `-- name: ListUnrewardedDebits :many
+SELECT t.id, t.account_id, t.amount, t.type, t.input_file_id, t.input_date, t.created_at, t.description, t.merchant, t.category, t.transfer_id, t.voided, t.updated_at, t.reversal_of, t.reversal_kind, t.status, t.authorized_at, t.posted_at, t.note, t.installment_plan_id, t.supersedes, t.superseded_by FROM transactions t
+WHERE t.type = 'debit'
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.transfer_id IS NULL
+  AND t.input_date >= $1
+  AND NOT EXISTS (
+      SELECT 1 FROM reward_entries e
+      WHERE e.transaction_id = t.id AND e.kind = 'earn'
+  )
+  AND (t.created_at, t.id) > ($2::bigint, $3::uuid)
+ORDER BY t.created_at, t.id
+LIMIT $4
+`
+
+type ListUnrewardedDebitsParams struct {
+	StartDate      time.Time `json:"start_date"`
+	AfterCreatedAt int64     `json:"after_created_at"`
+	AfterID        uuid.UUID `json:"after_id"`
+	Limit          int64     `json:"limit"`
+}
+
+func (q *Queries) ListUnrewardedDebits(ctx context.Context, arg ListUnrewardedDebitsParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listUnrewardedDebits,
+		arg.StartDate,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockRewardEntry = `-- name: LockRewardEntry :exec
+SELECT id FROM reward_entries
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockRewardEntry(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, lockRewardEntry, id)
+	return err
+}
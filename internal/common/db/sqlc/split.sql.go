@@ -0,0 +1,98 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: split.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createTransactionSplit = `-- name: CreateTransactionSplit :one
+INSERT INTO transaction_splits (id, transaction_id, position, amount, category, note, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, transaction_id, position, amount, category, note, created_at
+`
+
+type CreateTransactionSplitParams struct {
+	ID            uuid.UUID `json:"id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Position      int32     `json:"position"`
+	Amount        string    `json:"amount"`
+	Category      string    `json:"category"`
+	Note          string    `json:"note"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+func (q *Queries) CreateTransactionSplit(ctx context.Context, arg CreateTransactionSplitParams) (TransactionSplit, error) {
+	row := q.db.QueryRowContext(ctx, createTransactionSplit,
+		arg.ID,
+		arg.TransactionID,
+		arg.Position,
+		arg.Amount,
+		arg.Category,
+		arg.Note,
+		arg.CreatedAt,
+	)
+	var i TransactionSplit
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.Position,
+		&i.Amount,
+		&i.Category,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteTransactionSplits = `-- name: DeleteTransactionSplits :exec
+DELETE FROM transaction_splits
+WHERE transaction_id = $1
+`
+
+func (q *Queries) DeleteTransactionSplits(ctx context.Context, transactionID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTransactionSplits, transactionID)
+	return err
+}
+
+const listTransactionSplits = `-- name: ListTransactionSplits :many
+SELECT id, transaction_id, position, amount, category, note, created_at FROM transaction_splits
+WHERE transaction_id = $1
+ORDER BY position
+`
+
+func (q *Queries) ListTransactionSplits(ctx context.Context, transactionID uuid.UUID) ([]TransactionSplit, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionSplits, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransactionSplit{}
+	for rows.Next() {
+		var i TransactionSplit
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.Position,
+			&i.Amount,
+			&i.Category,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,305 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: subscription.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createSubscriptionAlert = `-- name: CreateSubscriptionAlert :one
+INSERT INTO subscription_alerts (id, subscription_id, account_id, kind, charge_date, amount, previous_amount, message, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (subscription_id, kind, charge_date) DO NOTHING
+RETURNING id, subscription_id, account_id, kind, charge_date, amount, previous_amount, message, created_at
+`
+
+type CreateSubscriptionAlertParams struct {
+	ID             uuid.UUID `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	AccountID      uuid.UUID `json:"account_id"`
+	Kind           string    `json:"kind"`
+	ChargeDate     time.Time `json:"charge_date"`
+	Amount         string    `json:"amount"`
+	PreviousAmount string    `json:"previous_amount"`
+	Message        string    `json:"message"`
+	CreatedAt      int64     `json:"created_at"`
+}
+
+func (q *Queries) CreateSubscriptionAlert(ctx context.Context, arg CreateSubscriptionAlertParams) (SubscriptionAlert, error) {
+	row := q.db.QueryRowContext(ctx, createSubscriptionAlert,
+		arg.ID,
+		arg.SubscriptionID,
+		arg.AccountID,
+		arg.Kind,
+		arg.ChargeDate,
+		arg.Amount,
+		arg.PreviousAmount,
+		arg.Message,
+		arg.CreatedAt,
+	)
+	var i SubscriptionAlert
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.AccountID,
+		&i.Kind,
+		&i.ChargeDate,
+		&i.Amount,
+		&i.PreviousAmount,
+		&i.Message,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listSubscriptionAccounts = `-- name: ListSubscriptionAccounts :many
+SELECT DISTINCT account_id FROM transactions
+WHERE type = 'debit' AND status = 'posted' AND voided = false AND superseded_by IS NULL AND input_date >= $1
+ORDER BY account_id
+`
+
+func (q *Queries) ListSubscriptionAccounts(ctx context.Context, inputDate time.Time) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listSubscriptionAccounts, inputDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var account_id uuid.UUID
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubscriptionAlerts = `-- name: ListSubscriptionAlerts :many
+SELECT id, subscription_id, account_id, kind, charge_date, amount, previous_amount, message, created_at FROM subscription_alerts
+WHERE account_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2
+`
+
+type ListSubscriptionAlertsParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Limit     int64     `json:"limit"`
+}
+
+func (q *Queries) ListSubscriptionAlerts(ctx context.Context, arg ListSubscriptionAlertsParams) ([]SubscriptionAlert, error) {
+	rows, err := q.db.QueryContext(ctx, listSubscriptionAlerts, arg.AccountID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SubscriptionAlert{}
+	for rows.Next() {
+		var i SubscriptionAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.AccountID,
+			&i.Kind,
+			&i.ChargeDate,
+			&i.Amount,
+			&i.PreviousAmount,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubscriptionCandidates = `-- name: ListSubscriptionCandidates :many
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
+WHERE account_id = $1
+  AND type = 'debit'
+  AND status = 'posted'
+  AND voided = false AND superseded_by IS NULL
+  AND transfer_id IS NULL
+  AND input_date >= $2
+ORDER BY input_date, created_at, id
+`
+
+type ListSubscriptionCandidatesParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	InputDate time.Time `json:"input_date"`
+}
+
+func (q *Queries) ListSubscriptionCandidates(ctx context.Context, arg ListSubscriptionCandidatesParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listSubscriptionCandidates, arg.AccountID, arg.InputDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubscriptionsByAccount = `-- name: ListSubscriptionsByAccount :many
+SELECT id, account_id, merchant, category, cadence, average_amount, last_amount, last_charge_date, next_expected_date, charge_count, status, created_at, updated_at FROM subscriptions
+WHERE account_id = $1
+ORDER BY status, next_expected_date, merchant
+`
+
+func (q *Queries) ListSubscriptionsByAccount(ctx context.Context, accountID uuid.UUID) ([]Subscription, error) {
+	rows, err := q.db.QueryContext(ctx, listSubscriptionsByAccount, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Subscription{}
+	for rows.Next() {
+		var i Subscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Merchant,
+			&i.Category,
+			&i.Cadence,
+			&i.AverageAmount,
+			&i.LastAmount,
+			&i.LastChargeDate,
+			&i.NextExpectedDate,
+			&i.ChargeCount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertSubscription = `-- name: UpsertSubscription :one
+INSERT INTO subscriptions (id, account_id, merchant, category, cadence, average_amount, last_amount, last_charge_date, next_expected_date, charge_count, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (account_id, merchant) DO UPDATE
+SET category = EXCLUDED.category,
+    cadence = EXCLUDED.cadence,
+    average_amount = EXCLUDED.average_amount,
+    last_amount = EXCLUDED.last_amount,
+    last_charge_date = EXCLUDED.last_charge_date,
+    next_expected_date = EXCLUDED.next_expected_date,
+    charge_count = EXCLUDED.charge_count,
+    status = EXCLUDED.status,
+    updated_at = EXCLUDED.updated_at
+RETURNING id, account_id, merchant, category, cadence, average_amount, last_amount, last_charge_date, next_expected_date, charge_count, status, created_at, updated_at
+`
+
+type UpsertSubscriptionParams struct {
+	ID               uuid.UUID `json:"id"`
+	AccountID        uuid.UUID `json:"account_id"`
+	Merchant         string    `json:"merchant"`
+	Category         string    `json:"category"`
+	Cadence          string    `json:"cadence"`
+	AverageAmount    string    `json:"average_amount"`
+	LastAmount       string    `json:"last_amount"`
+	LastChargeDate   time.Time `json:"last_charge_date"`
+	NextExpectedDate time.Time `json:"next_expected_date"`
+	ChargeCount      int32     `json:"charge_count"`
+	Status           string    `json:"status"`
+	CreatedAt        int64     `json:"created_at"`
+	UpdatedAt        int64     `json:"updated_at"`
+}
+
+func (q *Queries) UpsertSubscription(ctx context.Context, arg UpsertSubscriptionParams) (Subscription, error) {
+	row := q.db.QueryRowContext(ctx, upsertSubscription,
+		arg.ID,
+		arg.AccountID,
+		arg.Merchant,
+		arg.Category,
+		arg.Cadence,
+		arg.AverageAmount,
+		arg.LastAmount,
+		arg.LastChargeDate,
+		arg.NextExpectedDate,
+		arg.ChargeCount,
+		arg.Status,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i Subscription
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Merchant,
+		&i.Category,
+		&i.Cadence,
+		&i.AverageAmount,
+		&i.LastAmount,
+		&i.LastChargeDate,
+		&i.NextExpectedDate,
+		&i.ChargeCount,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,445 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: summary.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const listSummaryCategories = `-- name: ListSummaryCategories :many
+SELECT date_trunc('month', t.input_date)::timestamp AS month,
+       (CASE WHEN COALESCE(s.category, t.category) = '' THEN 'uncategorized'
+             ELSE COALESCE(s.category, t.category) END)::text AS category,
+       SUM(-COALESCE(s.amount, t.amount))::float8 AS total,
+       COUNT(DISTINCT t.id) AS count
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = $1
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND ($2::timestamp IS NULL OR t.input_date >= $2::timestamp)
+  AND ($3::timestamp IS NULL OR t.input_date < $3::timestamp)
+  AND (t.status = 'posted' OR ($4::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> $5::text[]
+  AND ($6::text = ''
+       OR strpos(lower(t.description), lower($6::text)) > 0
+       OR strpos(lower(t.merchant), lower($6::text)) > 0
+       OR strpos(lower(t.category), lower($6::text)) > 0
+       OR strpos(lower(t.note), lower($6::text)) > 0)
+  AND t.amount < 0
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 1, 2
+ORDER BY 1, 3 DESC, 2
+`
+
+type ListSummaryCategoriesParams struct {
+	AccountID      uuid.UUID    `json:"account_id"`
+	FromDate       sql.NullTime `json:"from_date"`
+	ToDate         sql.NullTime `json:"to_date"`
+	IncludePending bool         `json:"include_pending"`
+	Tags           []string     `json:"tags"`
+	Text           string       `json:"text"`
+}
+
+type ListSummaryCategoriesRow struct {
+	Month    time.Time `json:"month"`
+	Category string    `json:"category"`
+	Total    float64   `json:"total"`
+	Count    int64     `json:"count"`
+}
+
+// Spend per month and category, split across the categories of split
+// transactions.
+func (q *Queries) ListSummaryCategories(ctx context.Context, arg ListSummaryCategoriesParams) ([]ListSummaryCategoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSummaryCategories,
+		arg.AccountID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.IncludePending,
+		pq.Array(arg.Tags),
+		arg.Text,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSummaryCategoriesRow{}
+	for rows.Next() {
+		var i ListSummaryCategoriesRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.Category,
+			&i.Total,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSummaryDays = `-- name: ListSummaryDays :many
+SELECT date_trunc('day', t.input_date)::timestamp AS day,
+       COUNT(*) AS total,
+       COALESCE(SUM(t.amount), 0)::float8 AS amount,
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NULL) AS credit_count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NULL), 0)::float8 AS credits,
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount <= 0) AS debit_count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount <= 0), 0)::float8 AS debits,
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NOT NULL) AS refund_count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NOT NULL), 0)::float8 AS refunds,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'installment'), 0)::float8 AS installments
+FROM transactions t
+WHERE t.account_id = $1
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND ($2::timestamp IS NULL OR t.input_date >= $2::timestamp)
+  AND ($3::timestamp IS NULL OR t.input_date < $3::timestamp)
+  AND (t.status = 'posted' OR ($4::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> $5::text[]
+  AND ($6::text = ''
+       OR strpos(lower(t.description), lower($6::text)) > 0
+       OR strpos(lower(t.merchant), lower($6::text)) > 0
+       OR strpos(lower(t.category), lower($6::text)) > 0
+       OR strpos(lower(t.note), lower($6::text)) > 0)
+GROUP BY 1
+ORDER BY 1
+`
+
+type ListSummaryDaysParams struct {
+	AccountID      uuid.UUID    `json:"account_id"`
+	FromDate       sql.NullTime `json:"from_date"`
+	ToDate         sql.NullTime `json:"to_date"`
+	IncludePending bool         `json:"include_pending"`
+	Tags           []string     `json:"tags"`
+	Text           string       `json:"text"`
+}
+
+type ListSummaryDaysRow struct {
+	Day          time.Time `json:"day"`
+	Total        int64     `json:"total"`
+	Amount       float64   `json:"amount"`
+	CreditCount  int64     `json:"credit_count"`
+	Credits      float64   `json:"credits"`
+	DebitCount   int64     `json:"debit_count"`
+	Debits       float64   `json:"debits"`
+	RefundCount  int64     `json:"refund_count"`
+	Refunds      float64   `json:"refunds"`
+	Installments float64   `json:"installments"`
+}
+
+// Totals per day of the transactions a summary covers: those of the
+// account in the range, neither voided nor superseded by a correction,
+// posted or optionally pending, carrying every tag of the search and
+// containing its text in the description, merchant, category or note.
+// Every summary query selects them the same way. Installment movements
+// only count towards the balance and the installments billed, as the
+// purchase already counts as spend; refunds give back spend instead of
+// counting as credits.
+func (q *Queries) ListSummaryDays(ctx context.Context, arg ListSummaryDaysParams) ([]ListSummaryDaysRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSummaryDays,
+		arg.AccountID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.IncludePending,
+		pq.Array(arg.Tags),
+		arg.Text,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSummaryDaysRow{}
+	for rows.Next() {
+		var i ListSummaryDaysRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Total,
+			&i.Amount,
+			&i.CreditCount,
+			&i.Credits,
+			&i.DebitCount,
+			&i.Debits,
+			&i.RefundCount,
+			&i.Refunds,
+			&i.Installments,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSummaryMerchants = `-- name: ListSummaryMerchants :many
+SELECT m.month, m.merchant, m.category, m.total, m.count
+FROM (
+    SELECT date_trunc('month', t.input_date)::timestamp AS month,
+           t.merchant,
+           (array_agg(t.category ORDER BY t.input_date, t.id))[1]::text AS category,
+           SUM(-t.amount)::float8 AS total,
+           COUNT(*) AS count,
+           ROW_NUMBER() OVER (PARTITION BY date_trunc('month', t.input_date)::timestamp
+                              ORDER BY SUM(-t.amount) DESC, t.merchant) AS rank
+    FROM transactions t
+    WHERE t.account_id = $1
+      AND t.voided = false AND t.superseded_by IS NULL
+      AND ($2::timestamp IS NULL OR t.input_date >= $2::timestamp)
+      AND ($3::timestamp IS NULL OR t.input_date < $3::timestamp)
+      AND (t.status = 'posted' OR ($4::boolean AND t.status = 'pending'))
+      AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> $5::text[]
+      AND ($6::text = ''
+           OR strpos(lower(t.description), lower($6::text)) > 0
+           OR strpos(lower(t.merchant), lower($6::text)) > 0
+           OR strpos(lower(t.category), lower($6::text)) > 0
+           OR strpos(lower(t.note), lower($6::text)) > 0)
+      AND t.amount < 0
+      AND t.merchant <> ''
+      AND t.type NOT IN ('installment', 'installment_conversion')
+    GROUP BY 1, 2
+) m
+WHERE m.rank <= $7::bigint
+ORDER BY m.month, m.rank
+`
+
+type ListSummaryMerchantsParams struct {
+	AccountID      uuid.UUID    `json:"account_id"`
+	FromDate       sql.NullTime `json:"from_date"`
+	ToDate         sql.NullTime `json:"to_date"`
+	IncludePending bool         `json:"include_pending"`
+	Tags           []string     `json:"tags"`
+	Text           string       `json:"text"`
+	MerchantLimit  int64        `json:"merchant_limit"`
+}
+
+type ListSummaryMerchantsRow struct {
+	Month    time.Time `json:"month"`
+	Merchant string    `json:"merchant"`
+	Category string    `json:"category"`
+	Total    float64   `json:"total"`
+	Count    int64     `json:"count"`
+}
+
+// The merchants with the most spend of every month, up to merchant_limit
+// per month.
+func (q *Queries) ListSummaryMerchants(ctx context.Context, arg ListSummaryMerchantsParams) ([]ListSummaryMerchantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSummaryMerchants,
+		arg.AccountID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.IncludePending,
+		pq.Array(arg.Tags),
+		arg.Text,
+		arg.MerchantLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSummaryMerchantsRow{}
+	for rows.Next() {
+		var i ListSummaryMerchantsRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.Merchant,
+			&i.Category,
+			&i.Total,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSummaryTagTotals = `-- name: ListSummaryTagTotals :many
+SELECT g.tag,
+       COUNT(*) AS count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.amount > 0), 0)::float8 AS total_credit,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.amount <= 0), 0)::float8 AS total_debit,
+       SUM(t.amount)::float8 AS total
+FROM transactions t
+JOIN transaction_tags g ON g.transaction_id = t.id
+WHERE t.account_id = $1
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND ($2::timestamp IS NULL OR t.input_date >= $2::timestamp)
+  AND ($3::timestamp IS NULL OR t.input_date < $3::timestamp)
+  AND (t.status = 'posted' OR ($4::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> $5::text[]
+  AND ($6::text = ''
+       OR strpos(lower(t.description), lower($6::text)) > 0
+       OR strpos(lower(t.merchant), lower($6::text)) > 0
+       OR strpos(lower(t.category), lower($6::text)) > 0
+       OR strpos(lower(t.note), lower($6::text)) > 0)
+GROUP BY g.tag
+ORDER BY total_debit, g.tag
+`
+
+type ListSummaryTagTotalsParams struct {
+	AccountID      uuid.UUID    `json:"account_id"`
+	FromDate       sql.NullTime `json:"from_date"`
+	ToDate         sql.NullTime `json:"to_date"`
+	IncludePending bool         `json:"include_pending"`
+	Tags           []string     `json:"tags"`
+	Text           string       `json:"text"`
+}
+
+type ListSummaryTagTotalsRow struct {
+	Tag         string  `json:"tag"`
+	Count       int64   `json:"count"`
+	TotalCredit float64 `json:"total_credit"`
+	TotalDebit  float64 `json:"total_debit"`
+	Total       float64 `json:"total"`
+}
+
+func (q *Queries) ListSummaryTagTotals(ctx context.Context, arg ListSummaryTagTotalsParams) ([]ListSummaryTagTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSummaryTagTotals,
+		arg.AccountID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.IncludePending,
+		pq.Array(arg.Tags),
+		arg.Text,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSummaryTagTotalsRow{}
+	for rows.Next() {
+		var i ListSummaryTagTotalsRow
+		if err := rows.Scan(
+			&i.Tag,
+			&i.Count,
+			&i.TotalCredit,
+			&i.TotalDebit,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSummaryTransactions = `-- name: ListSummaryTransactions :many
+SELECT t.id, t.account_id, t.amount, t.type, t.input_file_id, t.input_date, t.created_at, t.description, t.merchant, t.category, t.transfer_id, t.voided, t.updated_at, t.reversal_of, t.reversal_kind, t.status, t.authorized_at, t.posted_at, t.note, t.installment_plan_id, t.supersedes, t.superseded_by FROM transactions t
+WHERE t.account_id = $1
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND ($2::timestamp IS NULL OR t.input_date >= $2::timestamp)
+  AND ($3::timestamp IS NULL OR t.input_date < $3::timestamp)
+  AND (t.status = 'posted' OR ($4::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> $5::text[]
+  AND ($6::text = ''
+       OR strpos(lower(t.description), lower($6::text)) > 0
+       OR strpos(lower(t.merchant), lower($6::text)) > 0
+       OR strpos(lower(t.category), lower($6::text)) > 0
+       OR strpos(lower(t.note), lower($6::text)) > 0)
+ORDER BY t.input_date DESC, t.id
+LIMIT $8::bigint OFFSET $7::bigint
+`
+
+type ListSummaryTransactionsParams struct {
+	AccountID      uuid.UUID    `json:"account_id"`
+	FromDate       sql.NullTime `json:"from_date"`
+	ToDate         sql.NullTime `json:"to_date"`
+	IncludePending bool         `json:"include_pending"`
+	Tags           []string     `json:"tags"`
+	Text           string       `json:"text"`
+	RowOffset      int64        `json:"row_offset"`
+	RowLimit       int64        `json:"row_limit"`
+}
+
+// A page of the transactions of a summary, newest first.
+func (q *Queries) ListSummaryTransactions(ctx context.Context, arg ListSummaryTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listSummaryTransactions,
+		arg.AccountID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.IncludePending,
+		pq.Array(arg.Tags),
+		arg.Text,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
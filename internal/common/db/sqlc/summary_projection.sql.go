@@ -0,0 +1,478 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: summary_projection.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const deleteMonthlyCategorySummary = `-- name: DeleteMonthlyCategorySummary :exec
+DELETE FROM monthly_category_summary
+WHERE account_id = $1 AND month = $2
+`
+
+type DeleteMonthlyCategorySummaryParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+func (q *Queries) DeleteMonthlyCategorySummary(ctx context.Context, arg DeleteMonthlyCategorySummaryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMonthlyCategorySummary, arg.AccountID, arg.Month)
+	return err
+}
+
+const deleteMonthlyMerchantSummary = `-- name: DeleteMonthlyMerchantSummary :exec
+DELETE FROM monthly_merchant_summary
+WHERE account_id = $1 AND month = $2
+`
+
+type DeleteMonthlyMerchantSummaryParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+func (q *Queries) DeleteMonthlyMerchantSummary(ctx context.Context, arg DeleteMonthlyMerchantSummaryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMonthlyMerchantSummary, arg.AccountID, arg.Month)
+	return err
+}
+
+const deleteStaleSummaryMonth = `-- name: DeleteStaleSummaryMonth :exec
+DELETE FROM stale_summary_months
+WHERE account_id = $1 AND month = $2 AND version = $3
+`
+
+type DeleteStaleSummaryMonthParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+	Version   int64     `json:"version"`
+}
+
+// Dequeues a refreshed month unless it was written to since the refresh
+// read its version.
+func (q *Queries) DeleteStaleSummaryMonth(ctx context.Context, arg DeleteStaleSummaryMonthParams) error {
+	_, err := q.db.ExecContext(ctx, deleteStaleSummaryMonth, arg.AccountID, arg.Month, arg.Version)
+	return err
+}
+
+const getStaleSummaryMonthVersion = `-- name: GetStaleSummaryMonthVersion :one
+SELECT version FROM stale_summary_months
+WHERE account_id = $1 AND month = $2
+`
+
+type GetStaleSummaryMonthVersionParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+func (q *Queries) GetStaleSummaryMonthVersion(ctx context.Context, arg GetStaleSummaryMonthVersionParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getStaleSummaryMonthVersion, arg.AccountID, arg.Month)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const insertMonthlyCategorySummary = `-- name: InsertMonthlyCategorySummary :exec
+INSERT INTO monthly_category_summary (account_id, month, category, total, count)
+SELECT $1::uuid,
+       $2::date,
+       CASE WHEN COALESCE(s.category, t.category) = '' THEN 'uncategorized'
+            ELSE COALESCE(s.category, t.category) END,
+       SUM(-COALESCE(s.amount, t.amount)),
+       COUNT(DISTINCT t.id)
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = $1::uuid
+  AND t.input_date >= $2::date
+  AND t.input_date < $2::date + INTERVAL '1 month'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.status = 'posted'
+  AND t.amount < 0
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 3
+`
+
+type InsertMonthlyCategorySummaryParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+// Recomputes the spend per category of an account and month, as
+// ListSummaryCategories does.
+func (q *Queries) InsertMonthlyCategorySummary(ctx context.Context, arg InsertMonthlyCategorySummaryParams) error {
+	_, err := q.db.ExecContext(ctx, insertMonthlyCategorySummary, arg.AccountID, arg.Month)
+	return err
+}
+
+const insertMonthlyMerchantSummary = `-- name: InsertMonthlyMerchantSummary :exec
+INSERT INTO monthly_merchant_summary (account_id, month, merchant, category, total, count)
+SELECT $1::uuid,
+       $2::date,
+       t.merchant,
+       (array_agg(t.category ORDER BY t.input_date, t.id))[1],
+       SUM(-t.amount),
+       COUNT(*)
+FROM transactions t
+WHERE t.account_id = $1::uuid
+  AND t.input_date >= $2::date
+  AND t.input_date < $2::date + INTERVAL '1 month'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.status = 'posted'
+  AND t.amount < 0
+  AND t.merchant <> ''
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 3
+`
+
+type InsertMonthlyMerchantSummaryParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+// Recomputes the spend per merchant of an account and month, as
+// ListSummaryMerchants does.
+func (q *Queries) InsertMonthlyMerchantSummary(ctx context.Context, arg InsertMonthlyMerchantSummaryParams) error {
+	_, err := q.db.ExecContext(ctx, insertMonthlyMerchantSummary, arg.AccountID, arg.Month)
+	return err
+}
+
+const listProjectedCategories = `-- name: ListProjectedCategories :many
+SELECT month, category, total::float8 AS total, count
+FROM monthly_category_summary
+WHERE account_id = $1
+  AND ($2::date IS NULL OR month >= $2::date)
+  AND ($3::date IS NULL OR month < $3::date)
+ORDER BY month, total DESC, category
+`
+
+type ListProjectedCategoriesParams struct {
+	AccountID uuid.UUID    `json:"account_id"`
+	FromDate  sql.NullTime `json:"from_date"`
+	ToDate    sql.NullTime `json:"to_date"`
+}
+
+type ListProjectedCategoriesRow struct {
+	Month    time.Time `json:"month"`
+	Category string    `json:"category"`
+	Total    float64   `json:"total"`
+	Count    int32     `json:"count"`
+}
+
+func (q *Queries) ListProjectedCategories(ctx context.Context, arg ListProjectedCategoriesParams) ([]ListProjectedCategoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProjectedCategories, arg.AccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectedCategoriesRow{}
+	for rows.Next() {
+		var i ListProjectedCategoriesRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.Category,
+			&i.Total,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectedMerchants = `-- name: ListProjectedMerchants :many
+SELECT m.month, m.merchant, m.category, m.total, m.count
+FROM (
+    SELECT month, merchant, category, total::float8 AS total, count,
+           ROW_NUMBER() OVER (PARTITION BY month ORDER BY total DESC, merchant) AS rank
+    FROM monthly_merchant_summary
+    WHERE account_id = $1
+      AND ($2::date IS NULL OR month >= $2::date)
+      AND ($3::date IS NULL OR month < $3::date)
+) m
+WHERE m.rank <= $4::bigint
+ORDER BY m.month, m.rank
+`
+
+type ListProjectedMerchantsParams struct {
+	AccountID     uuid.UUID    `json:"account_id"`
+	FromDate      sql.NullTime `json:"from_date"`
+	ToDate        sql.NullTime `json:"to_date"`
+	MerchantLimit int64        `json:"merchant_limit"`
+}
+
+type ListProjectedMerchantsRow struct {
+	Month    time.Time `json:"month"`
+	Merchant string    `json:"merchant"`
+	Category string    `json:"category"`
+	Total    float64   `json:"total"`
+	Count    int32     `json:"count"`
+}
+
+// The merchants with the most spend of every month, up to merchant_limit
+// per month.
+func (q *Queries) ListProjectedMerchants(ctx context.Context, arg ListProjectedMerchantsParams) ([]ListProjectedMerchantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProjectedMerchants,
+		arg.AccountID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.MerchantLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectedMerchantsRow{}
+	for rows.Next() {
+		var i ListProjectedMerchantsRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.Merchant,
+			&i.Category,
+			&i.Total,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectedMonths = `-- name: ListProjectedMonths :many
+SELECT month,
+       total_count,
+       amount::float8 AS amount,
+       credit_count,
+       credits::float8 AS credits,
+       debit_count,
+       debits::float8 AS debits,
+       refund_count,
+       refunds::float8 AS refunds,
+       installments::float8 AS installments
+FROM monthly_account_summary
+WHERE account_id = $1
+  AND total_count > 0
+  AND ($2::date IS NULL OR month >= $2::date)
+  AND ($3::date IS NULL OR month < $3::date)
+ORDER BY month
+`
+
+type ListProjectedMonthsParams struct {
+	AccountID uuid.UUID    `json:"account_id"`
+	FromDate  sql.NullTime `json:"from_date"`
+	ToDate    sql.NullTime `json:"to_date"`
+}
+
+type ListProjectedMonthsRow struct {
+	Month        time.Time `json:"month"`
+	TotalCount   int32     `json:"total_count"`
+	Amount       float64   `json:"amount"`
+	CreditCount  int32     `json:"credit_count"`
+	Credits      float64   `json:"credits"`
+	DebitCount   int32     `json:"debit_count"`
+	Debits       float64   `json:"debits"`
+	RefundCount  int32     `json:"refund_count"`
+	Refunds      float64   `json:"refunds"`
+	Installments float64   `json:"installments"`
+}
+
+func (q *Queries) ListProjectedMonths(ctx context.Context, arg ListProjectedMonthsParams) ([]ListProjectedMonthsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProjectedMonths, arg.AccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectedMonthsRow{}
+	for rows.Next() {
+		var i ListProjectedMonthsRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.TotalCount,
+			&i.Amount,
+			&i.CreditCount,
+			&i.Credits,
+			&i.DebitCount,
+			&i.Debits,
+			&i.RefundCount,
+			&i.Refunds,
+			&i.Installments,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStaleSummaryMonths = `-- name: ListStaleSummaryMonths :many
+SELECT account_id, month FROM stale_summary_months
+ORDER BY marked_at, account_id, month
+LIMIT $1::bigint
+`
+
+type ListStaleSummaryMonthsRow struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+func (q *Queries) ListStaleSummaryMonths(ctx context.Context, limit int64) ([]ListStaleSummaryMonthsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStaleSummaryMonths, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStaleSummaryMonthsRow{}
+	for rows.Next() {
+		var i ListStaleSummaryMonthsRow
+		if err := rows.Scan(&i.AccountID, &i.Month); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSummaryProjectionMonths = `-- name: ListSummaryProjectionMonths :many
+SELECT t.account_id, date_trunc('month', t.input_date)::date AS month
+FROM transactions t
+UNION
+SELECT p.account_id, p.month
+FROM monthly_account_summary p
+ORDER BY 1, 2
+`
+
+type ListSummaryProjectionMonthsRow struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+// Every account and month with transactions or already projected, to
+// rebuild the projection.
+func (q *Queries) ListSummaryProjectionMonths(ctx context.Context) ([]ListSummaryProjectionMonthsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSummaryProjectionMonths)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSummaryProjectionMonthsRow{}
+	for rows.Next() {
+		var i ListSummaryProjectionMonthsRow
+		if err := rows.Scan(&i.AccountID, &i.Month); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markSummaryMonthStale = `-- name: MarkSummaryMonthStale :exec
+INSERT INTO stale_summary_months (account_id, month, marked_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_id, month) DO UPDATE
+SET version = stale_summary_months.version + 1,
+    marked_at = EXCLUDED.marked_at
+`
+
+type MarkSummaryMonthStaleParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Month     time.Time `json:"month"`
+	MarkedAt  int64     `json:"marked_at"`
+}
+
+// Queues a month for the next flush, in the database transaction of the
+// write that changed it.
+func (q *Queries) MarkSummaryMonthStale(ctx context.Context, arg MarkSummaryMonthStaleParams) error {
+	_, err := q.db.ExecContext(ctx, markSummaryMonthStale, arg.AccountID, arg.Month, arg.MarkedAt)
+	return err
+}
+
+const refreshMonthlyAccountSummary = `-- name: RefreshMonthlyAccountSummary :exec
+INSERT INTO monthly_account_summary (account_id, month, total_count, amount, credit_count, credits,
+                                     debit_count, debits, refund_count, refunds, installments, refreshed_at)
+SELECT $1::uuid,
+       $2::date,
+       COUNT(t.id),
+       COALESCE(SUM(t.amount), 0),
+       COUNT(t.id) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NULL),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NULL), 0),
+       COUNT(t.id) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount <= 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount <= 0), 0),
+       COUNT(t.id) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NOT NULL),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NOT NULL), 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'installment'), 0),
+       $3::bigint
+FROM transactions t
+WHERE t.account_id = $1::uuid
+  AND t.input_date >= $2::date
+  AND t.input_date < $2::date + INTERVAL '1 month'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.status = 'posted'
+ON CONFLICT (account_id, month) DO UPDATE
+SET total_count = EXCLUDED.total_count,
+    amount = EXCLUDED.amount,
+    credit_count = EXCLUDED.credit_count,
+    credits = EXCLUDED.credits,
+    debit_count = EXCLUDED.debit_count,
+    debits = EXCLUDED.debits,
+    refund_count = EXCLUDED.refund_count,
+    refunds = EXCLUDED.refunds,
+    installments = EXCLUDED.installments,
+    refreshed_at = EXCLUDED.refreshed_at
+`
+
+type RefreshMonthlyAccountSummaryParams struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	Month       time.Time `json:"month"`
+	RefreshedAt int64     `json:"refreshed_at"`
+}
+
+// Recomputes the totals of an account and month from its posted,
+// current, non-voided transactions, the same way ListSummaryDays does. A month left
+// without transactions keeps a row of zeros.
+func (q *Queries) RefreshMonthlyAccountSummary(ctx context.Context, arg RefreshMonthlyAccountSummaryParams) error {
+	_, err := q.db.ExecContext(ctx, refreshMonthlyAccountSummary, arg.AccountID, arg.Month, arg.RefreshedAt)
+	return err
+}
@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: tag.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const addTransactionTag = `-- name: AddTransactionTag :exec
+INSERT INTO transaction_tags (transaction_id, tag, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (transaction_id, tag) DO NOTHING
+`
+
+type AddTransactionTagParams struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Tag           string    `json:"tag"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+func (q *Queries) AddTransactionTag(ctx context.Context, arg AddTransactionTagParams) error {
+	_, err := q.db.ExecContext(ctx, addTransactionTag, arg.TransactionID, arg.Tag, arg.CreatedAt)
+	return err
+}
+
+const deleteTransactionTags = `-- name: DeleteTransactionTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id = $1
+`
+
+func (q *Queries) DeleteTransactionTags(ctx context.Context, transactionID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTransactionTags, transactionID)
+	return err
+}
+
+const listTransactionTags = `-- name: ListTransactionTags :many
+SELECT tag FROM transaction_tags
+WHERE transaction_id = $1
+ORDER BY tag
+`
+
+func (q *Queries) ListTransactionTags(ctx context.Context, transactionID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactionTags, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		items = append(items, tag)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransactionNote = `-- name: UpdateTransactionNote :exec
+UPDATE transactions
+SET note = $2, updated_at = $3
+WHERE id = $1
+`
+
+type UpdateTransactionNoteParams struct {
+	ID        uuid.UUID `json:"id"`
+	Note      string    `json:"note"`
+	UpdatedAt int64     `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTransactionNote(ctx context.Context, arg UpdateTransactionNoteParams) error {
+	_, err := q.db.ExecContext(ctx, updateTransactionNote, arg.ID, arg.Note, arg.UpdatedAt)
+	return err
+}
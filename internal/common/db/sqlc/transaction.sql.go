@@ -7,25 +7,58 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const createImportRow = `-- name: CreateImportRow :exec
+INSERT INTO import_rows (account_id, import_key, transaction_id, created_at)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateImportRowParams struct {
+	AccountID     uuid.UUID `json:"account_id"`
+	ImportKey     string    `json:"import_key"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	CreatedAt     int64     `json:"created_at"`
+}
+
+func (q *Queries) CreateImportRow(ctx context.Context, arg CreateImportRowParams) error {
+	_, err := q.db.ExecContext(ctx, createImportRow,
+		arg.AccountID,
+		arg.ImportKey,
+		arg.TransactionID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createTransaction = `-- name: CreateTransaction :one
-INSERT INTO transactions (id, account_id, amount, type, input_file_id, input_date, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, account_id, amount, type, input_file_id, input_date, created_at
+INSERT INTO transactions (id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, updated_at, status, authorized_at, posted_at, installment_plan_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
 `
 
 type CreateTransactionParams struct {
-	ID          uuid.UUID `json:"id"`
-	AccountID   uuid.UUID `json:"account_id"`
-	Amount      string    `json:"amount"`
-	Type        string    `json:"type"`
-	InputFileID string    `json:"input_file_id"`
-	InputDate   time.Time `json:"input_date"`
-	CreatedAt   int64     `json:"created_at"`
+	ID                uuid.UUID     `json:"id"`
+	AccountID         uuid.UUID     `json:"account_id"`
+	Amount            string        `json:"amount"`
+	Type              string        `json:"type"`
+	InputFileID       string        `json:"input_file_id"`
+	InputDate         time.Time     `json:"input_date"`
+	CreatedAt         int64         `json:"created_at"`
+	Description       string        `json:"description"`
+	Merchant          string        `json:"merchant"`
+	Category          string        `json:"category"`
+	TransferID        uuid.NullUUID `json:"transfer_id"`
+	UpdatedAt         int64         `json:"updated_at"`
+	Status            string        `json:"status"`
+	AuthorizedAt      time.Time     `json:"authorized_at"`
+	PostedAt          sql.NullTime  `json:"posted_at"`
+	InstallmentPlanID uuid.NullUUID `json:"installment_plan_id"`
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
@@ -37,6 +70,15 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.InputFileID,
 		arg.InputDate,
 		arg.CreatedAt,
+		arg.Description,
+		arg.Merchant,
+		arg.Category,
+		arg.TransferID,
+		arg.UpdatedAt,
+		arg.Status,
+		arg.AuthorizedAt,
+		arg.PostedAt,
+		arg.InstallmentPlanID,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -47,12 +89,85 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.InputFileID,
 		&i.InputDate,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
 	)
 	return i, err
 }
 
+const expirePendingTransactions = `-- name: ExpirePendingTransactions :many
+UPDATE transactions
+SET status = 'expired', updated_at = $1
+WHERE status = 'pending' AND authorized_at < $2
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
+`
+
+type ExpirePendingTransactionsParams struct {
+	UpdatedAt        int64     `json:"updated_at"`
+	AuthorizedBefore time.Time `json:"authorized_before"`
+}
+
+func (q *Queries) ExpirePendingTransactions(ctx context.Context, arg ExpirePendingTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, expirePendingTransactions, arg.UpdatedAt, arg.AuthorizedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTransaction = `-- name: GetTransaction :one
-SELECT id, account_id, amount, type, input_file_id, input_date, created_at FROM transactions
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
 WHERE id = $1 LIMIT 1
 `
 
@@ -67,42 +182,216 @@ func (q *Queries) GetTransaction(ctx context.Context, id uuid.UUID) (Transaction
 		&i.InputFileID,
 		&i.InputDate,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
 	)
 	return i, err
 }
 
-const getTransactionSummary = `-- name: GetTransactionSummary :one
-SELECT
-    SUM(CASE WHEN type = 'credit' THEN amount ELSE -amount END) as total_balance,
-    COUNT(*) as transaction_count,
-    AVG(CASE WHEN type = 'credit' THEN amount ELSE NULL END) as average_credit,
-    AVG(CASE WHEN type = 'debit' THEN amount ELSE NULL END) as average_debit
-FROM transactions
-WHERE account_id = $1
+const getTransactionForUpdate = `-- name: GetTransactionForUpdate :one
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
+WHERE id = $1 LIMIT 1
+FOR UPDATE
 `
 
-type GetTransactionSummaryRow struct {
-	TotalBalance     string `json:"total_balance"`
-	TransactionCount int64  `json:"transaction_count"`
-	AverageCredit    string `json:"average_credit"`
-	AverageDebit     string `json:"average_debit"`
-}
-
-func (q *Queries) GetTransactionSummary(ctx context.Context, accountID uuid.UUID) (GetTransactionSummaryRow, error) {
-	row := q.db.QueryRowContext(ctx, getTransactionSummary, accountID)
-	var i GetTransactionSummaryRow
+func (q *Queries) GetTransactionForUpdate(ctx context.Context, id uuid.UUID) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionForUpdate, id)
+	var i Transaction
 	err := row.Scan(
-		&i.TotalBalance,
-		&i.TransactionCount,
-		&i.AverageCredit,
-		&i.AverageDebit,
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Type,
+		&i.InputFileID,
+		&i.InputDate,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
 	)
 	return i, err
 }
 
-const listTransactionsByAccount = `-- name: ListTransactionsByAccount :many
-SELECT id, account_id, amount, type, input_file_id, input_date, created_at FROM transactions
+const listImportedKeys = `-- name: ListImportedKeys :many
+SELECT import_key FROM import_rows
+WHERE account_id = $1 AND import_key = ANY($2::text[])
+`
+
+type ListImportedKeysParams struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	ImportKeys []string  `json:"import_keys"`
+}
+
+// The keys among import_keys of rows the account already imported.
+func (q *Queries) ListImportedKeys(ctx context.Context, arg ListImportedKeysParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listImportedKeys, arg.AccountID, pq.Array(arg.ImportKeys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var import_key string
+		if err := rows.Scan(&import_key); err != nil {
+			return nil, err
+		}
+		items = append(items, import_key)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingAuthorizations = `-- name: ListPendingAuthorizations :many
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
 WHERE account_id = $1
+  AND status = 'pending'
+  AND authorized_at BETWEEN $2 AND $3
+ORDER BY authorized_at, id
+`
+
+type ListPendingAuthorizationsParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	FromDate  time.Time `json:"from_date"`
+	ToDate    time.Time `json:"to_date"`
+}
+
+func (q *Queries) ListPendingAuthorizations(ctx context.Context, arg ListPendingAuthorizationsParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingAuthorizations, arg.AccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactions = `-- name: ListTransactions :many
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
+WHERE superseded_by IS NULL
+ORDER BY created_at, id
+LIMIT $1 OFFSET $2
+`
+
+type ListTransactionsParams struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listTransactions, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Type,
+			&i.InputFileID,
+			&i.InputDate,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsByAccount = `-- name: ListTransactionsByAccount :many
+SELECT id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by FROM transactions
+WHERE account_id = $1 AND superseded_by IS NULL
 ORDER BY created_at
 LIMIT $2 OFFSET $3
 `
@@ -130,6 +419,21 @@ func (q *Queries) ListTransactionsByAccount(ctx context.Context, arg ListTransac
 			&i.InputFileID,
 			&i.InputDate,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Merchant,
+			&i.Category,
+			&i.TransferID,
+			&i.Voided,
+			&i.UpdatedAt,
+			&i.ReversalOf,
+			&i.ReversalKind,
+			&i.Status,
+			&i.AuthorizedAt,
+			&i.PostedAt,
+			&i.Note,
+			&i.InstallmentPlanID,
+			&i.Supersedes,
+			&i.SupersededBy,
 		); err != nil {
 			return nil, err
 		}
@@ -143,3 +447,116 @@ func (q *Queries) ListTransactionsByAccount(ctx context.Context, arg ListTransac
 	}
 	return items, nil
 }
+
+const settleTransaction = `-- name: SettleTransaction :one
+UPDATE transactions
+SET amount = $2, type = $3, input_date = $4, posted_at = $5, status = 'posted', updated_at = $6
+WHERE id = $1
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
+`
+
+type SettleTransactionParams struct {
+	ID        uuid.UUID    `json:"id"`
+	Amount    string       `json:"amount"`
+	Type      string       `json:"type"`
+	InputDate time.Time    `json:"input_date"`
+	PostedAt  sql.NullTime `json:"posted_at"`
+	UpdatedAt int64        `json:"updated_at"`
+}
+
+func (q *Queries) SettleTransaction(ctx context.Context, arg SettleTransactionParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, settleTransaction,
+		arg.ID,
+		arg.Amount,
+		arg.Type,
+		arg.InputDate,
+		arg.PostedAt,
+		arg.UpdatedAt,
+	)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Type,
+		&i.InputFileID,
+		&i.InputDate,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
+	)
+	return i, err
+}
+
+const updateTransactionMerchant = `-- name: UpdateTransactionMerchant :exec
+UPDATE transactions
+SET merchant = $2, category = $3
+WHERE id = $1
+`
+
+type UpdateTransactionMerchantParams struct {
+	ID       uuid.UUID `json:"id"`
+	Merchant string    `json:"merchant"`
+	Category string    `json:"category"`
+}
+
+func (q *Queries) UpdateTransactionMerchant(ctx context.Context, arg UpdateTransactionMerchantParams) error {
+	_, err := q.db.ExecContext(ctx, updateTransactionMerchant, arg.ID, arg.Merchant, arg.Category)
+	return err
+}
+
+const updateTransactionStatus = `-- name: UpdateTransactionStatus :one
+UPDATE transactions
+SET status = $2, updated_at = $3
+WHERE id = $1
+RETURNING id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes, superseded_by
+`
+
+type UpdateTransactionStatusParams struct {
+	ID        uuid.UUID `json:"id"`
+	Status    string    `json:"status"`
+	UpdatedAt int64     `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTransactionStatus(ctx context.Context, arg UpdateTransactionStatusParams) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, updateTransactionStatus, arg.ID, arg.Status, arg.UpdatedAt)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Type,
+		&i.InputFileID,
+		&i.InputDate,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Merchant,
+		&i.Category,
+		&i.TransferID,
+		&i.Voided,
+		&i.UpdatedAt,
+		&i.ReversalOf,
+		&i.ReversalKind,
+		&i.Status,
+		&i.AuthorizedAt,
+		&i.PostedAt,
+		&i.Note,
+		&i.InstallmentPlanID,
+		&i.Supersedes,
+		&i.SupersededBy,
+	)
+	return i, err
+}
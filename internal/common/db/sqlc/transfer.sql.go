@@ -0,0 +1,103 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: transfer.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createTransfer = `-- name: CreateTransfer :one
+INSERT INTO transfers (id, source_account_id, destination_account_id, amount, description, idempotency_key, debit_transaction_id, credit_transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (idempotency_key) DO NOTHING
+RETURNING id, source_account_id, destination_account_id, amount, description, idempotency_key, debit_transaction_id, credit_transaction_id, created_at
+`
+
+type CreateTransferParams struct {
+	ID                   uuid.UUID      `json:"id"`
+	SourceAccountID      uuid.UUID      `json:"source_account_id"`
+	DestinationAccountID uuid.UUID      `json:"destination_account_id"`
+	Amount               string         `json:"amount"`
+	Description          string         `json:"description"`
+	IdempotencyKey       sql.NullString `json:"idempotency_key"`
+	DebitTransactionID   uuid.UUID      `json:"debit_transaction_id"`
+	CreditTransactionID  uuid.UUID      `json:"credit_transaction_id"`
+	CreatedAt            int64          `json:"created_at"`
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.ID,
+		arg.SourceAccountID,
+		arg.DestinationAccountID,
+		arg.Amount,
+		arg.Description,
+		arg.IdempotencyKey,
+		arg.DebitTransactionID,
+		arg.CreditTransactionID,
+		arg.CreatedAt,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.SourceAccountID,
+		&i.DestinationAccountID,
+		&i.Amount,
+		&i.Description,
+		&i.IdempotencyKey,
+		&i.DebitTransactionID,
+		&i.CreditTransactionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransfer = `-- name: GetTransfer :one
+SELECT id, source_account_id, destination_account_id, amount, description, idempotency_key, debit_transaction_id, credit_transaction_id, created_at FROM transfers
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTransfer(ctx context.Context, id uuid.UUID) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransfer, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.SourceAccountID,
+		&i.DestinationAccountID,
+		&i.Amount,
+		&i.Description,
+		&i.IdempotencyKey,
+		&i.DebitTransactionID,
+		&i.CreditTransactionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferByIdempotencyKey = `-- name: GetTransferByIdempotencyKey :one
+SELECT id, source_account_id, destination_account_id, amount, description, idempotency_key, debit_transaction_id, credit_transaction_id, created_at FROM transfers
+WHERE idempotency_key = $1 LIMIT 1
+`
+
+func (q *Queries) GetTransferByIdempotencyKey(ctx context.Context, idempotencyKey sql.NullString) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransferByIdempotencyKey, idempotencyKey)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.SourceAccountID,
+		&i.DestinationAccountID,
+		&i.Amount,
+		&i.Description,
+		&i.IdempotencyKey,
+		&i.DebitTransactionID,
+		&i.CreditTransactionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
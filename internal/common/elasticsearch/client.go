@@ -23,6 +23,9 @@ func NewElasticsearchClient(url string) (*elastic.Client, error) {
 	if err := SetupElasticsearchIndex(esClient, "transactions"); err != nil {
 		log.Fatalf("Failed to setup Elasticsearch index 'transactions': %v", err)
 	}
+	if err := SetupElasticsearchIndex(esClient, "transfers"); err != nil {
+		log.Fatalf("Failed to setup Elasticsearch index 'transfers': %v", err)
+	}
 
 	return esClient, err
 }
@@ -0,0 +1,47 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+)
+
+// UpdateEvent carries updates for the browsers from the processes without
+// WebSocket connections, such as the worker, to the API that holds them.
+const UpdateEvent = "websocket.update"
+
+// Update is a message for the browser of a user.
+type Update struct {
+	UserID  string          `json:"user_id"`
+	Message json.RawMessage `json:"message"`
+}
+
+// Relay sends updates over NATS to every API instance, which delivers them
+// to the connections it holds. It has the SendUpdate of WebSocketService.
+type Relay struct {
+	nats *nats.NatsClient
+}
+
+func NewRelay(nc *nats.NatsClient) *Relay {
+	return &Relay{nats: nc}
+}
+
+func (r *Relay) SendUpdate(userID string, message []byte) {
+	if err := r.nats.Publish(UpdateEvent, Update{UserID: userID, Message: message}); err != nil {
+		log.Printf("Error relaying WebSocket message: %v", err)
+	}
+}
+
+// Deliver subscribes the service to the updates relayed by other processes.
+func (s *WebSocketService) Deliver(nc *nats.NatsClient) error {
+	_, err := nc.Subscribe(UpdateEvent, func(data []byte) {
+		var update Update
+		if err := json.Unmarshal(data, &update); err != nil {
+			log.Printf("Error unmarshaling WebSocket update: %v", err)
+			return
+		}
+		s.SendUpdate(update.UserID, update.Message)
+	})
+	return err
+}
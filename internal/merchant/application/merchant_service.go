@@ -0,0 +1,72 @@
+package application
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/ports"
+)
+
+type merchantMatcher struct {
+	merchant *domain.Merchant
+	patterns []*regexp.Regexp
+}
+
+type MerchantService struct {
+	merchants []*domain.Merchant
+	matchers  []merchantMatcher
+}
+
+func NewMerchantService(dictionary ports.MerchantDictionary) (*MerchantService, error) {
+	merchants, err := dictionary.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load merchant dictionary: %w", err)
+	}
+
+	service := &MerchantService{merchants: merchants}
+	for _, m := range merchants {
+		matcher := merchantMatcher{merchant: m}
+		for _, p := range m.Patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q for merchant %s: %w", p, m.Name, err)
+			}
+			matcher.patterns = append(matcher.patterns, re)
+		}
+		service.matchers = append(service.matchers, matcher)
+	}
+	return service, nil
+}
+
+// Match returns the dictionary merchant for a raw description, or nil when
+// no pattern matches.
+func (s *MerchantService) Match(description string) *domain.Merchant {
+	normalized := strings.ToUpper(strings.TrimSpace(description))
+	if normalized == "" {
+		return nil
+	}
+
+	for _, matcher := range s.matchers {
+		for _, re := range matcher.patterns {
+			if re.MatchString(normalized) {
+				return matcher.merchant
+			}
+		}
+	}
+	return nil
+}
+
+// Normalize resolves a raw description to a canonical merchant name and
+// category. Unknown merchants fall back to the cleaned description.
+func (s *MerchantService) Normalize(description string) (string, string) {
+	if m := s.Match(description); m != nil {
+		return m.Name, m.Category
+	}
+	return domain.CleanDescription(description), ""
+}
+
+func (s *MerchantService) ListMerchants() []*domain.Merchant {
+	return s.merchants
+}
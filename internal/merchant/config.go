@@ -0,0 +1,11 @@
+package merchant
+
+import (
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/infrastructure"
+)
+
+func SetupMerchantDomain() (*application.MerchantService, error) {
+	dictionary := infrastructure.NewJSONMerchantDictionary()
+	return application.NewMerchantService(dictionary)
+}
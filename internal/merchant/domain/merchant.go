@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Merchant is a canonical merchant from the local dictionary. Patterns are
+// regular expressions matched against the upper-cased raw description.
+type Merchant struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Logo     string   `json:"logo"`
+	Patterns []string `json:"patterns"`
+}
+
+const (
+	NormalizeRequestedEvent = "merchant.normalize.requested"
+)
+
+var (
+	storeNumberRegexp = regexp.MustCompile(`#\s*\d+|\b\d+\b`)
+	spacesRegexp      = regexp.MustCompile(`\s+`)
+)
+
+// CleanDescription removes store numbers and extra spaces from a raw
+// description so unknown merchants still group together.
+func CleanDescription(description string) string {
+	cleaned := strings.ToUpper(description)
+	cleaned = storeNumberRegexp.ReplaceAllString(cleaned, " ")
+	cleaned = spacesRegexp.ReplaceAllString(cleaned, " ")
+	return strings.TrimSpace(cleaned)
+}
@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/ports"
+)
+
+//go:embed merchants.json
+var defaultDictionary []byte
+
+type JSONMerchantDictionary struct {
+	data []byte
+}
+
+func NewJSONMerchantDictionary() ports.MerchantDictionary {
+	return &JSONMerchantDictionary{data: defaultDictionary}
+}
+
+func (d *JSONMerchantDictionary) List() ([]*domain.Merchant, error) {
+	var merchants []*domain.Merchant
+	if err := json.Unmarshal(d.data, &merchants); err != nil {
+		return nil, err
+	}
+	return merchants, nil
+}
@@ -0,0 +1,9 @@
+package ports
+
+import (
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/domain"
+)
+
+type MerchantDictionary interface {
+	List() ([]*domain.Merchant, error)
+}
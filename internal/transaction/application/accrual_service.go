@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type AccrualService struct {
+	repo   ports.AccrualRepository
+	policy accountDomain.AccrualPolicy
+}
+
+func NewAccrualService(repo ports.AccrualRepository, policy accountDomain.AccrualPolicy) *AccrualService {
+	return &AccrualService{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+// RunAccruals computes the interest and late fees of every credit card
+// account for each day in [from, to] and posts the ones not posted yet.
+// Runs are idempotent: accruals already recorded are reported, compared
+// with the recomputed amount and left untouched. A dry run posts nothing.
+func (s *AccrualService) RunAccruals(ctx context.Context, from, to time.Time, dryRun bool) (*accountDomain.AccrualRun, error) {
+	if err := s.policy.Validate(); err != nil {
+		return nil, err
+	}
+	from, to = truncateDay(from), truncateDay(to)
+	if err := accountDomain.ValidateAccrualRange(from, to, truncateDay(time.Now().UTC())); err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.repo.ListCardAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credit card accounts: %w", err)
+	}
+
+	run := &accountDomain.AccrualRun{From: from, To: to, DryRun: dryRun}
+	for _, account := range accounts {
+		if err := s.runAccount(ctx, run, account); err != nil {
+			return run, fmt.Errorf("failed to accrue account %s: %w", account.ID, err)
+		}
+	}
+	return run, nil
+}
+
+func (s *AccrualService) runAccount(ctx context.Context, run *accountDomain.AccrualRun, account *accountDomain.Account) error {
+	activity, err := s.repo.GetActivity(ctx, account.ID, run.To.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	existing, err := s.repo.ListAccruals(ctx, account.ID, run.From, run.To)
+	if err != nil {
+		return err
+	}
+	posted := make(map[string]*accountDomain.Accrual, len(existing))
+	for _, a := range existing {
+		posted[accrualKey(a.Kind, a.AccrualDate)] = a
+	}
+
+	for day := run.From; !day.After(run.To); day = day.AddDate(0, 0, 1) {
+		for _, accrual := range account.Accrue(s.policy, activity, day) {
+			if prev, ok := posted[accrualKey(accrual.Kind, day)]; ok {
+				line := accountDomain.AccrualLine{Accrual: accrual, Existing: true, PostedAmount: prev.Amount}
+				run.Lines = append(run.Lines, line)
+				run.Existing++
+				if line.Mismatched() {
+					run.Mismatched++
+					log.Printf("Accrual %s of account %s on %s was posted as %.2f, recomputed as %.2f",
+						accrual.Kind, account.ID, day.Format(time.DateOnly), prev.Amount, accrual.Amount)
+				}
+				// The posted charge is already part of the activity
+				continue
+			}
+
+			if !run.DryRun {
+				charge := domain.NewSystemCharge(account.ID, chargeType(accrual.Kind), accrual.Amount, accrualDescription(accrual), day)
+				if err := s.repo.Post(ctx, accrual, charge); err != nil {
+					if errors.Is(err, accountDomain.ErrAccrualPosted) {
+						// A concurrent run posted it first.
+						log.Printf("Skipping accrual %s of account %s on %s: %v", accrual.Kind, account.ID, day.Format(time.DateOnly), err)
+						continue
+					}
+					return err
+				}
+			}
+			activity.Add(day, -accrual.Amount)
+			run.Lines = append(run.Lines, accountDomain.AccrualLine{Accrual: accrual, PostedAmount: accrual.Amount})
+			run.Created++
+		}
+	}
+	return nil
+}
+
+// ListAccruals returns the accruals of an account with a date in [from, to].
+func (s *AccrualService) ListAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*accountDomain.Accrual, error) {
+	return s.repo.ListAccruals(ctx, accountID, truncateDay(from), truncateDay(to))
+}
+
+func accrualKey(kind string, day time.Time) string {
+	return kind + "/" + day.Format(time.DateOnly)
+}
+
+func chargeType(kind string) string {
+	if kind == accountDomain.AccrualLateFee {
+		return domain.TransactionTypeLateFee
+	}
+	return domain.TransactionTypeInterest
+}
+
+func accrualDescription(a *accountDomain.Accrual) string {
+	if a.Kind == accountDomain.AccrualLateFee {
+		return "Late fee statement " + a.StatementDate.Format(time.DateOnly)
+	}
+	return "Interest " + a.AccrualDate.Format(time.DateOnly)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
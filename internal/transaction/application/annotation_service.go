@@ -0,0 +1,26 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type AnnotationService struct {
+	repo ports.AnnotationRepository
+}
+
+func NewAnnotationService(repo ports.AnnotationRepository) *AnnotationService {
+	return &AnnotationService{
+		repo: repo,
+	}
+}
+
+// AnnotateTransaction replaces the tags and note of a transaction. An empty
+// list and note clear them.
+func (s *AnnotationService) AnnotateTransaction(ctx context.Context, id uuid.UUID, tags []string, note string) (*domain.Transaction, error) {
+	return s.repo.Annotate(ctx, id, tags, note)
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/email"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+	pb "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
+)
+
+type AnomalyService struct {
+	repo    ports.AnomalyRepository
+	account pb.AccountServiceClient
+	sender  *email.Sender
+	policy  domain.AnomalyPolicy
+}
+
+func NewAnomalyService(repo ports.AnomalyRepository, conn *grpc.ClientConn, sender *email.Sender, policy domain.AnomalyPolicy) *AnomalyService {
+	return &AnomalyService{
+		repo:    repo,
+		account: pb.NewAccountServiceClient(conn),
+		sender:  sender,
+		policy:  policy,
+	}
+}
+
+// Score checks a new transaction against the account history and flags it
+// when it looks suspicious. It returns nil when the transaction is not
+// flagged or was already flagged.
+func (s *AnomalyService) Score(ctx context.Context, t *domain.Transaction) (*domain.TransactionAlert, error) {
+	if !t.IsScored() {
+		return nil, nil
+	}
+
+	history, err := s.repo.History(ctx, t, s.policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of transaction %s: %w", t.ID, err)
+	}
+	alert := s.policy.Score(t, history)
+	if alert == nil {
+		return nil, nil
+	}
+
+	created, err := s.repo.Create(ctx, alert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flag transaction %s: %w", t.ID, err)
+	}
+	if !created {
+		return nil, nil
+	}
+	return alert, nil
+}
+
+func (s *AnomalyService) Get(ctx context.Context, id uuid.UUID) (*domain.TransactionAlert, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// ListQueue lists the alerts in a status, open ones by default, highest
+// score first.
+func (s *AnomalyService) ListQueue(ctx context.Context, status string, limit, offset int64) ([]*domain.TransactionAlert, error) {
+	if status == "" {
+		status = domain.AlertStatusOpen
+	}
+	return s.repo.ListByStatus(ctx, status, limit, offset)
+}
+
+func (s *AnomalyService) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.TransactionAlert, error) {
+	return s.repo.ListByAccount(ctx, accountID)
+}
+
+func (s *AnomalyService) Review(ctx context.Context, id uuid.UUID, status, reviewer, note string) (*domain.TransactionAlert, error) {
+	now := time.Now().UTC()
+	return s.repo.Review(ctx, id, func(a *domain.TransactionAlert) error {
+		return a.Review(status, reviewer, note, now)
+	})
+}
+
+// SendAlertEmail warns the account holder about a flagged transaction.
+func (s *AnomalyService) SendAlertEmail(ctx context.Context, alert *domain.TransactionAlert) error {
+	request := &pb.GetAccountRequest{Id: alert.AccountID.String()}
+	user, err := s.account.GetAccount(ctx, request)
+	if err != nil {
+		log.Printf("Error getting user account: %v", err)
+		return fmt.Errorf("failed to get user account: %w", err)
+	}
+
+	err = s.sender.SendWithTemplate(user.Email, "Actividad inusual en tu cuenta", "anomaly.gohtml", alert)
+	if err != nil {
+		log.Printf("Error sending email: %v", err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
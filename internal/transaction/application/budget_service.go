@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/email"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+	pb "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
+)
+
+type BudgetService struct {
+	repo    ports.BudgetRepository
+	account pb.AccountServiceClient
+	sender  *email.Sender
+}
+
+func NewBudgetService(repo ports.BudgetRepository, conn *grpc.ClientConn, sender *email.Sender) *BudgetService {
+	return &BudgetService{
+		repo:    repo,
+		account: pb.NewAccountServiceClient(conn),
+		sender:  sender,
+	}
+}
+
+// SetBudget sets the monthly budget of a category, or the overall budget
+// when category is empty, and checks it against the current month right
+// away. A failed check is only logged; the next transaction checks again.
+func (s *BudgetService) SetBudget(ctx context.Context, accountID uuid.UUID, category string, amount float64) (*domain.Budget, error) {
+	budget, err := domain.NewBudget(accountID, category, amount)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.repo.Save(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.track(ctx, accountID, time.Now().UTC()); err != nil {
+		log.Printf("Error tracking budgets: %v", err)
+	}
+	return saved, nil
+}
+
+func (s *BudgetService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// ListStatus reports the budgets of an account against the spend of the
+// calendar month of month.
+func (s *BudgetService) ListStatus(ctx context.Context, accountID uuid.UUID, month time.Time) ([]domain.BudgetStatus, error) {
+	budgets, err := s.repo.ListByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	spent, err := s.repo.Spending(ctx, accountID, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending: %w", err)
+	}
+	return domain.CompareBudgets(budgets, spent, month), nil
+}
+
+// Track checks the budgets of the account when a transaction arrives.
+// Only spend in the current month raises alerts, so that importing past
+// months does not warn about budgets long closed.
+func (s *BudgetService) Track(ctx context.Context, t *domain.Transaction, now time.Time) ([]*domain.BudgetAlert, error) {
+	if t.Amount >= 0 || !t.CountsInSummary(false) {
+		return nil, nil
+	}
+	if !domain.MonthStart(t.InputDate).Equal(domain.MonthStart(now)) {
+		return nil, nil
+	}
+	return s.track(ctx, t.AccountID, now)
+}
+
+func (s *BudgetService) track(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*domain.BudgetAlert, error) {
+	alerts, err := s.repo.Track(ctx, accountID, now, func(budgets []*domain.Budget, spent map[string]float64) []*domain.BudgetAlert {
+		var alerts []*domain.BudgetAlert
+		for _, status := range domain.CompareBudgets(budgets, spent, now) {
+			alerts = append(alerts, status.Alerts()...)
+		}
+		return alerts
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to track budgets of account %s: %w", accountID, err)
+	}
+	return alerts, nil
+}
+
+// SendAlertEmail tells the account holder a budget reached a threshold.
+func (s *BudgetService) SendAlertEmail(ctx context.Context, alert *domain.BudgetAlert) error {
+	request := &pb.GetAccountRequest{Id: alert.AccountID.String()}
+	user, err := s.account.GetAccount(ctx, request)
+	if err != nil {
+		log.Printf("Error getting user account: %v", err)
+		return fmt.Errorf("failed to get user account: %w", err)
+	}
+
+	err = s.sender.SendWithTemplate(user.Email, "Aviso de presupuesto", "budget.gohtml", alert)
+	if err != nil {
+		log.Printf("Error sending email: %v", err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
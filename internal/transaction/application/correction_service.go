@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type CorrectionService struct {
+	repo       ports.CorrectionRepository
+	normalizer ports.MerchantNormalizer
+}
+
+func NewCorrectionService(repo ports.CorrectionRepository, normalizer ports.MerchantNormalizer) *CorrectionService {
+	return &CorrectionService{
+		repo:       repo,
+		normalizer: normalizer,
+	}
+}
+
+// AmendTransaction changes the effective amount, description or category of
+// a transaction. A new description is run through the merchant dictionary
+// again unless the category is amended explicitly.
+func (s *CorrectionService) AmendTransaction(ctx context.Context, id uuid.UUID, amendment domain.Amendment, reason, actor string) (*domain.Transaction, *domain.Correction, error) {
+	return s.repo.Correct(ctx, id, func(t *domain.Transaction) (*domain.Correction, error) {
+		correction, err := t.Amend(amendment, reason, actor)
+		if err != nil {
+			return nil, err
+		}
+
+		if amendment.Description != nil && s.normalizer != nil {
+			merchant, category := s.normalizer.Normalize(t.Description)
+			if amendment.Category != nil {
+				category = t.Category
+			}
+			t.SetMerchant(merchant, category)
+			correction.NewCategory = t.Category
+		}
+		return correction, nil
+	})
+}
+
+// VoidTransaction cancels a transaction while keeping it in the history.
+func (s *CorrectionService) VoidTransaction(ctx context.Context, id uuid.UUID, reason, actor string) (*domain.Transaction, *domain.Correction, error) {
+	return s.repo.Correct(ctx, id, func(t *domain.Transaction) (*domain.Correction, error) {
+		return t.Void(reason, actor)
+	})
+}
+
+func (s *CorrectionService) GetTransactionHistory(ctx context.Context, id uuid.UUID) ([]*domain.Correction, error) {
+	return s.repo.ListByTransaction(ctx, id)
+}
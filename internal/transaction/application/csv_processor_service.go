@@ -45,7 +45,12 @@ func (s *CSVProcessorService) processTransaction(data []string) error {
 		return err
 	}
 
-	_, err = s.transactionService.CreateTransaction(context.Background(), s.accountID, amount, s.filepath, date)
+	description := ""
+	if len(data) > 2 {
+		description = data[2]
+	}
+
+	_, err = s.transactionService.CreateTransaction(context.Background(), s.accountID, amount, description, s.filepath, date)
 	if err != nil {
 		return err
 	}
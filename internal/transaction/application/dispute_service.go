@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/email"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+	pb "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
+)
+
+const disputeBatch = 100
+
+type DisputeService struct {
+	repo    ports.DisputeRepository
+	blobs   ports.BlobStore
+	account pb.AccountServiceClient
+	sender  *email.Sender
+	policy  domain.DisputePolicy
+}
+
+func NewDisputeService(repo ports.DisputeRepository, blobs ports.BlobStore, conn *grpc.ClientConn, sender *email.Sender, policy domain.DisputePolicy) *DisputeService {
+	return &DisputeService{
+		repo:    repo,
+		blobs:   blobs,
+		account: pb.NewAccountServiceClient(conn),
+		sender:  sender,
+		policy:  policy,
+	}
+}
+
+// Open disputes amount of a posted debit. A zero amount disputes what is
+// left of the transaction after refunds.
+func (s *DisputeService) Open(ctx context.Context, transactionID uuid.UUID, amount float64, reasonCode, description string) (*domain.Dispute, error) {
+	now := time.Now().UTC()
+	return s.repo.Open(ctx, transactionID, func(t *domain.Transaction, refunded float64) (*domain.Dispute, *domain.DisputeEvent, error) {
+		return domain.NewDispute(t, amount, refunded, reasonCode, description, s.policy, now)
+	})
+}
+
+func (s *DisputeService) Get(ctx context.Context, id uuid.UUID) (*domain.Dispute, error) {
+	return s.repo.Get(ctx, id)
+}
+
+func (s *DisputeService) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Dispute, error) {
+	return s.repo.ListByAccount(ctx, accountID)
+}
+
+// Transition moves a dispute to status: "under_review",
+// "provisional_credit", "won" or "lost".
+func (s *DisputeService) Transition(ctx context.Context, id uuid.UUID, status, note string) (*domain.DisputeChange, error) {
+	now := time.Now().UTC()
+	return s.repo.Transition(ctx, id, func(d *domain.Dispute) (*domain.DisputeEvent, *domain.Transaction, error) {
+		return d.Transition(status, note, now)
+	})
+}
+
+// AddAttachment stores an evidence file of a dispute in the blob store.
+func (s *DisputeService) AddAttachment(ctx context.Context, disputeID uuid.UUID, fileName, contentType string, size int64, content io.Reader) (*domain.DisputeAttachment, error) {
+	dispute, err := s.repo.Get(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := dispute.NewAttachment(fileName, contentType, size, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := s.blobs.Put(ctx, attachment.BlobKey, io.LimitReader(content, domain.MaxDisputeAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if written != size {
+		s.blobs.Delete(ctx, attachment.BlobKey)
+		return nil, domain.ErrInvalidDisputeAttachment
+	}
+
+	if err := s.repo.AddAttachment(ctx, attachment); err != nil {
+		s.blobs.Delete(ctx, attachment.BlobKey)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// OpenAttachment returns an attachment with its content. The caller closes
+// the content.
+func (s *DisputeService) OpenAttachment(ctx context.Context, disputeID, attachmentID uuid.UUID) (*domain.DisputeAttachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetAttachment(ctx, disputeID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.blobs.Open(ctx, attachment.BlobKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+	return attachment, content, nil
+}
+
+// ProcessDeadlines grants the provisional credits that fell due and
+// resolves in favour of the customer the disputes past their deadline. It
+// returns how many disputes were credited and resolved.
+func (s *DisputeService) ProcessDeadlines(ctx context.Context, now time.Time) (int, int, error) {
+	credited, err := s.transitionAll(ctx, domain.DisputeStatusProvisionalCredit, "Provisional credit granted automatically",
+		func() ([]*domain.Dispute, error) {
+			return s.repo.ListDueForCredit(ctx, now, disputeBatch)
+		})
+	if err != nil {
+		return credited, 0, err
+	}
+
+	resolved, err := s.transitionAll(ctx, domain.DisputeStatusWon, "Resolved in favour of the customer after the resolution deadline",
+		func() ([]*domain.Dispute, error) {
+			return s.repo.ListPastDeadline(ctx, now, disputeBatch)
+		})
+	return credited, resolved, err
+}
+
+func (s *DisputeService) transitionAll(ctx context.Context, status, note string, list func() ([]*domain.Dispute, error)) (int, error) {
+	moved := 0
+	for {
+		disputes, err := list()
+		if err != nil {
+			return moved, fmt.Errorf("failed to list disputes: %w", err)
+		}
+		if len(disputes) == 0 {
+			return moved, nil
+		}
+
+		for _, d := range disputes {
+			_, err := s.Transition(ctx, d.ID, status, note)
+			if errors.Is(err, domain.ErrDisputeClosed) || errors.Is(err, domain.ErrInvalidDisputeTransition) {
+				// Changed by someone else since it was listed
+				continue
+			}
+			if err != nil {
+				return moved, fmt.Errorf("failed to move dispute %s to %s: %w", d.ID, status, err)
+			}
+			moved++
+		}
+	}
+}
+
+// SendDisputeEmail notifies the account holder of a dispute transition.
+func (s *DisputeService) SendDisputeEmail(ctx context.Context, change *domain.DisputeChange) error {
+	request := &pb.GetAccountRequest{Id: change.Dispute.AccountID.String()}
+	user, err := s.account.GetAccount(ctx, request)
+	if err != nil {
+		log.Printf("Error getting user account: %v", err)
+		return fmt.Errorf("failed to get user account: %w", err)
+	}
+
+	err = s.sender.SendWithTemplate(user.Email, "Actualizacion de tu aclaracion", "dispute.gohtml", change)
+	if err != nil {
+		log.Printf("Error sending email: %v", err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
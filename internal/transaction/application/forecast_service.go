@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type ForecastService struct {
+	repo ports.ForecastRepository
+}
+
+func NewForecastService(repo ports.ForecastRepository) *ForecastService {
+	return &ForecastService{repo: repo}
+}
+
+// Run forecasts the balance of an account from its history and stores the
+// forecast, replacing the previous one.
+func (s *ForecastService) Run(ctx context.Context, accountID uuid.UUID, now time.Time) (*domain.CashFlowForecast, error) {
+	forecast, err := s.repo.Build(ctx, accountID, now.Add(-domain.ForecastLookback),
+		func(history []*domain.Transaction, balance float64) *domain.CashFlowForecast {
+			return domain.BuildForecast(accountID, history, balance, now)
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to forecast account %s: %w", accountID, err)
+	}
+	return forecast, nil
+}
+
+// RunAll forecasts every active account with recent activity and returns
+// how many were forecast.
+func (s *ForecastService) RunAll(ctx context.Context, now time.Time) (int, error) {
+	accounts, err := s.repo.ListAccounts(ctx, now.Add(-domain.ForecastLookback))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list accounts to forecast: %w", err)
+	}
+
+	for i, accountID := range accounts {
+		if _, err := s.Run(ctx, accountID, now); err != nil {
+			return i, err
+		}
+	}
+	return len(accounts), nil
+}
+
+// Get returns the latest forecast of an account over its first days.
+func (s *ForecastService) Get(ctx context.Context, accountID uuid.UUID, days int) (*domain.CashFlowForecast, error) {
+	forecast, err := s.repo.Get(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return forecast.Until(days)
+}
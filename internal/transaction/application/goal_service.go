@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type GoalService struct {
+	repo ports.GoalRepository
+}
+
+func NewGoalService(repo ports.GoalRepository) *GoalService {
+	return &GoalService{repo: repo}
+}
+
+// Create sets up a savings goal and reports its progress right away, which
+// is not empty when the goal starts in the past.
+func (s *GoalService) Create(ctx context.Context, accountID uuid.UUID, name, category string, target float64, targetDate, startDate time.Time) (domain.GoalProgress, error) {
+	goal, err := domain.NewSavingsGoal(accountID, name, category, target, targetDate, startDate)
+	if err != nil {
+		return domain.GoalProgress{}, err
+	}
+	created, err := s.repo.Create(ctx, goal)
+	if err != nil {
+		return domain.GoalProgress{}, err
+	}
+	return s.progress(ctx, created)
+}
+
+func (s *GoalService) Get(ctx context.Context, id uuid.UUID) (domain.GoalProgress, error) {
+	goal, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return domain.GoalProgress{}, err
+	}
+	return s.progress(ctx, goal)
+}
+
+func (s *GoalService) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]domain.GoalProgress, error) {
+	goals, err := s.repo.ListByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]domain.GoalProgress, 0, len(goals))
+	for _, g := range goals {
+		p, err := s.progress(ctx, g)
+		if err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+	return progress, nil
+}
+
+func (s *GoalService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *GoalService) progress(ctx context.Context, goal *domain.SavingsGoal) (domain.GoalProgress, error) {
+	p, err := s.repo.Progress(ctx, goal, time.Now().UTC())
+	if err != nil {
+		return domain.GoalProgress{}, fmt.Errorf("failed to get progress of goal %s: %w", goal.ID, err)
+	}
+	return p, nil
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+const installmentPostBatch = 500
+
+type InstallmentService struct {
+	repo ports.InstallmentRepository
+}
+
+func NewInstallmentService(repo ports.InstallmentRepository) *InstallmentService {
+	return &InstallmentService{
+		repo: repo,
+	}
+}
+
+// CreatePlan converts a card purchase into count monthly installments. A
+// zero annualRate is an interest-free plan (meses sin intereses).
+func (s *InstallmentService) CreatePlan(ctx context.Context, transactionID uuid.UUID, count int, annualRate float64) (*domain.InstallmentPlan, error) {
+	return s.repo.Create(ctx, transactionID, count, annualRate, time.Now().UTC())
+}
+
+func (s *InstallmentService) GetPlan(ctx context.Context, id uuid.UUID) (*domain.InstallmentPlan, error) {
+	return s.repo.Get(ctx, id)
+}
+
+func (s *InstallmentService) ListPlans(ctx context.Context, accountID uuid.UUID) ([]*domain.InstallmentPlan, error) {
+	return s.repo.ListByAccount(ctx, accountID)
+}
+
+// PostDueInstallments bills every installment due on or before asOf and
+// returns how many were posted.
+func (s *InstallmentService) PostDueInstallments(ctx context.Context, asOf time.Time) (int, error) {
+	posted := 0
+	for {
+		due, err := s.repo.ListDue(ctx, asOf, installmentPostBatch)
+		if err != nil {
+			return posted, fmt.Errorf("failed to list due installments: %w", err)
+		}
+		if len(due) == 0 {
+			return posted, nil
+		}
+
+		for _, installment := range due {
+			charge, err := s.repo.Post(ctx, installment)
+			if err != nil {
+				return posted, fmt.Errorf("failed to post installment %s: %w", installment.ID, err)
+			}
+			if charge != nil {
+				posted++
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+// balanceChunkDays is how many days of a balance series are read at a time.
+const balanceChunkDays = 92
+
+type LedgerService struct {
+	repo ports.LedgerRepository
+}
+
+func NewLedgerService(repo ports.LedgerRepository) *LedgerService {
+	return &LedgerService{
+		repo: repo,
+	}
+}
+
+// PostJournalEntry validates the double-entry invariants before storing
+// the entry, so an unbalanced entry never reaches the database.
+func (s *LedgerService) PostJournalEntry(ctx context.Context, entry *domain.JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.PostEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to post journal entry: %w", err)
+	}
+	return nil
+}
+
+func (s *LedgerService) GetTrialBalance(ctx context.Context, asOf time.Time) (*domain.TrialBalance, error) {
+	lines, err := s.repo.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trial balance: %w", err)
+	}
+
+	trialBalance := &domain.TrialBalance{
+		AsOf:  asOf,
+		Lines: lines,
+	}
+	for _, l := range lines {
+		trialBalance.Total += l.Balance
+	}
+	return trialBalance, nil
+}
+
+func (s *LedgerService) GetAccountStatement(ctx context.Context, accountID uuid.UUID, from, to time.Time) (*domain.AccountStatement, error) {
+	return s.GetLedgerStatement(ctx, domain.CustomerLedgerCode(accountID), from, to)
+}
+
+func (s *LedgerService) GetLedgerStatement(ctx context.Context, code string, from, to time.Time) (*domain.AccountStatement, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("invalid statement period %s - %s: %w", from.Format("2006-01-02"), to.Format("2006-01-02"), domain.ErrInvalidBalanceRange)
+	}
+
+	statement, err := s.repo.GetStatement(ctx, code, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement for %s: %w", code, err)
+	}
+	return statement, nil
+}
+
+// GetBalanceSeries returns the balance of an account for every day from
+// from to to, excluded, with the postings of each day when intraday is set.
+func (s *LedgerService) GetBalanceSeries(ctx context.Context, accountID uuid.UUID, from, to time.Time, intraday bool) (*domain.BalanceSeries, error) {
+	series := &domain.BalanceSeries{
+		LedgerAccountCode: domain.CustomerLedgerCode(accountID),
+		From:              from,
+		To:                to,
+	}
+	err := s.StreamBalanceSeries(ctx, accountID, from, to, intraday, func(d domain.DailyBalance) error {
+		series.Days = append(series.Days, d)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(series.Days) > 0 {
+		series.OpeningBalance = series.Days[0].OpeningBalance
+		series.ClosingBalance = series.Days[len(series.Days)-1].ClosingBalance
+	}
+	return series, nil
+}
+
+// StreamBalanceSeries calls fn with every day of the balance series of an
+// account in order, reading balanceChunkDays days at a time so that long
+// series are never held whole.
+func (s *LedgerService) StreamBalanceSeries(ctx context.Context, accountID uuid.UUID, from, to time.Time, intraday bool, fn func(domain.DailyBalance) error) error {
+	from, to = from.UTC().Truncate(24*time.Hour), to.UTC().Truncate(24*time.Hour)
+	if err := domain.ValidateBalanceRange(from, to); err != nil {
+		return err
+	}
+
+	code := domain.CustomerLedgerCode(accountID)
+	for start := from; start.Before(to); start = start.AddDate(0, 0, balanceChunkDays) {
+		end := start.AddDate(0, 0, balanceChunkDays)
+		if end.After(to) {
+			end = to
+		}
+
+		days, err := s.repo.GetDailyBalances(ctx, code, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get balances for %s: %w", code, err)
+		}
+
+		if intraday {
+			statement, err := s.repo.GetStatement(ctx, code, start, end)
+			if err != nil {
+				return fmt.Errorf("failed to get statement for %s: %w", code, err)
+			}
+			for _, line := range statement.Lines {
+				i := int(line.EffectiveDate.UTC().Sub(start).Hours() / 24)
+				if i >= 0 && i < len(days) {
+					days[i].Postings = append(days[i].Postings, line)
+				}
+			}
+		}
+
+		for _, d := range days {
+			if err := fn(d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetBalanceAsOf returns the balance of an account at the end of date,
+// with the activity of that day.
+func (s *LedgerService) GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, date time.Time) (*domain.DailyBalance, error) {
+	day := date.UTC().Truncate(24 * time.Hour)
+	code := domain.CustomerLedgerCode(accountID)
+
+	days, err := s.repo.GetDailyBalances(ctx, code, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance for %s: %w", code, err)
+	}
+	return &days[0], nil
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+const refundMatchBatch = 500
+
+type RefundService struct {
+	repo        ports.RefundRepository
+	matchWindow time.Duration
+}
+
+func NewRefundService(repo ports.RefundRepository, matchWindow time.Duration) *RefundService {
+	return &RefundService{
+		repo:        repo,
+		matchWindow: matchWindow,
+	}
+}
+
+// LinkRefund manually links a credit to the debit it refunds or reverses.
+func (s *RefundService) LinkRefund(ctx context.Context, refundID, originalID uuid.UUID, kind string) (*domain.Transaction, error) {
+	return s.repo.Link(ctx, refundID, originalID, kind)
+}
+
+// MatchRefunds links the given credits to an earlier debit of the same
+// merchant inside the match window and returns how many were linked.
+func (s *RefundService) MatchRefunds(ctx context.Context, transactions []*domain.Transaction) (int, error) {
+	linked := 0
+	for _, t := range transactions {
+		if !refundable(t) {
+			continue
+		}
+
+		candidates, err := s.repo.FindCandidates(ctx, t, t.InputDate.Add(-s.matchWindow), t.InputDate)
+		if err != nil {
+			return linked, fmt.Errorf("failed to find refund candidates for transaction %s: %w", t.ID, err)
+		}
+
+		original := domain.MatchRefund(t, candidates)
+		if original == nil {
+			continue
+		}
+
+		linkedRefund, err := s.repo.Link(ctx, t.ID, original.ID, domain.ReversalKindRefund)
+		if err != nil {
+			if isRefundConflict(err) {
+				// Another refund took the remaining amount first.
+				log.Printf("Skipping refund match for transaction %s: %v", t.ID, err)
+				continue
+			}
+			return linked, fmt.Errorf("failed to link refund %s: %w", t.ID, err)
+		}
+		*t = *linkedRefund
+		linked++
+	}
+	return linked, nil
+}
+
+// MatchAllRefunds runs the matcher over every credit that is not linked yet.
+func (s *RefundService) MatchAllRefunds(ctx context.Context) (int, error) {
+	linked := 0
+	var afterCreatedAt int64
+	afterID := uuid.Nil
+	for {
+		credits, err := s.repo.ListUnlinkedCredits(ctx, afterCreatedAt, afterID, refundMatchBatch)
+		if err != nil {
+			return linked, fmt.Errorf("failed to list unlinked credits: %w", err)
+		}
+		if len(credits) == 0 {
+			return linked, nil
+		}
+
+		last := credits[len(credits)-1]
+		afterCreatedAt, afterID = last.CreatedAt, last.ID
+
+		n, err := s.MatchRefunds(ctx, credits)
+		linked += n
+		if err != nil {
+			return linked, err
+		}
+	}
+}
+
+func refundable(t *domain.Transaction) bool {
+	return t.Amount > 0 && t.IsPosted() && t.Merchant != "" && !t.ReversalOf.Valid && !t.TransferID.Valid && !t.Voided
+}
+
+func isRefundConflict(err error) bool {
+	return errors.Is(err, domain.ErrRefundExceedsOriginal) ||
+		errors.Is(err, domain.ErrRefundAlreadyLinked) ||
+		errors.Is(err, domain.ErrTransactionVoided) ||
+		errors.Is(err, domain.ErrInstallmentPlanLocked)
+}
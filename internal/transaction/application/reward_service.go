@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+const rewardBatch = 500
+
+type RewardService struct {
+	repo    ports.RewardRepository
+	program *domain.RewardProgram
+}
+
+func NewRewardService(repo ports.RewardRepository, program *domain.RewardProgram) *RewardService {
+	return &RewardService{
+		repo:    repo,
+		program: program,
+	}
+}
+
+func (s *RewardService) Program() *domain.RewardProgram {
+	return s.program
+}
+
+// EarnRewards records the points of the eligible purchases among
+// transactions, e.g. right after a file import. It returns the points
+// earned.
+func (s *RewardService) EarnRewards(ctx context.Context, transactions []*domain.Transaction) (int64, error) {
+	var points int64
+	start := s.program.Start()
+	for _, t := range transactions {
+		if !t.EarnsRewards() || t.InputDate.Before(start) {
+			continue
+		}
+		entry, err := s.repo.Earn(ctx, t.ID, s.program.Earn)
+		if err != nil {
+			return points, fmt.Errorf("failed to earn rewards for transaction %s: %w", t.ID, err)
+		}
+		if entry != nil {
+			points += entry.Points
+		}
+	}
+	return points, nil
+}
+
+// EarnPending catches up on every eligible purchase that has not earned
+// points yet, such as transactions sent through the API, and returns the
+// points earned.
+func (s *RewardService) EarnPending(ctx context.Context) (int64, error) {
+	var points int64
+	var afterCreatedAt int64
+	afterID := uuid.Nil
+	for {
+		transactions, err := s.repo.ListUnrewarded(ctx, s.program.Start(), afterCreatedAt, afterID, rewardBatch)
+		if err != nil {
+			return points, fmt.Errorf("failed to list unrewarded transactions: %w", err)
+		}
+		if len(transactions) == 0 {
+			return points, nil
+		}
+
+		earned, err := s.EarnRewards(ctx, transactions)
+		points += earned
+		if err != nil {
+			return points, err
+		}
+
+		last := transactions[len(transactions)-1]
+		afterCreatedAt, afterID = last.CreatedAt, last.ID
+	}
+}
+
+// ClawBack takes back the points of purchases voided, amended down,
+// refunded or won in a dispute after they earned them, in proportion to
+// the spend that no longer stands, and returns how many were reversed.
+func (s *RewardService) ClawBack(ctx context.Context) (int, error) {
+	reversed := 0
+	for {
+		clawbacks, err := s.repo.ListClawbacks(ctx, rewardBatch)
+		if err != nil {
+			return reversed, fmt.Errorf("failed to list reward clawbacks: %w", err)
+		}
+
+		batch := 0
+		for _, clawback := range clawbacks {
+			reversal, err := s.repo.Reverse(ctx, clawback)
+			if err != nil {
+				return reversed, fmt.Errorf("failed to reverse rewards of transaction %s: %w", clawback.Earn.TransactionID, err)
+			}
+			if reversal != nil {
+				batch++
+			}
+		}
+		reversed += batch
+
+		// Settled clawbacks drop out of the list; stop if none did
+		if batch == 0 {
+			return reversed, nil
+		}
+	}
+}
+
+// Redeem exchanges points for a statement credit on the account.
+func (s *RewardService) Redeem(ctx context.Context, accountID uuid.UUID, points int64) (*domain.RewardEntry, error) {
+	now := time.Now().UTC()
+	return s.repo.Redeem(ctx, accountID, func(account *accountDomain.Account, balance int64) (*domain.RewardEntry, *domain.Transaction, error) {
+		return s.program.Redeem(accountID, points, balance, account.CycleStart(now), now)
+	})
+}
+
+func (s *RewardService) GetBalance(ctx context.Context, accountID uuid.UUID, limit, offset int64) (*domain.RewardBalance, error) {
+	balance, err := s.repo.GetBalance(ctx, accountID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	balance.Value = math.Round(float64(balance.Points)*s.program.PointValue*100) / 100
+	return balance, nil
+}
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type SplitService struct {
+	repo ports.SplitRepository
+}
+
+func NewSplitService(repo ports.SplitRepository) *SplitService {
+	return &SplitService{
+		repo: repo,
+	}
+}
+
+// SplitTransaction allocates a transaction across categories. The parts
+// must add up to the transaction amount; an empty list removes the split.
+func (s *SplitService) SplitTransaction(ctx context.Context, id uuid.UUID, parts []domain.SplitPart) (*domain.Transaction, error) {
+	return s.repo.SetSplits(ctx, id, parts)
+}
+
+func (s *SplitService) GetSplits(ctx context.Context, id uuid.UUID) ([]domain.Split, error) {
+	return s.repo.ListSplits(ctx, id)
+}
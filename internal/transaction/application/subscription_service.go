@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+const subscriptionAlertLimit = 50
+
+type SubscriptionService struct {
+	repo ports.SubscriptionRepository
+}
+
+func NewSubscriptionService(repo ports.SubscriptionRepository) *SubscriptionService {
+	return &SubscriptionService{repo: repo}
+}
+
+// Detect looks for recurring charges in the recent history of an account
+// and returns the alerts raised for the first time.
+func (s *SubscriptionService) Detect(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*domain.SubscriptionAlert, error) {
+	alerts, err := s.repo.Detect(ctx, accountID, now.Add(-domain.SubscriptionLookback),
+		func(history []*domain.Transaction, existing []*domain.Subscription) ([]*domain.Subscription, []*domain.SubscriptionAlert) {
+			return domain.DetectSubscriptions(accountID, history, existing, now)
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect subscriptions for account %s: %w", accountID, err)
+	}
+	return alerts, nil
+}
+
+// DetectAll runs detection for every account with recent purchases so that
+// missing charges are noticed even when nothing new is imported. It
+// returns the number of alerts raised.
+func (s *SubscriptionService) DetectAll(ctx context.Context, now time.Time) (int, error) {
+	accounts, err := s.repo.ListAccounts(ctx, now.Add(-domain.SubscriptionLookback))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list accounts for subscription detection: %w", err)
+	}
+
+	var raised int
+	for _, accountID := range accounts {
+		alerts, err := s.Detect(ctx, accountID, now)
+		if err != nil {
+			return raised, err
+		}
+		raised += len(alerts)
+	}
+	return raised, nil
+}
+
+func (s *SubscriptionService) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Subscription, error) {
+	return s.repo.ListByAccount(ctx, accountID)
+}
+
+func (s *SubscriptionService) ListAlerts(ctx context.Context, accountID uuid.UUID) ([]*domain.SubscriptionAlert, error) {
+	return s.repo.ListAlerts(ctx, accountID, subscriptionAlertLimit)
+}
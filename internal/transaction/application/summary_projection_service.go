@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+// summaryFlushBatch bounds how many stale months a flush refreshes.
+const summaryFlushBatch = 1000
+
+// SummaryProjectionService keeps the monthly summary projection up to date.
+// Every write queues the months it touches in the database, in the same
+// transaction, and Apply recomputes the month of each transaction event
+// only while it is still queued, so that an import of thousands of
+// transactions costs one refresh per month instead of one per transaction.
+// Flush is the backstop for the events the worker missed: a worker restart
+// or a lost message leaves the month queued, not stale for good.
+type SummaryProjectionService struct {
+	repo ports.SummaryProjectionRepository
+}
+
+func NewSummaryProjectionService(repo ports.SummaryProjectionRepository) *SummaryProjectionService {
+	return &SummaryProjectionService{
+		repo: repo,
+	}
+}
+
+// Apply refreshes the month of a transaction created or changed, unless
+// an earlier event or the import of its file already did.
+func (s *SummaryProjectionService) Apply(ctx context.Context, transaction *domain.Transaction) error {
+	month := domain.SummaryMonthOf(transaction)
+	if _, err := s.repo.RefreshStale(ctx, month); err != nil {
+		return fmt.Errorf("failed to refresh summary of account %s for %s: %w", month.AccountID, month.Month.Format("2006-01"), err)
+	}
+	return nil
+}
+
+// Flush refreshes the oldest stale months and returns how many were
+// refreshed. Months that fail stay queued for the next Flush.
+func (s *SummaryProjectionService) Flush(ctx context.Context) (int, error) {
+	months, err := s.repo.ListStale(ctx, summaryFlushBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stale summary months: %w", err)
+	}
+
+	for i, month := range months {
+		if err := s.repo.Refresh(ctx, month); err != nil {
+			return i, fmt.Errorf("failed to refresh summary of account %s for %s: %w", month.AccountID, month.Month.Format("2006-01"), err)
+		}
+	}
+	return len(months), nil
+}
+
+// Refresh recomputes right away the months of the transactions, for
+// callers that read the summary next.
+func (s *SummaryProjectionService) Refresh(ctx context.Context, transactions []*domain.Transaction) error {
+	months := make(map[domain.SummaryMonth]struct{})
+	for _, t := range transactions {
+		months[domain.SummaryMonthOf(t)] = struct{}{}
+	}
+	for month := range months {
+		if err := s.repo.Refresh(ctx, month); err != nil {
+			return fmt.Errorf("failed to refresh summary of account %s for %s: %w", month.AccountID, month.Month.Format("2006-01"), err)
+		}
+	}
+	return nil
+}
+
+// Rebuild recomputes every month of the projection from the transactions
+// and returns how many months were refreshed.
+func (s *SummaryProjectionService) Rebuild(ctx context.Context) (int, error) {
+	months, err := s.repo.ListMonths(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list summary months: %w", err)
+	}
+	for i, month := range months {
+		if err := s.repo.Refresh(ctx, month); err != nil {
+			return i, fmt.Errorf("failed to refresh summary of account %s for %s: %w", month.AccountID, month.Month.Format("2006-01"), err)
+		}
+	}
+	return len(months), nil
+}
@@ -15,31 +15,65 @@ import (
 	pb "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
 )
 
+const merchantNormalizeBatch = 500
+
 type TransactionService struct {
-	repo    ports.TransactionRepository
-	query   ports.TransactionQueryRepository
-	account pb.AccountServiceClient
-	sender  *email.Sender
+	repo                ports.TransactionRepository
+	query               ports.TransactionQueryRepository
+	account             pb.AccountServiceClient
+	sender              *email.Sender
+	normalizer          ports.MerchantNormalizer
+	authorizationWindow time.Duration
+	// summaryProjection serves the summaries it can from the monthly
+	// summary projection instead of the transactions.
+	summaryProjection bool
 }
 
-func NewTransactionService(repo ports.TransactionRepository, query ports.TransactionQueryRepository, conn *grpc.ClientConn, sender *email.Sender) *TransactionService {
+func NewTransactionService(
+	repo ports.TransactionRepository, query ports.TransactionQueryRepository, conn *grpc.ClientConn,
+	sender *email.Sender, normalizer ports.MerchantNormalizer, authorizationWindow time.Duration,
+	summaryProjection bool) *TransactionService {
 	return &TransactionService{
-		repo:    repo,
-		query:   query,
-		account: pb.NewAccountServiceClient(conn),
-		sender:  sender,
+		repo:                repo,
+		query:               query,
+		account:             pb.NewAccountServiceClient(conn),
+		sender:              sender,
+		normalizer:          normalizer,
+		authorizationWindow: authorizationWindow,
+		summaryProjection:   summaryProjection,
 	}
 }
 
-func (s *TransactionService) CreateTransaction(ctx context.Context, accountID uuid.UUID, amount float64, inputFileID string, inputDate time.Time) (*domain.Transaction, error) {
-	transaction := domain.NewTransaction(accountID, amount, inputFileID, inputDate)
-	err := s.repo.Create(ctx, transaction)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction: %w", err)
+func (s *TransactionService) CreateTransaction(ctx context.Context, accountID uuid.UUID, amount float64, description, inputFileID string, inputDate time.Time) (*domain.Transaction, error) {
+	transaction := domain.NewTransaction(accountID, amount, description, inputFileID, inputDate)
+	if err := s.create(ctx, transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// CreateAuthorization records a pending card authorization.
+func (s *TransactionService) CreateAuthorization(ctx context.Context, accountID uuid.UUID, amount float64, description, inputFileID string, authorizedAt time.Time) (*domain.Transaction, error) {
+	transaction := domain.NewAuthorization(accountID, amount, description, inputFileID, authorizedAt)
+	if err := s.create(ctx, transaction); err != nil {
+		return nil, err
 	}
 	return transaction, nil
 }
 
+func (s *TransactionService) create(ctx context.Context, transaction *domain.Transaction) error {
+	s.normalizeMerchant(transaction)
+	remaining, settlements, err := s.matchAuthorizations(ctx, []*domain.Transaction{transaction})
+	if err != nil {
+		return fmt.Errorf("failed to match authorization: %w", err)
+	}
+
+	if err := s.repo.CreateBulk(ctx, remaining, settlements); err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	return nil
+}
+
 func (s *TransactionService) GetTransaction(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
 	return s.query.GetByID(ctx, id)
 }
@@ -48,72 +82,280 @@ func (s *TransactionService) GetTransactionsByAccount(ctx context.Context, accou
 	return s.query.GetByAccountID(ctx, accountID, limit, offset)
 }
 
-func (s *TransactionService) GetTransactionSummary(ctx context.Context, accountID uuid.UUID) (*domain.TransactionSummary, error) {
-	transactions, err := s.query.GetByAccountID(ctx, accountID, 1000, 0)
-	if err != nil {
+// SearchTransactions returns the transactions of an account matching the
+// tags and text of the search.
+func (s *TransactionService) SearchTransactions(ctx context.Context, accountID uuid.UUID, search domain.TransactionSearch, limit, offset int64) ([]*domain.Transaction, error) {
+	return s.query.Search(ctx, accountID, search, limit, offset)
+}
+
+// GetTransactionSummary aggregates every transaction of the account the
+// options select and, when they ask for it, returns a page of those
+// transactions in their months.
+func (s *TransactionService) GetTransactionSummary(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) (*domain.TransactionSummary, error) {
+	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
+	if opts.Granularity == domain.GranularityCycle && opts.CycleClosingDay == 0 {
+		// Card accounts close their cycles on their statement closing day
+		account, err := s.account.GetAccount(ctx, &pb.GetAccountRequest{Id: accountID.String()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account: %w", err)
+		}
+		opts.CycleClosingDay = int(account.StatementClosingDay)
+	}
 
-	summary := &domain.TransactionSummary{
-		Monthly: make(map[string]*domain.TransactionMonthly),
+	summary, err := s.aggregateSummary(ctx, accountID, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, t := range transactions {
-		key := t.InputDate.Format("2006-01")
-		summary.TotalCount++
-		summary.TotalBalance += t.Amount
-
-		if _, ok := summary.Monthly[key]; !ok {
-			summary.Monthly[key] = &domain.TransactionMonthly{
-				Year:         t.InputDate.Year(),
-				Month:        int(t.InputDate.Month()),
-				Transactions: make([]domain.Transaction, 0),
+	if opts.DetailLimit > 0 {
+		transactions, err := s.repo.ListSummaryTransactions(ctx, accountID, opts, opts.DetailLimit, opts.DetailOffset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list summary transactions: %w", err)
+		}
+		for _, t := range transactions {
+			if monthly, ok := summary.Monthly[t.InputDate.Format("2006-01")]; ok {
+				monthly.Transactions = append(monthly.Transactions, *t)
 			}
 		}
+	}
 
-		summary.Monthly[key].Transactions = append(summary.Monthly[key].Transactions, *t)
-		summary.Monthly[key].Total++
+	summary.InstallmentBalance, err = s.repo.GetInstallmentBalance(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installment balance: %w", err)
+	}
 
-		if t.Amount > 0 {
-			summary.CreditCount++
-			summary.TotalCredit += t.Amount
-			summary.Monthly[key].AverageCredit += t.Amount
-			summary.Monthly[key].CreditCount++
-			summary.Monthly[key].Balance += t.Amount
-		} else {
-			summary.DebitCount++
-			summary.TotalDebit += t.Amount
-			summary.Monthly[key].AverageDebit += t.Amount
-			summary.Monthly[key].DebitCount++
-			summary.Monthly[key].Balance -= t.Amount
+	summary.Rewards, err = s.repo.GetRewardSummary(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reward points: %w", err)
+	}
+	months, err := s.repo.ListRewardMonths(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reward points: %w", err)
+	}
+	for _, m := range months {
+		if monthly, ok := summary.Monthly[fmt.Sprintf("%04d-%02d", m.Year, m.Month)]; ok {
+			monthly.PointsEarned = m.Earned
+			monthly.PointsRedeemed = m.Redeemed
 		}
 	}
 
-	// Recorremos los Montlhy del summary
-	for k, v := range summary.Monthly {
-		if v.CreditCount > 0 {
-			v.AverageCredit = v.AverageCredit / float64(v.CreditCount)
-		}
+	summary.Subscriptions, err = s.repo.ListSubscriptions(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
 
-		if v.DebitCount > 0 {
-			v.AverageDebit = v.AverageDebit / float64(v.DebitCount)
+	budgets, err := s.repo.ListBudgets(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+	if len(budgets) > 0 {
+		var latest string
+		for key, monthly := range summary.Monthly {
+			month := time.Date(monthly.Year, time.Month(monthly.Month), 1, 0, 0, 0, 0, time.UTC)
+			monthly.Budgets = domain.CompareBudgets(budgets, domain.CategorySpend(monthly.Categories), month)
+			if key > latest {
+				latest = key
+			}
+		}
+		if latest != "" {
+			summary.Budgets = summary.Monthly[latest].Budgets
 		}
-		summary.Monthly[k] = v
 	}
 
-	if summary.CreditCount > 0 {
-		summary.AverageCredit = summary.TotalCredit / float64(summary.CreditCount)
+	summary.Goals, err = s.repo.ListGoalProgress(ctx, accountID, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list savings goals: %w", err)
 	}
 
-	if summary.DebitCount > 0 {
-		summary.AverageDebit = summary.TotalDebit / float64(summary.DebitCount)
+	summary.Forecast, err = s.repo.GetForecast(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forecast: %w", err)
 	}
 
 	return summary, nil
 }
 
+// aggregateSummary computes the totals, months and periods of a summary,
+// from the monthly summary projection when it can serve the options, and
+// logs how long it took so both sources can be compared.
+func (s *TransactionService) aggregateSummary(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) (*domain.TransactionSummary, error) {
+	start := time.Now()
+	source := "transactions"
+
+	// Read from the period before the range to compare the first period
+	lookback := opts
+	lookback.From = opts.LookbackStart()
+
+	var summary *domain.TransactionSummary
+	var days []domain.SummaryDay
+	var err error
+	if s.summaryProjection && opts.Projectable() {
+		source = "projection"
+		if summary, err = s.repo.GetProjectedSummary(ctx, accountID, opts); err != nil {
+			return nil, fmt.Errorf("failed to get summary: %w", err)
+		}
+		if days, err = s.repo.ListProjectedMonths(ctx, accountID, lookback); err != nil {
+			return nil, fmt.Errorf("failed to list summary months: %w", err)
+		}
+	} else {
+		if summary, err = s.repo.GetSummary(ctx, accountID, opts); err != nil {
+			return nil, fmt.Errorf("failed to get summary: %w", err)
+		}
+		if days, err = s.repo.ListSummaryDays(ctx, accountID, lookback); err != nil {
+			return nil, fmt.Errorf("failed to list summary days: %w", err)
+		}
+	}
+
+	summary.From, summary.To, summary.Granularity = opts.From, opts.To, opts.Granularity
+	if summary.Periods, err = domain.BuildPeriods(days, opts); err != nil {
+		return nil, err
+	}
+	log.Printf("Summary of account %s aggregated from %s in %s", accountID, source, time.Since(start))
+	return summary, nil
+}
+
+// GetTagTotals reports, for every tag used by the account, how many
+// transactions carry it and their credits, debits and net total.
+func (s *TransactionService) GetTagTotals(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) ([]domain.TagTotal, error) {
+	return s.repo.GetTagTotals(ctx, accountID, opts)
+}
+
+// CreateBulkTransactions records the transactions, settling the pending
+// authorizations matched by the posted ones, in a single database
+// transaction.
 func (s *TransactionService) CreateBulkTransactions(ctx context.Context, transactions []*domain.Transaction) error {
-	return s.repo.CreateBulk(ctx, transactions)
+	for _, t := range transactions {
+		s.normalizeMerchant(t)
+	}
+
+	remaining, settlements, err := s.matchAuthorizations(ctx, transactions)
+	if err != nil {
+		return fmt.Errorf("failed to match authorizations: %w", err)
+	}
+	if len(remaining) == 0 && len(settlements) == 0 {
+		return nil
+	}
+	return s.repo.CreateBulk(ctx, remaining, settlements)
+}
+
+// ImportTransactions creates the transactions read from a file of an
+// account, skipping the rows it already imported, and returns those
+// created. The rows skipped are counted on the import.
+func (s *TransactionService) ImportTransactions(ctx context.Context, fileImport *domain.FileImport, transactions []*domain.Transaction) ([]*domain.Transaction, error) {
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(transactions))
+	for _, t := range transactions {
+		if t.ImportKey != "" {
+			keys = append(keys, t.ImportKey)
+		}
+	}
+	imported, err := s.repo.ListImportedKeys(ctx, fileImport.AccountID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list imported rows: %w", err)
+	}
+
+	kept, duplicates := domain.SkipDuplicates(transactions, imported)
+	fileImport.DuplicateCount = duplicates
+	if len(kept) == 0 {
+		return nil, nil
+	}
+	if err := s.CreateBulkTransactions(ctx, kept); err != nil {
+		return nil, err
+	}
+	return kept, nil
+}
+
+// matchAuthorizations pairs the posted transactions with the pending
+// authorizations they settle. A settled transaction takes over the pending
+// row, so it is left out of the returned slice of rows to insert.
+func (s *TransactionService) matchAuthorizations(ctx context.Context, transactions []*domain.Transaction) ([]*domain.Transaction, []domain.Settlement, error) {
+	remaining := make([]*domain.Transaction, 0, len(transactions))
+	var settlements []domain.Settlement
+	claimed := make(map[uuid.UUID]bool)
+	for _, t := range transactions {
+		if t.Status != domain.StatusPosted || t.TransferID.Valid || s.authorizationWindow <= 0 {
+			remaining = append(remaining, t)
+			continue
+		}
+
+		pending, err := s.repo.ListPending(ctx, t.AccountID, t.InputDate.Add(-s.authorizationWindow), t.InputDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidates := pending[:0]
+		for _, p := range pending {
+			if !claimed[p.ID] {
+				candidates = append(candidates, p)
+			}
+		}
+
+		match := domain.MatchAuthorization(t, candidates)
+		if match == nil {
+			remaining = append(remaining, t)
+			continue
+		}
+		claimed[match.ID] = true
+		settlements = append(settlements, domain.Settlement{PendingID: match.ID, Posted: t})
+	}
+	return remaining, settlements, nil
+}
+
+// ReverseAuthorization releases a pending authorization.
+func (s *TransactionService) ReverseAuthorization(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	return s.repo.ReverseAuthorization(ctx, id)
+}
+
+// ExpireAuthorizations expires pending authorizations that were not settled
+// within the authorization window.
+func (s *TransactionService) ExpireAuthorizations(ctx context.Context, now time.Time) (int, error) {
+	if s.authorizationWindow <= 0 {
+		return 0, nil
+	}
+	expired, err := s.repo.ExpirePending(ctx, now.Add(-s.authorizationWindow))
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire authorizations: %w", err)
+	}
+	return len(expired), nil
+}
+
+// NormalizeMerchants re-applies the merchant dictionary to every stored
+// transaction and returns how many of them changed.
+func (s *TransactionService) NormalizeMerchants(ctx context.Context) (int, error) {
+	updated := 0
+	for offset := int64(0); ; offset += merchantNormalizeBatch {
+		transactions, err := s.repo.List(ctx, merchantNormalizeBatch, offset)
+		if err != nil {
+			return updated, fmt.Errorf("failed to list transactions: %w", err)
+		}
+
+		for _, t := range transactions {
+			merchant, category := t.Merchant, t.Category
+			s.normalizeMerchant(t)
+			if t.Merchant == merchant && t.Category == category {
+				continue
+			}
+			if err := s.repo.UpdateMerchant(ctx, t); err != nil {
+				return updated, fmt.Errorf("failed to update merchant for transaction %s: %w", t.ID, err)
+			}
+			updated++
+		}
+
+		if len(transactions) < merchantNormalizeBatch {
+			return updated, nil
+		}
+	}
+}
+
+func (s *TransactionService) normalizeMerchant(t *domain.Transaction) {
+	if s.normalizer == nil || t.Description == "" {
+		return
+	}
+	t.SetMerchant(s.normalizer.Normalize(t.Description))
 }
 
 func (s *TransactionService) SendSummaryEmail(ctx context.Context, summary *domain.TransactionSummary, userID uuid.UUID) error {
@@ -125,6 +367,17 @@ func (s *TransactionService) SendSummaryEmail(ctx context.Context, summary *doma
 		return fmt.Errorf("failed to get user account: %w", err)
 	}
 
+	if statement := user.GetStatement(); statement != nil {
+		summary.Card = &domain.CardCycle{
+			CreditLimit:      user.CreditLimit,
+			AvailableCredit:  user.AvailableCredit,
+			ClosingDate:      statement.ClosingDate.AsTime(),
+			DueDate:          statement.DueDate.AsTime(),
+			StatementBalance: statement.StatementBalance,
+			MinimumPayment:   statement.MinimumPayment,
+		}
+	}
+
 	err = s.sender.SendWithTemplate(user.Email, "Resumen de Transacciones", "summary.gohtml", summary)
 	if err != nil {
 		log.Printf("Error sending email: %v", err)
@@ -132,3 +385,32 @@ func (s *TransactionService) SendSummaryEmail(ctx context.Context, summary *doma
 	}
 	return nil
 }
+
+// SendImportEmail sends the summary of an import to the account holder,
+// with the billing cycle of card accounts when the summary of the account
+// comes along.
+func (s *TransactionService) SendImportEmail(ctx context.Context, summary *domain.ImportSummary) error {
+	user, err := s.account.GetAccount(ctx, &pb.GetAccountRequest{Id: summary.Import.AccountID.String()})
+	if err != nil {
+		log.Printf("Error getting user account: %v", err)
+		return fmt.Errorf("failed to get user account: %w", err)
+	}
+
+	if statement := user.GetStatement(); statement != nil && summary.Lifetime != nil {
+		summary.Lifetime.Card = &domain.CardCycle{
+			CreditLimit:      user.CreditLimit,
+			AvailableCredit:  user.AvailableCredit,
+			ClosingDate:      statement.ClosingDate.AsTime(),
+			DueDate:          statement.DueDate.AsTime(),
+			StatementBalance: statement.StatementBalance,
+			MinimumPayment:   statement.MinimumPayment,
+		}
+	}
+
+	err = s.sender.SendWithTemplate(user.Email, "Resumen de tu Archivo", "import.gohtml", summary)
+	if err != nil {
+		log.Printf("Error sending email: %v", err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+// fakeTransactionRepository serves the pending authorizations and imported
+// file rows of an account and records what CreateBulk is asked to write.
+// Any other write panics through the nil embedded interface.
+type fakeTransactionRepository struct {
+	ports.TransactionRepository
+	pending     []*domain.Transaction
+	imported    []string
+	createErr   error
+	created     [][]*domain.Transaction
+	settlements [][]domain.Settlement
+}
+
+func (r *fakeTransactionRepository) ListPending(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*domain.Transaction, error) {
+	var pending []*domain.Transaction
+	for _, p := range r.pending {
+		if p.AccountID == accountID && !p.AuthorizedAt.Before(from) && !p.AuthorizedAt.After(to) {
+			pending = append(pending, p)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeTransactionRepository) ListImportedKeys(ctx context.Context, accountID uuid.UUID, keys []string) ([]string, error) {
+	return r.imported, nil
+}
+
+func (r *fakeTransactionRepository) CreateBulk(ctx context.Context, transactions []*domain.Transaction, settlements []domain.Settlement) error {
+	r.created = append(r.created, transactions)
+	r.settlements = append(r.settlements, settlements)
+	return r.createErr
+}
+
+func TestCreateBulkTransactionsSettlesWithTheFile(t *testing.T) {
+	account := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		createErr     error
+		wantErr       bool
+		wantInserted  int
+		wantSettled   int
+		authorization bool
+	}{
+		{name: "settles and inserts in one call", authorization: true, wantInserted: 1, wantSettled: 1},
+		{name: "nothing to settle", wantInserted: 2},
+		{name: "failure settles nothing", authorization: true, createErr: errors.New("insert failed"), wantErr: true, wantInserted: 1, wantSettled: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTransactionRepository{createErr: tt.createErr}
+			var pending *domain.Transaction
+			if tt.authorization {
+				pending = domain.NewAuthorization(account, -10, "Coffee Shop", "auth.csv", day.AddDate(0, 0, -1))
+				repo.pending = []*domain.Transaction{pending}
+			}
+			service := NewTransactionService(repo, nil, nil, nil, nil, 72*time.Hour, false)
+
+			coffee := domain.NewTransaction(account, -10.5, "Coffee Shop", "file.csv", day)
+			payroll := domain.NewTransaction(account, 1000, "Payroll", "file.csv", day)
+			err := service.CreateBulkTransactions(context.Background(), []*domain.Transaction{coffee, payroll})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateBulkTransactions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// Settlements only reach the database together with the rest of
+			// the file, so a failed CreateBulk leaves every authorization
+			// pending
+			if len(repo.created) != 1 {
+				t.Fatalf("CreateBulk called %d times, want 1", len(repo.created))
+			}
+			if got := len(repo.created[0]); got != tt.wantInserted {
+				t.Errorf("inserted %d transactions, want %d", got, tt.wantInserted)
+			}
+			if got := len(repo.settlements[0]); got != tt.wantSettled {
+				t.Fatalf("settled %d authorizations, want %d", got, tt.wantSettled)
+			}
+			if tt.wantSettled > 0 {
+				settlement := repo.settlements[0][0]
+				if settlement.PendingID != pending.ID || settlement.Posted != coffee {
+					t.Errorf("settlement = %v, want the coffee authorization settled by the coffee row", settlement)
+				}
+				if pending.Status != domain.StatusPending {
+					t.Errorf("authorization status = %s, want it untouched by the service", pending.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchAuthorizationsClaimsEachOnce(t *testing.T) {
+	account := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	pending := domain.NewAuthorization(account, -10, "Coffee Shop", "auth.csv", day.AddDate(0, 0, -1))
+	repo := &fakeTransactionRepository{pending: []*domain.Transaction{pending}}
+	service := NewTransactionService(repo, nil, nil, nil, nil, 72*time.Hour, false)
+
+	first := domain.NewTransaction(account, -10, "Coffee Shop", "file.csv", day)
+	second := domain.NewTransaction(account, -10, "Coffee Shop", "file.csv", day)
+	remaining, settlements, err := service.matchAuthorizations(context.Background(), []*domain.Transaction{first, second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(settlements) != 1 || settlements[0].Posted != first {
+		t.Fatalf("settlements = %v, want the first row only", settlements)
+	}
+	if len(remaining) != 1 || remaining[0] != second {
+		t.Fatalf("remaining = %v, want the second row", remaining)
+	}
+}
+
+func TestImportTransactionsSkipsImportedRows(t *testing.T) {
+	account := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	file := domain.HashFile([]byte("2024-03-10,-10.5\n2024-03-10,-10.5\n"))
+
+	// The first upload stopped after its first row; the second charge of
+	// the same amount that day is still to be imported
+	repo := &fakeTransactionRepository{imported: []string{domain.ImportKey(file, 2)}}
+	service := NewTransactionService(repo, nil, nil, nil, fixedNormalizer{"Coffee Shop", "food"}, 72*time.Hour, false)
+
+	fileImport := domain.NewFileImport(account, "file.csv", 64)
+	first := domain.NewTransaction(account, -10.5, "", "file.csv", day)
+	first.ImportKey = domain.ImportKey(file, 2)
+	second := domain.NewTransaction(account, -10.5, "", "file.csv", day)
+	second.ImportKey = domain.ImportKey(file, 3)
+	kept, err := service.ImportTransactions(context.Background(), fileImport, []*domain.Transaction{first, second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(kept) != 1 || kept[0] != second || fileImport.DuplicateCount != 1 {
+		t.Fatalf("kept %d rows with %d duplicates, want the second row only", len(kept), fileImport.DuplicateCount)
+	}
+	if len(repo.created) != 1 || len(repo.created[0]) != 1 {
+		t.Fatalf("created %v, want the second row", repo.created)
+	}
+}
+
+type fixedNormalizer struct {
+	merchant, category string
+}
+
+func (n fixedNormalizer) Normalize(description string) (string, string) {
+	return n.merchant, n.category
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type TransferService struct {
+	repo  ports.TransferRepository
+	query ports.TransferQueryRepository
+}
+
+func NewTransferService(repo ports.TransferRepository, query ports.TransferQueryRepository) *TransferService {
+	return &TransferService{
+		repo:  repo,
+		query: query,
+	}
+}
+
+// CreateTransfer moves amount from source to destination. Requests sharing
+// an idempotency key return the original transfer instead of moving money
+// twice; the boolean reports whether a new transfer was created.
+func (s *TransferService) CreateTransfer(ctx context.Context, sourceAccountID, destinationAccountID uuid.UUID, amount float64, description, idempotencyKey string) (*domain.Transfer, bool, error) {
+	if idempotencyKey != "" {
+		existing, err := s.replay(ctx, sourceAccountID, destinationAccountID, amount, idempotencyKey)
+		if err != nil || existing != nil {
+			return existing, false, err
+		}
+	}
+
+	transfer, err := domain.NewTransfer(sourceAccountID, destinationAccountID, amount, description, idempotencyKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = s.repo.Create(ctx, transfer)
+	if errors.Is(err, domain.ErrDuplicateTransfer) {
+		// A concurrent request with the same key won the race.
+		existing, err := s.replay(ctx, sourceAccountID, destinationAccountID, amount, idempotencyKey)
+		return existing, false, err
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create transfer: %w", err)
+	}
+	return transfer, true, nil
+}
+
+func (s *TransferService) GetTransfer(ctx context.Context, id uuid.UUID) (*domain.Transfer, error) {
+	return s.query.GetByID(ctx, id)
+}
+
+func (s *TransferService) GetTransfersByAccount(ctx context.Context, accountID uuid.UUID, limit, offset int64) ([]*domain.Transfer, error) {
+	return s.query.GetByAccountID(ctx, accountID, limit, offset)
+}
+
+func (s *TransferService) replay(ctx context.Context, sourceAccountID, destinationAccountID uuid.UUID, amount float64, idempotencyKey string) (*domain.Transfer, error) {
+	existing, err := s.repo.GetByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	if existing != nil && !existing.SameRequest(sourceAccountID, destinationAccountID, amount) {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	return existing, nil
+}
@@ -2,18 +2,112 @@ package transaction
 
 import (
 	"database/sql"
+	"time"
 
 	"google.golang.org/grpc"
 
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/email"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/infrastructure"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
 	"github.com/olivere/elastic/v7"
 )
 
-func SetupTransactionDomain(db *sql.DB, esClient *elastic.Client, nc *nats.NatsClient, conn *grpc.ClientConn, sender *email.Sender) *application.TransactionService {
+func SetupTransactionDomain(
+	db *sql.DB, esClient *elastic.Client, nc *nats.NatsClient, conn *grpc.ClientConn,
+	sender *email.Sender, normalizer ports.MerchantNormalizer, authorizationWindow time.Duration,
+	summaryProjection bool) *application.TransactionService {
 	repo := infrastructure.NewPostgresTransactionRepository(db, nc)
 	queryRepo := infrastructure.NewElasticsearchTransactionRepository(esClient, nc, "transactions")
-	return application.NewTransactionService(repo, queryRepo, conn, sender)
+	return application.NewTransactionService(repo, queryRepo, conn, sender, normalizer, authorizationWindow, summaryProjection)
+}
+
+func SetupLedgerDomain(db *sql.DB, nc *nats.NatsClient) *application.LedgerService {
+	repo := infrastructure.NewPostgresLedgerRepository(db, nc)
+	return application.NewLedgerService(repo)
+}
+
+func SetupTransferDomain(db *sql.DB, esClient *elastic.Client, nc *nats.NatsClient) *application.TransferService {
+	repo := infrastructure.NewPostgresTransferRepository(db, nc)
+	queryRepo := infrastructure.NewElasticsearchTransferRepository(esClient, nc, "transfers")
+	return application.NewTransferService(repo, queryRepo)
+}
+
+func SetupCorrectionDomain(db *sql.DB, nc *nats.NatsClient, normalizer ports.MerchantNormalizer) *application.CorrectionService {
+	repo := infrastructure.NewPostgresCorrectionRepository(db, nc)
+	return application.NewCorrectionService(repo, normalizer)
+}
+
+func SetupRefundDomain(db *sql.DB, nc *nats.NatsClient, matchWindow time.Duration) *application.RefundService {
+	repo := infrastructure.NewPostgresRefundRepository(db, nc)
+	return application.NewRefundService(repo, matchWindow)
+}
+
+func SetupSplitDomain(db *sql.DB, nc *nats.NatsClient) *application.SplitService {
+	repo := infrastructure.NewPostgresSplitRepository(db, nc)
+	return application.NewSplitService(repo)
+}
+
+func SetupAnnotationDomain(db *sql.DB, nc *nats.NatsClient) *application.AnnotationService {
+	repo := infrastructure.NewPostgresAnnotationRepository(db, nc)
+	return application.NewAnnotationService(repo)
+}
+
+func SetupInstallmentDomain(db *sql.DB, nc *nats.NatsClient) *application.InstallmentService {
+	repo := infrastructure.NewPostgresInstallmentRepository(db, nc)
+	return application.NewInstallmentService(repo)
+}
+
+func SetupAccrualDomain(db *sql.DB, nc *nats.NatsClient, policy accountDomain.AccrualPolicy) *application.AccrualService {
+	repo := infrastructure.NewPostgresAccrualRepository(db, nc)
+	return application.NewAccrualService(repo, policy)
+}
+
+func SetupRewardDomain(db *sql.DB, nc *nats.NatsClient, source ports.RewardProgramSource) (*application.RewardService, error) {
+	program, err := source.Load()
+	if err != nil {
+		return nil, err
+	}
+	repo := infrastructure.NewPostgresRewardRepository(db, nc)
+	return application.NewRewardService(repo, program), nil
+}
+
+func SetupDisputeDomain(
+	db *sql.DB, nc *nats.NatsClient, blobs ports.BlobStore, conn *grpc.ClientConn,
+	sender *email.Sender, policy domain.DisputePolicy) *application.DisputeService {
+	repo := infrastructure.NewPostgresDisputeRepository(db, nc)
+	return application.NewDisputeService(repo, blobs, conn, sender, policy)
+}
+
+func SetupSubscriptionDomain(db *sql.DB, nc *nats.NatsClient) *application.SubscriptionService {
+	repo := infrastructure.NewPostgresSubscriptionRepository(db, nc)
+	return application.NewSubscriptionService(repo)
+}
+
+func SetupBudgetDomain(db *sql.DB, nc *nats.NatsClient, conn *grpc.ClientConn, sender *email.Sender) *application.BudgetService {
+	repo := infrastructure.NewPostgresBudgetRepository(db, nc)
+	return application.NewBudgetService(repo, conn, sender)
+}
+
+func SetupGoalDomain(db *sql.DB, nc *nats.NatsClient) *application.GoalService {
+	repo := infrastructure.NewPostgresGoalRepository(db, nc)
+	return application.NewGoalService(repo)
+}
+
+func SetupAnomalyDomain(db *sql.DB, nc *nats.NatsClient, conn *grpc.ClientConn, sender *email.Sender, policy domain.AnomalyPolicy) *application.AnomalyService {
+	repo := infrastructure.NewPostgresAnomalyRepository(db, nc)
+	return application.NewAnomalyService(repo, conn, sender, policy)
+}
+
+func SetupSummaryProjectionDomain(db *sql.DB, nc *nats.NatsClient) *application.SummaryProjectionService {
+	repo := infrastructure.NewPostgresSummaryProjectionRepository(db, nc)
+	return application.NewSummaryProjectionService(repo)
+}
+
+func SetupForecastDomain(db *sql.DB, nc *nats.NatsClient) *application.ForecastService {
+	repo := infrastructure.NewPostgresForecastRepository(db, nc)
+	return application.NewForecastService(repo)
 }
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TransactionAlertCreatedEvent  = "transactions.alert.created"
+	TransactionAlertReviewedEvent = "transactions.alert.reviewed"
+
+	AlertStatusOpen      = "open"
+	AlertStatusConfirmed = "confirmed"
+	AlertStatusDismissed = "dismissed"
+
+	AnomalyAmount          = "amount_zscore"
+	AnomalyUnusualMerchant = "unusual_merchant"
+	AnomalyDebitBurst      = "debit_burst"
+	AnomalyDuplicateCharge = "duplicate_charge"
+
+	// AnomalyHistoryWindow is how far back the amount statistics of an
+	// account are computed.
+	AnomalyHistoryWindow = 180 * 24 * time.Hour
+	// anomalyMinHistory is how many debits an account needs before its
+	// amounts and merchants are considered usual.
+	anomalyMinHistory = 10
+	// anomalyFlagScore is the score from which a transaction is flagged; an
+	// unusual merchant alone stays below it.
+	anomalyFlagScore = 0.4
+)
+
+// anomalyWeights is how much each signal adds to the score of a
+// transaction, which is capped at 1.
+var anomalyWeights = map[string]float64{
+	AnomalyAmount:          0.5,
+	AnomalyUnusualMerchant: 0.2,
+	AnomalyDebitBurst:      0.4,
+	AnomalyDuplicateCharge: 0.5,
+}
+
+var (
+	ErrTransactionAlertNotFound = errors.New("transaction alert not found")
+	ErrInvalidAlertReview       = errors.New("alerts are reviewed as confirmed or dismissed")
+	ErrAlertReviewed            = errors.New("alert was already reviewed")
+)
+
+// AnomalyPolicy sets when a debit looks suspicious: an amount ZScore
+// standard deviations above the account average, BurstCount debits within
+// BurstWindow, or the same charge again within DuplicateWindow.
+type AnomalyPolicy struct {
+	ZScore          float64
+	BurstWindow     time.Duration
+	BurstCount      int
+	DuplicateWindow time.Duration
+}
+
+// AnomalyHistory is what the account history says about a transaction:
+// the amount statistics of earlier debits, how often the merchant was
+// seen before, the other debits within the burst window and the possible
+// duplicates within the duplicate window.
+type AnomalyHistory struct {
+	DebitCount    int
+	Mean          float64
+	StdDev        float64
+	MerchantCount int
+	RecentDebits  int
+	Duplicates    []uuid.UUID
+}
+
+// TransactionAlert flags a suspicious transaction for review.
+type TransactionAlert struct {
+	ID            uuid.UUID
+	TransactionID uuid.UUID
+	AccountID     uuid.UUID
+	Score         float64
+	Reasons       []AnomalyReason
+	Status        string // "open", "confirmed" or "dismissed"
+	ReviewedBy    string
+	ReviewNote    string
+	ReviewedAt    int64
+	CreatedAt     int64
+	UpdatedAt     int64
+
+	Transaction *Transaction `json:",omitempty"`
+}
+
+type AnomalyReason struct {
+	Code   string
+	Weight float64
+	Detail string
+}
+
+// IsScored reports whether the transaction is checked for anomalies: a
+// customer debit still in effect.
+func (t *Transaction) IsScored() bool {
+	return t.Type == "debit" && t.Amount < 0 && !t.Voided && t.Status != StatusExpired
+}
+
+// Score checks a debit against the account history and returns an alert
+// when it looks suspicious, or nil.
+func (p AnomalyPolicy) Score(t *Transaction, h AnomalyHistory) *TransactionAlert {
+	amount := -t.Amount
+	var reasons []AnomalyReason
+	add := func(code, detail string) {
+		reasons = append(reasons, AnomalyReason{Code: code, Weight: anomalyWeights[code], Detail: detail})
+	}
+
+	if h.DebitCount >= anomalyMinHistory && h.StdDev > 0 {
+		if z := (amount - h.Mean) / h.StdDev; z >= p.ZScore {
+			add(AnomalyAmount, fmt.Sprintf("%.2f is %.1f standard deviations above the average debit of %.2f", amount, z, h.Mean))
+		}
+	}
+	if t.Merchant != "" && h.DebitCount >= anomalyMinHistory && h.MerchantCount == 0 {
+		add(AnomalyUnusualMerchant, fmt.Sprintf("first charge from %s", t.Merchant))
+	}
+	// Transactions imported with a date only cannot show a burst
+	if p.BurstCount > 0 && hasTimeOfDay(t.AuthorizedAt) && h.RecentDebits+1 >= p.BurstCount {
+		add(AnomalyDebitBurst, fmt.Sprintf("%d debits within %s", h.RecentDebits+1, p.BurstWindow))
+	}
+	if len(h.Duplicates) > 0 {
+		add(AnomalyDuplicateCharge, fmt.Sprintf("same charge as transaction %s within %s", h.Duplicates[0], p.DuplicateWindow))
+	}
+
+	var score float64
+	for _, r := range reasons {
+		score += r.Weight
+	}
+	score = math.Min(1, score)
+	if score < anomalyFlagScore {
+		return nil
+	}
+
+	now := time.Now().UTC().Unix()
+	return &TransactionAlert{
+		ID:            uuid.New(),
+		TransactionID: t.ID,
+		AccountID:     t.AccountID,
+		Score:         math.Round(score*100) / 100,
+		Reasons:       reasons,
+		Status:        AlertStatusOpen,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Transaction:   t,
+	}
+}
+
+// Review closes an open alert as confirmed fraud or as a false positive.
+func (a *TransactionAlert) Review(status, reviewer, note string, now time.Time) error {
+	if status != AlertStatusConfirmed && status != AlertStatusDismissed {
+		return ErrInvalidAlertReview
+	}
+	if a.Status != AlertStatusOpen {
+		return ErrAlertReviewed
+	}
+	a.Status = status
+	a.ReviewedBy = reviewer
+	a.ReviewNote = note
+	a.ReviewedAt = now.UTC().Unix()
+	a.UpdatedAt = a.ReviewedAt
+	return nil
+}
+
+func hasTimeOfDay(t time.Time) bool {
+	return !t.Equal(truncateDate(t))
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAnomalyPolicyScore(t *testing.T) {
+	policy := AnomalyPolicy{ZScore: 3, BurstWindow: 10 * time.Minute, BurstCount: 5, DuplicateWindow: time.Hour}
+	usual := AnomalyHistory{DebitCount: 40, Mean: 100, StdDev: 20, MerchantCount: 6}
+	withTime := time.Date(2024, 3, 10, 14, 32, 0, 0, time.UTC)
+	dateOnly := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		amount    float64
+		merchant  string
+		at        time.Time
+		history   func(h *AnomalyHistory)
+		wantScore float64 // zero when the debit is not flagged
+		wantCodes []string
+	}{
+		{name: "usual debit", amount: -110, merchant: "OXXO", at: withTime},
+		{name: "large amount", amount: -160, merchant: "OXXO", at: withTime, wantScore: 0.5, wantCodes: []string{AnomalyAmount}},
+		{name: "just below the z-score", amount: -159.99, merchant: "OXXO", at: withTime},
+		{
+			name: "unusual merchant alone", amount: -110, merchant: "Casino", at: withTime,
+			history: func(h *AnomalyHistory) { h.MerchantCount = 0 },
+		},
+		{
+			name: "large amount at an unusual merchant", amount: -300, merchant: "Casino", at: withTime,
+			history:   func(h *AnomalyHistory) { h.MerchantCount = 0 },
+			wantScore: 0.7, wantCodes: []string{AnomalyAmount, AnomalyUnusualMerchant},
+		},
+		{
+			name: "short history", amount: -300, merchant: "Casino", at: withTime,
+			history: func(h *AnomalyHistory) { h.DebitCount, h.MerchantCount = 5, 0 },
+		},
+		{
+			name: "burst", amount: -110, merchant: "OXXO", at: withTime,
+			history:   func(h *AnomalyHistory) { h.RecentDebits = 4 },
+			wantScore: 0.4, wantCodes: []string{AnomalyDebitBurst},
+		},
+		{
+			name: "burst without a time of day", amount: -110, merchant: "OXXO", at: dateOnly,
+			history: func(h *AnomalyHistory) { h.RecentDebits = 4 },
+		},
+		{
+			name: "duplicate", amount: -110, merchant: "OXXO", at: withTime,
+			history:   func(h *AnomalyHistory) { h.Duplicates = []uuid.UUID{uuid.New()} },
+			wantScore: 0.5, wantCodes: []string{AnomalyDuplicateCharge},
+		},
+		{
+			name: "capped score", amount: -300, merchant: "Casino", at: withTime,
+			history: func(h *AnomalyHistory) {
+				h.MerchantCount, h.RecentDebits, h.Duplicates = 0, 4, []uuid.UUID{uuid.New()}
+			},
+			wantScore: 1, wantCodes: []string{AnomalyAmount, AnomalyUnusualMerchant, AnomalyDebitBurst, AnomalyDuplicateCharge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := NewTransaction(uuid.New(), tt.amount, tt.merchant, "file.csv", tt.at)
+			transaction.Merchant = tt.merchant
+			history := usual
+			if tt.history != nil {
+				tt.history(&history)
+			}
+
+			alert := policy.Score(transaction, history)
+			if tt.wantScore == 0 {
+				if alert != nil {
+					t.Fatalf("Score() flagged %.2f for %+v, want no alert", alert.Score, alert.Reasons)
+				}
+				return
+			}
+			if alert == nil {
+				t.Fatalf("Score() = nil, want %.2f", tt.wantScore)
+			}
+			if alert.Score != tt.wantScore {
+				t.Errorf("score = %.2f, want %.2f", alert.Score, tt.wantScore)
+			}
+			if len(alert.Reasons) != len(tt.wantCodes) {
+				t.Fatalf("reasons = %+v, want %v", alert.Reasons, tt.wantCodes)
+			}
+			for i, code := range tt.wantCodes {
+				if alert.Reasons[i].Code != code {
+					t.Errorf("reason %d = %s, want %s", i, alert.Reasons[i].Code, code)
+				}
+			}
+			if alert.Status != AlertStatusOpen || alert.TransactionID != transaction.ID {
+				t.Errorf("alert %s of %s, want open of %s", alert.Status, alert.TransactionID, transaction.ID)
+			}
+		})
+	}
+}
+
+func TestTransactionAlertReview(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		current string
+		status  string
+		wantErr error
+	}{
+		{name: "confirm", current: AlertStatusOpen, status: AlertStatusConfirmed},
+		{name: "dismiss", current: AlertStatusOpen, status: AlertStatusDismissed},
+		{name: "reopen", current: AlertStatusOpen, status: AlertStatusOpen, wantErr: ErrInvalidAlertReview},
+		{name: "already reviewed", current: AlertStatusDismissed, status: AlertStatusConfirmed, wantErr: ErrAlertReviewed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := &TransactionAlert{Status: tt.current}
+
+			if err := alert.Review(tt.status, "ops@stori.mx", "called the customer", now); err != tt.wantErr {
+				t.Fatalf("Review() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (alert.Status != tt.status || alert.ReviewedAt != now.Unix()) {
+				t.Errorf("alert %s reviewed at %d, want %s at %d", alert.Status, alert.ReviewedAt, tt.status, now.Unix())
+			}
+		})
+	}
+}
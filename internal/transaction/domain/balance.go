@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxBalanceSeriesDays bounds the range of a balance series.
+const MaxBalanceSeriesDays = 3660
+
+var (
+	ErrLedgerAccountNotFound = errors.New("ledger account not found")
+	ErrInvalidBalanceRange   = errors.New("balance range must end after it starts")
+	ErrBalanceRangeTooLong   = fmt.Errorf("balance range spans more than %d days", MaxBalanceSeriesDays)
+)
+
+// DailyBalance is the activity of a day of a ledger account and its balance
+// at the end of the day. Debits are negative. Postings, the intraday points
+// of the day with their running balance, are only set when asked for.
+type DailyBalance struct {
+	Date           time.Time
+	OpeningBalance float64
+	Credits        float64
+	Debits         float64
+	ClosingBalance float64
+	PostingCount   int
+	Postings       []StatementLine
+}
+
+// BalanceSeries is the balance of an account day by day, from From to To,
+// excluded.
+type BalanceSeries struct {
+	LedgerAccountCode string
+	From              time.Time
+	To                time.Time
+	OpeningBalance    float64
+	ClosingBalance    float64
+	Days              []DailyBalance
+}
+
+// ValidateBalanceRange checks a balance series range of whole days.
+func ValidateBalanceRange(from, to time.Time) error {
+	if !to.After(from) {
+		return ErrInvalidBalanceRange
+	}
+	if to.Sub(from) > MaxBalanceSeriesDays*24*time.Hour {
+		return ErrBalanceRangeTooLong
+	}
+	return nil
+}
+
+// BuildDailyBalances runs the balance from opening through the days from
+// from to to, excluded, taking the activity of the days in totals, ordered
+// by date, and leaving the days without postings flat.
+func BuildDailyBalances(from, to time.Time, opening float64, totals []DailyBalance) []DailyBalance {
+	days := make([]DailyBalance, 0, int(to.Sub(from).Hours()/24))
+	balance := opening
+	i := 0
+	for day := truncateDate(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		d := DailyBalance{Date: day, OpeningBalance: balance}
+		for ; i < len(totals) && !truncateDate(totals[i].Date).After(day); i++ {
+			if truncateDate(totals[i].Date).Equal(day) {
+				d.Credits += totals[i].Credits
+				d.Debits += totals[i].Debits
+				d.PostingCount += totals[i].PostingCount
+			}
+		}
+		d.Credits, d.Debits = roundCents(d.Credits), roundCents(d.Debits)
+		balance = roundCents(balance + d.Credits + d.Debits)
+		d.ClosingBalance = balance
+		days = append(days, d)
+	}
+	return days
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	BudgetAlertCreatedEvent = "budgets.alert.created"
+
+	// OverallBudget is the category of the budget that covers all spend.
+	OverallBudget = ""
+)
+
+// BudgetThresholds are the percentages of a budget that raise an alert.
+var BudgetThresholds = []int{50, 80, 100}
+
+var (
+	ErrBudgetNotFound = errors.New("budget not found")
+	ErrInvalidBudget  = errors.New("budget amount must be positive")
+)
+
+// Budget caps the monthly spend of an account in a category, or overall
+// when Category is empty.
+type Budget struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	Category  string
+	Amount    float64
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// BudgetStatus compares a budget with the spend of a calendar month.
+// Threshold is the highest alert threshold reached, or zero.
+type BudgetStatus struct {
+	Budget    *Budget
+	Month     time.Time
+	Spent     float64
+	Remaining float64
+	Percent   float64
+	Threshold int
+}
+
+// BudgetAlert tells the account holder a budget reached a threshold in a
+// month.
+type BudgetAlert struct {
+	ID        uuid.UUID
+	BudgetID  uuid.UUID
+	AccountID uuid.UUID
+	Category  string
+	Month     time.Time
+	Threshold int
+	Spent     float64
+	Amount    float64
+	CreatedAt int64
+}
+
+func NewBudget(accountID uuid.UUID, category string, amount float64) (*Budget, error) {
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, ErrInvalidBudget
+	}
+	now := time.Now().UTC().Unix()
+	return &Budget{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Category:  strings.ToLower(strings.TrimSpace(category)),
+		Amount:    roundCents(amount),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsOverall reports whether the budget covers all categories.
+func (b *Budget) IsOverall() bool {
+	return b.Category == OverallBudget
+}
+
+// MonthStart is the first day of the calendar month of t.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// CompareBudgets reports each budget against spent, the spend per category
+// of month. The overall budget is compared with the spend of every
+// category.
+func CompareBudgets(budgets []*Budget, spent map[string]float64, month time.Time) []BudgetStatus {
+	var total float64
+	for _, amount := range spent {
+		total += amount
+	}
+
+	statuses := make([]BudgetStatus, 0, len(budgets))
+	for _, b := range budgets {
+		actual := spent[b.Category]
+		if b.IsOverall() {
+			actual = total
+		}
+		actual = roundCents(actual)
+
+		status := BudgetStatus{
+			Budget:    b,
+			Month:     MonthStart(month),
+			Spent:     actual,
+			Remaining: roundCents(b.Amount - actual),
+			Percent:   math.Round(actual/b.Amount*1000) / 10,
+		}
+		for _, threshold := range BudgetThresholds {
+			if status.Percent >= float64(threshold) {
+				status.Threshold = threshold
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].Budget.Category < statuses[j].Budget.Category
+	})
+	return statuses
+}
+
+// CategorySpend turns category totals, such as those of a summary month,
+// into the spend per category budgets are compared with.
+func CategorySpend(totals []CategoryTotal) map[string]float64 {
+	spent := make(map[string]float64, len(totals))
+	for _, c := range totals {
+		spent[c.Category] += c.Total
+	}
+	return spent
+}
+
+// Alerts returns an alert for every threshold the budget reached. The
+// repository keeps only the first alert per threshold and month.
+func (s BudgetStatus) Alerts() []*BudgetAlert {
+	var alerts []*BudgetAlert
+	now := time.Now().UTC().Unix()
+	for _, threshold := range BudgetThresholds {
+		if threshold > s.Threshold {
+			break
+		}
+		alerts = append(alerts, &BudgetAlert{
+			ID:        uuid.New(),
+			BudgetID:  s.Budget.ID,
+			AccountID: s.Budget.AccountID,
+			Category:  s.Budget.Category,
+			Month:     s.Month,
+			Threshold: threshold,
+			Spent:     s.Spent,
+			Amount:    s.Budget.Amount,
+			CreatedAt: now,
+		})
+	}
+	return alerts
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// System-generated charges use their own transaction types instead of
+// "debit" so that they can be told apart from customer activity.
+const (
+	TransactionTypeInterest = "interest"
+	TransactionTypeLateFee  = "late_fee"
+
+	CategoryFinanceCharges = "finance_charges"
+
+	// systemInputFileID marks transactions created by the platform rather
+	// than imported or sent by a client.
+	systemInputFileID = "system"
+)
+
+// NewSystemCharge creates a posted charge of the given type dated on the
+// day it was assessed. amount is the positive amount charged.
+func NewSystemCharge(accountID uuid.UUID, chargeType string, amount float64, description string, date time.Time) *Transaction {
+	t := NewTransaction(accountID, -amount, description, systemInputFileID, date)
+	t.Type = chargeType
+	t.Category = CategoryFinanceCharges
+	return t
+}
+
+// IsSystemCharge reports whether the transaction was generated by the
+// platform, such as accrued interest or a late fee.
+func (t *Transaction) IsSystemCharge() bool {
+	return t.Type == TransactionTypeInterest || t.Type == TransactionTypeLateFee
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CorrectionAmend = "amend"
+	CorrectionVoid  = "void"
+
+	TransactionCorrectedEvent = "transaction.corrected"
+)
+
+var (
+	ErrTransactionNotFound     = errors.New("transaction not found")
+	ErrTransactionVoided       = errors.New("transaction is voided")
+	ErrTransferLegCorrection   = errors.New("transfer transactions cannot be corrected individually")
+	ErrCorrectionReason        = errors.New("a correction reason is required")
+	ErrCorrectionActor         = errors.New("a correction actor is required")
+	ErrInvalidCorrectionAmount = errors.New("amended amount must be non-zero")
+	ErrNothingToAmend          = errors.New("amendment does not change the transaction")
+)
+
+// Correction records a change to a transaction. The previous and new values
+// are kept so that the full history can be rebuilt from the original row.
+// TransactionID is the version corrected and VersionID the row superseding
+// it.
+type Correction struct {
+	ID                  uuid.UUID
+	TransactionID       uuid.UUID
+	VersionID           uuid.UUID
+	Kind                string // "amend" or "void"
+	Reason              string
+	Actor               string
+	PreviousAmount      float64
+	NewAmount           float64
+	PreviousDescription string
+	NewDescription      string
+	PreviousCategory    string
+	NewCategory         string
+	JournalEntryID      uuid.NullUUID
+	CreatedAt           int64
+}
+
+// Amendment holds the fields to change on a transaction. Nil fields are
+// left untouched.
+type Amendment struct {
+	Amount      *float64
+	Description *string
+	Category    *string
+}
+
+// Amend applies the amendment to the transaction and returns the correction
+// describing it.
+func (t *Transaction) Amend(amendment Amendment, reason, actor string) (*Correction, error) {
+	correction, err := t.newCorrection(CorrectionAmend, reason, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if amendment.Amount != nil {
+		amount := *amendment.Amount
+		if toCents(amount) == 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+			return nil, ErrInvalidCorrectionAmount
+		}
+		if len(t.Splits) > 0 && toCents(amount) != toCents(t.Amount) {
+			return nil, ErrSplitAmountLocked
+		}
+		t.Amount = amount
+		if !t.IsSystemCharge() {
+			t.Type = getTransactionType(amount)
+		}
+	}
+	if amendment.Description != nil {
+		t.Description = *amendment.Description
+	}
+	if amendment.Category != nil {
+		t.Category = *amendment.Category
+	}
+
+	correction.NewAmount = t.Amount
+	correction.NewDescription = t.Description
+	correction.NewCategory = t.Category
+	if !correction.changed() {
+		return nil, ErrNothingToAmend
+	}
+
+	t.UpdatedAt = correction.CreatedAt
+	return correction, nil
+}
+
+// Void marks the transaction as no longer effective. Its amount is reversed
+// in the ledger.
+func (t *Transaction) Void(reason, actor string) (*Correction, error) {
+	correction, err := t.newCorrection(CorrectionVoid, reason, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Voided = true
+	t.UpdatedAt = correction.CreatedAt
+	return correction, nil
+}
+
+func (t *Transaction) newCorrection(kind, reason, actor string) (*Correction, error) {
+	if t.Voided {
+		return nil, ErrTransactionVoided
+	}
+	if !t.IsPosted() {
+		return nil, ErrTransactionNotPosted
+	}
+	if t.TransferID.Valid {
+		return nil, ErrTransferLegCorrection
+	}
+	if t.InstallmentPlanID.Valid {
+		return nil, ErrInstallmentPlanLocked
+	}
+	if t.ReversalKind == ReversalKindDispute {
+		return nil, ErrDisputeMovementLocked
+	}
+	if reason == "" {
+		return nil, ErrCorrectionReason
+	}
+	if actor == "" {
+		return nil, ErrCorrectionActor
+	}
+
+	return &Correction{
+		ID:                  uuid.New(),
+		TransactionID:       t.ID,
+		Kind:                kind,
+		Reason:              reason,
+		Actor:               actor,
+		PreviousAmount:      t.Amount,
+		NewAmount:           t.Amount,
+		PreviousDescription: t.Description,
+		NewDescription:      t.Description,
+		PreviousCategory:    t.Category,
+		NewCategory:         t.Category,
+		CreatedAt:           time.Now().UTC().Unix(),
+	}, nil
+}
+
+// Supersede turns t, holding the state Amend or Void left, into a new
+// version of previous, the row as it was before the correction, which is
+// kept unchanged but for the link to its successor. The version keeps the
+// creation time of the original so that listings keep their order.
+func (t *Transaction) Supersede(previous *Transaction, c *Correction) {
+	t.ID = uuid.New()
+	t.Supersedes = uuid.NullUUID{UUID: previous.ID, Valid: true}
+	t.SupersededBy = uuid.NullUUID{}
+	t.UpdatedAt = c.CreatedAt
+	previous.SupersededBy = uuid.NullUUID{UUID: t.ID, Valid: true}
+	c.VersionID = t.ID
+}
+
+// BalanceDelta is the change the correction makes to the account balance.
+func (c *Correction) BalanceDelta() float64 {
+	if c.Kind == CorrectionVoid {
+		return -c.PreviousAmount
+	}
+	return c.NewAmount - c.PreviousAmount
+}
+
+func (c *Correction) changed() bool {
+	return toCents(c.PreviousAmount) != toCents(c.NewAmount) ||
+		c.PreviousDescription != c.NewDescription ||
+		c.PreviousCategory != c.NewCategory
+}
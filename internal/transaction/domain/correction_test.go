@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAmend(t *testing.T) {
+	amount := func(v float64) *float64 { return &v }
+	text := func(v string) *string { return &v }
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		amendment Amendment
+		reason    string
+		wantErr   error
+		wantDelta float64
+		wantType  string
+	}{
+		{name: "lower amount", amendment: Amendment{Amount: amount(-45.5)}, reason: "duplicated digit", wantDelta: 4.5, wantType: "debit"},
+		{name: "sign flip", amendment: Amendment{Amount: amount(50)}, reason: "was a deposit", wantDelta: 100, wantType: "credit"},
+		{name: "description only", amendment: Amendment{Description: text("Groceries")}, reason: "typo", wantType: "debit"},
+		{name: "zero amount", amendment: Amendment{Amount: amount(0)}, reason: "typo", wantErr: ErrInvalidCorrectionAmount},
+		{name: "no change", amendment: Amendment{Amount: amount(-50)}, reason: "typo", wantErr: ErrNothingToAmend},
+		{name: "no reason", amendment: Amendment{Amount: amount(-45)}, wantErr: ErrCorrectionReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := NewTransaction(uuid.New(), -50, "Grocery", "file.csv", day)
+
+			correction, err := transaction.Amend(tt.amendment, tt.reason, "ops@stori.mx")
+			if err != tt.wantErr {
+				t.Fatalf("Amend() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := correction.BalanceDelta(); toCents(got) != toCents(tt.wantDelta) {
+				t.Errorf("BalanceDelta() = %.2f, want %.2f", got, tt.wantDelta)
+			}
+			if transaction.Type != tt.wantType {
+				t.Errorf("type = %s, want %s", transaction.Type, tt.wantType)
+			}
+			if correction.PreviousAmount != -50 {
+				t.Errorf("previous amount = %.2f, want -50", correction.PreviousAmount)
+			}
+		})
+	}
+}
+
+func TestVoid(t *testing.T) {
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		prepare   func(*Transaction)
+		wantErr   error
+		wantDelta float64
+	}{
+		{name: "posted debit", prepare: func(*Transaction) {}, wantDelta: 50},
+		{name: "already voided", prepare: func(t *Transaction) { t.Voided = true }, wantErr: ErrTransactionVoided},
+		{name: "pending", prepare: func(t *Transaction) { t.Status = StatusPending }, wantErr: ErrTransactionNotPosted},
+		{name: "transfer leg", prepare: func(t *Transaction) { t.TransferID = uuid.NullUUID{UUID: uuid.New(), Valid: true} }, wantErr: ErrTransferLegCorrection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := NewTransaction(uuid.New(), -50, "Grocery", "file.csv", day)
+			tt.prepare(transaction)
+
+			correction, err := transaction.Void("test charge", "ops@stori.mx")
+			if err != tt.wantErr {
+				t.Fatalf("Void() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !transaction.Voided {
+				t.Error("transaction not voided")
+			}
+			if got := correction.BalanceDelta(); toCents(got) != toCents(tt.wantDelta) {
+				t.Errorf("BalanceDelta() = %.2f, want %.2f", got, tt.wantDelta)
+			}
+		})
+	}
+}
+
+func TestSupersede(t *testing.T) {
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	transaction := NewTransaction(uuid.New(), -50, "Grocery", "file.csv", day)
+	transaction.CreatedAt = day.Unix()
+	previous := *transaction
+
+	correction, err := transaction.Void("test charge", "ops@stori.mx")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transaction.Supersede(&previous, correction)
+
+	// The original keeps its values and only learns about its successor
+	if previous.Voided || previous.Amount != -50 {
+		t.Errorf("original = voided %v amount %.2f, want it unchanged", previous.Voided, previous.Amount)
+	}
+	if !previous.SupersededBy.Valid || previous.SupersededBy.UUID != transaction.ID {
+		t.Errorf("original superseded by %v, want %s", previous.SupersededBy, transaction.ID)
+	}
+	if transaction.ID == previous.ID {
+		t.Fatal("the version reuses the id of the original")
+	}
+	if !transaction.Supersedes.Valid || transaction.Supersedes.UUID != previous.ID || transaction.SupersededBy.Valid {
+		t.Errorf("version supersedes %v and is superseded by %v, want the original and nothing", transaction.Supersedes, transaction.SupersededBy)
+	}
+	if transaction.CreatedAt != previous.CreatedAt {
+		t.Errorf("version created at %d, want the original's %d", transaction.CreatedAt, previous.CreatedAt)
+	}
+	if correction.TransactionID != previous.ID || correction.VersionID != transaction.ID {
+		t.Errorf("correction of %s into %s, want %s into %s", correction.TransactionID, correction.VersionID, previous.ID, transaction.ID)
+	}
+}
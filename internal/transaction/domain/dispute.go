@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DisputeUpdatedEvent = "transaction.dispute.updated"
+
+	DisputeStatusOpened            = "opened"
+	DisputeStatusUnderReview       = "under_review"
+	DisputeStatusProvisionalCredit = "provisional_credit"
+	DisputeStatusWon               = "won"
+	DisputeStatusLost              = "lost"
+
+	TransactionTypeDisputeCredit   = "dispute_credit"
+	TransactionTypeDisputeReversal = "dispute_reversal"
+
+	// ReversalKindDispute links dispute credits and their reversals to the
+	// disputed transaction.
+	ReversalKindDispute = "dispute"
+
+	CategoryDisputes = "disputes"
+
+	LedgerChargebacksReceivable = "chargebacks_receivable"
+
+	// DisputeFilingWindow is how long after its value date a transaction
+	// can be disputed.
+	DisputeFilingWindow = 120 * 24 * time.Hour
+
+	MaxDisputeAttachmentSize = 10 << 20
+)
+
+var (
+	ErrDisputeNotFound           = errors.New("dispute not found")
+	ErrDisputeAttachmentNotFound = errors.New("dispute attachment not found")
+	ErrDisputeExists             = errors.New("transaction already has a dispute in progress")
+	ErrDisputeNotDebit           = errors.New("only posted customer debits can be disputed")
+	ErrDisputeWindowClosed       = errors.New("transaction is too old to be disputed")
+	ErrInvalidDisputeReason      = errors.New("invalid dispute reason code")
+	ErrInvalidDisputeAmount      = errors.New("disputed amount must be positive and not exceed what is left of the transaction")
+	ErrInvalidDisputeTransition  = errors.New("invalid dispute status transition")
+	ErrDisputeClosed             = errors.New("dispute is already resolved")
+	ErrDisputeMovementLocked     = errors.New("dispute credits and reversals only change through their dispute")
+	ErrInvalidDisputeAttachment  = errors.New("attachments must be PDF, PNG or JPEG files of up to 10 MB")
+)
+
+// DisputeReasons are the reason codes a dispute can be opened with.
+var DisputeReasons = map[string]string{
+	"fraud":            "Transaction not made or authorized by the customer",
+	"not_received":     "Goods or services not received",
+	"not_as_described": "Goods or services not as described or defective",
+	"duplicate":        "Transaction charged more than once",
+	"incorrect_amount": "Amount charged differs from the agreed amount",
+	"canceled":         "Recurring charge or service already canceled",
+	"credit_not_given": "Refund promised by the merchant never arrived",
+}
+
+// DisputeAttachmentTypes are the content types accepted as evidence.
+var DisputeAttachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+}
+
+// DisputePolicy sets the deadlines of a dispute. A dispute still unresolved
+// ProvisionalCreditAfter it was opened gets a provisional credit, and one
+// unresolved past ResolutionWindow is resolved in favour of the customer.
+type DisputePolicy struct {
+	ProvisionalCreditAfter time.Duration
+	ResolutionWindow       time.Duration
+}
+
+type Dispute struct {
+	ID                    uuid.UUID
+	AccountID             uuid.UUID
+	TransactionID         uuid.UUID
+	Amount                float64 // positive
+	ReasonCode            string
+	Description           string
+	Status                string // "opened", "under_review", "provisional_credit", "won" or "lost"
+	ProvisionalCreditDue  time.Time
+	Deadline              time.Time
+	CreditTransactionID   uuid.NullUUID // provisional or final credit
+	ReversalTransactionID uuid.NullUUID // set when a lost dispute takes the credit back
+	Attachments           []DisputeAttachment
+	Events                []DisputeEvent
+	CreatedAt             int64
+	UpdatedAt             int64
+}
+
+type DisputeAttachment struct {
+	ID          uuid.UUID
+	DisputeID   uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	BlobKey     string
+	CreatedAt   int64
+}
+
+// DisputeEvent records a status transition of a dispute together with the
+// transaction it posted, if any.
+type DisputeEvent struct {
+	ID            uuid.UUID
+	DisputeID     uuid.UUID
+	FromStatus    string
+	ToStatus      string
+	Note          string
+	TransactionID uuid.NullUUID
+	CreatedAt     int64
+}
+
+// DisputeChange is published on every transition so that the customer can
+// be notified.
+type DisputeChange struct {
+	Dispute     *Dispute
+	Event       DisputeEvent
+	Transaction *Transaction `json:",omitempty"`
+}
+
+// NewDispute opens a dispute over amount of a posted debit. A zero amount
+// disputes what is left of the transaction after refunds.
+func NewDispute(t *Transaction, amount, refunded float64, reasonCode, description string, policy DisputePolicy, now time.Time) (*Dispute, *DisputeEvent, error) {
+	if t.Voided || !t.IsPosted() || t.Amount >= 0 || t.TransferID.Valid || t.ReversalOf.Valid {
+		return nil, nil, ErrDisputeNotDebit
+	}
+	if t.InstallmentPlanID.Valid {
+		return nil, nil, ErrInstallmentPlanLocked
+	}
+	if _, ok := DisputeReasons[reasonCode]; !ok {
+		return nil, nil, ErrInvalidDisputeReason
+	}
+	if now.Sub(t.InputDate) > DisputeFilingWindow {
+		return nil, nil, ErrDisputeWindowClosed
+	}
+
+	remaining := toCents(-t.Amount) - toCents(refunded)
+	if amount == 0 {
+		amount = float64(remaining) / 100
+	}
+	if toCents(amount) <= 0 || toCents(amount) > remaining {
+		return nil, nil, ErrInvalidDisputeAmount
+	}
+
+	d := &Dispute{
+		ID:                   uuid.New(),
+		AccountID:            t.AccountID,
+		TransactionID:        t.ID,
+		Amount:               float64(toCents(amount)) / 100,
+		ReasonCode:           reasonCode,
+		Description:          description,
+		Status:               DisputeStatusOpened,
+		ProvisionalCreditDue: truncateDate(now.Add(policy.ProvisionalCreditAfter)),
+		Deadline:             truncateDate(now.Add(policy.ResolutionWindow)),
+		CreatedAt:            now.Unix(),
+		UpdatedAt:            now.Unix(),
+	}
+	event := d.newEvent("", DisputeStatusOpened, DisputeReasons[reasonCode], uuid.NullUUID{}, now)
+	return d, event, nil
+}
+
+// IsClosed reports whether the dispute was resolved.
+func (d *Dispute) IsClosed() bool {
+	return d.Status == DisputeStatusWon || d.Status == DisputeStatusLost
+}
+
+// Transition moves the dispute to status. Granting a provisional credit, or
+// winning a dispute without one, posts a credit for the disputed amount;
+// losing a dispute that was credited posts its reversal. The returned
+// transaction is nil when no money moves.
+func (d *Dispute) Transition(status, note string, now time.Time) (*DisputeEvent, *Transaction, error) {
+	if d.IsClosed() {
+		return nil, nil, ErrDisputeClosed
+	}
+
+	var posted *Transaction
+	switch status {
+	case DisputeStatusUnderReview:
+		if d.Status != DisputeStatusOpened {
+			return nil, nil, fmt.Errorf("%w: %s to %s", ErrInvalidDisputeTransition, d.Status, status)
+		}
+	case DisputeStatusProvisionalCredit:
+		if d.Status != DisputeStatusOpened && d.Status != DisputeStatusUnderReview {
+			return nil, nil, fmt.Errorf("%w: %s to %s", ErrInvalidDisputeTransition, d.Status, status)
+		}
+		posted = d.newMovement(TransactionTypeDisputeCredit, d.Amount, "Provisional credit", now)
+		d.CreditTransactionID = uuid.NullUUID{UUID: posted.ID, Valid: true}
+	case DisputeStatusWon:
+		if !d.CreditTransactionID.Valid {
+			posted = d.newMovement(TransactionTypeDisputeCredit, d.Amount, "Dispute credit", now)
+			d.CreditTransactionID = uuid.NullUUID{UUID: posted.ID, Valid: true}
+		}
+	case DisputeStatusLost:
+		if d.CreditTransactionID.Valid {
+			posted = d.newMovement(TransactionTypeDisputeReversal, -d.Amount, "Provisional credit reversal", now)
+			d.ReversalTransactionID = uuid.NullUUID{UUID: posted.ID, Valid: true}
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w: %s to %s", ErrInvalidDisputeTransition, d.Status, status)
+	}
+
+	var transactionID uuid.NullUUID
+	if posted != nil {
+		transactionID = uuid.NullUUID{UUID: posted.ID, Valid: true}
+	}
+	event := d.newEvent(d.Status, status, note, transactionID, now)
+	d.Status = status
+	d.UpdatedAt = now.Unix()
+	return event, posted, nil
+}
+
+// NewAttachment validates an evidence file and names the blob it is stored
+// under.
+func (d *Dispute) NewAttachment(fileName, contentType string, size int64, now time.Time) (*DisputeAttachment, error) {
+	if d.IsClosed() {
+		return nil, ErrDisputeClosed
+	}
+	if !DisputeAttachmentTypes[contentType] || size <= 0 || size > MaxDisputeAttachmentSize {
+		return nil, ErrInvalidDisputeAttachment
+	}
+
+	id := uuid.New()
+	return &DisputeAttachment{
+		ID:          id,
+		DisputeID:   d.ID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		BlobKey:     fmt.Sprintf("disputes/%s/%s", d.ID, id),
+		CreatedAt:   now.Unix(),
+	}, nil
+}
+
+func (d *Dispute) newMovement(transactionType string, amount float64, description string, now time.Time) *Transaction {
+	t := NewTransaction(d.AccountID, amount, fmt.Sprintf("%s, dispute %s", description, d.ID), systemInputFileID, truncateDate(now))
+	t.Type = transactionType
+	t.Category = CategoryDisputes
+	t.ReversalOf = uuid.NullUUID{UUID: d.TransactionID, Valid: true}
+	t.ReversalKind = ReversalKindDispute
+	return t
+}
+
+func (d *Dispute) newEvent(from, to, note string, transactionID uuid.NullUUID, now time.Time) *DisputeEvent {
+	event := DisputeEvent{
+		ID:            uuid.New(),
+		DisputeID:     d.ID,
+		FromStatus:    from,
+		ToStatus:      to,
+		Note:          note,
+		TransactionID: transactionID,
+		CreatedAt:     now.Unix(),
+	}
+	d.Events = append(d.Events, event)
+	return &event
+}
+
+func truncateDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,319 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ForecastRunRequestedEvent = "transaction.forecast.run.requested"
+
+	// ForecastHorizonDays is how many days ahead a forecast runs.
+	ForecastHorizonDays = 90
+	// ForecastLookback is how much history a forecast reads: a year for
+	// the seasonality and a bit more to see annual charges twice.
+	ForecastLookback = SubscriptionLookback
+
+	// forecastBaselineDays is the window of the moving average of the
+	// activity that is not recurring.
+	forecastBaselineDays = 90
+	// forecastBandZ sets the confidence bands to 80%.
+	forecastBandZ = 1.28
+	// forecastSeasonalityDays is the history needed to compare the months
+	// of the year; with less, every month weighs the same.
+	forecastSeasonalityDays = 365
+	// forecastMinSeasonality and forecastMaxSeasonality bound how much a
+	// month may move the spend baseline.
+	forecastMinSeasonality = 0.5
+	forecastMaxSeasonality = 2.0
+)
+
+// ForecastHorizons are the horizons a forecast is summarized at.
+var ForecastHorizons = []int{30, 60, 90}
+
+var (
+	ErrForecastNotFound       = errors.New("forecast not found")
+	ErrInvalidForecastHorizon = fmt.Errorf("forecast horizon must be between 1 and %d days", ForecastHorizonDays)
+)
+
+// CashFlowForecast is the expected balance of an account for the days after
+// AsOf. Each day adds the recurring items expected on it to a baseline, the
+// moving average of the rest of the activity weighed by the season; the
+// bands widen with the spread of that activity.
+type CashFlowForecast struct {
+	AccountID       uuid.UUID
+	AsOf            time.Time
+	StartingBalance float64
+	DailyIncome     float64 // average non-recurring credits per day
+	DailySpend      float64 // average non-recurring debits per day, negative
+	DailyDeviation  float64
+	Points          []ForecastPoint
+	Items           []ForecastItem
+	GeneratedAt     int64
+}
+
+// ForecastPoint is the expected balance at the end of a day, within Low and
+// High, with what the day adds to it.
+type ForecastPoint struct {
+	Date      time.Time
+	Expected  float64
+	Low       float64
+	High      float64
+	Recurring float64
+	Baseline  float64
+}
+
+// ForecastItem is an expected occurrence of a recurring item, negative for
+// charges.
+type ForecastItem struct {
+	Date     time.Time
+	Name     string
+	Category string
+	Cadence  string
+	Amount   float64
+}
+
+// ForecastHorizon sums up a forecast a number of days ahead.
+type ForecastHorizon struct {
+	Days              int
+	Date              time.Time
+	Expected          float64
+	Low               float64
+	High              float64
+	RecurringIncome   float64
+	RecurringExpenses float64
+	Baseline          float64
+}
+
+// BuildForecast forecasts the balance of an account from its posted
+// history, oldest first, and its balance as of now.
+func BuildForecast(accountID uuid.UUID, history []*Transaction, balance float64, now time.Time) *CashFlowForecast {
+	today := truncateDate(now)
+	end := today.AddDate(0, 0, ForecastHorizonDays)
+	forecast := &CashFlowForecast{
+		AccountID:       accountID,
+		AsOf:            today,
+		StartingBalance: roundCents(balance),
+		GeneratedAt:     now.UTC().Unix(),
+	}
+
+	items, recurring := forecastItems(history, today, end)
+	forecast.Items = items
+
+	// Baseline of the activity that is not recurring over the last days
+	window := forecastBaselineDays
+	if len(history) > 0 {
+		window = min(window, int(today.Sub(truncateDate(history[0].InputDate)).Hours()/24))
+	}
+	windowStart := today.AddDate(0, 0, -window)
+	daily := make([]float64, max(window, 0))
+	monthly := make(map[time.Time]float64)
+	for _, t := range history {
+		if recurring[t.ID] {
+			continue
+		}
+		day := truncateDate(t.InputDate)
+		if t.Amount < 0 {
+			monthly[MonthStart(day)] += t.Amount
+		}
+		if day.Before(windowStart) || !day.Before(today) {
+			continue
+		}
+		daily[int(day.Sub(windowStart).Hours()/24)] += t.Amount
+		if t.Amount > 0 {
+			forecast.DailyIncome += t.Amount
+		} else {
+			forecast.DailySpend += t.Amount
+		}
+	}
+	if window > 0 {
+		forecast.DailyIncome = roundCents(forecast.DailyIncome / float64(window))
+		forecast.DailySpend = roundCents(forecast.DailySpend / float64(window))
+		var mean, variance float64
+		for _, net := range daily {
+			mean += net
+		}
+		mean /= float64(window)
+		for _, net := range daily {
+			variance += (net - mean) * (net - mean)
+		}
+		forecast.DailyDeviation = roundCents(math.Sqrt(variance / float64(window)))
+	}
+
+	// Weigh the spend by how the month compares with the months of the
+	// baseline window, last year
+	season := func(time.Time) float64 { return 1 }
+	if len(history) > 0 && !truncateDate(history[0].InputDate).After(today.AddDate(0, 0, -forecastSeasonalityDays)) {
+		factors := seasonality(monthly, MonthStart(today))
+		var windowFactor float64
+		for d := windowStart; d.Before(today); d = d.AddDate(0, 0, 1) {
+			windowFactor += factors[d.Month()]
+		}
+		windowFactor /= float64(window)
+		season = func(d time.Time) float64 { return factors[d.Month()] / windowFactor }
+	}
+
+	byDate := make(map[time.Time]float64)
+	for _, item := range items {
+		byDate[item.Date] += item.Amount
+	}
+	expected := forecast.StartingBalance
+	forecast.Points = make([]ForecastPoint, 0, ForecastHorizonDays)
+	for k := 1; k <= ForecastHorizonDays; k++ {
+		day := today.AddDate(0, 0, k)
+		point := ForecastPoint{
+			Date:      day,
+			Recurring: roundCents(byDate[day]),
+			Baseline:  roundCents(forecast.DailyIncome + forecast.DailySpend*season(day)),
+		}
+		expected = roundCents(expected + point.Recurring + point.Baseline)
+		band := roundCents(forecastBandZ * forecast.DailyDeviation * math.Sqrt(float64(k)))
+		point.Expected, point.Low, point.High = expected, roundCents(expected-band), roundCents(expected+band)
+		forecast.Points = append(forecast.Points, point)
+	}
+	return forecast
+}
+
+// forecastItems finds the recurring credits and charges of the history,
+// the way subscriptions are detected, and lays out their occurrences up to
+// end. It also returns the transactions of the series found, active or
+// not, which are left out of the baseline. A charge that is due but still
+// in its grace period is expected tomorrow.
+func forecastItems(history []*Transaction, today, end time.Time) ([]ForecastItem, map[uuid.UUID]bool) {
+	groups := make(map[string][]*Transaction)
+	var keys []string
+	for _, t := range history {
+		name := subscriptionName(t)
+		if name == "" || t.Amount == 0 {
+			continue
+		}
+		key := "-" + subscriptionKey(name)
+		if t.Amount > 0 {
+			key = "+" + subscriptionKey(name)
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+	sort.Strings(keys)
+
+	var items []ForecastItem
+	recurring := make(map[uuid.UUID]bool)
+	tomorrow := today.AddDate(0, 0, 1)
+	for _, key := range keys {
+		series, c := recurringSeries(groups[key])
+		if series == nil {
+			continue
+		}
+		for _, t := range series {
+			recurring[t.ID] = true
+		}
+
+		last := series[len(series)-1]
+		next := c.next(truncateDate(last.InputDate))
+		if subscriptionStatus(c, next, today) != SubscriptionActive {
+			continue
+		}
+		recent := series[max(0, len(series)-subscriptionAverageCharges):]
+		var total float64
+		for _, t := range recent {
+			total += t.Amount
+		}
+		amount := roundCents(total / float64(len(recent)))
+
+		for d := next; !d.After(end); d = c.next(d) {
+			date := d
+			if date.Before(tomorrow) {
+				date = tomorrow
+			}
+			items = append(items, ForecastItem{
+				Date:     date,
+				Name:     subscriptionName(last),
+				Category: last.Category,
+				Cadence:  c.name,
+				Amount:   amount,
+			})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Date.Before(items[j].Date) })
+	return items, recurring
+}
+
+// seasonality compares the spend of each month of the last twelve before
+// current with their average, within the seasonality bounds.
+func seasonality(monthly map[time.Time]float64, current time.Time) map[time.Month]float64 {
+	var total float64
+	for i := 1; i <= 12; i++ {
+		total += monthly[current.AddDate(0, -i, 0)]
+	}
+
+	factors := make(map[time.Month]float64, 12)
+	for i := 1; i <= 12; i++ {
+		month := current.AddDate(0, -i, 0)
+		factor := 1.0
+		if total != 0 {
+			factor = monthly[month] / (total / 12)
+		}
+		factors[month.Month()] = math.Min(math.Max(factor, forecastMinSeasonality), forecastMaxSeasonality)
+	}
+	return factors
+}
+
+// Until returns the forecast cut to its first days.
+func (f *CashFlowForecast) Until(days int) (*CashFlowForecast, error) {
+	if days < 1 || days > len(f.Points) {
+		return nil, ErrInvalidForecastHorizon
+	}
+	cut := *f
+	cut.Points = f.Points[:days]
+	end := f.AsOf.AddDate(0, 0, days)
+	cut.Items = nil
+	for _, item := range f.Items {
+		if !item.Date.After(end) {
+			cut.Items = append(cut.Items, item)
+		}
+	}
+	return &cut, nil
+}
+
+// Horizons sums up the forecast at each of ForecastHorizons it covers.
+func (f *CashFlowForecast) Horizons() []ForecastHorizon {
+	var horizons []ForecastHorizon
+	for _, days := range ForecastHorizons {
+		if days > len(f.Points) {
+			break
+		}
+		point := f.Points[days-1]
+		horizon := ForecastHorizon{
+			Days:     days,
+			Date:     point.Date,
+			Expected: point.Expected,
+			Low:      point.Low,
+			High:     point.High,
+		}
+		for _, p := range f.Points[:days] {
+			horizon.Baseline += p.Baseline
+		}
+		for _, item := range f.Items {
+			if item.Date.After(point.Date) {
+				continue
+			}
+			if item.Amount > 0 {
+				horizon.RecurringIncome += item.Amount
+			} else {
+				horizon.RecurringExpenses += item.Amount
+			}
+		}
+		horizon.Baseline = roundCents(horizon.Baseline)
+		horizon.RecurringIncome = roundCents(horizon.RecurringIncome)
+		horizon.RecurringExpenses = roundCents(horizon.RecurringExpenses)
+		horizons = append(horizons, horizon)
+	}
+	return horizons
+}
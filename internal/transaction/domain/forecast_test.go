@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildForecast(t *testing.T) {
+	accountID := uuid.New()
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	monthly := func(description string, amount float64, day int, months ...time.Month) []*Transaction {
+		var charges []*Transaction
+		for _, m := range months {
+			charges = append(charges, NewTransaction(accountID, amount, description, "file.csv", date(m, day)))
+		}
+		return charges
+	}
+
+	type item struct {
+		date   time.Time
+		name   string
+		amount float64
+	}
+	tests := []struct {
+		name          string
+		history       []*Transaction
+		balance       float64
+		wantItems     []item
+		wantSpend     float64
+		wantDeviation float64
+		wantExpected  map[int]float64 // expected balance by days ahead
+		wantBand      map[int]float64 // half the width of the band by days ahead
+	}{
+		{
+			name:         "no history",
+			balance:      1000,
+			wantExpected: map[int]float64{1: 1000, 90: 1000},
+			wantBand:     map[int]float64{90: 0},
+		},
+		{
+			name: "salary and rent",
+			history: append(
+				monthly("Payroll", 3000, 1, time.February, time.March, time.April, time.May, time.June),
+				monthly("Rent", -1000, 5, time.February, time.March, time.April, time.May, time.June)...),
+			balance: 1000,
+			wantItems: []item{
+				{date(7, 1), "Payroll", 3000}, {date(7, 5), "Rent", -1000},
+				{date(8, 1), "Payroll", 3000}, {date(8, 5), "Rent", -1000},
+				{date(9, 1), "Payroll", 3000}, {date(9, 5), "Rent", -1000},
+			},
+			wantExpected: map[int]float64{15: 1000, 16: 4000, 20: 3000, 90: 7000},
+			wantBand:     map[int]float64{90: 0},
+		},
+		{
+			name:         "charge due within its grace period",
+			history:      monthly("Rent", -1000, 10, time.March, time.April, time.May),
+			balance:      500,
+			wantItems:    []item{{date(6, 16), "Rent", -1000}, {date(7, 10), "Rent", -1000}, {date(8, 10), "Rent", -1000}, {date(9, 10), "Rent", -1000}},
+			wantExpected: map[int]float64{1: -500, 24: -500, 25: -1500, 90: -3500},
+		},
+		{
+			name: "baseline of the activity that is not recurring",
+			history: func() []*Transaction {
+				var coffees []*Transaction
+				for d := 90; d > 0; d -= 2 {
+					coffees = append(coffees, NewTransaction(accountID, -20, "Coffee", "file.csv", date(6, 15).AddDate(0, 0, -d)))
+				}
+				return coffees
+			}(),
+			balance:       1000,
+			wantSpend:     -10,
+			wantDeviation: 10,
+			wantExpected:  map[int]float64{1: 990, 4: 960, 90: 100},
+			wantBand:      map[int]float64{1: 12.8, 4: 25.6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The history is read oldest first
+			sort.SliceStable(tt.history, func(i, j int) bool { return tt.history[i].InputDate.Before(tt.history[j].InputDate) })
+
+			forecast := BuildForecast(accountID, tt.history, tt.balance, now)
+			if !forecast.AsOf.Equal(date(6, 15)) || len(forecast.Points) != ForecastHorizonDays {
+				t.Fatalf("forecast as of %s with %d points, want 2024-06-15 with %d", forecast.AsOf, len(forecast.Points), ForecastHorizonDays)
+			}
+			if forecast.DailySpend != tt.wantSpend || forecast.DailyDeviation != tt.wantDeviation {
+				t.Errorf("daily spend %.2f with deviation %.2f, want %.2f with %.2f", forecast.DailySpend, forecast.DailyDeviation, tt.wantSpend, tt.wantDeviation)
+			}
+
+			if len(forecast.Items) != len(tt.wantItems) {
+				t.Fatalf("items = %+v, want %+v", forecast.Items, tt.wantItems)
+			}
+			for i, want := range tt.wantItems {
+				got := forecast.Items[i]
+				if !got.Date.Equal(want.date) || got.Name != want.name || got.Amount != want.amount {
+					t.Errorf("item %d = %s %s %.2f, want %s %s %.2f", i, got.Date.Format(time.DateOnly), got.Name, got.Amount, want.date.Format(time.DateOnly), want.name, want.amount)
+				}
+			}
+
+			for days, want := range tt.wantExpected {
+				if got := forecast.Points[days-1].Expected; got != want {
+					t.Errorf("expected balance in %d days = %.2f, want %.2f", days, got, want)
+				}
+			}
+			for days, want := range tt.wantBand {
+				point := forecast.Points[days-1]
+				if toCents(point.Expected-point.Low) != toCents(want) || toCents(point.High-point.Expected) != toCents(want) {
+					t.Errorf("band in %d days = %.2f to %.2f around %.2f, want ±%.2f", days, point.Low, point.High, point.Expected, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	GoalAchieved = "achieved"
+	GoalOnTrack  = "on_track"
+	GoalBehind   = "behind"
+	GoalOverdue  = "overdue"
+
+	// GoalRateWindow is how far back the contribution rate that projects the
+	// completion of a goal is measured.
+	GoalRateWindow = 90 * 24 * time.Hour
+	// goalMaxProjection bounds how far a projected completion date goes;
+	// a slower rate leaves the goal without a projection.
+	goalMaxProjection = 100 * 365
+)
+
+var (
+	ErrSavingsGoalNotFound = errors.New("savings goal not found")
+	ErrInvalidSavingsGoal  = errors.New("savings goals need a name, a positive target amount and a target date after the start date")
+)
+
+// SavingsGoal is an amount an account holder wants to save by a date.
+// Contributions are the transactions of Category since StartDate, or the
+// net flow of the account when Category is empty.
+type SavingsGoal struct {
+	ID           uuid.UUID
+	AccountID    uuid.UUID
+	Name         string
+	TargetAmount float64
+	TargetDate   time.Time
+	Category     string
+	StartDate    time.Time
+	CreatedAt    int64
+	UpdatedAt    int64
+}
+
+// GoalProgress is how far a goal is. MonthlyRate is the contribution rate
+// of the last GoalRateWindow and ProjectedDate, zero when the goal is not
+// growing, the day the goal completes at that rate. MonthlyNeeded is what
+// is left to save per month to meet the target date.
+type GoalProgress struct {
+	Goal          *SavingsGoal
+	Saved         float64
+	Remaining     float64
+	Percent       float64
+	MonthlyRate   float64
+	MonthlyNeeded float64
+	ProjectedDate time.Time
+	Status        string // "achieved", "on_track", "behind" or "overdue"
+}
+
+// NewSavingsGoal creates a goal starting on startDate, today when zero, so
+// that savings made before the goal was set up can count towards it.
+func NewSavingsGoal(accountID uuid.UUID, name, category string, target float64, targetDate, startDate time.Time) (*SavingsGoal, error) {
+	now := time.Now().UTC()
+	if startDate.IsZero() {
+		startDate = now
+	}
+	name = strings.TrimSpace(name)
+	startDate, targetDate = truncateDate(startDate), truncateDate(targetDate)
+	if name == "" || target <= 0 || math.IsNaN(target) || math.IsInf(target, 0) || !targetDate.After(startDate) {
+		return nil, ErrInvalidSavingsGoal
+	}
+	return &SavingsGoal{
+		ID:           uuid.New(),
+		AccountID:    accountID,
+		Name:         name,
+		TargetAmount: roundCents(target),
+		TargetDate:   targetDate,
+		Category:     strings.ToLower(strings.TrimSpace(category)),
+		StartDate:    startDate,
+		CreatedAt:    now.Unix(),
+		UpdatedAt:    now.Unix(),
+	}, nil
+}
+
+// RateSince is the first day of the window the contribution rate of the
+// goal is measured over: GoalRateWindow back from now, but not before the
+// goal started.
+func (g *SavingsGoal) RateSince(now time.Time) time.Time {
+	since := truncateDate(now.Add(-GoalRateWindow))
+	if since.Before(g.StartDate) {
+		return g.StartDate
+	}
+	return since
+}
+
+// Progress computes the progress of the goal from saved, the contributions
+// since it started, and recent, the contributions since RateSince(now).
+func (g *SavingsGoal) Progress(saved, recent float64, now time.Time) GoalProgress {
+	today := truncateDate(now)
+	p := GoalProgress{
+		Goal:      g,
+		Saved:     roundCents(saved),
+		Remaining: roundCents(math.Max(0, g.TargetAmount-saved)),
+		Percent:   math.Max(0, math.Round(saved/g.TargetAmount*1000)/10),
+	}
+
+	// The window includes today, so a goal started today has a rate
+	days := today.Sub(g.RateSince(now)).Hours()/24 + 1
+	daily := recent / math.Max(1, days)
+	p.MonthlyRate = roundCents(daily * 30)
+
+	if p.Remaining > 0 && daily > 0 {
+		if ahead := math.Ceil(p.Remaining / daily); ahead <= goalMaxProjection {
+			p.ProjectedDate = today.AddDate(0, 0, int(ahead))
+		}
+	}
+	if left := g.TargetDate.Sub(today).Hours() / 24; left > 0 {
+		p.MonthlyNeeded = roundCents(p.Remaining / math.Max(1, left/30))
+	}
+
+	switch {
+	case p.Remaining == 0:
+		p.Status = GoalAchieved
+	case today.After(g.TargetDate):
+		p.Status = GoalOverdue
+	case !p.ProjectedDate.IsZero() && !p.ProjectedDate.After(g.TargetDate):
+		p.Status = GoalOnTrack
+	default:
+		p.Status = GoalBehind
+	}
+	return p
+}
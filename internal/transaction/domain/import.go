@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const FileImportedEvent = "transaction.file.imported"
+
+const (
+	ImportSucceeded = "succeeded"
+	ImportFailed    = "failed"
+)
+
+const (
+	// ImportRejectedRowsShown is how many rejected rows an import summary
+	// lists; the count covers all of them.
+	ImportRejectedRowsShown = 20
+	// ImportTransactionsShown is how many of the latest transactions of
+	// the file an import summary lists in their months.
+	ImportTransactionsShown = 100
+)
+
+// FileImport is the outcome of processing an uploaded file. Error is only
+// set on failed imports.
+type FileImport struct {
+	ID               uuid.UUID
+	AccountID        uuid.UUID
+	FileName         string
+	FileSize         int64 // bytes, decoded
+	Status           string
+	RowCount         int // data rows, header excluded
+	RejectedCount    int
+	DuplicateCount   int
+	TransactionCount int
+	Error            string `json:",omitempty"`
+	ProcessedAt      time.Time
+}
+
+// RejectedRow is a row of a file that could not be read. Line counts from
+// 1, the header included.
+type RejectedRow struct {
+	Line   int
+	Reason string
+}
+
+// ImportSummary is what an import added to an account: the totals and
+// months of the transactions created from the file alone, and the rows
+// left out. Lifetime, the summary of the whole account, is only set when
+// asked for.
+type ImportSummary struct {
+	Import   *FileImport
+	Rejected []RejectedRow // the first ImportRejectedRowsShown
+	Summary  *TransactionSummary
+	Lifetime *TransactionSummary
+}
+
+// NewFileImport starts the record of the import of a file.
+func NewFileImport(accountID uuid.UUID, fileName string, fileSize int64) *FileImport {
+	return &FileImport{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		FileName:  fileName,
+		FileSize:  fileSize,
+	}
+}
+
+// Succeed records the import of transactionCount transactions.
+func (i *FileImport) Succeed(transactionCount int) {
+	i.Status = ImportSucceeded
+	i.TransactionCount = transactionCount
+	i.ProcessedAt = time.Now().UTC()
+}
+
+// Fail records why the import failed.
+func (i *FileImport) Fail(err error) {
+	i.Status = ImportFailed
+	i.Error = err.Error()
+	i.ProcessedAt = time.Now().UTC()
+}
+
+// HashFile identifies the content of an uploaded file.
+func HashFile(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+// ImportKey identifies a row of an uploaded file by the hash of the file
+// and the line of the row, the header being line 1.
+func ImportKey(fileHash string, line int) string {
+	return fmt.Sprintf("%s:%d", fileHash, line)
+}
+
+// SkipDuplicates leaves out of rows those whose import key is among the
+// keys the account already imported, so that a file sent again adds
+// nothing twice. Only the same row of the same file is a duplicate: two
+// equal charges of a day, in one file or in two, both count.
+func SkipDuplicates(rows []*Transaction, imported []string) ([]*Transaction, int) {
+	seen := make(map[string]bool, len(imported))
+	for _, key := range imported {
+		seen[key] = true
+	}
+
+	kept := make([]*Transaction, 0, len(rows))
+	for _, t := range rows {
+		if t.ImportKey != "" && seen[t.ImportKey] {
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept, len(rows) - len(kept)
+}
+
+// SummarizeImport sums up the transactions created by an import with the
+// code summaries of the account are built with, so that both agree:
+// pending transactions and installment movements aside, refunds giving
+// back spend instead of counting as credits.
+func SummarizeImport(fileImport *FileImport, rejected []RejectedRow, transactions []*Transaction) *ImportSummary {
+	posted := make([]*Transaction, 0, len(transactions))
+	for _, t := range transactions {
+		if !t.Voided && t.Status == StatusPosted {
+			posted = append(posted, t)
+		}
+	}
+	// Oldest first, as the summary queries read them
+	sort.SliceStable(posted, func(i, j int) bool { return posted[i].InputDate.Before(posted[j].InputDate) })
+
+	days := SummaryDaysOf(posted)
+	summary := SummaryFromDays(days)
+	summary.Granularity = GranularityMonth
+	if len(days) > 0 {
+		summary.From, summary.To = days[0].Date, days[len(days)-1].Date.AddDate(0, 0, 1)
+	}
+	addSummaryCategoriesOf(summary, posted)
+	addSummaryMerchantsOf(summary, posted)
+
+	latest := append([]*Transaction(nil), posted...)
+	sort.SliceStable(latest, func(i, j int) bool { return latest[i].InputDate.After(latest[j].InputDate) })
+	if len(latest) > ImportTransactionsShown {
+		latest = latest[:ImportTransactionsShown]
+	}
+	for _, t := range latest {
+		monthly := summary.Monthly[truncateDate(t.InputDate).Format("2006-01")]
+		monthly.Transactions = append(monthly.Transactions, *t)
+	}
+
+	if len(rejected) > ImportRejectedRowsShown {
+		rejected = rejected[:ImportRejectedRowsShown]
+	}
+	return &ImportSummary{
+		Import:   fileImport,
+		Rejected: rejected,
+		Summary:  summary,
+	}
+}
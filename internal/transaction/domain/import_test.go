@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSkipDuplicates(t *testing.T) {
+	accountID := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	march, april := HashFile([]byte("2024-03-10,-50\n")), HashFile([]byte("2024-03-10,-50\n2024-04-02,-20\n"))
+	row := func(file string, line int, amount float64, description string) *Transaction {
+		transaction := NewTransaction(accountID, amount, description, "file.csv", day)
+		transaction.ImportKey = ImportKey(file, line)
+		return transaction
+	}
+
+	tests := []struct {
+		name     string
+		rows     []*Transaction
+		imported []string
+		want     int // rows kept
+	}{
+		{
+			name:     "file sent again",
+			rows:     []*Transaction{row(march, 2, -50, ""), row(march, 3, 100, "Payroll")},
+			imported: []string{ImportKey(march, 2), ImportKey(march, 3)},
+		},
+		{
+			name:     "file sent again after a failed row",
+			rows:     []*Transaction{row(march, 2, -50, ""), row(march, 3, 100, "Payroll")},
+			imported: []string{ImportKey(march, 2)},
+			want:     1,
+		},
+		{
+			name:     "equal charges of a day in another file",
+			rows:     []*Transaction{row(april, 2, -50, "")},
+			imported: []string{ImportKey(march, 2)},
+			want:     1,
+		},
+		{
+			name: "equal charges of a day in one file",
+			rows: []*Transaction{row(march, 2, -50, ""), row(march, 3, -50, "")},
+			want: 2,
+		},
+		{
+			name:     "rows not read from a file",
+			rows:     []*Transaction{NewTransaction(accountID, -50, "", "system", day)},
+			imported: []string{ImportKey(march, 2)},
+			want:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, skipped := SkipDuplicates(tt.rows, tt.imported)
+			if len(kept) != tt.want || skipped != len(tt.rows)-tt.want {
+				t.Fatalf("SkipDuplicates() kept %d and skipped %d, want %d and %d", len(kept), skipped, tt.want, len(tt.rows)-tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeImport(t *testing.T) {
+	accountID := uuid.New()
+	row := func(month time.Month, day int, amount float64, merchant, category string) *Transaction {
+		transaction := NewTransaction(accountID, amount, merchant, "file.csv", time.Date(2024, month, day, 0, 0, 0, 0, time.UTC))
+		transaction.SetMerchant(merchant, category)
+		return transaction
+	}
+	refund := row(time.March, 12, 20, "", "")
+	refund.ReversalOf = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	installment := row(time.March, 15, -15, "", "")
+	installment.Type = TransactionTypeInstallment
+	pending := row(time.March, 20, -10, "OXXO", "food")
+	pending.Status = StatusPending
+
+	transactions := []*Transaction{
+		row(time.April, 2, -40, "OXXO", "food"),
+		row(time.March, 10, -50, "OXXO", "food"),
+		row(time.March, 11, -30, "", ""),
+		row(time.March, 11, 100, "", ""),
+		refund, installment, pending,
+	}
+	summary := SummarizeImport(NewFileImport(accountID, "file.csv", 64), nil, transactions).Summary
+
+	if summary.TotalCount != 6 || summary.TotalBalance != -15 {
+		t.Errorf("%d transactions adding %.2f, want 6 adding -15", summary.TotalCount, summary.TotalBalance)
+	}
+	if summary.TotalDebit != -120 || summary.TotalCredit != 100 || summary.TotalRefunds != 20 || summary.NetSpend != -100 {
+		t.Errorf("debits %.2f, credits %.2f, refunds %.2f and net spend %.2f, want -120, 100, 20 and -100",
+			summary.TotalDebit, summary.TotalCredit, summary.TotalRefunds, summary.NetSpend)
+	}
+	if !summary.From.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)) || !summary.To.Equal(time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("summary from %s to %s, want 2024-03-10 to 2024-04-03", summary.From, summary.To)
+	}
+	if len(summary.Categories) != 2 || summary.Categories[0] != (CategoryTotal{"food", 90, 2}) || summary.Categories[1] != (CategoryTotal{"uncategorized", 30, 1}) {
+		t.Errorf("categories = %+v, want food 90 and uncategorized 30", summary.Categories)
+	}
+
+	march := summary.Monthly["2024-03"]
+	if march == nil || len(summary.Monthly) != 2 {
+		t.Fatalf("months = %v, want March and April", summary.Monthly)
+	}
+	if march.Total != 5 || march.NetSpend != -60 || march.Installments != -15 || len(march.Transactions) != 5 {
+		t.Errorf("March has %d transactions (%d listed), net spend %.2f and installments %.2f, want 5, -60 and -15",
+			march.Total, len(march.Transactions), march.NetSpend, march.Installments)
+	}
+	if len(march.TopMerchants) != 1 || march.TopMerchants[0] != (MerchantTotal{"OXXO", "food", 50, 1}) {
+		t.Errorf("March top merchants = %+v, want OXXO 50", march.TopMerchants)
+	}
+}
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// An installment plan moves a purchase out of the balance with a
+	// conversion credit and bills it back in monthly installments.
+	TransactionTypeInstallment           = "installment"
+	TransactionTypeInstallmentConversion = "installment_conversion"
+
+	LedgerInstallmentsReceivable = "installments_receivable"
+
+	InstallmentPlanActive    = "active"
+	InstallmentPlanCompleted = "completed"
+
+	MinInstallments = 2
+	MaxInstallments = 48
+)
+
+var (
+	ErrInstallmentPlanNotFound   = errors.New("installment plan not found")
+	ErrInvalidInstallmentCount   = errors.New("installments must be between 2 and 48")
+	ErrInvalidInstallmentRate    = errors.New("installment annual rate must be between 0 and 1")
+	ErrInstallmentTooSmall       = errors.New("each installment must be at least 1.00")
+	ErrInstallmentNotPurchase    = errors.New("only posted debits can be converted to installments")
+	ErrInstallmentPlanExists     = errors.New("transaction already has an installment plan")
+	ErrInstallmentPurchaseBilled = errors.New("only purchases of the open statement cycle can be converted to installments")
+	ErrInstallmentPlanLocked     = errors.New("transactions of an installment plan cannot be changed")
+)
+
+type InstallmentPlan struct {
+	ID                      uuid.UUID
+	AccountID               uuid.UUID
+	TransactionID           uuid.UUID // the converted purchase
+	ConversionTransactionID uuid.UUID
+	Principal               float64
+	Count                   int
+	AnnualRate              float64 // zero for meses sin intereses
+	InstallmentAmount       float64
+	TotalInterest           float64
+	Status                  string // "active" or "completed"
+	Installments            []Installment
+	CreatedAt               int64
+	UpdatedAt               int64
+}
+
+// Installment is one monthly charge of a plan. TransactionID is set once it
+// has been posted.
+type Installment struct {
+	ID            uuid.UUID
+	PlanID        uuid.UUID
+	Number        int
+	DueDate       time.Time
+	Principal     float64
+	Interest      float64
+	Amount        float64
+	TransactionID uuid.NullUUID
+}
+
+// NewInstallmentPlan converts purchase into count monthly installments, the
+// first one due on firstDue. With a non-zero annualRate the installments
+// are a fixed payment amortizing the purchase at annualRate/12 a month. It
+// returns the plan and the credit that takes the purchase out of the
+// balance.
+func NewInstallmentPlan(purchase *Transaction, count int, annualRate float64, firstDue time.Time) (*InstallmentPlan, *Transaction, error) {
+	if purchase.InstallmentPlanID.Valid {
+		return nil, nil, ErrInstallmentPlanExists
+	}
+	if purchase.Voided {
+		return nil, nil, ErrTransactionVoided
+	}
+	if !purchase.IsPosted() || purchase.Amount >= 0 || purchase.TransferID.Valid ||
+		purchase.IsSystemCharge() || purchase.ReversalOf.Valid {
+		return nil, nil, ErrInstallmentNotPurchase
+	}
+	if count < MinInstallments || count > MaxInstallments {
+		return nil, nil, ErrInvalidInstallmentCount
+	}
+	if annualRate < 0 || annualRate > 1 || math.IsNaN(annualRate) {
+		return nil, nil, ErrInvalidInstallmentRate
+	}
+	if toCents(-purchase.Amount) < int64(count)*100 {
+		return nil, nil, ErrInstallmentTooSmall
+	}
+
+	now := time.Now().UTC().Unix()
+	plan := &InstallmentPlan{
+		ID:            uuid.New(),
+		AccountID:     purchase.AccountID,
+		TransactionID: purchase.ID,
+		Principal:     -purchase.Amount,
+		Count:         count,
+		AnnualRate:    annualRate,
+		Status:        InstallmentPlanActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	plan.schedule(firstDue)
+
+	// The credit shares the purchase value date so that the purchase never
+	// counts towards a statement.
+	conversion := NewTransaction(purchase.AccountID, plan.Principal,
+		fmt.Sprintf("Installment plan %s", purchase.Description), systemInputFileID, purchase.InputDate)
+	conversion.Type = TransactionTypeInstallmentConversion
+	conversion.Merchant = purchase.Merchant
+	conversion.Category = purchase.Category
+	conversion.InstallmentPlanID = uuid.NullUUID{UUID: plan.ID, Valid: true}
+	plan.ConversionTransactionID = conversion.ID
+
+	purchase.InstallmentPlanID = conversion.InstallmentPlanID
+	purchase.UpdatedAt = now
+	return plan, conversion, nil
+}
+
+// schedule splits the principal into Count installments. Amounts are
+// rounded to cents and the last installment absorbs the rounding.
+func (p *InstallmentPlan) schedule(firstDue time.Time) {
+	principal := toCents(p.Principal)
+	rate := p.AnnualRate / 12
+	payment := principal / int64(p.Count)
+	if rate > 0 {
+		payment = int64(math.Round(float64(principal) * rate / (1 - math.Pow(1+rate, -float64(p.Count)))))
+	}
+
+	remaining := principal
+	var totalInterest int64
+	p.Installments = make([]Installment, 0, p.Count)
+	for n := 1; n <= p.Count; n++ {
+		interest := int64(math.Round(float64(remaining) * rate))
+		principalPart := payment - interest
+		if n == p.Count {
+			principalPart = remaining
+		}
+		remaining -= principalPart
+		totalInterest += interest
+
+		p.Installments = append(p.Installments, Installment{
+			ID:        uuid.New(),
+			PlanID:    p.ID,
+			Number:    n,
+			DueDate:   firstDue.AddDate(0, n-1, 0),
+			Principal: float64(principalPart) / 100,
+			Interest:  float64(interest) / 100,
+			Amount:    float64(principalPart+interest) / 100,
+		})
+	}
+	p.InstallmentAmount = float64(payment) / 100
+	p.TotalInterest = float64(totalInterest) / 100
+}
+
+// RemainingBalance is the sum of the installments not posted yet.
+func (p *InstallmentPlan) RemainingBalance() float64 {
+	var cents int64
+	for _, i := range p.Installments {
+		if !i.TransactionID.Valid {
+			cents += toCents(i.Amount)
+		}
+	}
+	return float64(cents) / 100
+}
+
+// PostedCount is the number of installments already charged.
+func (p *InstallmentPlan) PostedCount() int {
+	posted := 0
+	for _, i := range p.Installments {
+		if i.TransactionID.Valid {
+			posted++
+		}
+	}
+	return posted
+}
+
+// MarkPosted records the transaction that billed installment number and
+// completes the plan once every installment is posted.
+func (p *InstallmentPlan) MarkPosted(number int, transactionID uuid.UUID) {
+	for n := range p.Installments {
+		if p.Installments[n].Number == number {
+			p.Installments[n].TransactionID = uuid.NullUUID{UUID: transactionID, Valid: true}
+		}
+	}
+	if p.PostedCount() == p.Count {
+		p.Status = InstallmentPlanCompleted
+	}
+	p.UpdatedAt = time.Now().UTC().Unix()
+}
+
+// NewInstallmentCharge creates the debit that bills installment i of the
+// plan behind purchase on its due date.
+func NewInstallmentCharge(plan *InstallmentPlan, purchase *Transaction, i Installment) *Transaction {
+	t := NewTransaction(plan.AccountID, -i.Amount,
+		fmt.Sprintf("%s %d/%d", purchase.Description, i.Number, plan.Count), systemInputFileID, i.DueDate)
+	t.Type = TransactionTypeInstallment
+	t.Merchant = purchase.Merchant
+	t.Category = purchase.Category
+	t.InstallmentPlanID = uuid.NullUUID{UUID: plan.ID, Valid: true}
+	return t
+}
+
+// NewInstallmentEntry posts an installment: the principal settles the
+// receivable opened by the conversion and the interest is income.
+func NewInstallmentEntry(t *Transaction, i Installment) *JournalEntry {
+	entry := NewJournalEntry(t.Description, t.InputDate)
+	entry.TransactionID = uuid.NullUUID{UUID: t.ID, Valid: true}
+	entry.AddPosting(CustomerLedgerCode(t.AccountID), t.Amount)
+	entry.AddPosting(LedgerInstallmentsReceivable, -i.Principal)
+	if toCents(i.Interest) != 0 {
+		entry.AddPosting(LedgerInterestIncome, -i.Interest)
+	}
+	return entry
+}
+
+// IsInstallmentMovement reports whether the transaction only reschedules a
+// purchase already counted as spend: the conversion credit or an
+// installment.
+func (t *Transaction) IsInstallmentMovement() bool {
+	return t.Type == TransactionTypeInstallment || t.Type == TransactionTypeInstallmentConversion
+}
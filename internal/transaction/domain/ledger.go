@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Internal ledger accounts. Customer accounts use CustomerLedgerCode.
+const (
+	LedgerMerchantSettlement = "merchant_settlement"
+	LedgerCustomerFunding    = "customer_funding"
+	LedgerFees               = "fees"
+	LedgerAdjustments        = "adjustments"
+	LedgerTransfersClearing  = "transfers_clearing"
+	LedgerInterestIncome     = "interest_income"
+
+	LedgerAccountTypeCustomer = "customer"
+	LedgerAccountTypeInternal = "internal"
+
+	customerLedgerPrefix = "customer:"
+)
+
+const (
+	JournalEntryPostedEvent = "ledger.entry.posted"
+)
+
+var (
+	ErrEntryTooFewPostings  = errors.New("journal entry needs at least two postings")
+	ErrEntryZeroPosting     = errors.New("journal entry postings must have a non-zero amount")
+	ErrEntryUnbalanced      = errors.New("journal entry postings must sum to zero")
+	ErrEntryNoLedgerAccount = errors.New("journal entry postings need a ledger account")
+	ErrUnknownLedgerAccount = errors.New("unknown ledger account")
+)
+
+type JournalEntry struct {
+	ID            uuid.UUID
+	TransactionID uuid.NullUUID
+	Description   string
+	EffectiveDate time.Time
+	CreatedAt     int64
+	Postings      []Posting
+}
+
+// Posting moves Amount into the ledger account identified by
+// LedgerAccountCode. Postings of a balanced entry sum to zero.
+type Posting struct {
+	ID                uuid.UUID
+	JournalEntryID    uuid.UUID
+	LedgerAccountCode string
+	Amount            float64
+	CreatedAt         int64
+}
+
+type TrialBalanceLine struct {
+	Code    string
+	Name    string
+	Type    string
+	Balance float64
+}
+
+type TrialBalance struct {
+	AsOf  time.Time
+	Lines []TrialBalanceLine
+	Total float64
+}
+
+type StatementLine struct {
+	PostingID      uuid.UUID
+	JournalEntryID uuid.UUID
+	TransactionID  uuid.NullUUID
+	Description    string
+	EffectiveDate  time.Time
+	Amount         float64
+	RunningBalance float64
+}
+
+type AccountStatement struct {
+	LedgerAccountCode string
+	From              time.Time
+	To                time.Time
+	OpeningBalance    float64
+	ClosingBalance    float64
+	Lines             []StatementLine
+}
+
+func NewJournalEntry(description string, effectiveDate time.Time) *JournalEntry {
+	return &JournalEntry{
+		ID:            uuid.New(),
+		Description:   description,
+		EffectiveDate: effectiveDate,
+		CreatedAt:     time.Now().UTC().Unix(),
+	}
+}
+
+// NewTransactionEntry builds the journal entry behind a customer
+// transaction: the customer account receives the amount and the matching
+// internal account takes the other side.
+func NewTransactionEntry(t *Transaction) *JournalEntry {
+	entry := NewJournalEntry(t.Description, t.InputDate)
+	entry.TransactionID = uuid.NullUUID{UUID: t.ID, Valid: true}
+	entry.AddPosting(CustomerLedgerCode(t.AccountID), t.Amount)
+	entry.AddPosting(counterpartyLedgerCode(t), -t.Amount)
+	return entry
+}
+
+// NewCorrectionEntry posts the balance effect of a correction against the
+// adjustments account. It returns nil when the correction does not move
+// money, e.g. a description-only amendment.
+func NewCorrectionEntry(t *Transaction, c *Correction) *JournalEntry {
+	delta := c.BalanceDelta()
+	if toCents(delta) == 0 {
+		return nil
+	}
+
+	entry := NewJournalEntry(c.Kind+": "+c.Reason, time.Unix(c.CreatedAt, 0).UTC())
+	entry.TransactionID = uuid.NullUUID{UUID: t.ID, Valid: true}
+	entry.AddPosting(CustomerLedgerCode(t.AccountID), delta)
+	entry.AddPosting(LedgerAdjustments, -delta)
+	return entry
+}
+
+func (e *JournalEntry) AddPosting(ledgerAccountCode string, amount float64) {
+	e.Postings = append(e.Postings, Posting{
+		ID:                uuid.New(),
+		JournalEntryID:    e.ID,
+		LedgerAccountCode: ledgerAccountCode,
+		Amount:            amount,
+		CreatedAt:         e.CreatedAt,
+	})
+}
+
+// Validate enforces the double-entry invariants. Amounts are compared in
+// cents to avoid floating point noise.
+func (e *JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return ErrEntryTooFewPostings
+	}
+
+	var total int64
+	for _, p := range e.Postings {
+		cents := toCents(p.Amount)
+		if cents == 0 {
+			return ErrEntryZeroPosting
+		}
+		if p.LedgerAccountCode == "" {
+			return fmt.Errorf("%w: posting %s", ErrEntryNoLedgerAccount, p.ID)
+		}
+		total += cents
+	}
+	if total != 0 {
+		return fmt.Errorf("%w: off by %.2f", ErrEntryUnbalanced, float64(total)/100)
+	}
+	return nil
+}
+
+func CustomerLedgerCode(accountID uuid.UUID) string {
+	return customerLedgerPrefix + accountID.String()
+}
+
+// ParseCustomerLedgerCode returns the account behind a customer ledger code.
+func ParseCustomerLedgerCode(code string) (uuid.UUID, bool) {
+	if !strings.HasPrefix(code, customerLedgerPrefix) {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(strings.TrimPrefix(code, customerLedgerPrefix))
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func counterpartyLedgerCode(t *Transaction) string {
+	if t.TransferID.Valid {
+		return LedgerTransfersClearing
+	}
+	switch t.Type {
+	case TransactionTypeInterest:
+		return LedgerInterestIncome
+	case TransactionTypeLateFee:
+		return LedgerFees
+	case TransactionTypeInstallment, TransactionTypeInstallmentConversion:
+		return LedgerInstallmentsReceivable
+	case TransactionTypeRewardRedemption:
+		return LedgerRewardsExpense
+	case TransactionTypeDisputeCredit, TransactionTypeDisputeReversal:
+		return LedgerChargebacksReceivable
+	}
+	if t.Amount < 0 {
+		return LedgerMerchantSettlement
+	}
+	return LedgerCustomerFunding
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
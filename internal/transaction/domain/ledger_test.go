@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestJournalEntryValidate(t *testing.T) {
+	customer := CustomerLedgerCode(uuid.New())
+
+	type posting struct {
+		code   string
+		amount float64
+	}
+	tests := []struct {
+		name     string
+		postings []posting
+		wantErr  error
+	}{
+		{name: "balanced", postings: []posting{{customer, -50}, {LedgerMerchantSettlement, 50}}},
+		{name: "three postings", postings: []posting{{customer, -52.5}, {LedgerMerchantSettlement, 50}, {LedgerFees, 2.5}}},
+		{name: "floating point noise", postings: []posting{{customer, 0.1 + 0.2}, {LedgerAdjustments, -0.3}}},
+		{name: "single posting", postings: []posting{{customer, -50}}, wantErr: ErrEntryTooFewPostings},
+		{name: "no postings", wantErr: ErrEntryTooFewPostings},
+		{name: "zero posting", postings: []posting{{customer, 0}, {LedgerFees, 0}}, wantErr: ErrEntryZeroPosting},
+		{name: "sub-cent posting", postings: []posting{{customer, 0.001}, {LedgerFees, -0.001}}, wantErr: ErrEntryZeroPosting},
+		{name: "unbalanced", postings: []posting{{customer, -50}, {LedgerMerchantSettlement, 49.99}}, wantErr: ErrEntryUnbalanced},
+		{name: "no ledger account", postings: []posting{{customer, -50}, {"", 50}}, wantErr: ErrEntryNoLedgerAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := NewJournalEntry("test", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+			for _, p := range tt.postings {
+				entry.AddPosting(p.code, p.amount)
+			}
+
+			if err := entry.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTransactionEntry(t *testing.T) {
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		amount       float64
+		counterparty string
+	}{
+		{name: "debit", amount: -50, counterparty: LedgerMerchantSettlement},
+		{name: "credit", amount: 120.75, counterparty: LedgerCustomerFunding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := NewTransaction(uuid.New(), tt.amount, "Grocery", "file.csv", day)
+
+			entry := NewTransactionEntry(transaction)
+			if err := entry.Validate(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !entry.TransactionID.Valid || entry.TransactionID.UUID != transaction.ID {
+				t.Errorf("entry of transaction %v, want %s", entry.TransactionID, transaction.ID)
+			}
+			customer := entry.Postings[0]
+			if customer.LedgerAccountCode != CustomerLedgerCode(transaction.AccountID) || customer.Amount != tt.amount {
+				t.Errorf("customer posting = %s %.2f, want %s %.2f", customer.LedgerAccountCode, customer.Amount, CustomerLedgerCode(transaction.AccountID), tt.amount)
+			}
+			if got := entry.Postings[1].LedgerAccountCode; got != tt.counterparty {
+				t.Errorf("counterparty = %s, want %s", got, tt.counterparty)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	GranularityDay     = "day"
+	GranularityWeek    = "week"
+	GranularityMonth   = "month"
+	GranularityQuarter = "quarter"
+	GranularityYear    = "year"
+	// GranularityCycle buckets by billing cycle, closing on CycleClosingDay;
+	// without a closing day cycles are calendar months.
+	GranularityCycle = "cycle"
+
+	// maxSummaryPeriods bounds how many periods a summary returns.
+	maxSummaryPeriods = 1000
+	// maxCycleClosingDay keeps cycles closing on a day every month has.
+	maxCycleClosingDay = 28
+)
+
+var (
+	ErrInvalidGranularity  = errors.New("granularity must be day, week, month, quarter, year or cycle")
+	ErrInvalidSummaryRange = errors.New("summary range must end after it starts")
+	ErrInvalidClosingDay   = fmt.Errorf("cycle closing day must be between 1 and %d", maxCycleClosingDay)
+	ErrTooManyPeriods      = fmt.Errorf("summary range spans more than %d periods", maxSummaryPeriods)
+)
+
+// SummaryDay is the activity of a day of a summary, split like the summary
+// totals. Net is what the day added to the balance, installment movements
+// included.
+type SummaryDay struct {
+	Date         time.Time
+	Count        int
+	CreditCount  int
+	Credits      float64
+	DebitCount   int
+	Debits       float64
+	RefundCount  int
+	Refunds      float64
+	Installments float64
+	Net          float64
+}
+
+// SummaryPeriod is the activity of a period of a summary, from Start to
+// End, excluded. The first and last periods are cut to the range of the
+// summary. Change compares the period with the one before it; it is nil
+// when the summary starts with the period and there is no earlier data.
+type SummaryPeriod struct {
+	Start       time.Time
+	End         time.Time
+	Label       string
+	Count       int
+	CreditCount int
+	Credits     float64
+	DebitCount  int
+	Debits      float64
+	RefundCount int
+	Refunds     float64
+	NetSpend    float64 // debits net of refunds, negative like Debits
+	Net         float64
+	Change      *PeriodChange
+}
+
+// PeriodChange is the difference between a period and the previous one.
+// NetSpendPercent is how much the net spend grew, in percent, zero when
+// the previous period had no spend.
+type PeriodChange struct {
+	Count           int
+	Credits         float64
+	Debits          float64
+	NetSpend        float64
+	Net             float64
+	NetSpendPercent float64
+}
+
+// Validate checks the range and the granularity of the options, defaulting
+// the granularity to calendar months.
+func (o *SummaryOptions) Validate() error {
+	if o.Granularity == "" {
+		o.Granularity = GranularityMonth
+	}
+	switch o.Granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth, GranularityQuarter, GranularityYear, GranularityCycle:
+	default:
+		return ErrInvalidGranularity
+	}
+	if o.CycleClosingDay < 0 || o.CycleClosingDay > maxCycleClosingDay {
+		return ErrInvalidClosingDay
+	}
+	if !o.From.IsZero() && !o.To.IsZero() && !o.To.After(o.From) {
+		return ErrInvalidSummaryRange
+	}
+	return nil
+}
+
+// PeriodStart is the first day of the period of the options containing t.
+// Weeks start on Monday.
+func (o SummaryOptions) PeriodStart(t time.Time) time.Time {
+	day := truncateDate(t)
+	switch o.Granularity {
+	case GranularityDay:
+		return day
+	case GranularityWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case GranularityQuarter:
+		return time.Date(day.Year(), day.Month()-(day.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	case GranularityYear:
+		return time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	case GranularityCycle:
+		if o.CycleClosingDay == 0 {
+			return MonthStart(day)
+		}
+		closing := time.Date(day.Year(), day.Month(), o.CycleClosingDay, 0, 0, 0, 0, time.UTC)
+		if !day.After(closing) {
+			closing = closing.AddDate(0, -1, 0)
+		}
+		return closing.AddDate(0, 0, 1)
+	default:
+		return MonthStart(day)
+	}
+}
+
+// nextPeriod is the start of the period following the one starting on
+// start.
+func (o SummaryOptions) nextPeriod(start time.Time) time.Time {
+	switch o.Granularity {
+	case GranularityDay:
+		return start.AddDate(0, 0, 1)
+	case GranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case GranularityQuarter:
+		return start.AddDate(0, 3, 0)
+	case GranularityYear:
+		return start.AddDate(1, 0, 0)
+	case GranularityCycle:
+		if o.CycleClosingDay == 0 {
+			return start.AddDate(0, 1, 0)
+		}
+		// Count from the closing date, as a cycle starting on the 1st after
+		// a short February still closes on the closing day
+		closed := start.AddDate(0, 0, -1)
+		return time.Date(closed.Year(), closed.Month(), o.CycleClosingDay, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 1)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+func (o SummaryOptions) periodLabel(start time.Time) string {
+	switch o.Granularity {
+	case GranularityDay, GranularityCycle:
+		if o.Granularity == GranularityCycle && o.CycleClosingDay == 0 {
+			return start.Format("2006-01")
+		}
+		return start.Format("2006-01-02")
+	case GranularityWeek:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case GranularityQuarter:
+		return fmt.Sprintf("%04d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	case GranularityYear:
+		return start.Format("2006")
+	default:
+		return start.Format("2006-01")
+	}
+}
+
+// LookbackStart is where the activity read to build the periods of a
+// summary starts: the period before From, so that the first period has a
+// change too. It is zero for summaries over the whole history.
+func (o SummaryOptions) LookbackStart() time.Time {
+	if o.From.IsZero() {
+		return time.Time{}
+	}
+	return o.PeriodStart(o.PeriodStart(o.From).AddDate(0, 0, -1))
+}
+
+// BuildPeriods groups the activity of days, read from LookbackStart, into
+// the ordered periods of the summary, with empty periods for the gaps.
+// Without a range the periods run from the period of the first day with
+// activity to that of the last one, whole. With a range, only the days from
+// From on count for its periods. Days may also hold the activity
+// of a whole month, dated on its first day, for periods of months or
+// longer.
+func BuildPeriods(days []SummaryDay, o SummaryOptions) ([]SummaryPeriod, error) {
+	from, to := o.From, o.To
+	if from.IsZero() || to.IsZero() {
+		first, last := time.Time{}, time.Time{}
+		for _, d := range days {
+			if !o.From.IsZero() && d.Date.Before(o.From) {
+				continue
+			}
+			if first.IsZero() || d.Date.Before(first) {
+				first = d.Date
+			}
+			if d.Date.After(last) {
+				last = d.Date
+			}
+		}
+		if first.IsZero() {
+			return nil, nil
+		}
+		if from.IsZero() {
+			from = o.PeriodStart(first)
+		}
+		if to.IsZero() {
+			to = o.nextPeriod(o.PeriodStart(last))
+		}
+	}
+
+	first := o.PeriodStart(from)
+	start := first
+	if !o.From.IsZero() {
+		// Start with the period before the range to compare the first one
+		start = o.LookbackStart()
+	}
+
+	var periods []SummaryPeriod
+	index := make(map[time.Time]int)
+	for s := start; s.Before(to); s = o.nextPeriod(s) {
+		if len(periods) > maxSummaryPeriods {
+			return nil, ErrTooManyPeriods
+		}
+		index[s] = len(periods)
+		periods = append(periods, SummaryPeriod{
+			Start: s,
+			End:   o.nextPeriod(s),
+			Label: o.periodLabel(s),
+		})
+	}
+
+	for _, d := range days {
+		i, ok := index[o.PeriodStart(d.Date)]
+		if !ok {
+			continue
+		}
+		// Days before the range only count for the period before it; those
+		// of its first period that fall before From are left out, as the
+		// period is cut to start on From
+		if d.Date.Before(from) && !periods[i].Start.Before(first) {
+			continue
+		}
+		p := &periods[i]
+		p.Count += d.Count
+		p.CreditCount += d.CreditCount
+		p.Credits += d.Credits
+		p.DebitCount += d.DebitCount
+		p.Debits += d.Debits
+		p.RefundCount += d.RefundCount
+		p.Refunds += d.Refunds
+		p.Net += d.Net
+	}
+
+	for i := range periods {
+		p := &periods[i]
+		p.Credits, p.Debits, p.Refunds, p.Net = roundCents(p.Credits), roundCents(p.Debits), roundCents(p.Refunds), roundCents(p.Net)
+		p.NetSpend = roundCents(p.Debits + p.Refunds)
+		if i > 0 {
+			p.Change = periodChange(periods[i-1], *p)
+		}
+	}
+
+	if !o.From.IsZero() && len(periods) > 0 && periods[0].Start.Before(first) {
+		periods = periods[1:]
+	}
+	if len(periods) > maxSummaryPeriods {
+		return nil, ErrTooManyPeriods
+	}
+	if len(periods) > 0 {
+		if periods[0].Start.Before(from) {
+			periods[0].Start = from
+		}
+		if last := &periods[len(periods)-1]; last.End.After(to) {
+			last.End = to
+		}
+	}
+	return periods, nil
+}
+
+func periodChange(previous, current SummaryPeriod) *PeriodChange {
+	change := &PeriodChange{
+		Count:    current.Count - previous.Count,
+		Credits:  roundCents(current.Credits - previous.Credits),
+		Debits:   roundCents(current.Debits - previous.Debits),
+		NetSpend: roundCents(current.NetSpend - previous.NetSpend),
+		Net:      roundCents(current.Net - previous.Net),
+	}
+	if previous.NetSpend != 0 {
+		change.NetSpendPercent = math.Round(change.NetSpend/previous.NetSpend*1000) / 10
+	}
+	return change
+}
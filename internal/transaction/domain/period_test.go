@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildPeriods(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	debit := func(d time.Time, amount float64) SummaryDay {
+		return SummaryDay{Date: d, Count: 1, DebitCount: 1, Debits: -amount, Net: -amount}
+	}
+
+	type period struct {
+		start, end time.Time
+		debits     float64
+		change     *float64 // change of the debits, nil without a change
+	}
+	change := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		days    []SummaryDay
+		options SummaryOptions
+		want    []period
+	}{
+		{
+			name: "aligned range",
+			days: []SummaryDay{debit(date(2024, 2, 10), 30), debit(date(2024, 3, 5), 10), debit(date(2024, 4, 20), 20)},
+			options: SummaryOptions{
+				From: date(2024, 3, 1), To: date(2024, 5, 1), Granularity: GranularityMonth,
+			},
+			want: []period{
+				{start: date(2024, 3, 1), end: date(2024, 4, 1), debits: -10, change: change(20)},
+				{start: date(2024, 4, 1), end: date(2024, 5, 1), debits: -20, change: change(-10)},
+			},
+		},
+		{
+			// March 1 to 14 is neither in the range nor in the period
+			// before it
+			name: "unaligned from",
+			days: []SummaryDay{debit(date(2024, 2, 10), 30), debit(date(2024, 3, 5), 100), debit(date(2024, 3, 20), 10)},
+			options: SummaryOptions{
+				From: date(2024, 3, 15), To: date(2024, 4, 1), Granularity: GranularityMonth,
+			},
+			want: []period{
+				{start: date(2024, 3, 15), end: date(2024, 4, 1), debits: -10, change: change(20)},
+			},
+		},
+		{
+			name: "unaligned weekly from",
+			days: []SummaryDay{debit(date(2024, 3, 4), 5), debit(date(2024, 3, 12), 7), debit(date(2024, 3, 14), 9)},
+			options: SummaryOptions{
+				From: date(2024, 3, 13), To: date(2024, 3, 18), Granularity: GranularityWeek,
+			},
+			want: []period{
+				{start: date(2024, 3, 13), end: date(2024, 3, 18), debits: -9, change: change(-4)},
+			},
+		},
+		{
+			name: "whole history with gaps",
+			days: []SummaryDay{debit(date(2024, 1, 31), 10), debit(date(2024, 3, 2), 20)},
+			options: SummaryOptions{
+				Granularity: GranularityMonth,
+			},
+			want: []period{
+				{start: date(2024, 1, 1), end: date(2024, 2, 1), debits: -10},
+				{start: date(2024, 2, 1), end: date(2024, 3, 1), change: change(10)},
+				{start: date(2024, 3, 1), end: date(2024, 4, 1), debits: -20, change: change(-20)},
+			},
+		},
+		{
+			name: "billing cycles",
+			days: []SummaryDay{debit(date(2024, 3, 10), 10), debit(date(2024, 3, 11), 20)},
+			options: SummaryOptions{
+				From: date(2024, 2, 11), To: date(2024, 4, 11), Granularity: GranularityCycle, CycleClosingDay: 10,
+			},
+			want: []period{
+				{start: date(2024, 2, 11), end: date(2024, 3, 11), debits: -10, change: change(-10)},
+				{start: date(2024, 3, 11), end: date(2024, 4, 11), debits: -20, change: change(-10)},
+			},
+		},
+		{
+			// The cycle closing on February 28 ends on March 1, and the
+			// next one runs from March 1 to the 29th
+			name: "billing cycles closing on the 28th",
+			days: []SummaryDay{
+				debit(date(2023, 2, 10), 10), debit(date(2023, 3, 1), 20), debit(date(2023, 3, 15), 30),
+				debit(date(2023, 4, 20), 40), debit(date(2023, 5, 28), 50),
+			},
+			options: SummaryOptions{
+				From: date(2023, 1, 29), To: date(2023, 5, 29), Granularity: GranularityCycle, CycleClosingDay: 28,
+			},
+			want: []period{
+				{start: date(2023, 1, 29), end: date(2023, 3, 1), debits: -10, change: change(-10)},
+				{start: date(2023, 3, 1), end: date(2023, 3, 29), debits: -50, change: change(-40)},
+				{start: date(2023, 3, 29), end: date(2023, 4, 29), debits: -40, change: change(10)},
+				{start: date(2023, 4, 29), end: date(2023, 5, 29), debits: -50, change: change(-10)},
+			},
+		},
+		{
+			name:    "no activity",
+			options: SummaryOptions{Granularity: GranularityMonth},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := BuildPeriods(tt.days, tt.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(periods) != len(tt.want) {
+				t.Fatalf("got %d periods, want %d", len(periods), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := periods[i]
+				if !got.Start.Equal(want.start) || !got.End.Equal(want.end) {
+					t.Errorf("period %d runs %s to %s, want %s to %s", i, got.Start, got.End, want.start, want.end)
+				}
+				if got.Debits != want.debits {
+					t.Errorf("period %d debits = %.2f, want %.2f", i, got.Debits, want.debits)
+				}
+				switch {
+				case want.change == nil && got.Change != nil:
+					t.Errorf("period %d change = %+v, want none", i, *got.Change)
+				case want.change != nil && got.Change == nil:
+					t.Errorf("period %d has no change, want %.2f", i, *want.change)
+				case want.change != nil && got.Change.Debits != *want.change:
+					t.Errorf("period %d debits change = %.2f, want %.2f", i, got.Change.Debits, *want.change)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildPeriodsTooMany(t *testing.T) {
+	options := SummaryOptions{
+		From:        time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Granularity: GranularityDay,
+	}
+	if _, err := BuildPeriods(nil, options); err != ErrTooManyPeriods {
+		t.Fatalf("BuildPeriods() error = %v, want %v", err, ErrTooManyPeriods)
+	}
+}
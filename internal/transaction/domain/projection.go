@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SummaryRebuildRequestedEvent asks the worker to recompute the monthly
+// summary projection of every account from the transactions.
+const SummaryRebuildRequestedEvent = "transaction.summary.rebuild.requested"
+
+// SummaryMonth identifies a month of the summary projection of an account.
+type SummaryMonth struct {
+	AccountID uuid.UUID
+	Month     time.Time
+}
+
+// SummaryMonthOf is the month of the projection a transaction counts in.
+func SummaryMonthOf(t *Transaction) SummaryMonth {
+	return SummaryMonth{AccountID: t.AccountID, Month: MonthStart(t.InputDate)}
+}
+
+// Projectable reports whether a summary can be served from the monthly
+// projection, which only holds posted transactions by calendar month:
+// there is no search, the range starts and ends on the first of a month
+// and the periods are whole months.
+func (o SummaryOptions) Projectable() bool {
+	if o.IncludePending || len(o.Search.Tags) > 0 || o.Search.Text != "" {
+		return false
+	}
+	if (!o.From.IsZero() && !o.From.Equal(MonthStart(o.From))) || (!o.To.IsZero() && !o.To.Equal(MonthStart(o.To))) {
+		return false
+	}
+	switch o.Granularity {
+	case GranularityMonth, GranularityQuarter, GranularityYear:
+		return true
+	case GranularityCycle:
+		return o.CycleClosingDay == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ReversalKindRefund   = "refund"
+	ReversalKindReversal = "reversal"
+
+	RefundMatchRequestedEvent = "transaction.refunds.match.requested"
+)
+
+var (
+	ErrInvalidReversalKind   = errors.New("reversal kind must be refund or reversal")
+	ErrRefundNotCredit       = errors.New("only credits can be linked as refunds")
+	ErrRefundOriginalDebit   = errors.New("refunds must be linked to a debit")
+	ErrRefundAccountMismatch = errors.New("refund and original transaction belong to different accounts")
+	ErrRefundAlreadyLinked   = errors.New("transaction is already linked to another transaction")
+	ErrRefundExceedsOriginal = errors.New("refunds exceed the original transaction amount")
+	ErrRefundReversalPartial = errors.New("a reversal must match the original amount")
+)
+
+// RefundCandidate is a debit a refund could be linked to, together with the
+// amount already refunded against it.
+type RefundCandidate struct {
+	Transaction *Transaction
+	Refunded    float64
+}
+
+// Remaining is the amount of the debit that can still be refunded.
+func (c RefundCandidate) Remaining() float64 {
+	return -c.Transaction.Amount - c.Refunded
+}
+
+// IsRefund reports whether the transaction gives back money from an earlier
+// debit and therefore is not a true credit.
+func (t *Transaction) IsRefund() bool {
+	return t.ReversalOf.Valid && t.Amount > 0
+}
+
+// LinkTo marks the transaction as a refund or reversal of original.
+// refunded is the amount already refunded against original.
+func (t *Transaction) LinkTo(original *Transaction, refunded float64, kind string) error {
+	if kind != ReversalKindRefund && kind != ReversalKindReversal {
+		return ErrInvalidReversalKind
+	}
+	if t.Voided || original.Voided {
+		return ErrTransactionVoided
+	}
+	if !t.IsPosted() || !original.IsPosted() {
+		return ErrTransactionNotPosted
+	}
+	if t.ReversalOf.Valid {
+		return ErrRefundAlreadyLinked
+	}
+	if t.Amount <= 0 {
+		return ErrRefundNotCredit
+	}
+	if original.Amount >= 0 {
+		return ErrRefundOriginalDebit
+	}
+	if t.AccountID != original.AccountID {
+		return ErrRefundAccountMismatch
+	}
+	if t.InstallmentPlanID.Valid || original.InstallmentPlanID.Valid {
+		return ErrInstallmentPlanLocked
+	}
+
+	remaining := toCents(-original.Amount) - toCents(refunded)
+	if toCents(t.Amount) > remaining {
+		return ErrRefundExceedsOriginal
+	}
+	if kind == ReversalKindReversal && toCents(t.Amount) != toCents(-original.Amount) {
+		return ErrRefundReversalPartial
+	}
+
+	t.ReversalOf.UUID = original.ID
+	t.ReversalOf.Valid = true
+	t.ReversalKind = kind
+	t.UpdatedAt = time.Now().UTC().Unix()
+	return nil
+}
+
+// MatchRefund picks the debit a refund most likely belongs to. An exact
+// amount match wins; otherwise the most recent debit that can still absorb
+// the refund is used. Candidates are expected newest first.
+func MatchRefund(refund *Transaction, candidates []RefundCandidate) *Transaction {
+	var fallback *Transaction
+	for _, c := range candidates {
+		if c.Transaction.InputDate.After(refund.InputDate) {
+			continue
+		}
+		if c.Refunded == 0 && toCents(-c.Transaction.Amount) == toCents(refund.Amount) {
+			return c.Transaction
+		}
+		if fallback == nil && toCents(c.Remaining()) >= toCents(refund.Amount) {
+			fallback = c.Transaction
+		}
+	}
+	return fallback
+}
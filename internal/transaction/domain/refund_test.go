@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMatchRefund(t *testing.T) {
+	accountID := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	debit := func(amount float64, daysAgo int) *Transaction {
+		return NewTransaction(accountID, amount, "Store", "file.csv", day.AddDate(0, 0, -daysAgo))
+	}
+
+	// Candidates are newest first, as the repository lists them
+	recent, older, exact := debit(-80, 1), debit(-60, 5), debit(-30, 9)
+	later := debit(-30, -2)
+
+	tests := []struct {
+		name       string
+		amount     float64
+		candidates []RefundCandidate
+		want       *Transaction
+	}{
+		{
+			name:       "exact amount wins over a more recent debit",
+			amount:     30,
+			candidates: []RefundCandidate{{Transaction: recent}, {Transaction: older}, {Transaction: exact}},
+			want:       exact,
+		},
+		{
+			name:       "most recent debit that absorbs a partial refund",
+			amount:     50,
+			candidates: []RefundCandidate{{Transaction: recent}, {Transaction: older}},
+			want:       recent,
+		},
+		{
+			name:       "partially refunded exact amount is no exact match",
+			amount:     30,
+			candidates: []RefundCandidate{{Transaction: recent, Refunded: 70}, {Transaction: exact, Refunded: 10}, {Transaction: older}},
+			want:       older,
+		},
+		{
+			name:       "debits after the refund are skipped",
+			amount:     30,
+			candidates: []RefundCandidate{{Transaction: later}, {Transaction: recent}},
+			want:       recent,
+		},
+		{
+			name:       "no debit can absorb the refund",
+			amount:     100,
+			candidates: []RefundCandidate{{Transaction: recent}, {Transaction: older}},
+		},
+		{
+			name:   "no candidates",
+			amount: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund := NewTransaction(accountID, tt.amount, "Store refund", "file.csv", day)
+
+			if got := MatchRefund(refund, tt.candidates); got != tt.want {
+				t.Errorf("MatchRefund() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkTo(t *testing.T) {
+	accountID := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		amount   float64
+		original *Transaction
+		refunded float64
+		kind     string
+		wantErr  error
+	}{
+		{name: "partial refund", amount: 20, original: NewTransaction(accountID, -50, "Store", "file.csv", day), kind: ReversalKindRefund},
+		{name: "full reversal", amount: 50, original: NewTransaction(accountID, -50, "Store", "file.csv", day), kind: ReversalKindReversal},
+		{name: "partial reversal", amount: 20, original: NewTransaction(accountID, -50, "Store", "file.csv", day), kind: ReversalKindReversal, wantErr: ErrRefundReversalPartial},
+		{name: "exceeds what is left", amount: 20, original: NewTransaction(accountID, -50, "Store", "file.csv", day), refunded: 40, kind: ReversalKindRefund, wantErr: ErrRefundExceedsOriginal},
+		{name: "debit as refund", amount: -20, original: NewTransaction(accountID, -50, "Store", "file.csv", day), kind: ReversalKindRefund, wantErr: ErrRefundNotCredit},
+		{name: "credit as original", amount: 20, original: NewTransaction(accountID, 50, "Payroll", "file.csv", day), kind: ReversalKindRefund, wantErr: ErrRefundOriginalDebit},
+		{name: "other account", amount: 20, original: NewTransaction(uuid.New(), -50, "Store", "file.csv", day), kind: ReversalKindRefund, wantErr: ErrRefundAccountMismatch},
+		{name: "unknown kind", amount: 20, original: NewTransaction(accountID, -50, "Store", "file.csv", day), kind: "chargeback", wantErr: ErrInvalidReversalKind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund := NewTransaction(accountID, tt.amount, "Store refund", "file.csv", day)
+
+			if err := refund.LinkTo(tt.original, tt.refunded, tt.kind); err != tt.wantErr {
+				t.Fatalf("LinkTo() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !refund.IsRefund() || refund.ReversalOf.UUID != tt.original.ID || refund.ReversalKind != tt.kind {
+				t.Errorf("refund linked to %v as %q, want %s as %q", refund.ReversalOf, refund.ReversalKind, tt.original.ID, tt.kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RewardEntryCreatedEvent = "rewards.entry.created"
+
+	TransactionTypeRewardRedemption = "reward_redemption"
+
+	CategoryRewards = "rewards"
+
+	LedgerRewardsExpense = "rewards_expense"
+
+	RewardEarn     = "earn"
+	RewardRedeem   = "redeem"
+	RewardReversal = "reversal"
+
+	// baseRewardRule names the points earned at the program base rate.
+	baseRewardRule = "base"
+)
+
+var (
+	ErrInvalidRewardProgram = errors.New("invalid rewards program")
+	ErrInvalidRedemption    = errors.New("points to redeem must be positive and at least the program minimum")
+	ErrInsufficientPoints   = errors.New("not enough reward points")
+)
+
+// RewardRule sets the points earned per peso spent at a merchant or in a
+// category. CycleCap limits the points the rule earns per account and
+// billing cycle; zero means no limit.
+type RewardRule struct {
+	Name     string  `json:"name"`
+	Merchant string  `json:"merchant"`
+	Category string  `json:"category"`
+	Rate     float64 `json:"rate"`
+	CycleCap int64   `json:"cycle_cap"`
+}
+
+// RewardPromotion multiplies the points earned between From and To, both
+// inclusive, optionally only at a merchant or in a category.
+type RewardPromotion struct {
+	Name       string  `json:"name"`
+	Merchant   string  `json:"merchant"`
+	Category   string  `json:"category"`
+	Multiplier float64 `json:"multiplier"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+}
+
+// RewardProgram holds the earning rules. Transactions before StartDate do
+// not earn points; PointValue is the statement credit a point redeems for.
+type RewardProgram struct {
+	StartDate     string            `json:"start_date"`
+	PointValue    float64           `json:"point_value"`
+	BaseRate      float64           `json:"base_rate"`
+	CycleCap      int64             `json:"cycle_cap"`
+	MinRedemption int64             `json:"min_redemption"`
+	Rules         []RewardRule      `json:"rules"`
+	Promotions    []RewardPromotion `json:"promotions"`
+}
+
+// RewardEntry is a movement of the points ledger of an account. Earned
+// points are positive and redeemed or reversed points negative, but for
+// reversals given back when a refund is undone.
+type RewardEntry struct {
+	ID            uuid.UUID
+	AccountID     uuid.UUID
+	Kind          string // "earn", "redeem" or "reversal"
+	Points        int64
+	TransactionID uuid.UUID // the purchase, or the credit of a redemption
+	Basis         float64   // the purchase amount an earn is based on
+	Rule          string
+	CycleStart    time.Time
+	EffectiveDate time.Time
+	Description   string
+	CreatedAt     int64
+}
+
+// RewardClawback is what stands of a purchase that earned points: its
+// current amount net of refunds and won disputes, zero once voided, and the
+// points already reversed.
+type RewardClawback struct {
+	Earn     *RewardEntry
+	Retained float64
+	Reversed int64 // negative
+}
+
+// RewardCycleTotals are the points an account already earned in a billing
+// cycle, in total and per rule.
+type RewardCycleTotals struct {
+	Total  int64
+	ByRule map[string]int64
+}
+
+type RewardBalance struct {
+	AccountID uuid.UUID
+	Points    int64
+	Value     float64
+	Earned    int64 // net of reversals
+	Redeemed  int64
+	Entries   []*RewardEntry
+}
+
+// RewardMonth is the points activity of an account in a calendar month.
+type RewardMonth struct {
+	Year     int
+	Month    int
+	Earned   int64
+	Redeemed int64
+}
+
+// RewardSummary is the points activity shown with a transaction summary.
+type RewardSummary struct {
+	Balance  int64
+	Earned   int64
+	Redeemed int64
+}
+
+func (p *RewardProgram) Validate() error {
+	if _, err := time.Parse(time.DateOnly, p.StartDate); err != nil {
+		return fmt.Errorf("%w: start_date must be YYYY-MM-DD", ErrInvalidRewardProgram)
+	}
+	if p.PointValue <= 0 || p.BaseRate < 0 || p.CycleCap < 0 || p.MinRedemption < 0 {
+		return fmt.Errorf("%w: point value must be positive and rates and caps not negative", ErrInvalidRewardProgram)
+	}
+	for _, r := range p.Rules {
+		if r.Name == "" || r.Name == baseRewardRule || (r.Merchant == "" && r.Category == "") || r.Rate < 0 || r.CycleCap < 0 {
+			return fmt.Errorf("%w: rule %q needs a unique name, a merchant or category and non-negative rate and cap", ErrInvalidRewardProgram, r.Name)
+		}
+	}
+	for _, promo := range p.Promotions {
+		from, err := time.Parse(time.DateOnly, promo.From)
+		if err != nil {
+			return fmt.Errorf("%w: promotion %q has an invalid from date", ErrInvalidRewardProgram, promo.Name)
+		}
+		to, err := time.Parse(time.DateOnly, promo.To)
+		if err != nil || to.Before(from) {
+			return fmt.Errorf("%w: promotion %q has an invalid to date", ErrInvalidRewardProgram, promo.Name)
+		}
+		if promo.Multiplier <= 0 {
+			return fmt.Errorf("%w: promotion %q needs a positive multiplier", ErrInvalidRewardProgram, promo.Name)
+		}
+	}
+	return nil
+}
+
+// Start is the first value date that earns points.
+func (p *RewardProgram) Start() time.Time {
+	start, _ := time.Parse(time.DateOnly, p.StartDate)
+	return start
+}
+
+// IsPurchase reports whether the transaction is a posted customer debit
+// that is not a transfer.
+func (t *Transaction) IsPurchase() bool {
+	return t.Type == "debit" && t.Amount < 0 && t.IsPosted() && !t.Voided && !t.TransferID.Valid
+}
+
+// EarnsRewards reports whether the transaction is an eligible purchase.
+func (t *Transaction) EarnsRewards() bool {
+	return t.IsPurchase()
+}
+
+// Earn computes the points a purchase earns in a billing cycle starting on
+// cycleStart, given the points already earned in it. Capped purchases earn
+// zero points and are still recorded so that they are not processed again.
+func (p *RewardProgram) Earn(t *Transaction, cycleStart time.Time, totals RewardCycleTotals) *RewardEntry {
+	rule := p.rule(t)
+	multiplier := p.multiplier(t)
+	points := int64(math.Floor(float64(toCents(-t.Amount)) / 100 * rule.Rate * multiplier))
+
+	description := fmt.Sprintf("%s: %.2f points per peso", rule.Name, rule.Rate*multiplier)
+	if rule.CycleCap > 0 && totals.ByRule[rule.Name]+points > rule.CycleCap {
+		points = max(0, rule.CycleCap-totals.ByRule[rule.Name])
+		description += ", rule cap reached"
+	}
+	if p.CycleCap > 0 && totals.Total+points > p.CycleCap {
+		points = max(0, p.CycleCap-totals.Total)
+		description += ", cycle cap reached"
+	}
+
+	return &RewardEntry{
+		ID:            uuid.New(),
+		AccountID:     t.AccountID,
+		Kind:          RewardEarn,
+		Points:        points,
+		TransactionID: t.ID,
+		Basis:         -t.Amount,
+		Rule:          rule.Name,
+		CycleStart:    cycleStart,
+		EffectiveDate: t.InputDate,
+		Description:   description,
+		CreatedAt:     time.Now().UTC().Unix(),
+	}
+}
+
+// rule picks the first rule for the transaction merchant, then the first
+// one for its category, falling back to the base rate.
+func (p *RewardProgram) rule(t *Transaction) RewardRule {
+	for _, r := range p.Rules {
+		if r.Merchant != "" && strings.EqualFold(r.Merchant, t.Merchant) {
+			return r
+		}
+	}
+	for _, r := range p.Rules {
+		if r.Merchant == "" && strings.EqualFold(r.Category, t.Category) {
+			return r
+		}
+	}
+	return RewardRule{Name: baseRewardRule, Rate: p.BaseRate}
+}
+
+// multiplier is the largest multiplier of the promotions running on the
+// transaction value date. Promotions do not stack.
+func (p *RewardProgram) multiplier(t *Transaction) float64 {
+	multiplier := 1.0
+	day := t.InputDate.UTC().Format(time.DateOnly)
+	for _, promo := range p.Promotions {
+		if day < promo.From || day > promo.To {
+			continue
+		}
+		if promo.Merchant != "" && !strings.EqualFold(promo.Merchant, t.Merchant) {
+			continue
+		}
+		if promo.Category != "" && !strings.EqualFold(promo.Category, t.Category) {
+			continue
+		}
+		multiplier = math.Max(multiplier, promo.Multiplier)
+	}
+	return multiplier
+}
+
+// Reversal takes back the share of the points earned that no longer
+// stands, or gives back what was taken when a refund is undone. The points
+// kept are floored like the points earned. It is nil when the reversals
+// already match.
+func (c RewardClawback) Reversal() *RewardEntry {
+	e := c.Earn
+	basis := toCents(e.Basis)
+	if e.Points <= 0 || basis <= 0 {
+		return nil
+	}
+	retained := min(max(toCents(c.Retained), 0), basis)
+	points := e.Points*retained/basis - e.Points - c.Reversed
+	if points == 0 {
+		return nil
+	}
+
+	description := fmt.Sprintf("Purchase reduced to %.2f", float64(retained)/100)
+	switch {
+	case retained == 0:
+		description = "Purchase voided or refunded"
+	case points > 0:
+		description = fmt.Sprintf("Purchase restored to %.2f", float64(retained)/100)
+	}
+	return &RewardEntry{
+		ID:            uuid.New(),
+		AccountID:     e.AccountID,
+		Kind:          RewardReversal,
+		Points:        points,
+		TransactionID: e.TransactionID,
+		Rule:          e.Rule,
+		CycleStart:    e.CycleStart,
+		EffectiveDate: time.Now().UTC(),
+		Description:   description,
+		CreatedAt:     time.Now().UTC().Unix(),
+	}
+}
+
+// Redeem exchanges points for a statement credit worth points times the
+// point value. balance is the current points balance of the account.
+func (p *RewardProgram) Redeem(accountID uuid.UUID, points, balance int64, cycleStart, today time.Time) (*RewardEntry, *Transaction, error) {
+	if points <= 0 || points < p.MinRedemption {
+		return nil, nil, ErrInvalidRedemption
+	}
+	if points > balance {
+		return nil, nil, ErrInsufficientPoints
+	}
+
+	amount := float64(int64(math.Round(float64(points)*p.PointValue*100))) / 100
+	credit := NewTransaction(accountID, amount, fmt.Sprintf("Rewards redemption %d points", points), systemInputFileID, today)
+	credit.Type = TransactionTypeRewardRedemption
+	credit.Category = CategoryRewards
+
+	entry := &RewardEntry{
+		ID:            uuid.New(),
+		AccountID:     accountID,
+		Kind:          RewardRedeem,
+		Points:        -points,
+		TransactionID: credit.ID,
+		CycleStart:    cycleStart,
+		EffectiveDate: today,
+		Description:   credit.Description,
+		CreatedAt:     credit.CreatedAt,
+	}
+	return entry, credit, nil
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRewardClawbackReversal(t *testing.T) {
+	earn := &RewardEntry{ID: uuid.New(), AccountID: uuid.New(), Kind: RewardEarn, Points: 150, TransactionID: uuid.New(), Basis: 100}
+
+	tests := []struct {
+		name     string
+		earn     *RewardEntry
+		retained float64
+		reversed int64
+		want     int64 // points of the reversal, zero for none
+	}{
+		{name: "voided", earn: earn, retained: 0, want: -150},
+		{name: "partial refund", earn: earn, retained: 60, want: -60},
+		{name: "kept points are floored", earn: earn, retained: 33.33, want: -101},
+		{name: "second refund", earn: earn, retained: 30, reversed: -60, want: -45},
+		{name: "already reversed", earn: earn, retained: 60, reversed: -60},
+		{name: "refund undone", earn: earn, retained: 100, reversed: -60, want: 60},
+		{name: "amended up", earn: earn, retained: 120},
+		{name: "capped purchase", earn: &RewardEntry{Kind: RewardEarn, Points: 0, Basis: 100}},
+		{name: "earned before the basis was kept", earn: &RewardEntry{Kind: RewardEarn, Points: 150}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reversal := RewardClawback{Earn: tt.earn, Retained: tt.retained, Reversed: tt.reversed}.Reversal()
+			if tt.want == 0 {
+				if reversal != nil {
+					t.Fatalf("Reversal() = %d points, want none", reversal.Points)
+				}
+				return
+			}
+			if reversal == nil {
+				t.Fatalf("Reversal() = nil, want %d points", tt.want)
+			}
+			if reversal.Points != tt.want || reversal.Kind != RewardReversal || reversal.TransactionID != tt.earn.TransactionID {
+				t.Errorf("Reversal() = %d %s points of %s, want %d reversal points of %s",
+					reversal.Points, reversal.Kind, reversal.TransactionID, tt.want, tt.earn.TransactionID)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TransactionSplitEvent = "transaction.split"
+
+	UncategorizedCategory = "uncategorized"
+)
+
+var (
+	ErrSplitTooFewParts    = errors.New("a split needs at least two parts")
+	ErrSplitCategory       = errors.New("every split part needs a category")
+	ErrSplitInvalidAmount  = errors.New("split amounts must be non-zero and have the sign of the transaction")
+	ErrSplitSumMismatch    = errors.New("split amounts must add up to the transaction amount")
+	ErrSplitAmountLocked   = errors.New("remove the splits before amending the transaction amount")
+	ErrSplitTransferOrVoid = errors.New("transfers and voided transactions cannot be split")
+)
+
+// Split allocates part of a transaction to a category. The parent still
+// counts once for balances; splits only change category totals.
+type Split struct {
+	ID            uuid.UUID
+	TransactionID uuid.UUID
+	Amount        float64
+	Category      string
+	Note          string
+	CreatedAt     int64
+}
+
+type SplitPart struct {
+	Amount   float64
+	Category string
+	Note     string
+}
+
+// SetSplits replaces the splits of the transaction. An empty list removes
+// them.
+func (t *Transaction) SetSplits(parts []SplitPart) error {
+	if t.Voided || t.TransferID.Valid {
+		return ErrSplitTransferOrVoid
+	}
+	if !t.IsPosted() {
+		return ErrTransactionNotPosted
+	}
+
+	now := time.Now().UTC().Unix()
+	if len(parts) == 0 {
+		t.Splits = nil
+		t.UpdatedAt = now
+		return nil
+	}
+	if len(parts) < 2 {
+		return ErrSplitTooFewParts
+	}
+
+	var total int64
+	splits := make([]Split, 0, len(parts))
+	for i, p := range parts {
+		category := strings.TrimSpace(p.Category)
+		if category == "" {
+			return fmt.Errorf("part %d: %w", i+1, ErrSplitCategory)
+		}
+		cents := toCents(p.Amount)
+		if cents == 0 || (cents < 0) != (t.Amount < 0) {
+			return fmt.Errorf("part %d: %w", i+1, ErrSplitInvalidAmount)
+		}
+		total += cents
+
+		splits = append(splits, Split{
+			ID:            uuid.New(),
+			TransactionID: t.ID,
+			Amount:        p.Amount,
+			Category:      category,
+			Note:          p.Note,
+			CreatedAt:     now,
+		})
+	}
+	if total != toCents(t.Amount) {
+		return fmt.Errorf("%w: %.2f of %.2f", ErrSplitSumMismatch, float64(total)/100, t.Amount)
+	}
+
+	t.Splits = splits
+	t.UpdatedAt = now
+	return nil
+}
+
+// CategoryAmounts returns how the transaction amount is allocated across
+// categories, honoring splits when present.
+func (t *Transaction) CategoryAmounts() map[string]float64 {
+	amounts := make(map[string]float64)
+	if len(t.Splits) == 0 {
+		amounts[categoryOrDefault(t.Category)] = t.Amount
+		return amounts
+	}
+	for _, s := range t.Splits {
+		amounts[categoryOrDefault(s.Category)] += s.Amount
+	}
+	return amounts
+}
+
+func categoryOrDefault(category string) string {
+	if category == "" {
+		return UncategorizedCategory
+	}
+	return category
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSetSplits(t *testing.T) {
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		prepare func(*Transaction)
+		parts   []SplitPart
+		wantErr error
+		want    map[string]float64 // category amounts after the split
+	}{
+		{
+			name:  "two categories",
+			parts: []SplitPart{{Amount: -70, Category: "groceries"}, {Amount: -30, Category: " household "}},
+			want:  map[string]float64{"groceries": -70, "household": -30},
+		},
+		{
+			name:  "cents add up",
+			parts: []SplitPart{{Amount: -33.33, Category: "a"}, {Amount: -33.33, Category: "b"}, {Amount: -33.34, Category: "c"}},
+			want:  map[string]float64{"a": -33.33, "b": -33.33, "c": -33.34},
+		},
+		{
+			name:  "same category twice",
+			parts: []SplitPart{{Amount: -60, Category: "food"}, {Amount: -40, Category: "food"}},
+			want:  map[string]float64{"food": -100},
+		},
+		{
+			name:    "removal",
+			prepare: func(t *Transaction) { t.Splits = []Split{{Amount: -100, Category: "food"}} },
+			want:    map[string]float64{"shopping": -100},
+		},
+		{name: "single part", parts: []SplitPart{{Amount: -100, Category: "food"}}, wantErr: ErrSplitTooFewParts},
+		{name: "missing category", parts: []SplitPart{{Amount: -70, Category: "food"}, {Amount: -30, Category: " "}}, wantErr: ErrSplitCategory},
+		{name: "opposite sign", parts: []SplitPart{{Amount: -120, Category: "food"}, {Amount: 20, Category: "cashback"}}, wantErr: ErrSplitInvalidAmount},
+		{name: "zero part", parts: []SplitPart{{Amount: -100, Category: "food"}, {Amount: 0, Category: "other"}}, wantErr: ErrSplitInvalidAmount},
+		{name: "short of the amount", parts: []SplitPart{{Amount: -70, Category: "food"}, {Amount: -29.99, Category: "other"}}, wantErr: ErrSplitSumMismatch},
+		{
+			name:    "voided",
+			prepare: func(t *Transaction) { t.Voided = true },
+			parts:   []SplitPart{{Amount: -70, Category: "food"}, {Amount: -30, Category: "other"}},
+			wantErr: ErrSplitTransferOrVoid,
+		},
+		{
+			name:    "transfer leg",
+			prepare: func(t *Transaction) { t.TransferID = uuid.NullUUID{UUID: uuid.New(), Valid: true} },
+			parts:   []SplitPart{{Amount: -70, Category: "food"}, {Amount: -30, Category: "other"}},
+			wantErr: ErrSplitTransferOrVoid,
+		},
+		{
+			name:    "pending",
+			prepare: func(t *Transaction) { t.Status = StatusPending },
+			parts:   []SplitPart{{Amount: -70, Category: "food"}, {Amount: -30, Category: "other"}},
+			wantErr: ErrTransactionNotPosted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := NewTransaction(uuid.New(), -100, "Supermarket", "file.csv", day)
+			transaction.Category = "shopping"
+			if tt.prepare != nil {
+				tt.prepare(transaction)
+			}
+
+			err := transaction.SetSplits(tt.parts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetSplits() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			got := transaction.CategoryAmounts()
+			if len(got) != len(tt.want) {
+				t.Fatalf("CategoryAmounts() = %v, want %v", got, tt.want)
+			}
+			for category, amount := range tt.want {
+				if toCents(got[category]) != toCents(amount) {
+					t.Errorf("%s = %.2f, want %.2f", category, got[category], amount)
+				}
+			}
+		})
+	}
+}
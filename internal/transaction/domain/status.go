@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusPosted   = "posted"
+	StatusReversed = "reversed"
+	StatusExpired  = "expired"
+
+	TransactionStatusChangedEvent = "transaction.status.changed"
+
+	// settlementTolerance is how far, relative to the authorized amount, the
+	// settled amount may drift (tips, currency conversion) and still match.
+	settlementTolerance = 0.2
+)
+
+var (
+	ErrInvalidStatus           = errors.New("status must be pending or posted")
+	ErrInvalidStatusTransition = errors.New("invalid transaction status transition")
+	ErrTransactionNotPosted    = errors.New("transaction is not posted")
+)
+
+// StatusChange is published whenever a transaction moves between statuses.
+type StatusChange struct {
+	TransactionID uuid.UUID
+	AccountID     uuid.UUID
+	From          string
+	To            string
+	Transaction   *Transaction
+	ChangedAt     int64
+}
+
+// Settlement pairs a posted transaction with the pending authorization it
+// settles.
+type Settlement struct {
+	PendingID uuid.UUID
+	Posted    *Transaction
+}
+
+// NewAuthorization creates a pending card authorization. It does not move
+// the account balance until it is settled by a posted transaction.
+func NewAuthorization(accountID uuid.UUID, amount float64, description, inputFileID string, authorizedAt time.Time) *Transaction {
+	t := NewTransaction(accountID, amount, description, inputFileID, authorizedAt)
+	t.Status = StatusPending
+	t.PostedAt = time.Time{}
+	return t
+}
+
+// IsPosted reports whether the transaction affects balances. Documents
+// indexed before statuses existed have no status and are posted.
+func (t *Transaction) IsPosted() bool {
+	return t.Status == StatusPosted || t.Status == ""
+}
+
+// CountsInSummary reports whether the transaction is part of a summary,
+// optionally including pending authorizations.
+func (t *Transaction) CountsInSummary(includePending bool) bool {
+	if t.Voided {
+		return false
+	}
+	return t.IsPosted() || (includePending && t.Status == StatusPending)
+}
+
+// Settle posts a pending authorization with the amount and value date of
+// the settled transaction.
+func (t *Transaction) Settle(posted *Transaction) (*StatusChange, error) {
+	if t.Status != StatusPending {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	t.Amount = posted.Amount
+	t.Type = getTransactionType(posted.Amount)
+	t.InputDate = posted.InputDate
+	t.PostedAt = posted.InputDate
+	if t.Description == "" {
+		t.Description = posted.Description
+	}
+	return t.changeStatus(StatusPosted), nil
+}
+
+// ReverseAuthorization releases a pending authorization the merchant
+// cancelled before settling it.
+func (t *Transaction) ReverseAuthorization() (*StatusChange, error) {
+	if t.Status != StatusPending {
+		return nil, ErrInvalidStatusTransition
+	}
+	return t.changeStatus(StatusReversed), nil
+}
+
+func (t *Transaction) changeStatus(to string) *StatusChange {
+	change := &StatusChange{
+		TransactionID: t.ID,
+		AccountID:     t.AccountID,
+		From:          t.Status,
+		To:            to,
+		ChangedAt:     time.Now().UTC().Unix(),
+	}
+	t.Status = to
+	t.UpdatedAt = change.ChangedAt
+	change.Transaction = t
+	return change
+}
+
+// NewExpiredChange describes a pending authorization that expired.
+func NewExpiredChange(t *Transaction) *StatusChange {
+	return &StatusChange{
+		TransactionID: t.ID,
+		AccountID:     t.AccountID,
+		From:          StatusPending,
+		To:            StatusExpired,
+		Transaction:   t,
+		ChangedAt:     t.UpdatedAt,
+	}
+}
+
+// MatchAuthorization finds the pending authorization a posted transaction
+// settles: same account and merchant (or description), authorized no later
+// than the posting date and within the settlement tolerance. The closest
+// amount wins, then the oldest authorization.
+func MatchAuthorization(posted *Transaction, pending []*Transaction) *Transaction {
+	var best *Transaction
+	bestDiff := int64(math.MaxInt64)
+	for _, p := range pending {
+		if p.Status != StatusPending || p.AccountID != posted.AccountID {
+			continue
+		}
+		if p.AuthorizedAt.After(posted.InputDate) || !sameCounterparty(p, posted) {
+			continue
+		}
+		if (p.Amount < 0) != (posted.Amount < 0) {
+			continue
+		}
+
+		diff := toCents(math.Abs(p.Amount - posted.Amount))
+		if float64(diff) > math.Abs(float64(toCents(p.Amount)))*settlementTolerance {
+			continue
+		}
+		if diff < bestDiff {
+			best, bestDiff = p, diff
+		}
+	}
+	return best
+}
+
+func sameCounterparty(a, b *Transaction) bool {
+	if a.Merchant != "" || b.Merchant != "" {
+		return a.Merchant == b.Merchant
+	}
+	return strings.EqualFold(strings.TrimSpace(a.Description), strings.TrimSpace(b.Description))
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMatchAuthorization(t *testing.T) {
+	account := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	authorization := func(amount float64, description string, authorizedAt time.Time) *Transaction {
+		return NewAuthorization(account, amount, description, "auth.csv", authorizedAt)
+	}
+	coffee := authorization(-10, "Coffee Shop", day.AddDate(0, 0, -2))
+	closer := authorization(-11.5, "Coffee Shop", day.AddDate(0, 0, -1))
+	grocery := authorization(-80, "Grocery", day.AddDate(0, 0, -1))
+	later := authorization(-10, "Coffee Shop", day.AddDate(0, 0, 1))
+	other := NewAuthorization(uuid.New(), -10, "Coffee Shop", "auth.csv", day.AddDate(0, 0, -1))
+	refund := authorization(10, "Coffee Shop", day.AddDate(0, 0, -1))
+	settled := authorization(-10, "Coffee Shop", day.AddDate(0, 0, -1))
+	settled.Status = StatusPosted
+
+	tests := []struct {
+		name    string
+		posted  *Transaction
+		pending []*Transaction
+		want    *Transaction
+	}{
+		{
+			name:    "same description within tolerance",
+			posted:  NewTransaction(account, -11, " coffee shop ", "file.csv", day),
+			pending: []*Transaction{coffee},
+			want:    coffee,
+		},
+		{
+			name:    "closest amount wins",
+			posted:  NewTransaction(account, -11.5, "Coffee Shop", "file.csv", day),
+			pending: []*Transaction{coffee, closer},
+			want:    closer,
+		},
+		{
+			name:    "outside the tolerance",
+			posted:  NewTransaction(account, -12.5, "Coffee Shop", "file.csv", day),
+			pending: []*Transaction{coffee},
+		},
+		{
+			name:    "other counterparty",
+			posted:  NewTransaction(account, -80, "Coffee Shop", "file.csv", day),
+			pending: []*Transaction{grocery},
+		},
+		{
+			name:    "authorized after the posting",
+			posted:  NewTransaction(account, -10, "Coffee Shop", "file.csv", day),
+			pending: []*Transaction{later},
+		},
+		{
+			name:    "other account",
+			posted:  NewTransaction(account, -10, "Coffee Shop", "file.csv", day),
+			pending: []*Transaction{other},
+		},
+		{
+			name:    "opposite sign",
+			posted:  NewTransaction(account, -10, "Coffee Shop", "file.csv", day),
+			pending: []*Transaction{refund},
+		},
+		{
+			name:    "already settled",
+			posted:  NewTransaction(account, -10, "Coffee Shop", "file.csv", day),
+			pending: []*Transaction{settled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchAuthorization(tt.posted, tt.pending); got != tt.want {
+				t.Errorf("MatchAuthorization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAuthorizationByMerchant(t *testing.T) {
+	account := uuid.New()
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	pending := NewAuthorization(account, -20, "OXXO 1234 MTY", "auth.csv", day.AddDate(0, 0, -1))
+	pending.SetMerchant("OXXO", "convenience")
+	posted := NewTransaction(account, -20, "OXXO 9876 GDL", "file.csv", day)
+	posted.SetMerchant("OXXO", "convenience")
+
+	if got := MatchAuthorization(posted, []*Transaction{pending}); got != pending {
+		t.Fatalf("MatchAuthorization() = %v, want the authorization of the same merchant", got)
+	}
+}
+
+func TestSettle(t *testing.T) {
+	account := uuid.New()
+	authorizedAt := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	postedAt := authorizedAt.AddDate(0, 0, 2)
+
+	tests := []struct {
+		name    string
+		status  string
+		wantErr error
+	}{
+		{name: "pending", status: StatusPending},
+		{name: "posted", status: StatusPosted, wantErr: ErrInvalidStatusTransition},
+		{name: "reversed", status: StatusReversed, wantErr: ErrInvalidStatusTransition},
+		{name: "expired", status: StatusExpired, wantErr: ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending := NewAuthorization(account, -10, "Coffee Shop", "auth.csv", authorizedAt)
+			pending.Status = tt.status
+			posted := NewTransaction(account, -11.25, "COFFEE SHOP 42", "file.csv", postedAt)
+
+			change, err := pending.Settle(posted)
+			if err != tt.wantErr {
+				t.Fatalf("Settle() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if change.From != StatusPending || change.To != StatusPosted {
+				t.Errorf("change = %s -> %s, want pending -> posted", change.From, change.To)
+			}
+			if pending.Status != StatusPosted || pending.Amount != -11.25 || pending.Type != "debit" {
+				t.Errorf("settled = %s %.2f %s, want posted -11.25 debit", pending.Status, pending.Amount, pending.Type)
+			}
+			if !pending.InputDate.Equal(postedAt) || !pending.PostedAt.Equal(postedAt) {
+				t.Errorf("settled on %s, posted %s, want %s", pending.InputDate, pending.PostedAt, postedAt)
+			}
+			if !pending.AuthorizedAt.Equal(authorizedAt) {
+				t.Errorf("authorized at %s, want %s", pending.AuthorizedAt, authorizedAt)
+			}
+			if pending.Description != "Coffee Shop" {
+				t.Errorf("description = %q, want the authorization's", pending.Description)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SubscriptionAlertCreatedEvent = "subscriptions.alert.created"
+
+	CadenceWeekly  = "weekly"
+	CadenceMonthly = "monthly"
+	CadenceAnnual  = "annual"
+
+	SubscriptionActive   = "active"
+	SubscriptionMissed   = "missed"
+	SubscriptionInactive = "inactive"
+
+	SubscriptionAlertMissingCharge = "missing_charge"
+	SubscriptionAlertPriceIncrease = "price_increase"
+
+	// SubscriptionLookback is how far back detection reads an account's
+	// history; long enough to see two charges of an annual plan.
+	SubscriptionLookback = 400 * 24 * time.Hour
+
+	// subscriptionAmountTolerance is how far earlier charges may be from
+	// the reference charge and still belong to the same series.
+	subscriptionAmountTolerance = 0.2
+	// subscriptionPriceJump is the increase over the series average that
+	// raises a price alert, with subscriptionMinPriceJump as a floor.
+	subscriptionPriceJump    = 0.1
+	subscriptionMinPriceJump = 1.0
+	// subscriptionAverageCharges is how many recent charges the average
+	// amount is computed over.
+	subscriptionAverageCharges = 6
+	// subscriptionInactiveAfter is how many missed periods turn a missed
+	// subscription inactive.
+	subscriptionInactiveAfter = 3
+)
+
+// cadence describes the spacing between the charges of a subscription.
+type cadence struct {
+	name       string
+	minDays    int
+	maxDays    int
+	minCharges int
+	grace      int // days a charge may be late before it counts as missing
+}
+
+var cadences = []cadence{
+	{name: CadenceWeekly, minDays: 5, maxDays: 9, minCharges: 4, grace: 3},
+	{name: CadenceMonthly, minDays: 26, maxDays: 35, minCharges: 3, grace: 7},
+	{name: CadenceAnnual, minDays: 350, maxDays: 380, minCharges: 2, grace: 21},
+}
+
+func cadenceByName(name string) cadence {
+	for _, c := range cadences {
+		if c.name == name {
+			return c
+		}
+	}
+	return cadences[1]
+}
+
+// next is the date the charge after last is expected on.
+func (c cadence) next(last time.Time) time.Time {
+	switch c.name {
+	case CadenceWeekly:
+		return last.AddDate(0, 0, 7)
+	case CadenceAnnual:
+		return last.AddDate(1, 0, 0)
+	default:
+		return last.AddDate(0, 1, 0)
+	}
+}
+
+// Subscription is a recurring charge detected in the history of an
+// account. Amounts are positive.
+type Subscription struct {
+	ID               uuid.UUID
+	AccountID        uuid.UUID
+	Merchant         string
+	Category         string
+	Cadence          string // "weekly", "monthly" or "annual"
+	AverageAmount    float64
+	LastAmount       float64
+	LastChargeDate   time.Time
+	NextExpectedDate time.Time
+	ChargeCount      int
+	Status           string // "active", "missed" or "inactive"
+	CreatedAt        int64
+	UpdatedAt        int64
+}
+
+// SubscriptionAlert warns about a subscription charge that did not arrive
+// or that costs more than usual. ChargeDate is the expected date of a
+// missing charge or the date of the more expensive one.
+type SubscriptionAlert struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	AccountID      uuid.UUID
+	Kind           string // "missing_charge" or "price_increase"
+	ChargeDate     time.Time
+	Amount         float64
+	PreviousAmount float64
+	Message        string
+	CreatedAt      int64
+}
+
+// MonthlyCost is the subscription average amount spread over a month.
+func (s *Subscription) MonthlyCost() float64 {
+	switch s.Cadence {
+	case CadenceWeekly:
+		return roundCents(s.AverageAmount * 52 / 12)
+	case CadenceAnnual:
+		return roundCents(s.AverageAmount / 12)
+	default:
+		return s.AverageAmount
+	}
+}
+
+// subscriptionName is the merchant of a charge, falling back to the
+// description for transactions without a merchant.
+func subscriptionName(t *Transaction) string {
+	name := t.Merchant
+	if name == "" {
+		name = t.Description
+	}
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// subscriptionKey groups the charges of a merchant regardless of case.
+func subscriptionKey(name string) string {
+	return strings.ToUpper(name)
+}
+
+// DetectSubscriptions finds the recurring charges in transactions, the
+// history of one account in value date order, and returns them merged
+// with the subscriptions already stored for the account, along with the
+// alerts they raise as of now. Stored subscriptions whose pattern no
+// longer shows up keep their last state and are only aged.
+func DetectSubscriptions(accountID uuid.UUID, transactions []*Transaction, existing []*Subscription, now time.Time) ([]*Subscription, []*SubscriptionAlert) {
+	today := truncateDate(now)
+	stored := make(map[string]*Subscription, len(existing))
+	for _, s := range existing {
+		stored[subscriptionKey(s.Merchant)] = s
+	}
+
+	groups := make(map[string][]*Transaction)
+	var keys []string
+	for _, t := range transactions {
+		if !t.IsPurchase() {
+			continue
+		}
+		key := subscriptionKey(subscriptionName(t))
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+	sort.Strings(keys)
+
+	var subscriptions []*Subscription
+	var alerts []*SubscriptionAlert
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		series, c := recurringSeries(groups[key])
+		if series == nil {
+			continue
+		}
+		sub := newSubscription(accountID, series, c, stored[key], today)
+		seen[key] = true
+		subscriptions = append(subscriptions, sub)
+		if alert := priceIncreaseAlert(sub, series); alert != nil {
+			alerts = append(alerts, alert)
+		}
+		if alert := missingChargeAlert(sub, today); alert != nil {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	for _, s := range existing {
+		if seen[subscriptionKey(s.Merchant)] {
+			continue
+		}
+		aged := *s
+		aged.Status = subscriptionStatus(cadenceByName(s.Cadence), s.NextExpectedDate, today)
+		if aged.Status == s.Status {
+			continue
+		}
+		aged.UpdatedAt = now.UTC().Unix()
+		subscriptions = append(subscriptions, &aged)
+		if alert := missingChargeAlert(&aged, today); alert != nil {
+			alerts = append(alerts, alert)
+		}
+	}
+	return subscriptions, alerts
+}
+
+// recurringSeries walks the charges of a merchant back from the latest
+// one while they keep a regular cadence and a similar amount, and returns
+// the series oldest first with its cadence. The latest charge may differ
+// in amount so that a price increase still extends the series. Amounts
+// are compared by size, so recurring credits form series too.
+func recurringSeries(charges []*Transaction) ([]*Transaction, cadence) {
+	if len(charges) < 2 {
+		return nil, cadence{}
+	}
+	last := charges[len(charges)-1]
+	reference := math.Abs(charges[len(charges)-2].Amount)
+	if ratio := math.Abs(last.Amount) / reference; ratio < 0.5 || ratio > 2 {
+		return nil, cadence{}
+	}
+
+	for _, c := range cadences {
+		series := []*Transaction{last}
+		for i := len(charges) - 2; i >= 0; i-- {
+			head := series[len(series)-1]
+			days := int(math.Round(truncateDate(head.InputDate).Sub(truncateDate(charges[i].InputDate)).Hours() / 24))
+			if days < c.minDays || days > c.maxDays {
+				break
+			}
+			if math.Abs(math.Abs(charges[i].Amount)-reference) > reference*subscriptionAmountTolerance {
+				break
+			}
+			series = append(series, charges[i])
+		}
+		if len(series) >= c.minCharges {
+			for i, j := 0, len(series)-1; i < j; i, j = i+1, j-1 {
+				series[i], series[j] = series[j], series[i]
+			}
+			return series, c
+		}
+	}
+	return nil, cadence{}
+}
+
+func newSubscription(accountID uuid.UUID, series []*Transaction, c cadence, stored *Subscription, today time.Time) *Subscription {
+	last := series[len(series)-1]
+	recent := series[max(0, len(series)-subscriptionAverageCharges):]
+	var total float64
+	for _, t := range recent {
+		total -= t.Amount
+	}
+
+	now := time.Now().UTC().Unix()
+	sub := &Subscription{
+		ID:               uuid.New(),
+		AccountID:        accountID,
+		Merchant:         subscriptionName(last),
+		Category:         last.Category,
+		Cadence:          c.name,
+		AverageAmount:    roundCents(total / float64(len(recent))),
+		LastAmount:       -last.Amount,
+		LastChargeDate:   truncateDate(last.InputDate),
+		NextExpectedDate: c.next(truncateDate(last.InputDate)),
+		ChargeCount:      len(series),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if stored != nil {
+		sub.ID = stored.ID
+		sub.Merchant = stored.Merchant
+		sub.CreatedAt = stored.CreatedAt
+	}
+	sub.Status = subscriptionStatus(c, sub.NextExpectedDate, today)
+	return sub
+}
+
+// subscriptionStatus is missed once the grace period after the expected
+// date is over and inactive after several missed periods.
+func subscriptionStatus(c cadence, next, today time.Time) string {
+	due := next.AddDate(0, 0, c.grace)
+	if !today.After(due) {
+		return SubscriptionActive
+	}
+	inactive := next
+	for range subscriptionInactiveAfter {
+		inactive = c.next(inactive)
+	}
+	if today.After(inactive) {
+		return SubscriptionInactive
+	}
+	return SubscriptionMissed
+}
+
+// priceIncreaseAlert flags a latest charge noticeably above the average of
+// the charges before it. Old increases of lapsed subscriptions are not
+// worth an alert.
+func priceIncreaseAlert(sub *Subscription, series []*Transaction) *SubscriptionAlert {
+	if sub.Status != SubscriptionActive {
+		return nil
+	}
+	earlier := series[max(0, len(series)-1-subscriptionAverageCharges) : len(series)-1]
+	var total float64
+	for _, t := range earlier {
+		total -= t.Amount
+	}
+	previous := roundCents(total / float64(len(earlier)))
+	increase := sub.LastAmount - previous
+	if increase < subscriptionMinPriceJump || increase <= previous*subscriptionPriceJump {
+		return nil
+	}
+	return &SubscriptionAlert{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		AccountID:      sub.AccountID,
+		Kind:           SubscriptionAlertPriceIncrease,
+		ChargeDate:     sub.LastChargeDate,
+		Amount:         sub.LastAmount,
+		PreviousAmount: previous,
+		Message: fmt.Sprintf("%s charged %.2f on %s, up from %.2f on average",
+			sub.Merchant, sub.LastAmount, sub.LastChargeDate.Format(time.DateOnly), previous),
+		CreatedAt: time.Now().UTC().Unix(),
+	}
+}
+
+// missingChargeAlert flags a missed subscription whose expected charge did
+// not arrive. Inactive subscriptions were already alerted while missed.
+func missingChargeAlert(sub *Subscription, today time.Time) *SubscriptionAlert {
+	if sub.Status != SubscriptionMissed {
+		return nil
+	}
+	return &SubscriptionAlert{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		AccountID:      sub.AccountID,
+		Kind:           SubscriptionAlertMissingCharge,
+		ChargeDate:     sub.NextExpectedDate,
+		Amount:         sub.AverageAmount,
+		PreviousAmount: sub.LastAmount,
+		Message: fmt.Sprintf("%s %s charge of about %.2f expected on %s has not arrived",
+			sub.Merchant, sub.Cadence, sub.AverageAmount, sub.NextExpectedDate.Format(time.DateOnly)),
+		CreatedAt: time.Now().UTC().Unix(),
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return float64(toCents(amount)) / 100
+}
@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// SummaryFromDays derives the months and the totals of a summary from the
+// activity of its days.
+func SummaryFromDays(days []SummaryDay) *TransactionSummary {
+	summary := &TransactionSummary{
+		Monthly: make(map[string]*TransactionMonthly),
+	}
+	credits := make(map[string]float64)
+	debits := make(map[string]float64)
+	for _, d := range days {
+		key := d.Date.Format("2006-01")
+		monthly, ok := summary.Monthly[key]
+		if !ok {
+			monthly = &TransactionMonthly{
+				Year:  d.Date.Year(),
+				Month: int(d.Date.Month()),
+			}
+			summary.Monthly[key] = monthly
+		}
+		monthly.Total += d.Count
+		monthly.CreditCount += d.CreditCount
+		monthly.DebitCount += d.DebitCount
+		monthly.RefundCount += d.RefundCount
+		monthly.Refunds += d.Refunds
+		monthly.NetSpend += d.Debits + d.Refunds
+		monthly.Balance += d.Credits + d.Refunds - d.Debits
+		monthly.Installments += d.Installments
+		credits[key] += d.Credits
+		debits[key] += d.Debits
+
+		summary.TotalCount += d.Count
+		summary.TotalBalance += d.Net
+		summary.CreditCount += d.CreditCount
+		summary.TotalCredit += d.Credits
+		summary.DebitCount += d.DebitCount
+		summary.TotalDebit += d.Debits
+		summary.RefundCount += d.RefundCount
+		summary.TotalRefunds += d.Refunds
+	}
+	for key, monthly := range summary.Monthly {
+		if monthly.CreditCount > 0 {
+			monthly.AverageCredit = credits[key] / float64(monthly.CreditCount)
+		}
+		if monthly.DebitCount > 0 {
+			monthly.AverageDebit = debits[key] / float64(monthly.DebitCount)
+		}
+	}
+	if summary.CreditCount > 0 {
+		summary.AverageCredit = summary.TotalCredit / float64(summary.CreditCount)
+	}
+	if summary.DebitCount > 0 {
+		summary.AverageDebit = summary.TotalDebit / float64(summary.DebitCount)
+	}
+	summary.NetSpend = summary.TotalDebit + summary.TotalRefunds
+	return summary
+}
+
+// AddSummaryCategory adds the spend of a category in a month to the month
+// and to the totals of the summary.
+func AddSummaryCategory(summary *TransactionSummary, totals map[string]*CategoryTotal, month time.Time, c CategoryTotal) {
+	if monthly, ok := summary.Monthly[month.Format("2006-01")]; ok {
+		monthly.Categories = append(monthly.Categories, c)
+	}
+	total, ok := totals[c.Category]
+	if !ok {
+		total = &CategoryTotal{Category: c.Category}
+		totals[c.Category] = total
+	}
+	total.Total += c.Total
+	total.Count += c.Count
+}
+
+// SortSummaryCategories sets the totals of the categories of a summary,
+// the largest spend first.
+func SortSummaryCategories(summary *TransactionSummary, totals map[string]*CategoryTotal) {
+	for _, total := range totals {
+		summary.Categories = append(summary.Categories, *total)
+	}
+	sortCategoryTotals(summary.Categories)
+}
+
+// AddSummaryMerchant adds one of the top merchants of a month to the month.
+func AddSummaryMerchant(summary *TransactionSummary, month time.Time, m MerchantTotal) {
+	if monthly, ok := summary.Monthly[month.Format("2006-01")]; ok {
+		monthly.TopMerchants = append(monthly.TopMerchants, m)
+	}
+}
+
+// SummaryDaysOf totals transactions by day the way the summary queries
+// do, oldest first: installment movements only count towards the balance
+// and the installments billed, and refunds give back spend instead of
+// counting as credits. The caller picks the transactions.
+func SummaryDaysOf(transactions []*Transaction) []SummaryDay {
+	byDate := make(map[time.Time]*SummaryDay)
+	for _, t := range transactions {
+		date := truncateDate(t.InputDate)
+		d, ok := byDate[date]
+		if !ok {
+			d = &SummaryDay{Date: date}
+			byDate[date] = d
+		}
+		d.Count++
+		d.Net += t.Amount
+
+		switch {
+		case t.Type == TransactionTypeInstallment:
+			d.Installments += t.Amount
+		case t.Type == TransactionTypeInstallmentConversion:
+		case t.Amount > 0 && t.ReversalOf.Valid:
+			d.RefundCount++
+			d.Refunds += t.Amount
+		case t.Amount > 0:
+			d.CreditCount++
+			d.Credits += t.Amount
+		default:
+			d.DebitCount++
+			d.Debits += t.Amount
+		}
+	}
+
+	days := make([]SummaryDay, 0, len(byDate))
+	for _, d := range byDate {
+		days = append(days, *d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date.Before(days[j].Date) })
+	return days
+}
+
+// addSummaryCategoriesOf adds the spend of transactions by month and
+// category to a summary the way GetSummary reads it from the database,
+// split transactions counting towards the categories of their splits.
+func addSummaryCategoriesOf(summary *TransactionSummary, transactions []*Transaction) {
+	type monthCategory struct {
+		month    time.Time
+		category string
+	}
+	spend := make(map[monthCategory]*CategoryTotal)
+	var keys []monthCategory
+	for _, t := range transactions {
+		if !summarySpends(t) {
+			continue
+		}
+		splits := t.Splits
+		if len(splits) == 0 {
+			splits = []Split{{Amount: t.Amount, Category: t.Category}}
+		}
+		counted := make(map[string]bool)
+		for _, split := range splits {
+			category := split.Category
+			if category == "" {
+				category = "uncategorized"
+			}
+			key := monthCategory{MonthStart(t.InputDate), category}
+			c, ok := spend[key]
+			if !ok {
+				c = &CategoryTotal{Category: category}
+				spend[key] = c
+				keys = append(keys, key)
+			}
+			c.Total -= split.Amount
+			if !counted[category] {
+				counted[category] = true
+				c.Count++
+			}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].month.Equal(keys[j].month) {
+			return keys[i].month.Before(keys[j].month)
+		}
+		if spend[keys[i]].Total != spend[keys[j]].Total {
+			return spend[keys[i]].Total > spend[keys[j]].Total
+		}
+		return keys[i].category < keys[j].category
+	})
+	totals := make(map[string]*CategoryTotal)
+	for _, key := range keys {
+		AddSummaryCategory(summary, totals, key.month, *spend[key])
+	}
+	SortSummaryCategories(summary, totals)
+}
+
+// addSummaryMerchantsOf adds the SummaryTopMerchants merchants with the
+// most spend of every month to a summary. Transactions are read oldest
+// first, so that a merchant keeps the category of its first one.
+func addSummaryMerchantsOf(summary *TransactionSummary, transactions []*Transaction) {
+	byMonth := make(map[time.Time]map[string]*MerchantTotal)
+	for _, t := range transactions {
+		if !summarySpends(t) || t.Merchant == "" {
+			continue
+		}
+		month := MonthStart(t.InputDate)
+		merchants, ok := byMonth[month]
+		if !ok {
+			merchants = make(map[string]*MerchantTotal)
+			byMonth[month] = merchants
+		}
+		m, ok := merchants[t.Merchant]
+		if !ok {
+			m = &MerchantTotal{Merchant: t.Merchant, Category: t.Category}
+			merchants[t.Merchant] = m
+		}
+		m.Total -= t.Amount
+		m.Count++
+	}
+
+	for month, merchants := range byMonth {
+		top := make([]MerchantTotal, 0, len(merchants))
+		for _, m := range merchants {
+			top = append(top, *m)
+		}
+		sort.Slice(top, func(i, j int) bool {
+			if top[i].Total != top[j].Total {
+				return top[i].Total > top[j].Total
+			}
+			return top[i].Merchant < top[j].Merchant
+		})
+		if len(top) > SummaryTopMerchants {
+			top = top[:SummaryTopMerchants]
+		}
+		for _, m := range top {
+			AddSummaryMerchant(summary, month, m)
+		}
+	}
+}
+
+// summarySpends reports whether a transaction counts as spend by category
+// and merchant in a summary.
+func summarySpends(t *Transaction) bool {
+	return t.Amount < 0 && t.Type != TransactionTypeInstallment && t.Type != TransactionTypeInstallmentConversion
+}
+
+// sortCategoryTotals orders categories by spend, the largest first.
+func sortCategoryTotals(categories []CategoryTotal) {
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Total != categories[j].Total {
+			return categories[i].Total > categories[j].Total
+		}
+		return categories[i].Category < categories[j].Category
+	})
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	TransactionAnnotatedEvent = "transaction.annotated"
+
+	maxTags       = 20
+	maxTagLength  = 50
+	maxNoteLength = 1000
+
+	// tagQueryPrefix marks a tag filter inside a search query, e.g.
+	// "tag:reimbursable uber".
+	tagQueryPrefix = "tag:"
+)
+
+var (
+	ErrInvalidTag  = errors.New("tags may only contain letters, digits, '-' and '_'")
+	ErrTooManyTags = fmt.Errorf("a transaction can have at most %d tags", maxTags)
+	ErrNoteTooLong = fmt.Errorf("notes are limited to %d characters", maxNoteLength)
+
+	tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+)
+
+// TransactionSearch filters transactions by tags and free text. A
+// transaction matches when it has every tag and contains the text in its
+// description, merchant, category or note.
+type TransactionSearch struct {
+	Tags []string
+	Text string
+}
+
+// TagTotal aggregates the transactions carrying a tag.
+type TagTotal struct {
+	Tag         string
+	Count       int
+	TotalCredit float64
+	TotalDebit  float64 // negative like TransactionSummary.TotalDebit
+	Total       float64
+}
+
+// NormalizeTag lower-cases a tag and strips surrounding spaces and a leading
+// '#', rejecting anything that is not a simple slug.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	if len(tag) > maxTagLength || !tagPattern.MatchString(tag) {
+		return "", fmt.Errorf("%q: %w", tag, ErrInvalidTag)
+	}
+	return tag, nil
+}
+
+// Annotate replaces the tags and note of the transaction. Tags are
+// normalized, deduplicated and sorted.
+func (t *Transaction) Annotate(tags []string, note string) error {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > maxTags {
+		return ErrTooManyTags
+	}
+
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > maxNoteLength {
+		return ErrNoteTooLong
+	}
+
+	sort.Strings(normalized)
+	t.Tags = normalized
+	t.Note = note
+	t.UpdatedAt = time.Now().UTC().Unix()
+	return nil
+}
+
+// HasTag reports whether the transaction carries the tag.
+func (t *Transaction) HasTag(tag string) bool {
+	for _, tt := range t.Tags {
+		if tt == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSearchQuery splits a query such as "tag:reimbursable tag:trip-cdmx
+// uber" into tag filters and free text.
+func ParseSearchQuery(query string) (TransactionSearch, error) {
+	var search TransactionSearch
+	var words []string
+	for _, field := range strings.Fields(query) {
+		if !strings.HasPrefix(strings.ToLower(field), tagQueryPrefix) {
+			words = append(words, field)
+			continue
+		}
+		tag, err := NormalizeTag(field[len(tagQueryPrefix):])
+		if err != nil {
+			return TransactionSearch{}, err
+		}
+		search.Tags = append(search.Tags, tag)
+	}
+	search.Text = strings.Join(words, " ")
+	return search, nil
+}
@@ -7,13 +7,51 @@ import (
 )
 
 type Transaction struct {
-	ID          uuid.UUID
-	AccountID   uuid.UUID
-	Amount      float64
-	Type        string // "credit" or "debit"
-	InputFileID string
-	InputDate   time.Time
-	CreatedAt   int64
+	ID                uuid.UUID
+	AccountID         uuid.UUID
+	Amount            float64
+	Type              string // "credit", "debit" or a system type such as "interest"
+	Description       string
+	Merchant          string
+	Category          string
+	TransferID        uuid.NullUUID
+	ReversalOf        uuid.NullUUID
+	ReversalKind      string        // "refund", "reversal" or "dispute" when ReversalOf is set
+	InstallmentPlanID uuid.NullUUID // set on a purchase converted to installments and on the plan's transactions
+	Voided            bool
+	Supersedes        uuid.NullUUID // the version a correction replaced with this row
+	SupersededBy      uuid.NullUUID // set once a correction replaces this row; only current rows count
+	Status            string        // "pending", "posted", "reversed" or "expired"
+	InputFileID       string
+	ImportKey         string    `json:",omitempty"` // file row it was read from, only set while importing
+	InputDate         time.Time // value date once posted, authorization date while pending
+	AuthorizedAt      time.Time
+	PostedAt          time.Time // zero until the transaction is posted
+	// Splits, Tags and Note are omitted when empty so that partial updates
+	// of the read model from other events keep the stored values.
+	Splits    []Split  `json:",omitempty"`
+	Tags      []string `json:",omitempty"`
+	Note      string   `json:",omitempty"`
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// SummaryTopMerchants is how many merchants each month of a summary lists.
+const SummaryTopMerchants = 5
+
+// SummaryOptions selects which transactions a summary covers, from From to
+// To, excluded, and how its periods are bucketed. Summaries aggregate all
+// of them; the transactions themselves are only returned when DetailLimit
+// is set, a page at a time.
+type SummaryOptions struct {
+	IncludePending  bool
+	Search          TransactionSearch
+	From            time.Time // zero from the first transaction
+	To              time.Time // zero up to the last transaction
+	Granularity     string    // "day", "week", "month", "quarter", "year" or "cycle"
+	CycleClosingDay int
+	DetailLimit     int64
+	DetailOffset    int64
 }
 
 type TransactionSummary struct {
@@ -25,37 +63,98 @@ type TransactionSummary struct {
 	TotalCount    int
 	TotalCredit   float64
 	TotalDebit    float64
-	Monthly       map[string]*TransactionMonthly
+	RefundCount   int
+	TotalRefunds  float64
+	NetSpend      float64 // debits net of refunds, negative like TotalDebit
+	Categories    []CategoryTotal
+	Monthly       map[string]*TransactionMonthly // keyed "2006-01"
+	From          time.Time
+	To            time.Time
+	Granularity   string
+	Periods       []SummaryPeriod // ordered, with empty periods for the gaps
+	Card          *CardCycle      // last closed cycle, set for credit card accounts
+	// InstallmentBalance is what is left to bill of the installment plans.
+	InstallmentBalance float64
+	Rewards            *RewardSummary
+	Subscriptions      []*Subscription // active and missed
+	Budgets            []BudgetStatus  // budget vs actual of the latest month
+	Goals              []GoalProgress
+	Forecast           *CashFlowForecast // latest, nil until the worker runs one
+}
+
+// CardCycle is the billing cycle data of a credit card account shown with a
+// summary.
+type CardCycle struct {
+	CreditLimit      float64
+	AvailableCredit  float64
+	ClosingDate      time.Time
+	DueDate          time.Time
+	StatementBalance float64
+	MinimumPayment   float64
 }
 
 type TransactionMonthly struct {
-	Year          int
-	Month         int
-	AverageCredit float64
-	AverageDebit  float64
-	Balance       float64
-	CreditCount   int
-	DebitCount    int
-	Total         int
-	Transactions  []Transaction
+	Year           int
+	Month          int
+	AverageCredit  float64
+	AverageDebit   float64
+	Balance        float64
+	CreditCount    int
+	DebitCount     int
+	Total          int
+	RefundCount    int
+	Refunds        float64
+	NetSpend       float64
+	Installments   float64 // installments billed in the month, negative
+	PointsEarned   int64
+	PointsRedeemed int64
+	Categories     []CategoryTotal
+	Budgets        []BudgetStatus
+	TopMerchants   []MerchantTotal
+	Transactions   []Transaction
+}
+
+type CategoryTotal struct {
+	Category string
+	Total    float64
+	Count    int
+}
+
+type MerchantTotal struct {
+	Merchant string
+	Category string
+	Total    float64
+	Count    int
 }
 
 const (
 	TransactionCreatedEvent = "transaction.created"
+	TransactionUpdatedEvent = "transaction.updated"
 )
 
-func NewTransaction(accountID uuid.UUID, amount float64, inputFileID string, inputDate time.Time) *Transaction {
+func NewTransaction(accountID uuid.UUID, amount float64, description, inputFileID string, inputDate time.Time) *Transaction {
+	now := time.Now().UTC().Unix()
 	return &Transaction{
-		ID:          uuid.New(),
-		AccountID:   accountID,
-		Amount:      amount,
-		Type:        getTransactionType(amount),
-		InputFileID: inputFileID,
-		InputDate:   inputDate,
-		CreatedAt:   time.Now().UTC().Unix(),
+		ID:           uuid.New(),
+		AccountID:    accountID,
+		Amount:       amount,
+		Type:         getTransactionType(amount),
+		Description:  description,
+		Status:       StatusPosted,
+		InputFileID:  inputFileID,
+		InputDate:    inputDate,
+		AuthorizedAt: inputDate,
+		PostedAt:     inputDate,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 }
 
+func (t *Transaction) SetMerchant(merchant, category string) {
+	t.Merchant = merchant
+	t.Category = category
+}
+
 func getTransactionType(amount float64) string {
 	if amount > 0 {
 		return "credit"
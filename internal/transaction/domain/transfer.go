@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TransferCompletedEvent = "transfer.completed"
+
+	TransferCategory = "transfer"
+)
+
+var (
+	ErrInvalidTransferAmount = errors.New("transfer amount must be greater than zero")
+	ErrSameAccountTransfer   = errors.New("source and destination accounts must be different")
+	ErrAccountNotFound       = errors.New("account not found")
+	ErrAccountInactive       = errors.New("account is not active")
+	ErrDuplicateTransfer     = errors.New("transfer already exists for idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used for a different transfer")
+)
+
+// Transfer moves money between two accounts. It is stored as a debit on the
+// source and a credit on the destination, both linked by the transfer ID.
+type Transfer struct {
+	ID                   uuid.UUID
+	SourceAccountID      uuid.UUID
+	DestinationAccountID uuid.UUID
+	Amount               float64
+	Description          string
+	IdempotencyKey       string
+	DebitTransactionID   uuid.UUID
+	CreditTransactionID  uuid.UUID
+	CreatedAt            int64
+}
+
+func NewTransfer(sourceAccountID, destinationAccountID uuid.UUID, amount float64, description, idempotencyKey string) (*Transfer, error) {
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, ErrInvalidTransferAmount
+	}
+	if sourceAccountID == destinationAccountID {
+		return nil, ErrSameAccountTransfer
+	}
+
+	return &Transfer{
+		ID:                   uuid.New(),
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               amount,
+		Description:          description,
+		IdempotencyKey:       idempotencyKey,
+		DebitTransactionID:   uuid.New(),
+		CreditTransactionID:  uuid.New(),
+		CreatedAt:            time.Now().UTC().Unix(),
+	}, nil
+}
+
+// Legs returns the debit and credit transactions that make up the transfer.
+func (t *Transfer) Legs() (*Transaction, *Transaction) {
+	transferID := uuid.NullUUID{UUID: t.ID, Valid: true}
+	date := time.Unix(t.CreatedAt, 0).UTC()
+
+	debit := NewTransaction(t.SourceAccountID, -t.Amount, t.Description, "", date)
+	debit.ID = t.DebitTransactionID
+	debit.TransferID = transferID
+	debit.Category = TransferCategory
+	debit.CreatedAt = t.CreatedAt
+	debit.UpdatedAt = t.CreatedAt
+
+	credit := NewTransaction(t.DestinationAccountID, t.Amount, t.Description, "", date)
+	credit.ID = t.CreditTransactionID
+	credit.TransferID = transferID
+	credit.Category = TransferCategory
+	credit.CreatedAt = t.CreatedAt
+	credit.UpdatedAt = t.CreatedAt
+
+	return debit, credit
+}
+
+// SameRequest reports whether a replayed request carries the same payload
+// as the stored transfer.
+func (t *Transfer) SameRequest(sourceAccountID, destinationAccountID uuid.UUID, amount float64) bool {
+	return t.SourceAccountID == sourceAccountID &&
+		t.DestinationAccountID == destinationAccountID &&
+		toCents(t.Amount) == toCents(amount)
+}
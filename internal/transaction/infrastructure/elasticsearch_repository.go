@@ -31,7 +31,10 @@ func NewElasticsearchTransactionRepository(client *elastic.Client, nc *nats.Nats
 }
 
 func (r *ElasticsearchTransactionRepository) subscribeToEvents() {
-	r.nats.Subscribe("transaction.created", r.handleTransactionCreated)
+	r.nats.Subscribe(domain.TransactionCreatedEvent, r.handleTransactionCreated)
+	r.nats.Subscribe(domain.TransactionUpdatedEvent, r.handleTransactionUpdated)
+	r.nats.Subscribe(domain.TransactionSplitEvent, r.handleTransactionSplit)
+	r.nats.Subscribe(domain.TransactionAnnotatedEvent, r.handleTransactionAnnotated)
 }
 
 func (r *ElasticsearchTransactionRepository) handleTransactionCreated(data []byte) {
@@ -51,6 +54,79 @@ func (r *ElasticsearchTransactionRepository) handleTransactionCreated(data []byt
 	}
 }
 
+func (r *ElasticsearchTransactionRepository) handleTransactionUpdated(data []byte) {
+	var transaction domain.Transaction
+	if err := json.Unmarshal(data, &transaction); err != nil {
+		log.Printf("Error unmarshaling transaction: %v", err)
+		return
+	}
+
+	_, err := r.client.Update().
+		Index(r.index).
+		Id(transaction.ID.String()).
+		Doc(transaction).
+		DocAsUpsert(true).
+		Do(context.Background())
+	if err != nil {
+		log.Printf("Error updating transaction: %v", err)
+	}
+}
+
+// handleTransactionSplit replaces only the splits of the indexed document;
+// an empty list clears them.
+func (r *ElasticsearchTransactionRepository) handleTransactionSplit(data []byte) {
+	var transaction domain.Transaction
+	if err := json.Unmarshal(data, &transaction); err != nil {
+		log.Printf("Error unmarshaling transaction: %v", err)
+		return
+	}
+
+	splits := transaction.Splits
+	if splits == nil {
+		splits = []domain.Split{}
+	}
+
+	_, err := r.client.Update().
+		Index(r.index).
+		Id(transaction.ID.String()).
+		Doc(map[string]interface{}{
+			"Splits":    splits,
+			"UpdatedAt": transaction.UpdatedAt,
+		}).
+		Do(context.Background())
+	if err != nil {
+		log.Printf("Error updating transaction splits: %v", err)
+	}
+}
+
+// handleTransactionAnnotated replaces only the tags and note of the indexed
+// document.
+func (r *ElasticsearchTransactionRepository) handleTransactionAnnotated(data []byte) {
+	var transaction domain.Transaction
+	if err := json.Unmarshal(data, &transaction); err != nil {
+		log.Printf("Error unmarshaling transaction: %v", err)
+		return
+	}
+
+	tags := transaction.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	_, err := r.client.Update().
+		Index(r.index).
+		Id(transaction.ID.String()).
+		Doc(map[string]interface{}{
+			"Tags":      tags,
+			"Note":      transaction.Note,
+			"UpdatedAt": transaction.UpdatedAt,
+		}).
+		Do(context.Background())
+	if err != nil {
+		log.Printf("Error updating transaction annotations: %v", err)
+	}
+}
+
 func (r *ElasticsearchTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
 	result, err := r.client.Get().
 		Index(r.index).
@@ -72,8 +148,10 @@ func (r *ElasticsearchTransactionRepository) GetByID(ctx context.Context, id uui
 }
 
 func (r *ElasticsearchTransactionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int64) ([]*domain.Transaction, error) {
-	// Crear la consulta
-	query := elastic.NewTermQuery("AccountID.keyword", accountID.String())
+	// Crear la consulta; las versiones reemplazadas por una corrección quedan fuera
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery("AccountID.keyword", accountID.String())).
+		MustNot(elastic.NewExistsQuery("SupersededBy"))
 
 	// Crear el source de búsqueda
 	searchSource := elastic.NewSearchSource().
@@ -103,54 +181,37 @@ func (r *ElasticsearchTransactionRepository) GetByAccountID(ctx context.Context,
 	return transactions, nil
 }
 
-func (r *ElasticsearchTransactionRepository) GetSummary(ctx context.Context, accountID uuid.UUID) (*domain.TransactionSummary, error) {
-	query := elastic.NewTermQuery("accountid", accountID.String())
-
-	aggs := elastic.NewTermsAggregation().Field("type")
-	aggs.SubAggregation("total", elastic.NewSumAggregation().Field("amount"))
-	aggs.SubAggregation("avg", elastic.NewAvgAggregation().Field("amount"))
-	aggs.SubAggregation("count", elastic.NewValueCountAggregation().Field("_id"))
-	aggs.SubAggregation("by_year_month", elastic.NewDateHistogramAggregation().Field("input_date").CalendarInterval("month").Format("yyyy-MM"))
+// Search returns the current transactions of an account carrying every tag
+// of the search and matching its text, newest first.
+func (r *ElasticsearchTransactionRepository) Search(ctx context.Context, accountID uuid.UUID, search domain.TransactionSearch, limit, offset int64) ([]*domain.Transaction, error) {
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery("AccountID.keyword", accountID.String())).
+		MustNot(elastic.NewExistsQuery("SupersededBy"))
+	for _, tag := range search.Tags {
+		query.Filter(elastic.NewTermQuery("Tags.keyword", tag))
+	}
+	if search.Text != "" {
+		query.Must(elastic.NewMultiMatchQuery(search.Text, "Description", "Merchant", "Category", "Note").Operator("and"))
+	}
 
 	searchResult, err := r.client.Search().
 		Index(r.index).
 		Query(query).
-		Aggregation("transactions", aggs).
-		Size(0).
+		Sort("InputDate", false).
+		From(int(offset)).
+		Size(int(limit)).
 		Do(ctx)
 	if err != nil {
-		return nil, err
-	}
-
-	summary := &domain.TransactionSummary{
-		Monthly: make(map[string]*domain.TransactionMonthly),
+		return nil, fmt.Errorf("error executing Elasticsearch query: %v", err)
 	}
 
-	if agg, found := searchResult.Aggregations.Terms("transactions"); found {
-		for _, bucket := range agg.Buckets {
-			transactionType := bucket.Key.(string)
-
-			if totalAgg, found := bucket.Sum("total"); found {
-				if transactionType == "credit" {
-					summary.TotalBalance += *totalAgg.Value
-				} else if transactionType == "debit" {
-					summary.TotalBalance -= *totalAgg.Value
-				}
-			}
-
-			if avgAgg, found := bucket.Avg("avg"); found {
-				if transactionType == "credit" {
-					summary.AverageCredit = *avgAgg.Value
-				} else if transactionType == "debit" {
-					summary.AverageDebit = *avgAgg.Value
-				}
-			}
-
-			if countAgg, found := bucket.ValueCount("count"); found {
-				summary.TotalCount += int(*countAgg.Value)
-			}
+	transactions := make([]*domain.Transaction, 0, len(searchResult.Hits.Hits))
+	for _, hit := range searchResult.Hits.Hits {
+		var transaction domain.Transaction
+		if err := json.Unmarshal(hit.Source, &transaction); err != nil {
+			return nil, err
 		}
+		transactions = append(transactions, &transaction)
 	}
-
-	return summary, nil
+	return transactions, nil
 }
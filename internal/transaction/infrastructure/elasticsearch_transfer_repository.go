@@ -0,0 +1,106 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/olivere/elastic/v7"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type ElasticsearchTransferRepository struct {
+	client *elastic.Client
+	index  string
+	nats   *nats.NatsClient
+}
+
+func NewElasticsearchTransferRepository(client *elastic.Client, nc *nats.NatsClient, index string) ports.TransferQueryRepository {
+	repo := &ElasticsearchTransferRepository{
+		client: client,
+		index:  index,
+		nats:   nc,
+	}
+	repo.subscribeToEvents()
+	return repo
+}
+
+func (r *ElasticsearchTransferRepository) subscribeToEvents() {
+	r.nats.Subscribe(domain.TransferCompletedEvent, r.handleTransferCompleted)
+}
+
+func (r *ElasticsearchTransferRepository) handleTransferCompleted(data []byte) {
+	var transfer domain.Transfer
+	if err := json.Unmarshal(data, &transfer); err != nil {
+		log.Printf("Error unmarshaling transfer: %v", err)
+		return
+	}
+
+	_, err := r.client.Index().
+		Index(r.index).
+		Id(transfer.ID.String()).
+		BodyJson(transfer).
+		Do(context.Background())
+	if err != nil {
+		log.Printf("Error indexing transfer: %v", err)
+	}
+}
+
+func (r *ElasticsearchTransferRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transfer, error) {
+	result, err := r.client.Get().
+		Index(r.index).
+		Id(id.String()).
+		Do(ctx)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !result.Found {
+		return nil, nil
+	}
+
+	var transfer domain.Transfer
+	err = json.Unmarshal(result.Source, &transfer)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *ElasticsearchTransferRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int64) ([]*domain.Transfer, error) {
+	query := elastic.NewBoolQuery().
+		Should(
+			elastic.NewTermQuery("SourceAccountID.keyword", accountID.String()),
+			elastic.NewTermQuery("DestinationAccountID.keyword", accountID.String()),
+		).
+		MinimumNumberShouldMatch(1)
+
+	searchResult, err := r.client.Search().
+		Index(r.index).
+		Query(query).
+		Sort("CreatedAt", false).
+		From(int(offset)).
+		Size(int(limit)).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error executing Elasticsearch query: %v", err)
+	}
+
+	var transfers []*domain.Transfer
+	for _, hit := range searchResult.Hits.Hits {
+		var transfer domain.Transfer
+		err := json.Unmarshal(hit.Source, &transfer)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, &transfer)
+	}
+	return transfers, nil
+}
@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+//go:embed reward_program.json
+var defaultRewardProgram []byte
+
+type JSONRewardProgramSource struct {
+	data []byte
+}
+
+func NewJSONRewardProgramSource() ports.RewardProgramSource {
+	return &JSONRewardProgramSource{data: defaultRewardProgram}
+}
+
+func (s *JSONRewardProgramSource) Load() (*domain.RewardProgram, error) {
+	var program domain.RewardProgram
+	if err := json.Unmarshal(s.data, &program); err != nil {
+		return nil, err
+	}
+	if err := program.Validate(); err != nil {
+		return nil, err
+	}
+	return &program, nil
+}
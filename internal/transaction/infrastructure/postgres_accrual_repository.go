@@ -0,0 +1,172 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresAccrualRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresAccrualRepository(db *sql.DB, nc *nats.NatsClient) ports.AccrualRepository {
+	return &PostgresAccrualRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+func (r *PostgresAccrualRepository) ListCardAccounts(ctx context.Context) ([]*accountDomain.Account, error) {
+	rows, err := r.queries.ListCreditCardAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*accountDomain.Account, 0, len(rows))
+	for _, row := range rows {
+		account, err := toAccountEvent(row)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// GetActivity loads the posted, non-voided activity of the account by value
+// date, up to but excluding before.
+func (r *PostgresAccrualRepository) GetActivity(ctx context.Context, accountID uuid.UUID, before time.Time) (*accountDomain.AccountActivity, error) {
+	rows, err := r.queries.ListAccountDailyActivity(ctx, sqlc.ListAccountDailyActivityParams{
+		AccountID: accountID,
+		InputDate: before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activity := &accountDomain.AccountActivity{Days: make([]accountDomain.DailyActivity, 0, len(rows))}
+	for _, row := range rows {
+		debits, err := strconv.ParseFloat(row.Debits, 64)
+		if err != nil {
+			return nil, err
+		}
+		credits, err := strconv.ParseFloat(row.Credits, 64)
+		if err != nil {
+			return nil, err
+		}
+		activity.Days = append(activity.Days, accountDomain.DailyActivity{
+			Date:    row.Day.UTC(),
+			Debits:  debits,
+			Credits: credits,
+		})
+	}
+	return activity, nil
+}
+
+func (r *PostgresAccrualRepository) ListAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*accountDomain.Accrual, error) {
+	rows, err := r.queries.ListCardAccruals(ctx, sqlc.ListCardAccrualsParams{
+		AccountID: accountID,
+		FromDate:  from,
+		ToDate:    to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accruals := make([]*accountDomain.Accrual, 0, len(rows))
+	for _, row := range rows {
+		accrual, err := toDomainAccrual(row)
+		if err != nil {
+			return nil, err
+		}
+		accruals = append(accruals, accrual)
+	}
+	return accruals, nil
+}
+
+// Post records the accrual and its charge, with the journal entry and the
+// balance update, in one database transaction. An accrual already posted
+// for the same account, kind and day is rejected with ErrAccrualPosted.
+func (r *PostgresAccrualRepository) Post(ctx context.Context, accrual *accountDomain.Accrual, charge *domain.Transaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	accounts, err := postTransactions(ctx, qtx, []*domain.Transaction{charge})
+	if err != nil {
+		return err
+	}
+
+	accrual.TransactionID = charge.ID
+	_, err = qtx.CreateCardAccrual(ctx, sqlc.CreateCardAccrualParams{
+		ID:            accrual.ID,
+		AccountID:     accrual.AccountID,
+		Kind:          accrual.Kind,
+		AccrualDate:   accrual.AccrualDate,
+		StatementDate: accrual.StatementDate,
+		Basis:         strconv.FormatFloat(accrual.Basis, 'f', 2, 64),
+		DailyRate:     strconv.FormatFloat(accrual.DailyRate, 'f', 10, 64),
+		Amount:        strconv.FormatFloat(accrual.Amount, 'f', 2, 64),
+		TransactionID: accrual.TransactionID,
+		CreatedAt:     accrual.CreatedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return accountDomain.ErrAccrualPosted
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Publish messages to NATS
+	return publishPosted(r.nats, []*domain.Transaction{charge}, accounts)
+}
+
+func toDomainAccrual(row sqlc.CardAccrual) (*accountDomain.Accrual, error) {
+	basis, err := strconv.ParseFloat(row.Basis, 64)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := strconv.ParseFloat(row.DailyRate, 64)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountDomain.Accrual{
+		ID:            row.ID,
+		AccountID:     row.AccountID,
+		Kind:          row.Kind,
+		AccrualDate:   row.AccrualDate.UTC(),
+		StatementDate: row.StatementDate.UTC(),
+		Basis:         basis,
+		DailyRate:     rate,
+		Amount:        amount,
+		TransactionID: row.TransactionID,
+		CreatedAt:     row.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresAnnotationRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresAnnotationRepository(db *sql.DB, nc *nats.NatsClient) ports.AnnotationRepository {
+	return &PostgresAnnotationRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Annotate replaces the tags and note of a transaction. Annotations are
+// informational and never touch amounts or the ledger.
+func (r *PostgresAnnotationRepository) Annotate(ctx context.Context, transactionID uuid.UUID, tags []string, note string) (*domain.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	transaction, err := lockTransaction(ctx, qtx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transaction.Annotate(tags, note); err != nil {
+		return nil, err
+	}
+
+	err = qtx.UpdateTransactionNote(ctx, sqlc.UpdateTransactionNoteParams{
+		ID:        transaction.ID,
+		Note:      transaction.Note,
+		UpdatedAt: transaction.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := qtx.DeleteTransactionTags(ctx, transaction.ID); err != nil {
+		return nil, err
+	}
+	for _, tag := range transaction.Tags {
+		err := qtx.AddTransactionTag(ctx, sqlc.AddTransactionTagParams{
+			TransactionID: transaction.ID,
+			Tag:           tag,
+			CreatedAt:     transaction.UpdatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish a message to NATS
+	if err := r.nats.Publish(domain.TransactionAnnotatedEvent, transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
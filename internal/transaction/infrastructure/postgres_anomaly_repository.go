@@ -0,0 +1,280 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresAnomalyRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresAnomalyRepository(db *sql.DB, nc *nats.NatsClient) ports.AnomalyRepository {
+	return &PostgresAnomalyRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// History gathers what the account history says about t. Amounts and
+// merchants are compared with what happened before the transaction was
+// authorized, bursts with the debits shortly before it and duplicates with
+// the same charge on either side of it.
+func (r *PostgresAnomalyRepository) History(ctx context.Context, t *domain.Transaction, policy domain.AnomalyPolicy) (domain.AnomalyHistory, error) {
+	var history domain.AnomalyHistory
+
+	stats, err := r.queries.GetAnomalyAmountStats(ctx, sqlc.GetAnomalyAmountStatsParams{
+		AccountID: t.AccountID,
+		Since:     t.AuthorizedAt.Add(-domain.AnomalyHistoryWindow),
+		Before:    t.AuthorizedAt,
+	})
+	if err != nil {
+		return history, err
+	}
+	history.DebitCount = int(stats.DebitCount)
+	history.Mean = stats.Mean
+	history.StdDev = stats.Stddev
+
+	if t.Merchant != "" {
+		count, err := r.queries.CountMerchantHistory(ctx, sqlc.CountMerchantHistoryParams{
+			AccountID: t.AccountID,
+			Merchant:  t.Merchant,
+			Before:    t.AuthorizedAt,
+		})
+		if err != nil {
+			return history, err
+		}
+		history.MerchantCount = int(count)
+	}
+
+	recent, err := r.queries.CountDebitsBetween(ctx, sqlc.CountDebitsBetweenParams{
+		AccountID: t.AccountID,
+		ExcludeID: t.ID,
+		Since:     t.AuthorizedAt.Add(-policy.BurstWindow),
+		Until:     t.AuthorizedAt,
+	})
+	if err != nil {
+		return history, err
+	}
+	history.RecentDebits = int(recent)
+
+	history.Duplicates, err = r.queries.ListDuplicateCharges(ctx, sqlc.ListDuplicateChargesParams{
+		AccountID:   t.AccountID,
+		Amount:      strconv.FormatFloat(t.Amount, 'f', -1, 64),
+		Merchant:    t.Merchant,
+		Description: t.Description,
+		ExcludeID:   t.ID,
+		Since:       t.AuthorizedAt.Add(-policy.DuplicateWindow),
+		Until:       t.AuthorizedAt.Add(policy.DuplicateWindow),
+	})
+	return history, err
+}
+
+// Create stores an alert with its reasons. It reports false when the
+// transaction was already flagged, e.g. by another worker.
+func (r *PostgresAnomalyRepository) Create(ctx context.Context, alert *domain.TransactionAlert) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	_, err = qtx.CreateTransactionAlert(ctx, sqlc.CreateTransactionAlertParams{
+		ID:            alert.ID,
+		TransactionID: alert.TransactionID,
+		AccountID:     alert.AccountID,
+		Score:         strconv.FormatFloat(alert.Score, 'f', 2, 64),
+		Status:        alert.Status,
+		CreatedAt:     alert.CreatedAt,
+		UpdatedAt:     alert.UpdatedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, reason := range alert.Reasons {
+		err := qtx.CreateTransactionAlertReason(ctx, sqlc.CreateTransactionAlertReasonParams{
+			AlertID: alert.ID,
+			Code:    reason.Code,
+			Weight:  strconv.FormatFloat(reason.Weight, 'f', 2, 64),
+			Detail:  reason.Detail,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	// Publish messages to NATS
+	if err := r.nats.Publish(domain.TransactionAlertCreatedEvent, alert); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+func (r *PostgresAnomalyRepository) Get(ctx context.Context, id uuid.UUID) (*domain.TransactionAlert, error) {
+	row, err := r.queries.GetTransactionAlert(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTransactionAlertNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loadTransactionAlert(ctx, r.queries, row)
+}
+
+// ListByStatus pages through the alerts in a status, highest score first,
+// which for open alerts is the review queue.
+func (r *PostgresAnomalyRepository) ListByStatus(ctx context.Context, status string, limit, offset int64) ([]*domain.TransactionAlert, error) {
+	rows, err := r.queries.ListTransactionAlertsByStatus(ctx, sqlc.ListTransactionAlertsByStatusParams{
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadTransactionAlerts(ctx, r.queries, rows)
+}
+
+func (r *PostgresAnomalyRepository) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.TransactionAlert, error) {
+	if _, err := r.queries.GetAccount(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.queries.ListTransactionAlertsByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return loadTransactionAlerts(ctx, r.queries, rows)
+}
+
+// Review applies a review decision with the alert row locked so that two
+// reviewers cannot close the same alert.
+func (r *PostgresAnomalyRepository) Review(ctx context.Context, id uuid.UUID, review func(a *domain.TransactionAlert) error) (*domain.TransactionAlert, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	row, err := qtx.GetTransactionAlertForUpdate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTransactionAlertNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	alert, err := loadTransactionAlert(ctx, qtx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := review(alert); err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.ReviewTransactionAlert(ctx, sqlc.ReviewTransactionAlertParams{
+		ID:         alert.ID,
+		Status:     alert.Status,
+		ReviewedBy: alert.ReviewedBy,
+		ReviewNote: alert.ReviewNote,
+		ReviewedAt: alert.ReviewedAt,
+		UpdatedAt:  alert.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	if err := r.nats.Publish(domain.TransactionAlertReviewedEvent, alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func loadTransactionAlerts(ctx context.Context, q *sqlc.Queries, rows []sqlc.TransactionAlert) ([]*domain.TransactionAlert, error) {
+	alerts := make([]*domain.TransactionAlert, 0, len(rows))
+	for _, row := range rows {
+		alert, err := loadTransactionAlert(ctx, q, row)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
+
+// loadTransactionAlert reads the reasons and the flagged transaction of an
+// alert.
+func loadTransactionAlert(ctx context.Context, q *sqlc.Queries, row sqlc.TransactionAlert) (*domain.TransactionAlert, error) {
+	score, err := strconv.ParseFloat(row.Score, 64)
+	if err != nil {
+		return nil, err
+	}
+	alert := &domain.TransactionAlert{
+		ID:            row.ID,
+		TransactionID: row.TransactionID,
+		AccountID:     row.AccountID,
+		Score:         score,
+		Status:        row.Status,
+		ReviewedBy:    row.ReviewedBy,
+		ReviewNote:    row.ReviewNote,
+		ReviewedAt:    row.ReviewedAt,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+
+	reasons, err := q.ListTransactionAlertReasons(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, reason := range reasons {
+		weight, err := strconv.ParseFloat(reason.Weight, 64)
+		if err != nil {
+			return nil, err
+		}
+		alert.Reasons = append(alert.Reasons, domain.AnomalyReason{
+			Code:   reason.Code,
+			Weight: weight,
+			Detail: reason.Detail,
+		})
+	}
+
+	transaction, err := q.GetTransaction(ctx, row.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	alert.Transaction, err = toDomainTransaction(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
@@ -0,0 +1,223 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresBudgetRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresBudgetRepository(db *sql.DB, nc *nats.NatsClient) ports.BudgetRepository {
+	return &PostgresBudgetRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Save creates the budget or, when the account already has one for the
+// category, replaces its amount.
+func (r *PostgresBudgetRepository) Save(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	if _, err := r.queries.GetAccount(ctx, budget.AccountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.UpsertBudget(ctx, sqlc.UpsertBudgetParams{
+		ID:        budget.ID,
+		AccountID: budget.AccountID,
+		Category:  budget.Category,
+		Amount:    strconv.FormatFloat(budget.Amount, 'f', 2, 64),
+		CreatedAt: budget.CreatedAt,
+		UpdatedAt: budget.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBudget(row)
+}
+
+func (r *PostgresBudgetRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Budget, error) {
+	row, err := r.queries.GetBudget(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrBudgetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBudget(row)
+}
+
+// Delete removes a budget along with its alerts.
+func (r *PostgresBudgetRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if _, err := qtx.GetBudget(ctx, id); errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrBudgetNotFound
+	} else if err != nil {
+		return err
+	}
+	if err := qtx.DeleteBudgetAlerts(ctx, id); err != nil {
+		return err
+	}
+	if err := qtx.DeleteBudget(ctx, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresBudgetRepository) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Budget, error) {
+	if _, err := r.queries.GetAccount(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return listBudgets(ctx, r.queries, accountID)
+}
+
+// Spending reports the spend per category of the calendar month starting
+// on month.
+func (r *PostgresBudgetRepository) Spending(ctx context.Context, accountID uuid.UUID, month time.Time) (map[string]float64, error) {
+	return categorySpend(ctx, r.queries, accountID, month)
+}
+
+// Track compares the budgets of an account with the spend of a month and
+// records the alerts of the thresholds reached. The account row is locked
+// so that concurrent transactions do not alert twice. Only the highest
+// threshold newly reached by each budget is published, so that a large
+// purchase crossing several thresholds notifies once.
+func (r *PostgresBudgetRepository) Track(ctx context.Context, accountID uuid.UUID, month time.Time, evaluate func(budgets []*domain.Budget, spent map[string]float64) []*domain.BudgetAlert) ([]*domain.BudgetAlert, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if _, err := qtx.GetAccountForUpdate(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	budgets, err := listBudgets(ctx, qtx, accountID)
+	if err != nil || len(budgets) == 0 {
+		return nil, err
+	}
+	spent, err := categorySpend(ctx, qtx, accountID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	highest := make(map[uuid.UUID]*domain.BudgetAlert)
+	var order []uuid.UUID
+	for _, alert := range evaluate(budgets, spent) {
+		_, err := qtx.CreateBudgetAlert(ctx, sqlc.CreateBudgetAlertParams{
+			ID:        alert.ID,
+			BudgetID:  alert.BudgetID,
+			AccountID: alert.AccountID,
+			Month:     alert.Month,
+			Threshold: int32(alert.Threshold),
+			Spent:     strconv.FormatFloat(alert.Spent, 'f', 2, 64),
+			CreatedAt: alert.CreatedAt,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if previous, ok := highest[alert.BudgetID]; !ok {
+			order = append(order, alert.BudgetID)
+			highest[alert.BudgetID] = alert
+		} else if alert.Threshold > previous.Threshold {
+			highest[alert.BudgetID] = alert
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	alerts := make([]*domain.BudgetAlert, 0, len(order))
+	for _, id := range order {
+		if err := r.nats.Publish(domain.BudgetAlertCreatedEvent, highest[id]); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, highest[id])
+	}
+	return alerts, nil
+}
+
+func listBudgets(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID) ([]*domain.Budget, error) {
+	rows, err := q.ListBudgetsByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := make([]*domain.Budget, 0, len(rows))
+	for _, row := range rows {
+		b, err := toDomainBudget(row)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, b)
+	}
+	return budgets, nil
+}
+
+func categorySpend(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID, month time.Time) (map[string]float64, error) {
+	start := domain.MonthStart(month)
+	rows, err := q.ListCategorySpend(ctx, sqlc.ListCategorySpendParams{
+		AccountID:  accountID,
+		MonthStart: start,
+		MonthEnd:   start.AddDate(0, 1, 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spent := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		spent[row.Category] = row.Spent
+	}
+	return spent, nil
+}
+
+func toDomainBudget(row sqlc.Budget) (*domain.Budget, error) {
+	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Budget{
+		ID:        row.ID,
+		AccountID: row.AccountID,
+		Category:  row.Category,
+		Amount:    amount,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}, nil
+}
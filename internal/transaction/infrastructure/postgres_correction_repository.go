@@ -0,0 +1,276 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresCorrectionRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresCorrectionRepository(db *sql.DB, nc *nats.NatsClient) ports.CorrectionRepository {
+	return &PostgresCorrectionRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Correct locks the current version of the transaction, applies the
+// correction and stores the result as a new version superseding it, along
+// with the correction record and its ledger adjustment, in a single database
+// transaction. The corrected row is never edited but for the link to its
+// successor; splits, tags, refunds, disputes and reward entries follow the
+// new version.
+func (r *PostgresCorrectionRepository) Correct(ctx context.Context, transactionID uuid.UUID, apply ports.CorrectionFunc) (*domain.Transaction, *domain.Correction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	transaction, err := lockTransaction(ctx, qtx, transactionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	transaction.Splits, err = listSplits(ctx, qtx, transaction.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	previous := *transaction
+
+	correction, err := apply(transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+	transaction.Supersede(&previous, correction)
+
+	_, err = qtx.CreateTransactionVersion(ctx, sqlc.CreateTransactionVersionParams{
+		ID:                transaction.ID,
+		AccountID:         transaction.AccountID,
+		Amount:            strconv.FormatFloat(transaction.Amount, 'f', -1, 64),
+		Type:              transaction.Type,
+		InputFileID:       transaction.InputFileID,
+		InputDate:         transaction.InputDate,
+		CreatedAt:         transaction.CreatedAt,
+		Description:       transaction.Description,
+		Merchant:          transaction.Merchant,
+		Category:          transaction.Category,
+		TransferID:        transaction.TransferID,
+		Voided:            transaction.Voided,
+		UpdatedAt:         transaction.UpdatedAt,
+		ReversalOf:        transaction.ReversalOf,
+		ReversalKind:      transaction.ReversalKind,
+		Status:            transaction.Status,
+		AuthorizedAt:      transaction.AuthorizedAt,
+		PostedAt:          nullTime(transaction.PostedAt),
+		Note:              transaction.Note,
+		InstallmentPlanID: transaction.InstallmentPlanID,
+		Supersedes:        transaction.Supersedes,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	superseded, err := qtx.MarkTransactionSuperseded(ctx, sqlc.MarkTransactionSupersededParams{
+		ID:           previous.ID,
+		SupersededBy: previous.SupersededBy,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if superseded != 1 {
+		return nil, nil, fmt.Errorf("transaction %s was superseded concurrently", previous.ID)
+	}
+
+	reversals, err := r.moveLinks(ctx, qtx, previous.ID, transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := newBalanceChanges()
+	if entry := domain.NewCorrectionEntry(transaction, correction); entry != nil {
+		if err := insertJournalEntry(ctx, qtx, entry, changes); err != nil {
+			return nil, nil, fmt.Errorf("failed to post correction for transaction %s: %w", previous.ID, err)
+		}
+		correction.JournalEntryID = uuid.NullUUID{UUID: entry.ID, Valid: true}
+	}
+	changes.touch(transaction)
+	accounts, err := applyBalanceChanges(ctx, qtx, changes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := insertCorrection(ctx, qtx, correction); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	// Publish messages to NATS
+	if err := r.nats.Publish(domain.TransactionUpdatedEvent, &previous); err != nil {
+		return nil, nil, err
+	}
+	if err := r.nats.Publish(domain.TransactionUpdatedEvent, transaction); err != nil {
+		return nil, nil, err
+	}
+	for _, reversal := range reversals {
+		if err := r.nats.Publish(domain.TransactionUpdatedEvent, reversal); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, a := range accounts {
+		if err := r.nats.Publish(accountDomain.AccountUpdatedEvent, a); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := r.nats.Publish(domain.TransactionCorrectedEvent, correction); err != nil {
+		return nil, nil, err
+	}
+	return transaction, correction, nil
+}
+
+// moveLinks carries what belongs to the transaction rather than to one of its
+// versions over to the new version, reloading its splits and tags, and
+// returns the refunds and dispute movements now pointing at it.
+func (r *PostgresCorrectionRepository) moveLinks(ctx context.Context, q *sqlc.Queries, fromID uuid.UUID, version *domain.Transaction) ([]*domain.Transaction, error) {
+	var err error
+	if err = q.CopyTransactionSplits(ctx, sqlc.CopyTransactionSplitsParams{ToID: version.ID, FromID: fromID}); err != nil {
+		return nil, err
+	}
+	if version.Splits, err = listSplits(ctx, q, version.ID); err != nil {
+		return nil, err
+	}
+	if err = q.CopyTransactionTags(ctx, sqlc.CopyTransactionTagsParams{ToID: version.ID, FromID: fromID}); err != nil {
+		return nil, err
+	}
+	if version.Tags, err = q.ListTransactionTags(ctx, version.ID); err != nil {
+		return nil, err
+	}
+	if err = q.MoveTransactionDisputes(ctx, sqlc.MoveTransactionDisputesParams{ToID: version.ID, FromID: fromID}); err != nil {
+		return nil, err
+	}
+	if err = q.MoveTransactionRewardEntries(ctx, sqlc.MoveTransactionRewardEntriesParams{ToID: version.ID, FromID: fromID}); err != nil {
+		return nil, err
+	}
+
+	rows, err := q.MoveTransactionReversals(ctx, sqlc.MoveTransactionReversalsParams{
+		ToID:   uuid.NullUUID{UUID: version.ID, Valid: true},
+		FromID: uuid.NullUUID{UUID: fromID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	reversals := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		reversal, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		reversals = append(reversals, reversal)
+	}
+	return reversals, nil
+}
+
+// ListByTransaction returns the corrections of every version of the
+// transaction, oldest first, whichever of its versions is given.
+func (r *PostgresCorrectionRepository) ListByTransaction(ctx context.Context, transactionID uuid.UUID) ([]*domain.Correction, error) {
+	row, err := r.queries.GetTransaction(ctx, transactionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return []*domain.Correction{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for row.Supersedes.Valid {
+		if row, err = r.queries.GetTransaction(ctx, row.Supersedes.UUID); err != nil {
+			return nil, err
+		}
+	}
+
+	corrections := []*domain.Correction{}
+	for {
+		rows, err := r.queries.ListTransactionCorrections(ctx, row.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range rows {
+			correction, err := toDomainCorrection(c)
+			if err != nil {
+				return nil, err
+			}
+			corrections = append(corrections, correction)
+		}
+		if !row.SupersededBy.Valid {
+			return corrections, nil
+		}
+		if row, err = r.queries.GetTransaction(ctx, row.SupersededBy.UUID); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func insertCorrection(ctx context.Context, q *sqlc.Queries, correction *domain.Correction) error {
+	_, err := q.CreateTransactionCorrection(ctx, sqlc.CreateTransactionCorrectionParams{
+		ID:                  correction.ID,
+		TransactionID:       correction.TransactionID,
+		Kind:                correction.Kind,
+		Reason:              correction.Reason,
+		Actor:               correction.Actor,
+		PreviousAmount:      strconv.FormatFloat(correction.PreviousAmount, 'f', 2, 64),
+		NewAmount:           strconv.FormatFloat(correction.NewAmount, 'f', 2, 64),
+		PreviousDescription: correction.PreviousDescription,
+		NewDescription:      correction.NewDescription,
+		PreviousCategory:    correction.PreviousCategory,
+		NewCategory:         correction.NewCategory,
+		JournalEntryID:      correction.JournalEntryID,
+		CreatedAt:           correction.CreatedAt,
+		VersionID:           uuid.NullUUID{UUID: correction.VersionID, Valid: correction.VersionID != uuid.Nil},
+	})
+	return err
+}
+
+func toDomainCorrection(row sqlc.TransactionCorrection) (*domain.Correction, error) {
+	previousAmount, err := strconv.ParseFloat(row.PreviousAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	newAmount, err := strconv.ParseFloat(row.NewAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Correction{
+		ID:                  row.ID,
+		TransactionID:       row.TransactionID,
+		VersionID:           row.VersionID.UUID,
+		Kind:                row.Kind,
+		Reason:              row.Reason,
+		Actor:               row.Actor,
+		PreviousAmount:      previousAmount,
+		NewAmount:           newAmount,
+		PreviousDescription: row.PreviousDescription,
+		NewDescription:      row.NewDescription,
+		PreviousCategory:    row.PreviousCategory,
+		NewCategory:         row.NewCategory,
+		JournalEntryID:      row.JournalEntryID,
+		CreatedAt:           row.CreatedAt,
+	}, nil
+}
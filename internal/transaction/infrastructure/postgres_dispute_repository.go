@@ -0,0 +1,334 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresDisputeRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresDisputeRepository(db *sql.DB, nc *nats.NatsClient) ports.DisputeRepository {
+	return &PostgresDisputeRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Open creates a dispute over a transaction. The transaction row is locked
+// so that a refund linked at the same time is taken into account.
+func (r *PostgresDisputeRepository) Open(ctx context.Context, transactionID uuid.UUID, open func(t *domain.Transaction, refunded float64) (*domain.Dispute, *domain.DisputeEvent, error)) (*domain.Dispute, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	t, err := lockTransaction(ctx, qtx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := qtx.GetOpenDisputeByTransaction(ctx, t.ID); err == nil {
+		return nil, domain.ErrDisputeExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	sum, err := qtx.SumTransactionRefunds(ctx, uuid.NullUUID{UUID: t.ID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	refunded, err := strconv.ParseFloat(sum, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	dispute, event, err := open(t, refunded)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.CreateDispute(ctx, sqlc.CreateDisputeParams{
+		ID:                   dispute.ID,
+		AccountID:            dispute.AccountID,
+		TransactionID:        dispute.TransactionID,
+		Amount:               strconv.FormatFloat(dispute.Amount, 'f', 2, 64),
+		ReasonCode:           dispute.ReasonCode,
+		Description:          dispute.Description,
+		Status:               dispute.Status,
+		ProvisionalCreditDue: dispute.ProvisionalCreditDue,
+		Deadline:             dispute.Deadline,
+		CreatedAt:            dispute.CreatedAt,
+		UpdatedAt:            dispute.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := insertDisputeEvent(ctx, qtx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	change := &domain.DisputeChange{Dispute: dispute, Event: *event}
+	if err := r.nats.Publish(domain.DisputeUpdatedEvent, change); err != nil {
+		return nil, err
+	}
+	return dispute, nil
+}
+
+func (r *PostgresDisputeRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Dispute, error) {
+	row, err := r.queries.GetDispute(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDisputeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loadDispute(ctx, r.queries, row)
+}
+
+func (r *PostgresDisputeRepository) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Dispute, error) {
+	rows, err := r.queries.ListDisputesByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return loadDisputes(ctx, r.queries, rows)
+}
+
+// ListDueForCredit lists the disputes still unresolved without a credit
+// whose provisional credit is due on or before asOf.
+func (r *PostgresDisputeRepository) ListDueForCredit(ctx context.Context, asOf time.Time, limit int64) ([]*domain.Dispute, error) {
+	rows, err := r.queries.ListDisputesDueForCredit(ctx, sqlc.ListDisputesDueForCreditParams{
+		ProvisionalCreditDue: asOf,
+		Limit:                limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadDisputes(ctx, r.queries, rows)
+}
+
+// ListPastDeadline lists the unresolved disputes whose deadline is before
+// asOf.
+func (r *PostgresDisputeRepository) ListPastDeadline(ctx context.Context, asOf time.Time, limit int64) ([]*domain.Dispute, error) {
+	rows, err := r.queries.ListDisputesPastDeadline(ctx, sqlc.ListDisputesPastDeadlineParams{
+		Deadline: asOf,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadDisputes(ctx, r.queries, rows)
+}
+
+// Transition applies a status change with the dispute row locked, posting
+// the credit or reversal it produces in the same database transaction.
+func (r *PostgresDisputeRepository) Transition(ctx context.Context, id uuid.UUID, apply func(d *domain.Dispute) (*domain.DisputeEvent, *domain.Transaction, error)) (*domain.DisputeChange, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	row, err := qtx.GetDisputeForUpdate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDisputeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	dispute, err := loadDispute(ctx, qtx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	event, posted, err := apply(dispute)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []*accountDomain.Account
+	if posted != nil {
+		accounts, err = postTransactions(ctx, qtx, []*domain.Transaction{posted})
+		if err != nil {
+			return nil, err
+		}
+		_, err = qtx.LinkTransactionReversal(ctx, sqlc.LinkTransactionReversalParams{
+			ID:           posted.ID,
+			ReversalOf:   posted.ReversalOf,
+			ReversalKind: posted.ReversalKind,
+			UpdatedAt:    posted.UpdatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = qtx.UpdateDispute(ctx, sqlc.UpdateDisputeParams{
+		ID:                    dispute.ID,
+		Status:                dispute.Status,
+		CreditTransactionID:   dispute.CreditTransactionID,
+		ReversalTransactionID: dispute.ReversalTransactionID,
+		UpdatedAt:             dispute.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := insertDisputeEvent(ctx, qtx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	if posted != nil {
+		if err := publishPosted(r.nats, []*domain.Transaction{posted}, accounts); err != nil {
+			return nil, err
+		}
+	}
+	change := &domain.DisputeChange{Dispute: dispute, Event: *event, Transaction: posted}
+	if err := r.nats.Publish(domain.DisputeUpdatedEvent, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (r *PostgresDisputeRepository) AddAttachment(ctx context.Context, attachment *domain.DisputeAttachment) error {
+	_, err := r.queries.CreateDisputeAttachment(ctx, sqlc.CreateDisputeAttachmentParams{
+		ID:          attachment.ID,
+		DisputeID:   attachment.DisputeID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		BlobKey:     attachment.BlobKey,
+		CreatedAt:   attachment.CreatedAt,
+	})
+	return err
+}
+
+func (r *PostgresDisputeRepository) GetAttachment(ctx context.Context, disputeID, attachmentID uuid.UUID) (*domain.DisputeAttachment, error) {
+	row, err := r.queries.GetDisputeAttachment(ctx, sqlc.GetDisputeAttachmentParams{
+		ID:        attachmentID,
+		DisputeID: disputeID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDisputeAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	attachment := toDomainDisputeAttachment(row)
+	return &attachment, nil
+}
+
+func loadDisputes(ctx context.Context, q *sqlc.Queries, rows []sqlc.Dispute) ([]*domain.Dispute, error) {
+	disputes := make([]*domain.Dispute, 0, len(rows))
+	for _, row := range rows {
+		dispute, err := loadDispute(ctx, q, row)
+		if err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, dispute)
+	}
+	return disputes, nil
+}
+
+func loadDispute(ctx context.Context, q *sqlc.Queries, row sqlc.Dispute) (*domain.Dispute, error) {
+	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	dispute := &domain.Dispute{
+		ID:                    row.ID,
+		AccountID:             row.AccountID,
+		TransactionID:         row.TransactionID,
+		Amount:                amount,
+		ReasonCode:            row.ReasonCode,
+		Description:           row.Description,
+		Status:                row.Status,
+		ProvisionalCreditDue:  row.ProvisionalCreditDue.UTC(),
+		Deadline:              row.Deadline.UTC(),
+		CreditTransactionID:   row.CreditTransactionID,
+		ReversalTransactionID: row.ReversalTransactionID,
+		CreatedAt:             row.CreatedAt,
+		UpdatedAt:             row.UpdatedAt,
+	}
+
+	events, err := q.ListDisputeEvents(ctx, dispute.ID)
+	if err != nil {
+		return nil, err
+	}
+	dispute.Events = make([]domain.DisputeEvent, 0, len(events))
+	for _, e := range events {
+		dispute.Events = append(dispute.Events, domain.DisputeEvent{
+			ID:            e.ID,
+			DisputeID:     e.DisputeID,
+			FromStatus:    e.FromStatus,
+			ToStatus:      e.ToStatus,
+			Note:          e.Note,
+			TransactionID: e.TransactionID,
+			CreatedAt:     e.CreatedAt,
+		})
+	}
+
+	attachments, err := q.ListDisputeAttachments(ctx, dispute.ID)
+	if err != nil {
+		return nil, err
+	}
+	dispute.Attachments = make([]domain.DisputeAttachment, 0, len(attachments))
+	for _, a := range attachments {
+		dispute.Attachments = append(dispute.Attachments, toDomainDisputeAttachment(a))
+	}
+	return dispute, nil
+}
+
+func insertDisputeEvent(ctx context.Context, q *sqlc.Queries, e *domain.DisputeEvent) error {
+	return q.CreateDisputeEvent(ctx, sqlc.CreateDisputeEventParams{
+		ID:            e.ID,
+		DisputeID:     e.DisputeID,
+		FromStatus:    e.FromStatus,
+		ToStatus:      e.ToStatus,
+		Note:          e.Note,
+		TransactionID: e.TransactionID,
+		CreatedAt:     e.CreatedAt,
+	})
+}
+
+func toDomainDisputeAttachment(row sqlc.DisputeAttachment) domain.DisputeAttachment {
+	return domain.DisputeAttachment{
+		ID:          row.ID,
+		DisputeID:   row.DisputeID,
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		Size:        row.Size,
+		BlobKey:     row.BlobKey,
+		CreatedAt:   row.CreatedAt,
+	}
+}
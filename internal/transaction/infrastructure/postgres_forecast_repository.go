@@ -0,0 +1,213 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresForecastRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresForecastRepository(db *sql.DB, nc *nats.NatsClient) ports.ForecastRepository {
+	return &PostgresForecastRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// ListAccounts lists the active accounts with posted transactions on or
+// after since.
+func (r *PostgresForecastRepository) ListAccounts(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	return r.queries.ListForecastAccounts(ctx, since)
+}
+
+// Build forecasts an account from its posted history on or after since and
+// its balance, and replaces its stored forecast. The account row is locked
+// so that the balance matches the history read.
+func (r *PostgresForecastRepository) Build(ctx context.Context, accountID uuid.UUID, since time.Time, build func(history []*domain.Transaction, balance float64) *domain.CashFlowForecast) (*domain.CashFlowForecast, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	account, err := qtx.GetAccountForUpdate(ctx, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	balance, err := strconv.ParseFloat(account.Balance, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := qtx.ListForecastHistory(ctx, sqlc.ListForecastHistoryParams{
+		AccountID: accountID,
+		InputDate: since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		t, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, t)
+	}
+
+	forecast := build(history, balance)
+
+	err = qtx.UpsertForecast(ctx, sqlc.UpsertForecastParams{
+		AccountID:       forecast.AccountID,
+		AsOf:            forecast.AsOf,
+		StartingBalance: strconv.FormatFloat(forecast.StartingBalance, 'f', 2, 64),
+		DailyIncome:     strconv.FormatFloat(forecast.DailyIncome, 'f', 2, 64),
+		DailySpend:      strconv.FormatFloat(forecast.DailySpend, 'f', 2, 64),
+		DailyDeviation:  strconv.FormatFloat(forecast.DailyDeviation, 'f', 2, 64),
+		GeneratedAt:     forecast.GeneratedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := qtx.DeleteForecastPoints(ctx, accountID); err != nil {
+		return nil, err
+	}
+	for _, p := range forecast.Points {
+		err := qtx.CreateForecastPoint(ctx, sqlc.CreateForecastPointParams{
+			AccountID: accountID,
+			Day:       p.Date,
+			Expected:  strconv.FormatFloat(p.Expected, 'f', 2, 64),
+			Low:       strconv.FormatFloat(p.Low, 'f', 2, 64),
+			High:      strconv.FormatFloat(p.High, 'f', 2, 64),
+			Recurring: strconv.FormatFloat(p.Recurring, 'f', 2, 64),
+			Baseline:  strconv.FormatFloat(p.Baseline, 'f', 2, 64),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := qtx.DeleteForecastItems(ctx, accountID); err != nil {
+		return nil, err
+	}
+	for _, item := range forecast.Items {
+		err := qtx.CreateForecastItem(ctx, sqlc.CreateForecastItemParams{
+			AccountID: accountID,
+			Day:       item.Date,
+			Name:      item.Name,
+			Category:  item.Category,
+			Cadence:   item.Cadence,
+			Amount:    strconv.FormatFloat(item.Amount, 'f', 2, 64),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return forecast, tx.Commit()
+}
+
+func (r *PostgresForecastRepository) Get(ctx context.Context, accountID uuid.UUID) (*domain.CashFlowForecast, error) {
+	return getForecast(ctx, r.queries, accountID)
+}
+
+func getForecast(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID) (*domain.CashFlowForecast, error) {
+	row, err := q.GetForecast(ctx, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrForecastNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &domain.CashFlowForecast{
+		AccountID:   row.AccountID,
+		AsOf:        row.AsOf.UTC(),
+		GeneratedAt: row.GeneratedAt,
+	}
+	if forecast.StartingBalance, err = strconv.ParseFloat(row.StartingBalance, 64); err != nil {
+		return nil, err
+	}
+	if forecast.DailyIncome, err = strconv.ParseFloat(row.DailyIncome, 64); err != nil {
+		return nil, err
+	}
+	if forecast.DailySpend, err = strconv.ParseFloat(row.DailySpend, 64); err != nil {
+		return nil, err
+	}
+	if forecast.DailyDeviation, err = strconv.ParseFloat(row.DailyDeviation, 64); err != nil {
+		return nil, err
+	}
+
+	points, err := q.ListForecastPoints(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	forecast.Points = make([]domain.ForecastPoint, 0, len(points))
+	for _, p := range points {
+		point, err := toDomainForecastPoint(p)
+		if err != nil {
+			return nil, err
+		}
+		forecast.Points = append(forecast.Points, point)
+	}
+
+	items, err := q.ListForecastItems(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	forecast.Items = make([]domain.ForecastItem, 0, len(items))
+	for _, i := range items {
+		amount, err := strconv.ParseFloat(i.Amount, 64)
+		if err != nil {
+			return nil, err
+		}
+		forecast.Items = append(forecast.Items, domain.ForecastItem{
+			Date:     i.Day.UTC(),
+			Name:     i.Name,
+			Category: i.Category,
+			Cadence:  i.Cadence,
+			Amount:   amount,
+		})
+	}
+	return forecast, nil
+}
+
+func toDomainForecastPoint(row sqlc.CashFlowForecastPoint) (domain.ForecastPoint, error) {
+	point := domain.ForecastPoint{Date: row.Day.UTC()}
+	var err error
+	if point.Expected, err = strconv.ParseFloat(row.Expected, 64); err != nil {
+		return point, err
+	}
+	if point.Low, err = strconv.ParseFloat(row.Low, 64); err != nil {
+		return point, err
+	}
+	if point.High, err = strconv.ParseFloat(row.High, 64); err != nil {
+		return point, err
+	}
+	if point.Recurring, err = strconv.ParseFloat(row.Recurring, 64); err != nil {
+		return point, err
+	}
+	point.Baseline, err = strconv.ParseFloat(row.Baseline, 64)
+	return point, err
+}
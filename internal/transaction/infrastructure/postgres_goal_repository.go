@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresGoalRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresGoalRepository(db *sql.DB, nc *nats.NatsClient) ports.GoalRepository {
+	return &PostgresGoalRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+func (r *PostgresGoalRepository) Create(ctx context.Context, goal *domain.SavingsGoal) (*domain.SavingsGoal, error) {
+	if _, err := r.queries.GetAccount(ctx, goal.AccountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.CreateSavingsGoal(ctx, sqlc.CreateSavingsGoalParams{
+		ID:           goal.ID,
+		AccountID:    goal.AccountID,
+		Name:         goal.Name,
+		TargetAmount: strconv.FormatFloat(goal.TargetAmount, 'f', 2, 64),
+		TargetDate:   goal.TargetDate,
+		Category:     goal.Category,
+		StartDate:    goal.StartDate,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainSavingsGoal(row)
+}
+
+func (r *PostgresGoalRepository) Get(ctx context.Context, id uuid.UUID) (*domain.SavingsGoal, error) {
+	row, err := r.queries.GetSavingsGoal(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrSavingsGoalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainSavingsGoal(row)
+}
+
+func (r *PostgresGoalRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.queries.GetSavingsGoal(ctx, id); errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrSavingsGoalNotFound
+	} else if err != nil {
+		return err
+	}
+	return r.queries.DeleteSavingsGoal(ctx, id)
+}
+
+func (r *PostgresGoalRepository) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.SavingsGoal, error) {
+	if _, err := r.queries.GetAccount(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return listGoals(ctx, r.queries, accountID)
+}
+
+// Progress reads the contributions to the goal and computes its progress
+// as of now.
+func (r *PostgresGoalRepository) Progress(ctx context.Context, goal *domain.SavingsGoal, now time.Time) (domain.GoalProgress, error) {
+	return goalProgress(ctx, r.queries, goal, now)
+}
+
+func listGoals(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID) ([]*domain.SavingsGoal, error) {
+	rows, err := q.ListSavingsGoalsByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	goals := make([]*domain.SavingsGoal, 0, len(rows))
+	for _, row := range rows {
+		g, err := toDomainSavingsGoal(row)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, g)
+	}
+	return goals, nil
+}
+
+// goalProgress sums the contributions of a goal: the transactions of its
+// category or, without one, the net flow of the account.
+func goalProgress(ctx context.Context, q *sqlc.Queries, goal *domain.SavingsGoal, now time.Time) (domain.GoalProgress, error) {
+	var saved, recent float64
+	if goal.Category == "" {
+		row, err := q.GetGoalNetSavings(ctx, sqlc.GetGoalNetSavingsParams{
+			AccountID:   goal.AccountID,
+			Since:       goal.StartDate,
+			RecentSince: goal.RateSince(now),
+		})
+		if err != nil {
+			return domain.GoalProgress{}, err
+		}
+		saved, recent = row.Saved, row.Recent
+	} else {
+		row, err := q.GetGoalCategoryContributions(ctx, sqlc.GetGoalCategoryContributionsParams{
+			AccountID:   goal.AccountID,
+			Category:    goal.Category,
+			Since:       goal.StartDate,
+			RecentSince: goal.RateSince(now),
+		})
+		if err != nil {
+			return domain.GoalProgress{}, err
+		}
+		saved, recent = row.Saved, row.Recent
+	}
+	return goal.Progress(saved, recent, now), nil
+}
+
+func toDomainSavingsGoal(row sqlc.SavingsGoal) (*domain.SavingsGoal, error) {
+	target, err := strconv.ParseFloat(row.TargetAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SavingsGoal{
+		ID:           row.ID,
+		AccountID:    row.AccountID,
+		Name:         row.Name,
+		TargetAmount: target,
+		TargetDate:   row.TargetDate.UTC(),
+		Category:     row.Category,
+		StartDate:    row.StartDate.UTC(),
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,355 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresInstallmentRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresInstallmentRepository(db *sql.DB, nc *nats.NatsClient) ports.InstallmentRepository {
+	return &PostgresInstallmentRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Create converts a purchase of the open cycle of a card account into an
+// installment plan. The first installment is due when that cycle closes.
+func (r *PostgresInstallmentRepository) Create(ctx context.Context, transactionID uuid.UUID, count int, annualRate float64, now time.Time) (*domain.InstallmentPlan, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	purchase, err := lockTransaction(ctx, qtx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountRow, err := qtx.GetAccount(ctx, purchase.AccountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, accountDomain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	account, err := toAccountEvent(accountRow)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsCreditCard() {
+		return nil, accountDomain.ErrNotCreditCard
+	}
+	if purchase.InputDate.Before(account.LastClosingDate(now).AddDate(0, 0, 1)) {
+		return nil, domain.ErrInstallmentPurchaseBilled
+	}
+
+	plan, conversion, err := domain.NewInstallmentPlan(purchase, count, annualRate, account.NextClosingDate(now))
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := postTransactions(ctx, qtx, []*domain.Transaction{conversion})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.CreateInstallmentPlan(ctx, sqlc.CreateInstallmentPlanParams{
+		ID:                      plan.ID,
+		AccountID:               plan.AccountID,
+		TransactionID:           plan.TransactionID,
+		ConversionTransactionID: plan.ConversionTransactionID,
+		Principal:               strconv.FormatFloat(plan.Principal, 'f', 2, 64),
+		InstallmentCount:        int32(plan.Count),
+		AnnualRate:              strconv.FormatFloat(plan.AnnualRate, 'f', 6, 64),
+		InstallmentAmount:       strconv.FormatFloat(plan.InstallmentAmount, 'f', 2, 64),
+		TotalInterest:           strconv.FormatFloat(plan.TotalInterest, 'f', 2, 64),
+		Status:                  plan.Status,
+		CreatedAt:               plan.CreatedAt,
+		UpdatedAt:               plan.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range plan.Installments {
+		err := qtx.CreateInstallment(ctx, sqlc.CreateInstallmentParams{
+			ID:        i.ID,
+			PlanID:    i.PlanID,
+			Number:    int32(i.Number),
+			DueDate:   i.DueDate,
+			Principal: strconv.FormatFloat(i.Principal, 'f', 2, 64),
+			Interest:  strconv.FormatFloat(i.Interest, 'f', 2, 64),
+			Amount:    strconv.FormatFloat(i.Amount, 'f', 2, 64),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = qtx.SetTransactionInstallmentPlan(ctx, sqlc.SetTransactionInstallmentPlanParams{
+		ID:                purchase.ID,
+		InstallmentPlanID: purchase.InstallmentPlanID,
+		UpdatedAt:         purchase.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	if err := publishPosted(r.nats, []*domain.Transaction{conversion}, accounts); err != nil {
+		return nil, err
+	}
+	if err := r.nats.Publish(domain.TransactionUpdatedEvent, purchase); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (r *PostgresInstallmentRepository) Get(ctx context.Context, id uuid.UUID) (*domain.InstallmentPlan, error) {
+	row, err := r.queries.GetInstallmentPlan(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrInstallmentPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loadInstallmentPlan(ctx, r.queries, row)
+}
+
+func (r *PostgresInstallmentRepository) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.InstallmentPlan, error) {
+	rows, err := r.queries.ListInstallmentPlansByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*domain.InstallmentPlan, 0, len(rows))
+	for _, row := range rows {
+		plan, err := loadInstallmentPlan(ctx, r.queries, row)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func (r *PostgresInstallmentRepository) ListDue(ctx context.Context, asOf time.Time, limit int64) ([]domain.Installment, error) {
+	rows, err := r.queries.ListDueInstallments(ctx, sqlc.ListDueInstallmentsParams{
+		DueDate: asOf,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	installments := make([]domain.Installment, 0, len(rows))
+	for _, row := range rows {
+		installment, err := toDomainInstallment(row)
+		if err != nil {
+			return nil, err
+		}
+		installments = append(installments, installment)
+	}
+	return installments, nil
+}
+
+// Post bills one installment on its due date. The plan row is locked so
+// that concurrent workers post each installment once; an installment that
+// is already posted returns a nil transaction.
+func (r *PostgresInstallmentRepository) Post(ctx context.Context, installment domain.Installment) (*domain.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	row, err := qtx.GetInstallmentPlanForUpdate(ctx, installment.PlanID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrInstallmentPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan, err := loadInstallmentPlan(ctx, qtx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	var due *domain.Installment
+	for n := range plan.Installments {
+		if plan.Installments[n].ID == installment.ID {
+			due = &plan.Installments[n]
+		}
+	}
+	if due == nil || due.TransactionID.Valid {
+		return nil, nil
+	}
+
+	purchaseRow, err := qtx.GetTransaction(ctx, plan.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	purchase, err := toDomainTransaction(purchaseRow)
+	if err != nil {
+		return nil, err
+	}
+
+	charge := domain.NewInstallmentCharge(plan, purchase, *due)
+	if err := insertTransaction(ctx, qtx, charge); err != nil {
+		return nil, err
+	}
+	changes := newBalanceChanges()
+	if err := insertJournalEntry(ctx, qtx, domain.NewInstallmentEntry(charge, *due), changes); err != nil {
+		return nil, err
+	}
+	changes.touch(charge)
+	accounts, err := applyBalanceChanges(ctx, qtx, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.MarkInstallmentPosted(ctx, sqlc.MarkInstallmentPostedParams{
+		ID:            due.ID,
+		TransactionID: uuid.NullUUID{UUID: charge.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan.MarkPosted(due.Number, charge.ID)
+	err = qtx.UpdateInstallmentPlanStatus(ctx, sqlc.UpdateInstallmentPlanStatusParams{
+		ID:        plan.ID,
+		Status:    plan.Status,
+		UpdatedAt: plan.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	if err := publishPosted(r.nats, []*domain.Transaction{charge}, accounts); err != nil {
+		return nil, err
+	}
+	return charge, nil
+}
+
+// GetRemainingBalance sums the installments of the account not billed yet.
+func (r *PostgresInstallmentRepository) GetRemainingBalance(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	remaining, err := r.queries.GetAccountInstallmentBalance(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(remaining, 64)
+}
+
+func loadInstallmentPlan(ctx context.Context, q *sqlc.Queries, row sqlc.InstallmentPlan) (*domain.InstallmentPlan, error) {
+	plan, err := toDomainInstallmentPlan(row)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.ListPlanInstallments(ctx, plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	plan.Installments = make([]domain.Installment, 0, len(rows))
+	for _, row := range rows {
+		installment, err := toDomainInstallment(row)
+		if err != nil {
+			return nil, err
+		}
+		plan.Installments = append(plan.Installments, installment)
+	}
+	return plan, nil
+}
+
+func toDomainInstallmentPlan(row sqlc.InstallmentPlan) (*domain.InstallmentPlan, error) {
+	principal, err := strconv.ParseFloat(row.Principal, 64)
+	if err != nil {
+		return nil, err
+	}
+	annualRate, err := strconv.ParseFloat(row.AnnualRate, 64)
+	if err != nil {
+		return nil, err
+	}
+	installmentAmount, err := strconv.ParseFloat(row.InstallmentAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	totalInterest, err := strconv.ParseFloat(row.TotalInterest, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.InstallmentPlan{
+		ID:                      row.ID,
+		AccountID:               row.AccountID,
+		TransactionID:           row.TransactionID,
+		ConversionTransactionID: row.ConversionTransactionID,
+		Principal:               principal,
+		Count:                   int(row.InstallmentCount),
+		AnnualRate:              annualRate,
+		InstallmentAmount:       installmentAmount,
+		TotalInterest:           totalInterest,
+		Status:                  row.Status,
+		CreatedAt:               row.CreatedAt,
+		UpdatedAt:               row.UpdatedAt,
+	}, nil
+}
+
+func toDomainInstallment(row sqlc.Installment) (domain.Installment, error) {
+	principal, err := strconv.ParseFloat(row.Principal, 64)
+	if err != nil {
+		return domain.Installment{}, err
+	}
+	interest, err := strconv.ParseFloat(row.Interest, 64)
+	if err != nil {
+		return domain.Installment{}, err
+	}
+	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if err != nil {
+		return domain.Installment{}, err
+	}
+
+	return domain.Installment{
+		ID:            row.ID,
+		PlanID:        row.PlanID,
+		Number:        int(row.Number),
+		DueDate:       row.DueDate.UTC(),
+		Principal:     principal,
+		Interest:      interest,
+		Amount:        amount,
+		TransactionID: row.TransactionID,
+	}, nil
+}
@@ -0,0 +1,422 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresLedgerRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresLedgerRepository(db *sql.DB, nc *nats.NatsClient) ports.LedgerRepository {
+	return &PostgresLedgerRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// PostEntry stores a balanced journal entry and applies its customer
+// postings to the account balances in the same database transaction.
+func (r *PostgresLedgerRepository) PostEntry(ctx context.Context, entry *domain.JournalEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	changes := newBalanceChanges()
+	if err := insertJournalEntry(ctx, qtx, entry, changes); err != nil {
+		return err
+	}
+
+	accounts, err := applyBalanceChanges(ctx, qtx, changes)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Publish messages to NATS
+	if err := r.nats.Publish(domain.JournalEntryPostedEvent, entry); err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		if err := r.nats.Publish(accountDomain.AccountUpdatedEvent, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PostgresLedgerRepository) GetTrialBalance(ctx context.Context, asOf time.Time) ([]domain.TrialBalanceLine, error) {
+	rows, err := r.queries.GetTrialBalance(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]domain.TrialBalanceLine, 0, len(rows))
+	for _, row := range rows {
+		balance, err := strconv.ParseFloat(row.Balance, 64)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, domain.TrialBalanceLine{
+			Code:    row.Code,
+			Name:    row.Name,
+			Type:    row.Type,
+			Balance: balance,
+		})
+	}
+	return lines, nil
+}
+
+func (r *PostgresLedgerRepository) GetStatement(ctx context.Context, code string, from, to time.Time) (*domain.AccountStatement, error) {
+	ledgerAccount, err := r.queries.GetLedgerAccountByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrLedgerAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := r.queries.GetLedgerBalanceBefore(ctx, sqlc.GetLedgerBalanceBeforeParams{
+		LedgerAccountID: ledgerAccount.ID,
+		EffectiveDate:   from,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &domain.AccountStatement{
+		LedgerAccountCode: code,
+		From:              from,
+		To:                to,
+	}
+	statement.OpeningBalance, err = strconv.ParseFloat(opening, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.queries.ListLedgerPostings(ctx, sqlc.ListLedgerPostingsParams{
+		LedgerAccountID: ledgerAccount.ID,
+		FromDate:        from,
+		ToDate:          to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	running := statement.OpeningBalance
+	statement.Lines = make([]domain.StatementLine, 0, len(rows))
+	for _, row := range rows {
+		amount, err := strconv.ParseFloat(row.Amount, 64)
+		if err != nil {
+			return nil, err
+		}
+		running += amount
+		statement.Lines = append(statement.Lines, domain.StatementLine{
+			PostingID:      row.ID,
+			JournalEntryID: row.JournalEntryID,
+			TransactionID:  row.TransactionID,
+			Description:    row.Description,
+			EffectiveDate:  row.EffectiveDate,
+			Amount:         amount,
+			RunningBalance: running,
+		})
+	}
+	statement.ClosingBalance = running
+
+	return statement, nil
+}
+
+// GetDailyBalances returns the balance of a ledger account for every day
+// from from to to, excluded. Days are served from the daily_balances cache
+// up to its first gap and computed from the postings from there on; the
+// computed days before today are cached, today keeps moving.
+func (r *PostgresLedgerRepository) GetDailyBalances(ctx context.Context, code string, from, to time.Time) ([]domain.DailyBalance, error) {
+	ledgerAccount, err := r.queries.GetLedgerAccountByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrLedgerAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := cachedDailyBalances(ctx, r.queries, ledgerAccount.ID, from, to)
+	if err != nil || len(days) == daysBetween(from, to) {
+		return days, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	// Postings to a customer account lock its account row before clearing
+	// the cache, so the days read and cached below all see the same postings
+	if ledgerAccount.AccountID.Valid {
+		err = qtx.LockAccountForShare(ctx, ledgerAccount.AccountID.UUID)
+	} else {
+		err = qtx.LockLedgerAccount(ctx, ledgerAccount.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	days, err = cachedDailyBalances(ctx, qtx, ledgerAccount.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	start := from.AddDate(0, 0, len(days))
+
+	var opening float64
+	if len(days) > 0 {
+		opening = days[len(days)-1].ClosingBalance
+	} else {
+		balance, err := qtx.GetLedgerBalanceBefore(ctx, sqlc.GetLedgerBalanceBeforeParams{
+			LedgerAccountID: ledgerAccount.ID,
+			EffectiveDate:   start,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if opening, err = strconv.ParseFloat(balance, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := qtx.ListLedgerDailyTotals(ctx, sqlc.ListLedgerDailyTotalsParams{
+		LedgerAccountID: ledgerAccount.ID,
+		FromDate:        start,
+		ToDate:          to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	totals := make([]domain.DailyBalance, 0, len(rows))
+	for _, row := range rows {
+		totals = append(totals, domain.DailyBalance{
+			Date:         row.Day,
+			Credits:      row.Credits,
+			Debits:       row.Debits,
+			PostingCount: int(row.PostingCount),
+		})
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, d := range domain.BuildDailyBalances(start, to, opening, totals) {
+		if d.Date.Before(today) {
+			err := qtx.UpsertDailyBalance(ctx, sqlc.UpsertDailyBalanceParams{
+				LedgerAccountID: ledgerAccount.ID,
+				Day:             d.Date,
+				Credits:         strconv.FormatFloat(d.Credits, 'f', 2, 64),
+				Debits:          strconv.FormatFloat(d.Debits, 'f', 2, 64),
+				ClosingBalance:  strconv.FormatFloat(d.ClosingBalance, 'f', 2, 64),
+				PostingCount:    int32(d.PostingCount),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		days = append(days, d)
+	}
+
+	return days, tx.Commit()
+}
+
+// cachedDailyBalances returns the cached days of a ledger account from
+// from on, up to the first day missing from the cache.
+func cachedDailyBalances(ctx context.Context, q *sqlc.Queries, ledgerAccountID uuid.UUID, from, to time.Time) ([]domain.DailyBalance, error) {
+	rows, err := q.ListDailyBalances(ctx, sqlc.ListDailyBalancesParams{
+		LedgerAccountID: ledgerAccountID,
+		FromDate:        from,
+		ToDate:          to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]domain.DailyBalance, 0, len(rows))
+	day := from
+	for _, row := range rows {
+		if !row.Day.Equal(day) {
+			break
+		}
+		days = append(days, domain.DailyBalance{
+			Date:           day,
+			OpeningBalance: math.Round((row.ClosingBalance-row.Credits-row.Debits)*100) / 100,
+			Credits:        row.Credits,
+			Debits:         row.Debits,
+			ClosingBalance: row.ClosingBalance,
+			PostingCount:   int(row.PostingCount),
+		})
+		day = day.AddDate(0, 0, 1)
+	}
+	return days, nil
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// balanceChanges collects what the postings to customer ledger accounts
+// change: the balance of every account, the cached daily balances of every
+// ledger account from the earliest day posted on and the months of the
+// summary projection of the transactions posted.
+type balanceChanges struct {
+	deltas map[uuid.UUID]float64   // by account
+	from   map[uuid.UUID]time.Time // by ledger account
+	months map[domain.SummaryMonth]struct{}
+}
+
+func newBalanceChanges() *balanceChanges {
+	return &balanceChanges{
+		deltas: make(map[uuid.UUID]float64),
+		from:   make(map[uuid.UUID]time.Time),
+		months: make(map[domain.SummaryMonth]struct{}),
+	}
+}
+
+// touch records that t changed the month of the summary projection it
+// counts in.
+func (c *balanceChanges) touch(t *domain.Transaction) {
+	c.months[domain.SummaryMonthOf(t)] = struct{}{}
+}
+
+// insertJournalEntry writes the entry and its postings and adds the changes
+// of its customer postings to changes. It takes no locks: the changes are
+// applied by applyBalanceChanges.
+func insertJournalEntry(ctx context.Context, q *sqlc.Queries, entry *domain.JournalEntry, changes *balanceChanges) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	_, err := q.CreateJournalEntry(ctx, sqlc.CreateJournalEntryParams{
+		ID:            entry.ID,
+		TransactionID: entry.TransactionID,
+		Description:   entry.Description,
+		EffectiveDate: entry.EffectiveDate,
+		CreatedAt:     entry.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range entry.Postings {
+		ledgerAccount, err := resolveLedgerAccount(ctx, q, p.LedgerAccountCode)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePosting(ctx, sqlc.CreatePostingParams{
+			ID:              p.ID,
+			JournalEntryID:  entry.ID,
+			LedgerAccountID: ledgerAccount.ID,
+			Amount:          strconv.FormatFloat(p.Amount, 'f', 2, 64),
+			CreatedAt:       p.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if ledgerAccount.AccountID.Valid {
+			changes.deltas[ledgerAccount.AccountID.UUID] += p.Amount
+			if from, ok := changes.from[ledgerAccount.ID]; !ok || entry.EffectiveDate.Before(from) {
+				changes.from[ledgerAccount.ID] = entry.EffectiveDate
+			}
+		}
+	}
+	return nil
+}
+
+// applyBalanceChanges moves the account balances, then drops the cached
+// daily balances of their ledger accounts from the earliest day posted on,
+// as a posting moves every later closing balance, and queues the months
+// touched for the summary projection. Account rows are always locked before
+// any cached day or queued month, in every path, so postings never deadlock
+// with each other or with the filling of the cache.
+func applyBalanceChanges(ctx context.Context, q *sqlc.Queries, changes *balanceChanges) ([]*accountDomain.Account, error) {
+	accounts, err := adjustBalances(ctx, q, changes.deltas)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(changes.from))
+	for id := range changes.from {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	for _, id := range ids {
+		err := q.DeleteDailyBalancesFrom(ctx, sqlc.DeleteDailyBalancesFromParams{
+			LedgerAccountID: id,
+			FromDate:        changes.from[id],
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	months := make([]domain.SummaryMonth, 0, len(changes.months))
+	for month := range changes.months {
+		months = append(months, month)
+	}
+	if err := markSummaryStale(ctx, q, months...); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// resolveLedgerAccount opens customer ledger accounts on first use, for
+// accounts that exist. Internal accounts must already exist.
+func resolveLedgerAccount(ctx context.Context, q *sqlc.Queries, code string) (sqlc.LedgerAccount, error) {
+	ledgerAccount, err := q.GetLedgerAccountByCode(ctx, code)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return ledgerAccount, err
+	}
+
+	accountID, isCustomer := domain.ParseCustomerLedgerCode(code)
+	if !isCustomer {
+		return ledgerAccount, fmt.Errorf("%w %q", domain.ErrUnknownLedgerAccount, code)
+	}
+	if _, err := q.GetAccount(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return ledgerAccount, domain.ErrAccountNotFound
+	} else if err != nil {
+		return ledgerAccount, err
+	}
+
+	return q.EnsureLedgerAccount(ctx, sqlc.EnsureLedgerAccountParams{
+		ID:        uuid.New(),
+		Code:      code,
+		Name:      code,
+		Type:      domain.LedgerAccountTypeCustomer,
+		AccountID: uuid.NullUUID{UUID: accountID, Valid: true},
+		CreatedAt: time.Now().UTC().Unix(),
+	})
+}
@@ -0,0 +1,159 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresRefundRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresRefundRepository(db *sql.DB, nc *nats.NatsClient) ports.RefundRepository {
+	return &PostgresRefundRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Link locks the original debit and the refund, checks that the refund fits
+// in what is left of the original and stores the relation.
+func (r *PostgresRefundRepository) Link(ctx context.Context, refundID, originalID uuid.UUID, kind string) (*domain.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	// Lock the original first so that concurrent refunds of the same debit
+	// are serialized.
+	original, err := lockTransaction(ctx, qtx, originalID)
+	if err != nil {
+		return nil, err
+	}
+	refund, err := lockTransaction(ctx, qtx, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	refundedStr, err := qtx.SumTransactionRefunds(ctx, uuid.NullUUID{UUID: original.ID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	refunded, err := strconv.ParseFloat(refundedStr, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := refund.LinkTo(original, refunded, kind); err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.LinkTransactionReversal(ctx, sqlc.LinkTransactionReversalParams{
+		ID:           refund.ID,
+		ReversalOf:   refund.ReversalOf,
+		ReversalKind: refund.ReversalKind,
+		UpdatedAt:    refund.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// A linked credit counts as a refund instead of a credit
+	if err := markSummaryStale(ctx, qtx, domain.SummaryMonthOf(refund)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish a message to NATS
+	if err := r.nats.Publish(domain.TransactionUpdatedEvent, refund); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+func (r *PostgresRefundRepository) FindCandidates(ctx context.Context, refund *domain.Transaction, from, to time.Time) ([]domain.RefundCandidate, error) {
+	rows, err := r.queries.ListRefundCandidates(ctx, sqlc.ListRefundCandidatesParams{
+		AccountID: refund.AccountID,
+		Merchant:  refund.Merchant,
+		FromDate:  from,
+		ToDate:    to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]domain.RefundCandidate, 0, len(rows))
+	for _, row := range rows {
+		transaction, err := toDomainTransaction(row.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		refunded, err := strconv.ParseFloat(row.Refunded, 64)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, domain.RefundCandidate{
+			Transaction: transaction,
+			Refunded:    refunded,
+		})
+	}
+	return candidates, nil
+}
+
+func (r *PostgresRefundRepository) ListUnlinkedCredits(ctx context.Context, afterCreatedAt int64, afterID uuid.UUID, limit int64) ([]*domain.Transaction, error) {
+	rows, err := r.queries.ListUnlinkedCredits(ctx, sqlc.ListUnlinkedCreditsParams{
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		transaction, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// lockTransaction locks the current version of a transaction. A corrected
+// row is followed to the version superseding it, locking each along the way,
+// so that any ID of the transaction addresses its effective state.
+func lockTransaction(ctx context.Context, q *sqlc.Queries, id uuid.UUID) (*domain.Transaction, error) {
+	for {
+		row, err := q.GetTransactionForUpdate(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTransactionNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !row.SupersededBy.Valid {
+			return toDomainTransaction(row)
+		}
+		id = row.SupersededBy.UUID
+	}
+}
@@ -3,8 +3,15 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
@@ -26,42 +33,528 @@ func NewPostgresTransactionRepository(db *sql.DB, nc *nats.NatsClient) ports.Tra
 }
 
 func (r *PostgresTransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) error {
-	_, err := r.queries.CreateTransaction(ctx, sqlc.CreateTransactionParams{
-		ID:          transaction.ID,
-		AccountID:   transaction.AccountID,
-		Amount:      strconv.FormatFloat(transaction.Amount, 'f', -1, 64),
-		Type:        transaction.Type,
-		InputFileID: transaction.InputFileID,
-		InputDate:   transaction.InputDate,
-		CreatedAt:   transaction.CreatedAt,
+	return r.CreateBulk(ctx, []*domain.Transaction{transaction}, nil)
+}
+
+// CreateBulk settles the pending authorizations matched by posted
+// transactions and inserts the other transactions, together with their
+// journal entries, applying the customer postings to the account balances
+// inside a single database transaction: either the whole file is recorded
+// or nothing is. An authorization settled or released concurrently leaves
+// its posted transaction to be inserted as a new one. Once committed, the
+// posted transaction of every settlement is replaced by the settled row and
+// events are published.
+func (r *PostgresTransactionRepository) CreateBulk(ctx context.Context, transactions []*domain.Transaction, settlements []domain.Settlement) error {
+	// Start a transaction
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	// Pending rows are locked in a stable order so that concurrent imports
+	// of an account do not deadlock
+	settlements = append([]domain.Settlement(nil), settlements...)
+	sort.Slice(settlements, func(i, j int) bool {
+		return settlements[i].PendingID.String() < settlements[j].PendingID.String()
 	})
+
+	changes := newBalanceChanges()
+	inserted := transactions
+	settled := make([]*domain.StatusChange, 0, len(settlements))
+	posted := make([]*domain.Transaction, 0, len(settlements))
+	for _, st := range settlements {
+		change, err := settlePending(ctx, qtx, st, changes)
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			inserted = append(inserted, st.Posted)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		settled = append(settled, change)
+		posted = append(posted, st.Posted)
+	}
+
+	if err := insertTransactions(ctx, qtx, inserted, changes); err != nil {
+		return err
+	}
+	accounts, err := applyBalanceChanges(ctx, qtx, changes)
 	if err != nil {
 		return err
 	}
 
-	// Publish a message to NATS
-	return r.publishEvent("transaction.created", transaction)
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for i, change := range settled {
+		*posted[i] = *change.Transaction
+	}
+
+	// Publish messages to NATS
+	for _, change := range settled {
+		if err := r.publishStatusChange(change); err != nil {
+			return err
+		}
+	}
+	return publishPosted(r.nats, inserted, accounts)
 }
 
-func (r *PostgresTransactionRepository) CreateBulk(ctx context.Context, transactions []*domain.Transaction) error {
-	// Start a transaction
+// settlePending posts a pending authorization with the amount and value
+// date of the transaction that settles it and adds its journal entry to
+// changes.
+func settlePending(ctx context.Context, q *sqlc.Queries, settlement domain.Settlement, changes *balanceChanges) (*domain.StatusChange, error) {
+	transaction, err := lockTransaction(ctx, q, settlement.PendingID)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := transaction.Settle(settlement.Posted)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = q.SettleTransaction(ctx, sqlc.SettleTransactionParams{
+		ID:        transaction.ID,
+		Amount:    strconv.FormatFloat(transaction.Amount, 'f', -1, 64),
+		Type:      transaction.Type,
+		InputDate: transaction.InputDate,
+		PostedAt:  nullTime(transaction.PostedAt),
+		UpdatedAt: transaction.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The row that settled the authorization is imported into it
+	if err := recordImportRow(ctx, q, settlement.Posted, transaction.ID); err != nil {
+		return nil, err
+	}
+
+	if err := insertJournalEntry(ctx, q, domain.NewTransactionEntry(transaction), changes); err != nil {
+		return nil, fmt.Errorf("failed to post journal entry for transaction %s: %w", transaction.ID, err)
+	}
+	changes.touch(transaction)
+	return change, nil
+}
+
+// postTransactions inserts the transactions with their journal entries and
+// returns the updated customer accounts.
+func postTransactions(ctx context.Context, q *sqlc.Queries, transactions []*domain.Transaction) ([]*accountDomain.Account, error) {
+	changes := newBalanceChanges()
+	if err := insertTransactions(ctx, q, transactions, changes); err != nil {
+		return nil, err
+	}
+	return applyBalanceChanges(ctx, q, changes)
+}
+
+// insertTransactions inserts the transactions and the journal entries of
+// the posted ones, adding their customer postings to changes.
+func insertTransactions(ctx context.Context, q *sqlc.Queries, transactions []*domain.Transaction, changes *balanceChanges) error {
+	for _, t := range transactions {
+		if err := insertTransaction(ctx, q, t); err != nil {
+			return err
+		}
+		if !t.IsPosted() {
+			// Pending authorizations are posted when they settle
+			continue
+		}
+
+		if err := insertJournalEntry(ctx, q, domain.NewTransactionEntry(t), changes); err != nil {
+			return fmt.Errorf("failed to post journal entry for transaction %s: %w", t.ID, err)
+		}
+		changes.touch(t)
+	}
+	return nil
+}
+
+func publishPosted(nc *nats.NatsClient, transactions []*domain.Transaction, accounts []*accountDomain.Account) error {
+	for _, t := range transactions {
+		if err := nc.Publish(domain.TransactionCreatedEvent, t); err != nil {
+			return err
+		}
+	}
+	for _, a := range accounts {
+		if err := nc.Publish(accountDomain.AccountUpdatedEvent, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertTransaction(ctx context.Context, q *sqlc.Queries, transaction *domain.Transaction) error {
+	_, err := q.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+		ID:                transaction.ID,
+		AccountID:         transaction.AccountID,
+		Amount:            strconv.FormatFloat(transaction.Amount, 'f', -1, 64),
+		Type:              transaction.Type,
+		InputFileID:       transaction.InputFileID,
+		InputDate:         transaction.InputDate,
+		CreatedAt:         transaction.CreatedAt,
+		Description:       transaction.Description,
+		Merchant:          transaction.Merchant,
+		Category:          transaction.Category,
+		TransferID:        transaction.TransferID,
+		UpdatedAt:         transaction.UpdatedAt,
+		Status:            transaction.Status,
+		AuthorizedAt:      transaction.AuthorizedAt,
+		PostedAt:          nullTime(transaction.PostedAt),
+		InstallmentPlanID: transaction.InstallmentPlanID,
+	})
+	if err != nil {
+		return err
+	}
+	return recordImportRow(ctx, q, transaction, transaction.ID)
+}
+
+// recordImportRow remembers the file row a transaction was read from, if
+// any, as imported into transactionID.
+func recordImportRow(ctx context.Context, q *sqlc.Queries, transaction *domain.Transaction, transactionID uuid.UUID) error {
+	if transaction.ImportKey == "" {
+		return nil
+	}
+	return q.CreateImportRow(ctx, sqlc.CreateImportRowParams{
+		AccountID:     transaction.AccountID,
+		ImportKey:     transaction.ImportKey,
+		TransactionID: transactionID,
+		CreatedAt:     time.Now().UTC().Unix(),
+	})
+}
+
+// adjustBalances applies the per-account deltas in a stable order so that
+// concurrent imports lock account rows consistently.
+func adjustBalances(ctx context.Context, q *sqlc.Queries, deltas map[uuid.UUID]float64) ([]*accountDomain.Account, error) {
+	ids := make([]uuid.UUID, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	accounts := make([]*accountDomain.Account, 0, len(ids))
+	for _, id := range ids {
+		row, err := q.AdjustAccountBalance(ctx, sqlc.AdjustAccountBalanceParams{
+			ID:        id,
+			Balance:   strconv.FormatFloat(deltas[id], 'f', 2, 64),
+			UpdatedAt: time.Now().UTC().Unix(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update balance for account %s: %w", id, err)
+		}
+
+		account, err := toAccountEvent(row)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func toAccountEvent(row sqlc.Account) (*accountDomain.Account, error) {
+	balance, err := strconv.ParseFloat(row.Balance, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	creditLimit, err := strconv.ParseFloat(row.CreditLimit, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountDomain.Account{
+		ID:                  row.ID,
+		Nickname:            row.Nickname,
+		Email:               row.Email,
+		Balance:             balance,
+		Type:                row.Type,
+		CreditLimit:         creditLimit,
+		StatementClosingDay: int(row.StatementClosingDay),
+		PaymentDueDays:      int(row.PaymentDueDays),
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           row.UpdatedAt,
+		Active:              row.Active,
+	}, nil
+}
+
+func (r *PostgresTransactionRepository) List(ctx context.Context, limit, offset int64) ([]*domain.Transaction, error) {
+	rows, err := r.queries.ListTransactions(ctx, sqlc.ListTransactionsParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		transaction, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+func (r *PostgresTransactionRepository) UpdateMerchant(ctx context.Context, transaction *domain.Transaction) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	// Use the WithTx method to run queries within the transaction
-	//qtx := r.queries.WithTx(tx)
+	qtx := r.queries.WithTx(tx)
 
-	for _, t := range transactions {
-		err = r.Create(ctx, t)
+	err = qtx.UpdateTransactionMerchant(ctx, sqlc.UpdateTransactionMerchantParams{
+		ID:       transaction.ID,
+		Merchant: transaction.Merchant,
+		Category: transaction.Category,
+	})
+	if err != nil {
+		return err
+	}
+	if err := markSummaryStale(ctx, qtx, domain.SummaryMonthOf(transaction)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Publish a message to NATS
+	return r.publishEvent(domain.TransactionUpdatedEvent, transaction)
+}
+
+func (r *PostgresTransactionRepository) ListPending(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*domain.Transaction, error) {
+	rows, err := r.queries.ListPendingAuthorizations(ctx, sqlc.ListPendingAuthorizationsParams{
+		AccountID: accountID,
+		FromDate:  from,
+		ToDate:    to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		transaction, err := toDomainTransaction(row)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		transactions = append(transactions, transaction)
 	}
+	return transactions, nil
+}
+
+// ListImportedKeys returns the keys among keys of the file rows the
+// account already imported.
+func (r *PostgresTransactionRepository) ListImportedKeys(ctx context.Context, accountID uuid.UUID, keys []string) ([]string, error) {
+	return r.queries.ListImportedKeys(ctx, sqlc.ListImportedKeysParams{
+		AccountID:  accountID,
+		ImportKeys: keys,
+	})
+}
+
+// ReverseAuthorization releases a pending authorization. Nothing was posted
+// for it, so the ledger and balances are untouched.
+func (r *PostgresTransactionRepository) ReverseAuthorization(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	transaction, err := lockTransaction(ctx, qtx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := transaction.ReverseAuthorization()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = qtx.UpdateTransactionStatus(ctx, sqlc.UpdateTransactionStatusParams{
+		ID:        transaction.ID,
+		Status:    transaction.Status,
+		UpdatedAt: transaction.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish a message to NATS
+	if err := r.publishStatusChange(change); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (r *PostgresTransactionRepository) ExpirePending(ctx context.Context, authorizedBefore time.Time) ([]*domain.Transaction, error) {
+	rows, err := r.queries.ExpirePendingTransactions(ctx, sqlc.ExpirePendingTransactionsParams{
+		UpdatedAt:        time.Now().UTC().Unix(),
+		AuthorizedBefore: authorizedBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		transaction, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.publishStatusChange(domain.NewExpiredChange(transaction)); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// GetInstallmentBalance sums the installments of the account not billed
+// yet.
+func (r *PostgresTransactionRepository) GetInstallmentBalance(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	remaining, err := r.queries.GetAccountInstallmentBalance(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(remaining, 64)
+}
+
+func (r *PostgresTransactionRepository) GetRewardSummary(ctx context.Context, accountID uuid.UUID) (*domain.RewardSummary, error) {
+	row, err := r.queries.GetRewardBalance(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RewardSummary{
+		Balance:  row.Balance,
+		Earned:   row.Earned,
+		Redeemed: row.Redeemed,
+	}, nil
+}
+
+// ListRewardMonths reports the points earned, net of reversals, and
+// redeemed per calendar month.
+func (r *PostgresTransactionRepository) ListRewardMonths(ctx context.Context, accountID uuid.UUID) ([]domain.RewardMonth, error) {
+	rows, err := r.queries.ListRewardMonths(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]domain.RewardMonth, 0, len(rows))
+	for _, row := range rows {
+		months = append(months, domain.RewardMonth{
+			Year:     row.Month.Year(),
+			Month:    int(row.Month.Month()),
+			Earned:   row.Earned,
+			Redeemed: row.Redeemed,
+		})
+	}
+	return months, nil
+}
+
+// ListSubscriptions lists the subscriptions of the account still charging
+// or recently missed; inactive ones are left out of the summary.
+func (r *PostgresTransactionRepository) ListSubscriptions(ctx context.Context, accountID uuid.UUID) ([]*domain.Subscription, error) {
+	subscriptions, err := listSubscriptions(ctx, r.queries, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make([]*domain.Subscription, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		if s.Status != domain.SubscriptionInactive {
+			current = append(current, s)
+		}
+	}
+	return current, nil
+}
+
+func (r *PostgresTransactionRepository) ListBudgets(ctx context.Context, accountID uuid.UUID) ([]*domain.Budget, error) {
+	return listBudgets(ctx, r.queries, accountID)
+}
+
+// GetForecast returns the latest forecast of the account, nil until the
+// worker computes one.
+func (r *PostgresTransactionRepository) GetForecast(ctx context.Context, accountID uuid.UUID) (*domain.CashFlowForecast, error) {
+	forecast, err := getForecast(ctx, r.queries, accountID)
+	if errors.Is(err, domain.ErrForecastNotFound) {
+		return nil, nil
+	}
+	return forecast, err
+}
+
+func (r *PostgresTransactionRepository) ListGoalProgress(ctx context.Context, accountID uuid.UUID, now time.Time) ([]domain.GoalProgress, error) {
+	goals, err := listGoals(ctx, r.queries, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]domain.GoalProgress, 0, len(goals))
+	for _, g := range goals {
+		p, err := goalProgress(ctx, r.queries, g, now)
+		if err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+	return progress, nil
+}
+
+func (r *PostgresTransactionRepository) publishStatusChange(change *domain.StatusChange) error {
+	if err := r.publishEvent(domain.TransactionUpdatedEvent, change.Transaction); err != nil {
+		return err
+	}
+	return r.publishEvent(domain.TransactionStatusChangedEvent, change)
+}
+
+func toDomainTransaction(row sqlc.Transaction) (*domain.Transaction, error) {
+	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Transaction{
+		ID:                row.ID,
+		AccountID:         row.AccountID,
+		Amount:            amount,
+		Type:              row.Type,
+		Description:       row.Description,
+		Merchant:          row.Merchant,
+		Category:          row.Category,
+		TransferID:        row.TransferID,
+		ReversalOf:        row.ReversalOf,
+		ReversalKind:      row.ReversalKind,
+		InstallmentPlanID: row.InstallmentPlanID,
+		Voided:            row.Voided,
+		Supersedes:        row.Supersedes,
+		SupersededBy:      row.SupersededBy,
+		Status:            row.Status,
+		InputFileID:       row.InputFileID,
+		InputDate:         row.InputDate,
+		AuthorizedAt:      row.AuthorizedAt,
+		PostedAt:          row.PostedAt.Time,
+		Note:              row.Note,
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
+	}, nil
+}
 
-	return tx.Commit()
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
 }
 
 func (r *PostgresTransactionRepository) publishEvent(subject string, payload interface{}) error {
@@ -0,0 +1,338 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresRewardRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresRewardRepository(db *sql.DB, nc *nats.NatsClient) ports.RewardRepository {
+	return &PostgresRewardRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// ListUnrewarded pages through the eligible purchases on or after start
+// that have not earned points yet, ordered by creation.
+func (r *PostgresRewardRepository) ListUnrewarded(ctx context.Context, start time.Time, afterCreatedAt int64, afterID uuid.UUID, limit int64) ([]*domain.Transaction, error) {
+	rows, err := r.queries.ListUnrewardedDebits(ctx, sqlc.ListUnrewardedDebitsParams{
+		StartDate:      start,
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		t, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t)
+	}
+	return transactions, nil
+}
+
+// Earn records the points of one purchase. The account row is locked so
+// that the cycle caps hold across concurrent workers; a purchase that
+// already earned points, or is no longer eligible, returns a nil entry.
+func (r *PostgresRewardRepository) Earn(ctx context.Context, transactionID uuid.UUID, earn func(t *domain.Transaction, cycleStart time.Time, totals domain.RewardCycleTotals) *domain.RewardEntry) (*domain.RewardEntry, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	row, err := qtx.GetTransaction(ctx, transactionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	t, err := toDomainTransaction(row)
+	if err != nil {
+		return nil, err
+	}
+	if !t.EarnsRewards() {
+		return nil, nil
+	}
+
+	account, err := lockRewardAccount(ctx, qtx, t.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	cycleStart := account.CycleStart(t.InputDate)
+
+	totalRows, err := qtx.ListRewardCycleTotals(ctx, sqlc.ListRewardCycleTotalsParams{
+		AccountID:  account.ID,
+		CycleStart: cycleStart,
+	})
+	if err != nil {
+		return nil, err
+	}
+	totals := domain.RewardCycleTotals{ByRule: make(map[string]int64, len(totalRows))}
+	for _, row := range totalRows {
+		totals.ByRule[row.Rule] = row.Points
+		totals.Total += row.Points
+	}
+
+	entry := earn(t, cycleStart, totals)
+	created, err := insertRewardEntry(ctx, qtx, entry)
+	if err != nil || !created {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	if err := r.nats.Publish(domain.RewardEntryCreatedEvent, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListClawbacks lists the earned points of purchases voided, amended,
+// refunded or won in a dispute since, whose reversals do not match yet.
+func (r *PostgresRewardRepository) ListClawbacks(ctx context.Context, limit int64) ([]domain.RewardClawback, error) {
+	return listRewardClawbacks(ctx, r.queries, uuid.NullUUID{}, limit)
+}
+
+// Reverse records the reversal of a clawback. The earn entry stays locked
+// while what stands of the purchase is read again, so that concurrent runs
+// do not take the same points back twice; a clawback that is already
+// settled returns a nil entry.
+func (r *PostgresRewardRepository) Reverse(ctx context.Context, clawback domain.RewardClawback) (*domain.RewardEntry, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if err := qtx.LockRewardEntry(ctx, clawback.Earn.ID); err != nil {
+		return nil, err
+	}
+	current, err := listRewardClawbacks(ctx, qtx, uuid.NullUUID{UUID: clawback.Earn.ID, Valid: true}, 1)
+	if err != nil || len(current) == 0 {
+		return nil, err
+	}
+
+	reversal := current[0].Reversal()
+	if reversal == nil {
+		return nil, nil
+	}
+	if _, err := insertRewardEntry(ctx, qtx, reversal); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	if err := r.nats.Publish(domain.RewardEntryCreatedEvent, reversal); err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+func listRewardClawbacks(ctx context.Context, q *sqlc.Queries, entryID uuid.NullUUID, limit int64) ([]domain.RewardClawback, error) {
+	rows, err := q.ListRewardClawbacks(ctx, sqlc.ListRewardClawbacksParams{
+		EntryID: entryID,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clawbacks := make([]domain.RewardClawback, 0, len(rows))
+	for _, row := range rows {
+		earn, err := toDomainRewardEntry(row.RewardEntry)
+		if err != nil {
+			return nil, err
+		}
+		retained, err := strconv.ParseFloat(row.Retained, 64)
+		if err != nil {
+			return nil, err
+		}
+		clawbacks = append(clawbacks, domain.RewardClawback{
+			Earn:     earn,
+			Retained: retained,
+			Reversed: row.Reversed,
+		})
+	}
+	return clawbacks, nil
+}
+
+// Redeem exchanges points for a statement credit. The account row stays
+// locked while the balance is checked and the credit is posted so that
+// points cannot be redeemed twice.
+func (r *PostgresRewardRepository) Redeem(ctx context.Context, accountID uuid.UUID, redeem func(account *accountDomain.Account, balance int64) (*domain.RewardEntry, *domain.Transaction, error)) (*domain.RewardEntry, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	account, err := lockRewardAccount(ctx, qtx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.Active {
+		return nil, domain.ErrAccountInactive
+	}
+
+	balance, err := qtx.GetRewardBalance(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, credit, err := redeem(account, balance.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := postTransactions(ctx, qtx, []*domain.Transaction{credit})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := insertRewardEntry(ctx, qtx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	if err := publishPosted(r.nats, []*domain.Transaction{credit}, accounts); err != nil {
+		return nil, err
+	}
+	if err := r.nats.Publish(domain.RewardEntryCreatedEvent, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (r *PostgresRewardRepository) GetBalance(ctx context.Context, accountID uuid.UUID, limit, offset int64) (*domain.RewardBalance, error) {
+	if _, err := r.queries.GetAccount(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	totals, err := r.queries.GetRewardBalance(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListRewardEntries(ctx, sqlc.ListRewardEntriesParams{
+		AccountID: accountID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &domain.RewardBalance{
+		AccountID: accountID,
+		Points:    totals.Balance,
+		Earned:    totals.Earned,
+		Redeemed:  totals.Redeemed,
+		Entries:   make([]*domain.RewardEntry, 0, len(rows)),
+	}
+	for _, row := range rows {
+		entry, err := toDomainRewardEntry(row)
+		if err != nil {
+			return nil, err
+		}
+		balance.Entries = append(balance.Entries, entry)
+	}
+	return balance, nil
+}
+
+func lockRewardAccount(ctx context.Context, q *sqlc.Queries, id uuid.UUID) (*accountDomain.Account, error) {
+	row, err := q.GetAccountForUpdate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toAccountEvent(row)
+}
+
+// insertRewardEntry reports false when the transaction already has an
+// earn or redeem entry of the same kind.
+func insertRewardEntry(ctx context.Context, q *sqlc.Queries, e *domain.RewardEntry) (bool, error) {
+	_, err := q.CreateRewardEntry(ctx, sqlc.CreateRewardEntryParams{
+		ID:            e.ID,
+		AccountID:     e.AccountID,
+		Kind:          e.Kind,
+		Points:        e.Points,
+		TransactionID: e.TransactionID,
+		Rule:          e.Rule,
+		Basis:         strconv.FormatFloat(e.Basis, 'f', 2, 64),
+		CycleStart:    e.CycleStart,
+		EffectiveDate: e.EffectiveDate,
+		Description:   e.Description,
+		CreatedAt:     e.CreatedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func toDomainRewardEntry(row sqlc.RewardEntry) (*domain.RewardEntry, error) {
+	basis, err := strconv.ParseFloat(row.Basis, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RewardEntry{
+		ID:            row.ID,
+		AccountID:     row.AccountID,
+		Kind:          row.Kind,
+		Points:        row.Points,
+		TransactionID: row.TransactionID,
+		Basis:         basis,
+		Rule:          row.Rule,
+		CycleStart:    row.CycleStart.UTC(),
+		EffectiveDate: row.EffectiveDate.UTC(),
+		Description:   row.Description,
+		CreatedAt:     row.CreatedAt,
+	}, nil
+}
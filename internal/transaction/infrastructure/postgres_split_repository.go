@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresSplitRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresSplitRepository(db *sql.DB, nc *nats.NatsClient) ports.SplitRepository {
+	return &PostgresSplitRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// SetSplits replaces the splits of a transaction. The parent row and its
+// ledger entry are untouched because splits only reallocate categories.
+func (r *PostgresSplitRepository) SetSplits(ctx context.Context, transactionID uuid.UUID, parts []domain.SplitPart) (*domain.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	transaction, err := lockTransaction(ctx, qtx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transaction.SetSplits(parts); err != nil {
+		return nil, err
+	}
+
+	if err := qtx.DeleteTransactionSplits(ctx, transaction.ID); err != nil {
+		return nil, err
+	}
+	for i, split := range transaction.Splits {
+		_, err := qtx.CreateTransactionSplit(ctx, sqlc.CreateTransactionSplitParams{
+			ID:            split.ID,
+			TransactionID: split.TransactionID,
+			Position:      int32(i),
+			Amount:        strconv.FormatFloat(split.Amount, 'f', 2, 64),
+			Category:      split.Category,
+			Note:          split.Note,
+			CreatedAt:     split.CreatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := markSummaryStale(ctx, qtx, domain.SummaryMonthOf(transaction)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish a message to NATS
+	if err := r.nats.Publish(domain.TransactionSplitEvent, transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (r *PostgresSplitRepository) ListSplits(ctx context.Context, transactionID uuid.UUID) ([]domain.Split, error) {
+	return listSplits(ctx, r.queries, transactionID)
+}
+
+func listSplits(ctx context.Context, q *sqlc.Queries, transactionID uuid.UUID) ([]domain.Split, error) {
+	rows, err := q.ListTransactionSplits(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	splits := make([]domain.Split, 0, len(rows))
+	for _, row := range rows {
+		amount, err := strconv.ParseFloat(row.Amount, 64)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, domain.Split{
+			ID:            row.ID,
+			TransactionID: row.TransactionID,
+			Amount:        amount,
+			Category:      row.Category,
+			Note:          row.Note,
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+	return splits, nil
+}
@@ -0,0 +1,225 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresSubscriptionRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresSubscriptionRepository(db *sql.DB, nc *nats.NatsClient) ports.SubscriptionRepository {
+	return &PostgresSubscriptionRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// ListAccounts lists the accounts with purchases on or after since.
+func (r *PostgresSubscriptionRepository) ListAccounts(ctx context.Context, since time.Time) ([]uuid.UUID, error) {
+	return r.queries.ListSubscriptionAccounts(ctx, since)
+}
+
+// Detect runs detection over the purchases of an account on or after since
+// and stores the result. The account row is locked so that concurrent runs
+// do not raise the same alert twice; only the alerts created by this run
+// are returned and published.
+func (r *PostgresSubscriptionRepository) Detect(ctx context.Context, accountID uuid.UUID, since time.Time, detect func(history []*domain.Transaction, existing []*domain.Subscription) ([]*domain.Subscription, []*domain.SubscriptionAlert)) ([]*domain.SubscriptionAlert, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if _, err := qtx.GetAccountForUpdate(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	rows, err := qtx.ListSubscriptionCandidates(ctx, sqlc.ListSubscriptionCandidatesParams{
+		AccountID: accountID,
+		InputDate: since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		t, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, t)
+	}
+
+	existing, err := listSubscriptions(ctx, qtx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, alerts := detect(history, existing)
+	for _, s := range subscriptions {
+		if _, err := qtx.UpsertSubscription(ctx, sqlc.UpsertSubscriptionParams{
+			ID:               s.ID,
+			AccountID:        s.AccountID,
+			Merchant:         s.Merchant,
+			Category:         s.Category,
+			Cadence:          s.Cadence,
+			AverageAmount:    strconv.FormatFloat(s.AverageAmount, 'f', 2, 64),
+			LastAmount:       strconv.FormatFloat(s.LastAmount, 'f', 2, 64),
+			LastChargeDate:   s.LastChargeDate,
+			NextExpectedDate: s.NextExpectedDate,
+			ChargeCount:      int32(s.ChargeCount),
+			Status:           s.Status,
+			CreatedAt:        s.CreatedAt,
+			UpdatedAt:        s.UpdatedAt,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	created := make([]*domain.SubscriptionAlert, 0, len(alerts))
+	for _, a := range alerts {
+		_, err := qtx.CreateSubscriptionAlert(ctx, sqlc.CreateSubscriptionAlertParams{
+			ID:             a.ID,
+			SubscriptionID: a.SubscriptionID,
+			AccountID:      a.AccountID,
+			Kind:           a.Kind,
+			ChargeDate:     a.ChargeDate,
+			Amount:         strconv.FormatFloat(a.Amount, 'f', 2, 64),
+			PreviousAmount: strconv.FormatFloat(a.PreviousAmount, 'f', 2, 64),
+			Message:        a.Message,
+			CreatedAt:      a.CreatedAt,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, a)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Publish messages to NATS
+	for _, a := range created {
+		if err := r.nats.Publish(domain.SubscriptionAlertCreatedEvent, a); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+func (r *PostgresSubscriptionRepository) ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Subscription, error) {
+	if _, err := r.queries.GetAccount(ctx, accountID); errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAccountNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return listSubscriptions(ctx, r.queries, accountID)
+}
+
+func (r *PostgresSubscriptionRepository) ListAlerts(ctx context.Context, accountID uuid.UUID, limit int64) ([]*domain.SubscriptionAlert, error) {
+	rows, err := r.queries.ListSubscriptionAlerts(ctx, sqlc.ListSubscriptionAlertsParams{
+		AccountID: accountID,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*domain.SubscriptionAlert, 0, len(rows))
+	for _, row := range rows {
+		a, err := toDomainSubscriptionAlert(row)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, nil
+}
+
+func listSubscriptions(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID) ([]*domain.Subscription, error) {
+	rows, err := q.ListSubscriptionsByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*domain.Subscription, 0, len(rows))
+	for _, row := range rows {
+		s, err := toDomainSubscription(row)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	return subscriptions, nil
+}
+
+func toDomainSubscription(row sqlc.Subscription) (*domain.Subscription, error) {
+	average, err := strconv.ParseFloat(row.AverageAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	last, err := strconv.ParseFloat(row.LastAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Subscription{
+		ID:               row.ID,
+		AccountID:        row.AccountID,
+		Merchant:         row.Merchant,
+		Category:         row.Category,
+		Cadence:          row.Cadence,
+		AverageAmount:    average,
+		LastAmount:       last,
+		LastChargeDate:   row.LastChargeDate.UTC(),
+		NextExpectedDate: row.NextExpectedDate.UTC(),
+		ChargeCount:      int(row.ChargeCount),
+		Status:           row.Status,
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+	}, nil
+}
+
+func toDomainSubscriptionAlert(row sqlc.SubscriptionAlert) (*domain.SubscriptionAlert, error) {
+	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := strconv.ParseFloat(row.PreviousAmount, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SubscriptionAlert{
+		ID:             row.ID,
+		SubscriptionID: row.SubscriptionID,
+		AccountID:      row.AccountID,
+		Kind:           row.Kind,
+		ChargeDate:     row.ChargeDate.UTC(),
+		Amount:         amount,
+		PreviousAmount: previous,
+		Message:        row.Message,
+		CreatedAt:      row.CreatedAt,
+	}, nil
+}
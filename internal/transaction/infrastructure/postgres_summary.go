@@ -0,0 +1,256 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+// GetSummary aggregates every transaction the summary covers in the
+// database, day by day, and derives the months and the totals of the
+// summary from the days. Periods are left to the caller, which knows the
+// granularity, and monthly transactions to ListSummaryTransactions, which
+// pages through them.
+func (r *PostgresTransactionRepository) GetSummary(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) (*domain.TransactionSummary, error) {
+	filter := summaryFilter(accountID, opts)
+
+	days, err := r.ListSummaryDays(ctx, accountID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate days: %w", err)
+	}
+	summary := domain.SummaryFromDays(days)
+
+	categories, err := r.queries.ListSummaryCategories(ctx, sqlc.ListSummaryCategoriesParams{
+		AccountID:      filter.AccountID,
+		FromDate:       filter.FromDate,
+		ToDate:         filter.ToDate,
+		IncludePending: filter.IncludePending,
+		Tags:           filter.Tags,
+		Text:           filter.Text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate categories: %w", err)
+	}
+	totals := make(map[string]*domain.CategoryTotal)
+	for _, c := range categories {
+		domain.AddSummaryCategory(summary, totals, c.Month, domain.CategoryTotal{
+			Category: c.Category,
+			Total:    c.Total,
+			Count:    int(c.Count),
+		})
+	}
+	domain.SortSummaryCategories(summary, totals)
+
+	merchants, err := r.queries.ListSummaryMerchants(ctx, sqlc.ListSummaryMerchantsParams{
+		AccountID:      filter.AccountID,
+		FromDate:       filter.FromDate,
+		ToDate:         filter.ToDate,
+		IncludePending: filter.IncludePending,
+		Tags:           filter.Tags,
+		Text:           filter.Text,
+		MerchantLimit:  domain.SummaryTopMerchants,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate merchants: %w", err)
+	}
+	for _, m := range merchants {
+		domain.AddSummaryMerchant(summary, m.Month, domain.MerchantTotal{
+			Merchant: m.Merchant,
+			Category: m.Category,
+			Total:    m.Total,
+			Count:    int(m.Count),
+		})
+	}
+
+	return summary, nil
+}
+
+// GetProjectedSummary builds the same summary as GetSummary from the
+// monthly summary projection, for the options it can serve.
+func (r *PostgresTransactionRepository) GetProjectedSummary(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) (*domain.TransactionSummary, error) {
+	filter := summaryFilter(accountID, opts)
+
+	months, err := r.ListProjectedMonths(ctx, accountID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projected months: %w", err)
+	}
+	summary := domain.SummaryFromDays(months)
+
+	categories, err := r.queries.ListProjectedCategories(ctx, sqlc.ListProjectedCategoriesParams{
+		AccountID: filter.AccountID,
+		FromDate:  filter.FromDate,
+		ToDate:    filter.ToDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projected categories: %w", err)
+	}
+	totals := make(map[string]*domain.CategoryTotal)
+	for _, c := range categories {
+		domain.AddSummaryCategory(summary, totals, c.Month, domain.CategoryTotal{
+			Category: c.Category,
+			Total:    c.Total,
+			Count:    int(c.Count),
+		})
+	}
+	domain.SortSummaryCategories(summary, totals)
+
+	merchants, err := r.queries.ListProjectedMerchants(ctx, sqlc.ListProjectedMerchantsParams{
+		AccountID:     filter.AccountID,
+		FromDate:      filter.FromDate,
+		ToDate:        filter.ToDate,
+		MerchantLimit: domain.SummaryTopMerchants,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projected merchants: %w", err)
+	}
+	for _, m := range merchants {
+		domain.AddSummaryMerchant(summary, m.Month, domain.MerchantTotal{
+			Merchant: m.Merchant,
+			Category: m.Category,
+			Total:    m.Total,
+			Count:    int(m.Count),
+		})
+	}
+
+	return summary, nil
+}
+
+// ListProjectedMonths returns the activity of every month of a summary
+// with transactions from the monthly summary projection, oldest first,
+// each dated on the first of the month.
+func (r *PostgresTransactionRepository) ListProjectedMonths(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) ([]domain.SummaryDay, error) {
+	filter := summaryFilter(accountID, opts)
+	rows, err := r.queries.ListProjectedMonths(ctx, sqlc.ListProjectedMonthsParams{
+		AccountID: filter.AccountID,
+		FromDate:  filter.FromDate,
+		ToDate:    filter.ToDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]domain.SummaryDay, 0, len(rows))
+	for _, row := range rows {
+		months = append(months, domain.SummaryDay{
+			Date:         domain.MonthStart(row.Month),
+			Count:        int(row.TotalCount),
+			CreditCount:  int(row.CreditCount),
+			Credits:      row.Credits,
+			DebitCount:   int(row.DebitCount),
+			Debits:       row.Debits,
+			RefundCount:  int(row.RefundCount),
+			Refunds:      row.Refunds,
+			Installments: row.Installments,
+			Net:          row.Amount,
+		})
+	}
+	return months, nil
+}
+
+// ListSummaryTransactions returns a page of the transactions a summary
+// covers, newest first, with their splits and tags.
+func (r *PostgresTransactionRepository) ListSummaryTransactions(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions, limit, offset int64) ([]*domain.Transaction, error) {
+	filter := summaryFilter(accountID, opts)
+	rows, err := r.queries.ListSummaryTransactions(ctx, sqlc.ListSummaryTransactionsParams{
+		AccountID:      filter.AccountID,
+		FromDate:       filter.FromDate,
+		ToDate:         filter.ToDate,
+		IncludePending: filter.IncludePending,
+		Tags:           filter.Tags,
+		Text:           filter.Text,
+		RowLimit:       limit,
+		RowOffset:      offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(rows))
+	for _, row := range rows {
+		t, err := toDomainTransaction(row)
+		if err != nil {
+			return nil, err
+		}
+		if t.Splits, err = listSplits(ctx, r.queries, t.ID); err != nil {
+			return nil, err
+		}
+		if t.Tags, err = r.queries.ListTransactionTags(ctx, t.ID); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t)
+	}
+	return transactions, nil
+}
+
+// GetTagTotals aggregates the transactions a summary covers by tag.
+func (r *PostgresTransactionRepository) GetTagTotals(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) ([]domain.TagTotal, error) {
+	filter := summaryFilter(accountID, opts)
+	rows, err := r.queries.ListSummaryTagTotals(ctx, sqlc.ListSummaryTagTotalsParams{
+		AccountID:      filter.AccountID,
+		FromDate:       filter.FromDate,
+		ToDate:         filter.ToDate,
+		IncludePending: filter.IncludePending,
+		Tags:           filter.Tags,
+		Text:           filter.Text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]domain.TagTotal, 0, len(rows))
+	for _, row := range rows {
+		totals = append(totals, domain.TagTotal{
+			Tag:         row.Tag,
+			Count:       int(row.Count),
+			TotalCredit: row.TotalCredit,
+			TotalDebit:  row.TotalDebit,
+			Total:       row.Total,
+		})
+	}
+	return totals, nil
+}
+
+// ListSummaryDays returns the activity of every day of a summary with
+// transactions, oldest first.
+func (r *PostgresTransactionRepository) ListSummaryDays(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) ([]domain.SummaryDay, error) {
+	rows, err := r.queries.ListSummaryDays(ctx, summaryFilter(accountID, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]domain.SummaryDay, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, domain.SummaryDay{
+			Date:         row.Day.UTC(),
+			Count:        int(row.Total),
+			CreditCount:  int(row.CreditCount),
+			Credits:      row.Credits,
+			DebitCount:   int(row.DebitCount),
+			Debits:       row.Debits,
+			RefundCount:  int(row.RefundCount),
+			Refunds:      row.Refunds,
+			Installments: row.Installments,
+			Net:          row.Amount,
+		})
+	}
+	return days, nil
+}
+
+// summaryFilter turns the options of a summary into the filter every
+// summary query shares. Tags are never nil, as a NULL array would match
+// nothing.
+func summaryFilter(accountID uuid.UUID, opts domain.SummaryOptions) sqlc.ListSummaryDaysParams {
+	return sqlc.ListSummaryDaysParams{
+		AccountID:      accountID,
+		FromDate:       sql.NullTime{Time: opts.From, Valid: !opts.From.IsZero()},
+		ToDate:         sql.NullTime{Time: opts.To, Valid: !opts.To.IsZero()},
+		IncludePending: opts.IncludePending,
+		Tags:           append([]string{}, opts.Search.Tags...),
+		Text:           opts.Search.Text,
+	}
+}
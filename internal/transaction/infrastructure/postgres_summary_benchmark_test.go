@@ -0,0 +1,114 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+// BenchmarkSummary compares a two year monthly summary aggregated from the
+// transactions with the same summary served from the projection. It runs
+// against the database in SUMMARY_BENCHMARK_DATABASE_URL, which it migrates,
+// and seeds an account with 20 transactions a day:
+//
+//	SUMMARY_BENCHMARK_DATABASE_URL=postgres://... go test -run '^$' -bench Summary ./internal/transaction/infrastructure
+func BenchmarkSummary(b *testing.B) {
+	url := os.Getenv("SUMMARY_BENCHMARK_DATABASE_URL")
+	if url == "" {
+		b.Skip("SUMMARY_BENCHMARK_DATABASE_URL is not set")
+	}
+
+	conn, err := db.NewPostgresConnection(url)
+	if err != nil {
+		b.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+	if err := db.RunMigrations(conn, "../../../scripts/migrations"); err != nil {
+		b.Fatalf("failed to migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	accountID := uuid.New()
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(2, 0, 0)
+
+	b.Cleanup(func() {
+		for _, table := range []string{
+			"monthly_merchant_summary", "monthly_category_summary", "monthly_account_summary",
+			"stale_summary_months", "transactions", "accounts",
+		} {
+			column := "account_id"
+			if table == "accounts" {
+				column = "id"
+			}
+			if _, err := conn.ExecContext(ctx, "DELETE FROM "+table+" WHERE "+column+" = $1", accountID); err != nil {
+				b.Errorf("failed to clean up %s: %v", table, err)
+			}
+		}
+	})
+
+	now := time.Now().UTC().Unix()
+	if _, err := conn.ExecContext(ctx,
+		`INSERT INTO accounts (id, nickname, email, created_at, updated_at) VALUES ($1, 'bench', 'bench@stori.mx', $2, $2)`,
+		accountID, now); err != nil {
+		b.Fatalf("failed to seed the account: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO transactions (id, account_id, amount, type, input_file_id, input_date, authorized_at, posted_at,
+			created_at, updated_at, merchant, category)
+		SELECT gen_random_uuid(), $1, -((n % 200) + 1)::numeric, 'debit', 'bench.csv', d, d, d, $4, $4,
+			'merchant-' || (n % 50), 'category-' || (n % 8)
+		FROM generate_series($2::timestamp, $3::timestamp - interval '1 day', interval '1 day') AS d,
+			generate_series(1, 20) AS n`,
+		accountID, from, to, now); err != nil {
+		b.Fatalf("failed to seed the transactions: %v", err)
+	}
+
+	projection := NewPostgresSummaryProjectionRepository(conn, nil)
+	for month := from; month.Before(to); month = month.AddDate(0, 1, 0) {
+		if err := projection.Refresh(ctx, domain.SummaryMonth{AccountID: accountID, Month: month}); err != nil {
+			b.Fatalf("failed to project %s: %v", month.Format("2006-01"), err)
+		}
+	}
+
+	repo := NewPostgresTransactionRepository(conn, nil)
+	opts := domain.SummaryOptions{From: from, To: to, Granularity: domain.GranularityMonth}
+	lookback := opts
+	lookback.From = opts.LookbackStart()
+
+	b.Run("transactions", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetSummary(ctx, accountID, opts); err != nil {
+				b.Fatal(err)
+			}
+			days, err := repo.ListSummaryDays(ctx, accountID, lookback)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := domain.BuildPeriods(days, opts); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("projection", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetProjectedSummary(ctx, accountID, opts); err != nil {
+				b.Fatal(err)
+			}
+			months, err := repo.ListProjectedMonths(ctx, accountID, lookback)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := domain.BuildPeriods(months, opts); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresSummaryProjectionRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresSummaryProjectionRepository(db *sql.DB, nc *nats.NatsClient) ports.SummaryProjectionRepository {
+	return &PostgresSummaryProjectionRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Refresh recomputes a month of the projection from the transactions
+// inside a single database transaction. The totals row is upserted first,
+// so that concurrent refreshes of the same month wait on its lock. The
+// month leaves the stale queue only if no write queued it again while it
+// was recomputed.
+func (r *PostgresSummaryProjectionRepository) Refresh(ctx context.Context, month domain.SummaryMonth) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	version, err := qtx.GetStaleSummaryMonthVersion(ctx, sqlc.GetStaleSummaryMonthVersionParams{
+		AccountID: month.AccountID,
+		Month:     month.Month,
+	})
+	stale := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	err = qtx.RefreshMonthlyAccountSummary(ctx, sqlc.RefreshMonthlyAccountSummaryParams{
+		AccountID:   month.AccountID,
+		Month:       month.Month,
+		RefreshedAt: time.Now().UTC().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := qtx.DeleteMonthlyCategorySummary(ctx, sqlc.DeleteMonthlyCategorySummaryParams{
+		AccountID: month.AccountID,
+		Month:     month.Month,
+	}); err != nil {
+		return err
+	}
+	if err := qtx.InsertMonthlyCategorySummary(ctx, sqlc.InsertMonthlyCategorySummaryParams{
+		AccountID: month.AccountID,
+		Month:     month.Month,
+	}); err != nil {
+		return err
+	}
+
+	if err := qtx.DeleteMonthlyMerchantSummary(ctx, sqlc.DeleteMonthlyMerchantSummaryParams{
+		AccountID: month.AccountID,
+		Month:     month.Month,
+	}); err != nil {
+		return err
+	}
+	if err := qtx.InsertMonthlyMerchantSummary(ctx, sqlc.InsertMonthlyMerchantSummaryParams{
+		AccountID: month.AccountID,
+		Month:     month.Month,
+	}); err != nil {
+		return err
+	}
+
+	if stale {
+		err := qtx.DeleteStaleSummaryMonth(ctx, sqlc.DeleteStaleSummaryMonthParams{
+			AccountID: month.AccountID,
+			Month:     month.Month,
+			Version:   version,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RefreshStale refreshes a month only while writes keep it queued, and
+// reports whether it did.
+func (r *PostgresSummaryProjectionRepository) RefreshStale(ctx context.Context, month domain.SummaryMonth) (bool, error) {
+	_, err := r.queries.GetStaleSummaryMonthVersion(ctx, sqlc.GetStaleSummaryMonthVersionParams{
+		AccountID: month.AccountID,
+		Month:     month.Month,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, r.Refresh(ctx, month)
+}
+
+// ListMonths returns every month with transactions or already projected.
+func (r *PostgresSummaryProjectionRepository) ListMonths(ctx context.Context) ([]domain.SummaryMonth, error) {
+	rows, err := r.queries.ListSummaryProjectionMonths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]domain.SummaryMonth, 0, len(rows))
+	for _, row := range rows {
+		months = append(months, domain.SummaryMonth{
+			AccountID: row.AccountID,
+			Month:     domain.MonthStart(row.Month),
+		})
+	}
+	return months, nil
+}
+
+// ListStale returns the oldest months queued by writes since their last
+// refresh.
+func (r *PostgresSummaryProjectionRepository) ListStale(ctx context.Context, limit int64) ([]domain.SummaryMonth, error) {
+	rows, err := r.queries.ListStaleSummaryMonths(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]domain.SummaryMonth, 0, len(rows))
+	for _, row := range rows {
+		months = append(months, domain.SummaryMonth{
+			AccountID: row.AccountID,
+			Month:     domain.MonthStart(row.Month),
+		})
+	}
+	return months, nil
+}
+
+// markSummaryStale queues months of the projection for the next flush, in
+// the database transaction of the write that changed them. Months are
+// queued in a stable order so that concurrent writers do not deadlock.
+func markSummaryStale(ctx context.Context, q *sqlc.Queries, months ...domain.SummaryMonth) error {
+	sort.Slice(months, func(i, j int) bool {
+		if months[i].AccountID != months[j].AccountID {
+			return months[i].AccountID.String() < months[j].AccountID.String()
+		}
+		return months[i].Month.Before(months[j].Month)
+	})
+
+	now := time.Now().UTC().Unix()
+	for _, month := range months {
+		err := q.MarkSummaryMonthStale(ctx, sqlc.MarkSummaryMonthStaleParams{
+			AccountID: month.AccountID,
+			Month:     month.Month,
+			MarkedAt:  now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/db/sqlc"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/ports"
+)
+
+type PostgresTransferRepository struct {
+	queries *sqlc.Queries
+	db      *sql.DB
+	nats    *nats.NatsClient
+}
+
+func NewPostgresTransferRepository(db *sql.DB, nc *nats.NatsClient) ports.TransferRepository {
+	return &PostgresTransferRepository{
+		queries: sqlc.New(db),
+		db:      db,
+		nats:    nc,
+	}
+}
+
+// Create validates both accounts, stores the transfer and posts its debit
+// and credit legs atomically.
+func (r *PostgresTransferRepository) Create(ctx context.Context, transfer *domain.Transfer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	if err := lockActiveAccounts(ctx, qtx, transfer.SourceAccountID, transfer.DestinationAccountID); err != nil {
+		return err
+	}
+
+	_, err = qtx.CreateTransfer(ctx, sqlc.CreateTransferParams{
+		ID:                   transfer.ID,
+		SourceAccountID:      transfer.SourceAccountID,
+		DestinationAccountID: transfer.DestinationAccountID,
+		Amount:               strconv.FormatFloat(transfer.Amount, 'f', 2, 64),
+		Description:          transfer.Description,
+		IdempotencyKey:       sql.NullString{String: transfer.IdempotencyKey, Valid: transfer.IdempotencyKey != ""},
+		DebitTransactionID:   transfer.DebitTransactionID,
+		CreditTransactionID:  transfer.CreditTransactionID,
+		CreatedAt:            transfer.CreatedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrDuplicateTransfer
+	}
+	if err != nil {
+		return err
+	}
+
+	debit, credit := transfer.Legs()
+	transactions := []*domain.Transaction{debit, credit}
+	accounts, err := postTransactions(ctx, qtx, transactions)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Publish messages to NATS
+	if err := publishPosted(r.nats, transactions, accounts); err != nil {
+		return err
+	}
+	return r.nats.Publish(domain.TransferCompletedEvent, transfer)
+}
+
+func (r *PostgresTransferRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Transfer, error) {
+	row, err := r.queries.GetTransferByIdempotencyKey(ctx, sql.NullString{String: key, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainTransfer(row)
+}
+
+// lockActiveAccounts locks the account rows in a stable order and checks
+// that all of them exist and are active.
+func lockActiveAccounts(ctx context.Context, q *sqlc.Queries, ids ...uuid.UUID) error {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	for _, id := range ids {
+		account, err := q.GetAccountForUpdate(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !account.Active {
+			return domain.ErrAccountInactive
+		}
+	}
+	return nil
+}
+
+func toDomainTransfer(row sqlc.Transfer) (*domain.Transfer, error) {
+	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Transfer{
+		ID:                   row.ID,
+		SourceAccountID:      row.SourceAccountID,
+		DestinationAccountID: row.DestinationAccountID,
+		Amount:               amount,
+		Description:          row.Description,
+		IdempotencyKey:       row.IdempotencyKey.String,
+		DebitTransactionID:   row.DebitTransactionID,
+		CreditTransactionID:  row.CreditTransactionID,
+		CreatedAt:            row.CreatedAt,
+	}, nil
+}
@@ -2,19 +2,176 @@ package ports
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
 )
 
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *domain.Transaction) error
-	CreateBulk(ctx context.Context, transactions []*domain.Transaction) error
+	CreateBulk(ctx context.Context, transactions []*domain.Transaction, settlements []domain.Settlement) error
+	List(ctx context.Context, limit, offset int64) ([]*domain.Transaction, error)
+	UpdateMerchant(ctx context.Context, transaction *domain.Transaction) error
+	ListPending(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*domain.Transaction, error)
+	ListImportedKeys(ctx context.Context, accountID uuid.UUID, keys []string) ([]string, error)
+	ReverseAuthorization(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
+	ExpirePending(ctx context.Context, authorizedBefore time.Time) ([]*domain.Transaction, error)
+	GetInstallmentBalance(ctx context.Context, accountID uuid.UUID) (float64, error)
+	GetRewardSummary(ctx context.Context, accountID uuid.UUID) (*domain.RewardSummary, error)
+	ListRewardMonths(ctx context.Context, accountID uuid.UUID) ([]domain.RewardMonth, error)
+	ListSubscriptions(ctx context.Context, accountID uuid.UUID) ([]*domain.Subscription, error)
+	ListBudgets(ctx context.Context, accountID uuid.UUID) ([]*domain.Budget, error)
+	ListGoalProgress(ctx context.Context, accountID uuid.UUID, now time.Time) ([]domain.GoalProgress, error)
+	GetForecast(ctx context.Context, accountID uuid.UUID) (*domain.CashFlowForecast, error)
+	GetSummary(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) (*domain.TransactionSummary, error)
+	ListSummaryDays(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) ([]domain.SummaryDay, error)
+	GetProjectedSummary(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) (*domain.TransactionSummary, error)
+	ListProjectedMonths(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) ([]domain.SummaryDay, error)
+	ListSummaryTransactions(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions, limit, offset int64) ([]*domain.Transaction, error)
+	GetTagTotals(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) ([]domain.TagTotal, error)
 }
 
 type TransactionQueryRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
 	GetByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int64) ([]*domain.Transaction, error)
-	GetSummary(ctx context.Context, accountID uuid.UUID) (*domain.TransactionSummary, error)
+	Search(ctx context.Context, accountID uuid.UUID, search domain.TransactionSearch, limit, offset int64) ([]*domain.Transaction, error)
+}
+
+type LedgerRepository interface {
+	PostEntry(ctx context.Context, entry *domain.JournalEntry) error
+	GetTrialBalance(ctx context.Context, asOf time.Time) ([]domain.TrialBalanceLine, error)
+	GetStatement(ctx context.Context, code string, from, to time.Time) (*domain.AccountStatement, error)
+	GetDailyBalances(ctx context.Context, code string, from, to time.Time) ([]domain.DailyBalance, error)
+}
+
+type TransferRepository interface {
+	Create(ctx context.Context, transfer *domain.Transfer) error
+	GetByIdempotencyKey(ctx context.Context, key string) (*domain.Transfer, error)
+}
+
+type TransferQueryRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Transfer, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int64) ([]*domain.Transfer, error)
+}
+
+// CorrectionFunc applies a correction to the locked, current state of a
+// transaction.
+type CorrectionFunc func(transaction *domain.Transaction) (*domain.Correction, error)
+
+type CorrectionRepository interface {
+	Correct(ctx context.Context, transactionID uuid.UUID, apply CorrectionFunc) (*domain.Transaction, *domain.Correction, error)
+	ListByTransaction(ctx context.Context, transactionID uuid.UUID) ([]*domain.Correction, error)
+}
+
+type RefundRepository interface {
+	Link(ctx context.Context, refundID, originalID uuid.UUID, kind string) (*domain.Transaction, error)
+	FindCandidates(ctx context.Context, refund *domain.Transaction, from, to time.Time) ([]domain.RefundCandidate, error)
+	ListUnlinkedCredits(ctx context.Context, afterCreatedAt int64, afterID uuid.UUID, limit int64) ([]*domain.Transaction, error)
+}
+
+type SplitRepository interface {
+	SetSplits(ctx context.Context, transactionID uuid.UUID, parts []domain.SplitPart) (*domain.Transaction, error)
+	ListSplits(ctx context.Context, transactionID uuid.UUID) ([]domain.Split, error)
+}
+
+type AnnotationRepository interface {
+	Annotate(ctx context.Context, transactionID uuid.UUID, tags []string, note string) (*domain.Transaction, error)
+}
+
+type AccrualRepository interface {
+	ListCardAccounts(ctx context.Context) ([]*accountDomain.Account, error)
+	GetActivity(ctx context.Context, accountID uuid.UUID, before time.Time) (*accountDomain.AccountActivity, error)
+	ListAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*accountDomain.Accrual, error)
+	Post(ctx context.Context, accrual *accountDomain.Accrual, charge *domain.Transaction) error
+}
+
+type InstallmentRepository interface {
+	Create(ctx context.Context, transactionID uuid.UUID, count int, annualRate float64, now time.Time) (*domain.InstallmentPlan, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.InstallmentPlan, error)
+	ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.InstallmentPlan, error)
+	ListDue(ctx context.Context, asOf time.Time, limit int64) ([]domain.Installment, error)
+	Post(ctx context.Context, installment domain.Installment) (*domain.Transaction, error)
+	GetRemainingBalance(ctx context.Context, accountID uuid.UUID) (float64, error)
+}
+
+type RewardRepository interface {
+	ListUnrewarded(ctx context.Context, start time.Time, afterCreatedAt int64, afterID uuid.UUID, limit int64) ([]*domain.Transaction, error)
+	Earn(ctx context.Context, transactionID uuid.UUID, earn func(t *domain.Transaction, cycleStart time.Time, totals domain.RewardCycleTotals) *domain.RewardEntry) (*domain.RewardEntry, error)
+	ListClawbacks(ctx context.Context, limit int64) ([]domain.RewardClawback, error)
+	Reverse(ctx context.Context, clawback domain.RewardClawback) (*domain.RewardEntry, error)
+	Redeem(ctx context.Context, accountID uuid.UUID, redeem func(account *accountDomain.Account, balance int64) (*domain.RewardEntry, *domain.Transaction, error)) (*domain.RewardEntry, error)
+	GetBalance(ctx context.Context, accountID uuid.UUID, limit, offset int64) (*domain.RewardBalance, error)
+}
+
+// RewardProgramSource loads the rules the rewards program earns points by.
+type RewardProgramSource interface {
+	Load() (*domain.RewardProgram, error)
+}
+
+type DisputeRepository interface {
+	Open(ctx context.Context, transactionID uuid.UUID, open func(t *domain.Transaction, refunded float64) (*domain.Dispute, *domain.DisputeEvent, error)) (*domain.Dispute, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Dispute, error)
+	ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Dispute, error)
+	ListDueForCredit(ctx context.Context, asOf time.Time, limit int64) ([]*domain.Dispute, error)
+	ListPastDeadline(ctx context.Context, asOf time.Time, limit int64) ([]*domain.Dispute, error)
+	Transition(ctx context.Context, id uuid.UUID, apply func(d *domain.Dispute) (*domain.DisputeEvent, *domain.Transaction, error)) (*domain.DisputeChange, error)
+	AddAttachment(ctx context.Context, attachment *domain.DisputeAttachment) error
+	GetAttachment(ctx context.Context, disputeID, attachmentID uuid.UUID) (*domain.DisputeAttachment, error)
+}
+
+type SubscriptionRepository interface {
+	ListAccounts(ctx context.Context, since time.Time) ([]uuid.UUID, error)
+	Detect(ctx context.Context, accountID uuid.UUID, since time.Time, detect func(history []*domain.Transaction, existing []*domain.Subscription) ([]*domain.Subscription, []*domain.SubscriptionAlert)) ([]*domain.SubscriptionAlert, error)
+	ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Subscription, error)
+	ListAlerts(ctx context.Context, accountID uuid.UUID, limit int64) ([]*domain.SubscriptionAlert, error)
+}
+
+type AnomalyRepository interface {
+	History(ctx context.Context, t *domain.Transaction, policy domain.AnomalyPolicy) (domain.AnomalyHistory, error)
+	Create(ctx context.Context, alert *domain.TransactionAlert) (bool, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.TransactionAlert, error)
+	ListByStatus(ctx context.Context, status string, limit, offset int64) ([]*domain.TransactionAlert, error)
+	ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.TransactionAlert, error)
+	Review(ctx context.Context, id uuid.UUID, review func(a *domain.TransactionAlert) error) (*domain.TransactionAlert, error)
+}
+
+type BudgetRepository interface {
+	Save(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Budget, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.Budget, error)
+	Spending(ctx context.Context, accountID uuid.UUID, month time.Time) (map[string]float64, error)
+	Track(ctx context.Context, accountID uuid.UUID, month time.Time, evaluate func(budgets []*domain.Budget, spent map[string]float64) []*domain.BudgetAlert) ([]*domain.BudgetAlert, error)
+}
+
+type GoalRepository interface {
+	Create(ctx context.Context, goal *domain.SavingsGoal) (*domain.SavingsGoal, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.SavingsGoal, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByAccount(ctx context.Context, accountID uuid.UUID) ([]*domain.SavingsGoal, error)
+	Progress(ctx context.Context, goal *domain.SavingsGoal, now time.Time) (domain.GoalProgress, error)
+}
+
+type ForecastRepository interface {
+	ListAccounts(ctx context.Context, since time.Time) ([]uuid.UUID, error)
+	Build(ctx context.Context, accountID uuid.UUID, since time.Time, build func(history []*domain.Transaction, balance float64) *domain.CashFlowForecast) (*domain.CashFlowForecast, error)
+	Get(ctx context.Context, accountID uuid.UUID) (*domain.CashFlowForecast, error)
+}
+
+type SummaryProjectionRepository interface {
+	Refresh(ctx context.Context, month domain.SummaryMonth) error
+	RefreshStale(ctx context.Context, month domain.SummaryMonth) (bool, error)
+	ListStale(ctx context.Context, limit int64) ([]domain.SummaryMonth, error)
+	ListMonths(ctx context.Context) ([]domain.SummaryMonth, error)
+}
+
+// BlobStore keeps files such as dispute evidence.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
 }
@@ -13,5 +13,9 @@ type TransactionService interface {
 	CreateTransaction(ctx context.Context, accountID uuid.UUID, amount float64, transactionType, inputFileID string, inputDate time.Time) (*domain.Transaction, error)
 	GetTransaction(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
 	GetTransactionsByAccount(ctx context.Context, accountID uuid.UUID, limit, offset int32) ([]*domain.Transaction, error)
-	GetTransactionSummary(ctx context.Context, accountID uuid.UUID) (*domain.TransactionSummary, error)
+	GetTransactionSummary(ctx context.Context, accountID uuid.UUID, opts domain.SummaryOptions) (*domain.TransactionSummary, error)
+}
+
+type MerchantNormalizer interface {
+	Normalize(description string) (merchant string, category string)
 }
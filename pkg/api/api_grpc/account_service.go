@@ -2,9 +2,11 @@ package api_grpc
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/account/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
 	pb "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -22,20 +24,29 @@ func NewAccountServer(service *application.AccountService) *AccountServer {
 }
 
 func (s *AccountServer) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.Account, error) {
-	account, err := s.service.CreateAccount(ctx, req.Nickname, req.Email)
-	if err != nil {
+	var account *domain.Account
+	var err error
+	switch req.Type {
+	case "", domain.AccountTypeDebit:
+		account, err = s.service.CreateAccount(ctx, req.Nickname, req.Email)
+	case domain.AccountTypeCreditCard:
+		account, err = s.service.CreateCreditCardAccount(ctx, req.Nickname, req.Email, domain.CardTerms{
+			CreditLimit:         req.CreditLimit,
+			StatementClosingDay: int(req.StatementClosingDay),
+			PaymentDueDays:      int(req.PaymentDueDays),
+		})
+	default:
+		err = domain.ErrInvalidAccountType
+	}
+	switch {
+	case errors.Is(err, domain.ErrInvalidAccountType), errors.Is(err, domain.ErrInvalidCreditLimit),
+		errors.Is(err, domain.ErrInvalidClosingDay), errors.Is(err, domain.ErrInvalidPaymentDays):
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account: %v", err)
+	case err != nil:
 		return nil, status.Errorf(codes.Internal, "failed to create account: %v", err)
 	}
 
-	return &pb.Account{
-		Id:        account.ID.String(),
-		Nickname:  account.Nickname,
-		Balance:   account.Balance,
-		Email:     account.Email,
-		CreatedAt: timestamppb.New(time.Unix(int64(account.CreatedAt), 0)),
-		UpdatedAt: timestamppb.New(time.Unix(int64(account.UpdatedAt), 0)),
-		Active:    account.Active,
-	}, nil
+	return toProtoAccount(account, nil), nil
 }
 
 func (s *AccountServer) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.Account, error) {
@@ -48,16 +59,55 @@ func (s *AccountServer) GetAccount(ctx context.Context, req *pb.GetAccountReques
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
 	}
+	if account == nil {
+		return nil, status.Errorf(codes.NotFound, "account %s not found", id)
+	}
 
-	return &pb.Account{
-		Id:        account.ID.String(),
-		Nickname:  account.Nickname,
-		Balance:   account.Balance,
-		Email:     account.Email,
-		CreatedAt: timestamppb.New(time.Unix(int64(account.CreatedAt), 0)),
-		UpdatedAt: timestamppb.New(time.Unix(int64(account.UpdatedAt), 0)),
-		Active:    account.Active,
-	}, nil
+	statement, err := s.service.GetCurrentStatement(ctx, account)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account statement: %v", err)
+	}
+	installmentBalance, err := s.service.GetInstallmentBalance(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get installment balance: %v", err)
+	}
+
+	response := toProtoAccount(account, statement)
+	response.InstallmentBalance = installmentBalance
+	return response, nil
+}
+
+func toProtoAccount(account *domain.Account, statement *domain.CardStatement) *pb.Account {
+	response := &pb.Account{
+		Id:                  account.ID.String(),
+		Nickname:            account.Nickname,
+		Balance:             account.Balance,
+		Email:               account.Email,
+		CreatedAt:           timestamppb.New(time.Unix(int64(account.CreatedAt), 0)),
+		UpdatedAt:           timestamppb.New(time.Unix(int64(account.UpdatedAt), 0)),
+		Active:              account.Active,
+		Type:                account.Type,
+		CreditLimit:         account.CreditLimit,
+		AvailableCredit:     account.AvailableCredit(),
+		StatementClosingDay: int32(account.StatementClosingDay),
+		PaymentDueDays:      int32(account.PaymentDueDays),
+	}
+	if response.Type == "" {
+		response.Type = domain.AccountTypeDebit
+	}
+	if statement != nil {
+		response.Statement = &pb.CardStatement{
+			PeriodStart:      timestamppb.New(statement.PeriodStart),
+			ClosingDate:      timestamppb.New(statement.ClosingDate),
+			DueDate:          timestamppb.New(statement.DueDate),
+			OpeningBalance:   statement.OpeningBalance,
+			Purchases:        statement.Purchases,
+			Payments:         statement.Payments,
+			StatementBalance: statement.StatementBalance,
+			MinimumPayment:   statement.MinimumPayment,
+		}
+	}
+	return response
 }
 
 // Implement other gRPC methods (UpdateAccount, DeleteAccount, ListAccounts) similarly
@@ -0,0 +1,188 @@
+package api_grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/domain"
+	pb "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
+)
+
+// adminKeyMetadata carries the key of the admin calls.
+const adminKeyMetadata = "x-admin-key"
+
+type AnalyticsServer struct {
+	pb.UnimplementedAnalyticsServiceServer
+	service  *application.AnalyticsService
+	adminKey string
+}
+
+func NewAnalyticsServer(service *application.AnalyticsService, adminKey string) *AnalyticsServer {
+	return &AnalyticsServer{service: service, adminKey: adminKey}
+}
+
+func (s *AnalyticsServer) GetActivity(ctx context.Context, req *pb.AnalyticsRangeRequest) (*pb.Activity, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	from, to := analyticsRange(req.From, req.To)
+	activity, err := s.service.GetActivity(ctx, from, to)
+	if err != nil {
+		return nil, analyticsStatusError(err)
+	}
+
+	res := &pb.Activity{
+		From:             timestamppb.New(activity.From),
+		To:               timestamppb.New(activity.To),
+		ActiveAccounts:   activity.ActiveAccounts,
+		TransactionCount: activity.TransactionCount,
+		TotalCredit:      activity.TotalCredit,
+		TotalDebit:       activity.TotalDebit,
+		Days:             make([]*pb.DailyActivity, 0, len(activity.Days)),
+	}
+	for _, d := range activity.Days {
+		res.Days = append(res.Days, &pb.DailyActivity{
+			Date:             timestamppb.New(d.Date),
+			ActiveAccounts:   d.ActiveAccounts,
+			TransactionCount: d.TransactionCount,
+			Credit:           d.Credit,
+			Debit:            d.Debit,
+		})
+	}
+	return res, nil
+}
+
+func (s *AnalyticsServer) GetImportStats(ctx context.Context, req *pb.AnalyticsRangeRequest) (*pb.ImportStats, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	from, to := analyticsRange(req.From, req.To)
+	stats, err := s.service.GetImportStats(ctx, from, to)
+	if err != nil {
+		return nil, analyticsStatusError(err)
+	}
+
+	return &pb.ImportStats{
+		From:             timestamppb.New(stats.From),
+		To:               timestamppb.New(stats.To),
+		Total:            stats.Total,
+		Succeeded:        stats.Succeeded,
+		Failed:           stats.Failed,
+		SuccessRate:      stats.SuccessRate,
+		AverageFileSize:  stats.AverageFileSize,
+		TotalFileSize:    stats.TotalFileSize,
+		TransactionCount: stats.TransactionCount,
+	}, nil
+}
+
+func (s *AnalyticsServer) GetBalanceDistribution(ctx context.Context, req *pb.GetBalanceDistributionRequest) (*pb.BalanceDistribution, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	distribution, err := s.service.GetBalanceDistribution(ctx)
+	if err != nil {
+		return nil, analyticsStatusError(err)
+	}
+
+	res := &pb.BalanceDistribution{
+		AccountCount: distribution.AccountCount,
+		Total:        distribution.Total,
+		Average:      distribution.Average,
+		Min:          distribution.Min,
+		Max:          distribution.Max,
+		Percentiles:  make([]*pb.BalancePercentile, 0, len(distribution.Percentiles)),
+		Buckets:      make([]*pb.BalanceBucket, 0, len(distribution.Buckets)),
+	}
+	for _, p := range distribution.Percentiles {
+		res.Percentiles = append(res.Percentiles, &pb.BalancePercentile{
+			Percentile: p.Percentile,
+			Balance:    p.Balance,
+		})
+	}
+	for _, b := range distribution.Buckets {
+		res.Buckets = append(res.Buckets, &pb.BalanceBucket{
+			From:         b.From,
+			To:           b.To,
+			AccountCount: b.AccountCount,
+		})
+	}
+	return res, nil
+}
+
+func (s *AnalyticsServer) ListTopMerchants(ctx context.Context, req *pb.ListTopMerchantsRequest) (*pb.ListTopMerchantsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	from, to := analyticsRange(req.From, req.To)
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = domain.DefaultTopMerchants
+	}
+	merchants, err := s.service.ListTopMerchants(ctx, from, to, limit)
+	if err != nil {
+		return nil, analyticsStatusError(err)
+	}
+
+	res := &pb.ListTopMerchantsResponse{Merchants: make([]*pb.TopMerchant, 0, len(merchants))}
+	for _, m := range merchants {
+		res.Merchants = append(res.Merchants, &pb.TopMerchant{
+			Merchant:         m.Merchant,
+			TransactionCount: m.TransactionCount,
+			AccountCount:     m.AccountCount,
+			Total:            m.Total,
+		})
+	}
+	return res, nil
+}
+
+// authorize checks the admin key of the call. The service is disabled when
+// no key is configured.
+func (s *AnalyticsServer) authorize(ctx context.Context) error {
+	if s.adminKey == "" {
+		return status.Error(codes.PermissionDenied, "admin API is disabled")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(adminKeyMetadata)
+	if len(keys) != 1 || subtle.ConstantTimeCompare([]byte(keys[0]), []byte(s.adminKey)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid admin key")
+	}
+	return nil
+}
+
+// analyticsRange truncates a range to whole days, defaulting to the last 30
+// days.
+func analyticsRange(fromTs, toTs *timestamppb.Timestamp) (time.Time, time.Time) {
+	to := time.Now().UTC().AddDate(0, 0, 1)
+	if toTs != nil {
+		to = toTs.AsTime()
+	}
+	to = to.Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -30)
+	if fromTs != nil {
+		from = fromTs.AsTime().Truncate(24 * time.Hour)
+	}
+	return from, to
+}
+
+func analyticsStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRange),
+		errors.Is(err, domain.ErrRangeTooLong),
+		errors.Is(err, domain.ErrInvalidTopLimit):
+		return status.Errorf(codes.InvalidArgument, "invalid analytics request: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to aggregate analytics: %v", err)
+	}
+}
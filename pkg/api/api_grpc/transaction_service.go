@@ -1,10 +1,14 @@
 package api_grpc
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"sort"
 	"time"
 
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
 	pb "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -12,13 +16,32 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxSummaryDetails is the largest page of transactions a summary returns.
+const maxSummaryDetails = 1000
+
 type TransactionServer struct {
 	pb.UnimplementedTransactionServiceServer
-	service *application.TransactionService
+	service     *application.TransactionService
+	transfers   *application.TransferService
+	corrections *application.CorrectionService
+	refunds     *application.RefundService
+	splits      *application.SplitService
+	annotations *application.AnnotationService
+	disputes    *application.DisputeService
+	goals       *application.GoalService
+	ledger      *application.LedgerService
 }
 
-func NewTransactionServer(service *application.TransactionService) *TransactionServer {
-	return &TransactionServer{service: service}
+func NewTransactionServer(
+	service *application.TransactionService, transfers *application.TransferService,
+	corrections *application.CorrectionService, refunds *application.RefundService,
+	splits *application.SplitService, annotations *application.AnnotationService,
+	disputes *application.DisputeService, goals *application.GoalService,
+	ledger *application.LedgerService) *TransactionServer {
+	return &TransactionServer{
+		service: service, transfers: transfers, corrections: corrections, refunds: refunds,
+		splits: splits, annotations: annotations, disputes: disputes, goals: goals, ledger: ledger,
+	}
 }
 
 func (s *TransactionServer) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.Transaction, error) {
@@ -29,20 +52,392 @@ func (s *TransactionServer) CreateTransaction(ctx context.Context, req *pb.Creat
 
 	inputDate := req.InputDate.AsTime()
 
-	transaction, err := s.service.CreateTransaction(ctx, accountID, req.Amount, req.InputFileId, inputDate)
+	var transaction *domain.Transaction
+	switch req.Status {
+	case "", domain.StatusPosted:
+		transaction, err = s.service.CreateTransaction(ctx, accountID, req.Amount, req.Description, req.InputFileId, inputDate)
+	case domain.StatusPending:
+		transaction, err = s.service.CreateAuthorization(ctx, accountID, req.Amount, req.Description, req.InputFileId, inputDate)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid status: %v", domain.ErrInvalidStatus)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create transaction: %v", err)
 	}
 
+	return toProtoTransaction(transaction), nil
+}
+
+func toProtoTransaction(transaction *domain.Transaction) *pb.Transaction {
+	var transferID, reversalOf, installmentPlanID, supersedes, supersededBy string
+	if transaction.TransferID.Valid {
+		transferID = transaction.TransferID.UUID.String()
+	}
+	if transaction.ReversalOf.Valid {
+		reversalOf = transaction.ReversalOf.UUID.String()
+	}
+	if transaction.InstallmentPlanID.Valid {
+		installmentPlanID = transaction.InstallmentPlanID.UUID.String()
+	}
+	if transaction.Supersedes.Valid {
+		supersedes = transaction.Supersedes.UUID.String()
+	}
+	if transaction.SupersededBy.Valid {
+		supersededBy = transaction.SupersededBy.UUID.String()
+	}
+
+	var postedAt *timestamppb.Timestamp
+	if !transaction.PostedAt.IsZero() {
+		postedAt = timestamppb.New(transaction.PostedAt)
+	}
+
+	splits := make([]*pb.TransactionSplit, 0, len(transaction.Splits))
+	for _, split := range transaction.Splits {
+		splits = append(splits, &pb.TransactionSplit{
+			Id:       split.ID.String(),
+			Amount:   split.Amount,
+			Category: split.Category,
+			Note:     split.Note,
+		})
+	}
+
 	return &pb.Transaction{
-		Id:          transaction.ID.String(),
-		AccountId:   transaction.AccountID.String(),
-		Amount:      transaction.Amount,
-		Type:        transaction.Type,
-		InputFileId: transaction.InputFileID,
-		InputDate:   timestamppb.New(transaction.InputDate),
-		CreatedAt:   timestamppb.New(time.Unix(int64(transaction.CreatedAt), 0)),
-	}, nil
+		Id:                transaction.ID.String(),
+		AccountId:         transaction.AccountID.String(),
+		Amount:            transaction.Amount,
+		Type:              transaction.Type,
+		InputFileId:       transaction.InputFileID,
+		InputDate:         timestamppb.New(transaction.InputDate),
+		CreatedAt:         timestamppb.New(time.Unix(int64(transaction.CreatedAt), 0)),
+		Description:       transaction.Description,
+		Merchant:          transaction.Merchant,
+		Category:          transaction.Category,
+		TransferId:        transferID,
+		Voided:            transaction.Voided,
+		ReversalOf:        reversalOf,
+		ReversalKind:      transaction.ReversalKind,
+		Status:            transaction.Status,
+		AuthorizedAt:      timestamppb.New(transaction.AuthorizedAt),
+		PostedAt:          postedAt,
+		Splits:            splits,
+		Tags:              transaction.Tags,
+		Note:              transaction.Note,
+		InstallmentPlanId: installmentPlanID,
+		Supersedes:        supersedes,
+		SupersededBy:      supersededBy,
+	}
+}
+
+func (s *TransactionServer) AmendTransaction(ctx context.Context, req *pb.AmendTransactionRequest) (*pb.Transaction, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+
+	amendment := domain.Amendment{
+		Amount:      req.Amount,
+		Description: req.Description,
+		Category:    req.Category,
+	}
+
+	transaction, _, err := s.corrections.AmendTransaction(ctx, id, amendment, req.Reason, req.Actor)
+	if err != nil {
+		return nil, correctionStatusError(err)
+	}
+
+	return toProtoTransaction(transaction), nil
+}
+
+func (s *TransactionServer) VoidTransaction(ctx context.Context, req *pb.VoidTransactionRequest) (*pb.Transaction, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+
+	transaction, _, err := s.corrections.VoidTransaction(ctx, id, req.Reason, req.Actor)
+	if err != nil {
+		return nil, correctionStatusError(err)
+	}
+
+	return toProtoTransaction(transaction), nil
+}
+
+func (s *TransactionServer) GetTransactionHistory(ctx context.Context, req *pb.GetTransactionHistoryRequest) (*pb.TransactionHistory, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+
+	corrections, err := s.corrections.GetTransactionHistory(ctx, id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get transaction history: %v", err)
+	}
+
+	history := &pb.TransactionHistory{
+		Corrections: make([]*pb.TransactionCorrection, 0, len(corrections)),
+	}
+	for _, c := range corrections {
+		history.Corrections = append(history.Corrections, &pb.TransactionCorrection{
+			Id:                  c.ID.String(),
+			TransactionId:       c.TransactionID.String(),
+			Kind:                c.Kind,
+			Reason:              c.Reason,
+			Actor:               c.Actor,
+			PreviousAmount:      c.PreviousAmount,
+			NewAmount:           c.NewAmount,
+			PreviousDescription: c.PreviousDescription,
+			NewDescription:      c.NewDescription,
+			PreviousCategory:    c.PreviousCategory,
+			NewCategory:         c.NewCategory,
+			CreatedAt:           timestamppb.New(time.Unix(c.CreatedAt, 0)),
+			VersionId:           c.VersionID.String(),
+		})
+	}
+	return history, nil
+}
+
+func (s *TransactionServer) LinkRefund(ctx context.Context, req *pb.LinkRefundRequest) (*pb.Transaction, error) {
+	refundID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+	originalID, err := uuid.Parse(req.OriginalId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid original transaction ID: %v", err)
+	}
+
+	kind := req.Kind
+	if kind == "" {
+		kind = domain.ReversalKindRefund
+	}
+
+	transaction, err := s.refunds.LinkRefund(ctx, refundID, originalID, kind)
+	if err != nil {
+		return nil, refundStatusError(err)
+	}
+
+	return toProtoTransaction(transaction), nil
+}
+
+func (s *TransactionServer) ReverseAuthorization(ctx context.Context, req *pb.ReverseAuthorizationRequest) (*pb.Transaction, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+
+	transaction, err := s.service.ReverseAuthorization(ctx, id)
+	switch {
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return nil, status.Errorf(codes.NotFound, "failed to reverse authorization: %v", err)
+	case errors.Is(err, domain.ErrInvalidStatusTransition):
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to reverse authorization: %v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to reverse authorization: %v", err)
+	}
+
+	return toProtoTransaction(transaction), nil
+}
+
+func (s *TransactionServer) SplitTransaction(ctx context.Context, req *pb.SplitTransactionRequest) (*pb.Transaction, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+
+	parts := make([]domain.SplitPart, 0, len(req.Splits))
+	for _, split := range req.Splits {
+		parts = append(parts, domain.SplitPart{
+			Amount:   split.Amount,
+			Category: split.Category,
+			Note:     split.Note,
+		})
+	}
+
+	transaction, err := s.splits.SplitTransaction(ctx, id, parts)
+	switch {
+	case errors.Is(err, domain.ErrSplitTooFewParts), errors.Is(err, domain.ErrSplitCategory),
+		errors.Is(err, domain.ErrSplitInvalidAmount), errors.Is(err, domain.ErrSplitSumMismatch):
+		return nil, status.Errorf(codes.InvalidArgument, "invalid split: %v", err)
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return nil, status.Errorf(codes.NotFound, "failed to split transaction: %v", err)
+	case errors.Is(err, domain.ErrSplitTransferOrVoid), errors.Is(err, domain.ErrTransactionNotPosted):
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to split transaction: %v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to split transaction: %v", err)
+	}
+
+	return toProtoTransaction(transaction), nil
+}
+
+func (s *TransactionServer) AnnotateTransaction(ctx context.Context, req *pb.AnnotateTransactionRequest) (*pb.Transaction, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+
+	transaction, err := s.annotations.AnnotateTransaction(ctx, id, req.Tags, req.Note)
+	switch {
+	case errors.Is(err, domain.ErrInvalidTag), errors.Is(err, domain.ErrTooManyTags), errors.Is(err, domain.ErrNoteTooLong):
+		return nil, status.Errorf(codes.InvalidArgument, "invalid annotation: %v", err)
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return nil, status.Errorf(codes.NotFound, "failed to annotate transaction: %v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to annotate transaction: %v", err)
+	}
+
+	return toProtoTransaction(transaction), nil
+}
+
+func (s *TransactionServer) SearchTransactions(ctx context.Context, req *pb.SearchTransactionsRequest) (*pb.TransactionList, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	search, err := domain.ParseSearchQuery(req.Query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	transactions, err := s.service.SearchTransactions(ctx, accountID, search, limit, req.Offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search transactions: %v", err)
+	}
+
+	list := &pb.TransactionList{Transactions: make([]*pb.Transaction, 0, len(transactions))}
+	for _, t := range transactions {
+		list.Transactions = append(list.Transactions, toProtoTransaction(t))
+	}
+	return list, nil
+}
+
+func (s *TransactionServer) GetTagTotals(ctx context.Context, req *pb.GetTagTotalsRequest) (*pb.TagTotals, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	search, err := domain.ParseSearchQuery(req.Query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+
+	totals, err := s.service.GetTagTotals(ctx, accountID, domain.SummaryOptions{IncludePending: req.IncludePending, Search: search})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get tag totals: %v", err)
+	}
+
+	response := &pb.TagTotals{Totals: make([]*pb.TagTotal, 0, len(totals))}
+	for _, t := range totals {
+		response.Totals = append(response.Totals, &pb.TagTotal{
+			Tag:         t.Tag,
+			Count:       int32(t.Count),
+			TotalCredit: t.TotalCredit,
+			TotalDebit:  t.TotalDebit,
+			Total:       t.Total,
+		})
+	}
+	return response, nil
+}
+
+func refundStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidReversalKind), errors.Is(err, domain.ErrRefundNotCredit),
+		errors.Is(err, domain.ErrRefundOriginalDebit), errors.Is(err, domain.ErrRefundAccountMismatch),
+		errors.Is(err, domain.ErrRefundReversalPartial):
+		return status.Errorf(codes.InvalidArgument, "invalid refund link: %v", err)
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return status.Errorf(codes.NotFound, "failed to link refund: %v", err)
+	case errors.Is(err, domain.ErrRefundAlreadyLinked), errors.Is(err, domain.ErrRefundExceedsOriginal),
+		errors.Is(err, domain.ErrTransactionVoided), errors.Is(err, domain.ErrTransactionNotPosted),
+		errors.Is(err, domain.ErrInstallmentPlanLocked):
+		return status.Errorf(codes.FailedPrecondition, "failed to link refund: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to link refund: %v", err)
+	}
+}
+
+func correctionStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrCorrectionReason), errors.Is(err, domain.ErrCorrectionActor),
+		errors.Is(err, domain.ErrInvalidCorrectionAmount), errors.Is(err, domain.ErrNothingToAmend):
+		return status.Errorf(codes.InvalidArgument, "invalid correction: %v", err)
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return status.Errorf(codes.NotFound, "failed to correct transaction: %v", err)
+	case errors.Is(err, domain.ErrTransactionVoided), errors.Is(err, domain.ErrTransferLegCorrection),
+		errors.Is(err, domain.ErrTransactionNotPosted), errors.Is(err, domain.ErrInstallmentPlanLocked),
+		errors.Is(err, domain.ErrDisputeMovementLocked):
+		return status.Errorf(codes.FailedPrecondition, "failed to correct transaction: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to correct transaction: %v", err)
+	}
+}
+
+func (s *TransactionServer) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.Transfer, error) {
+	sourceID, err := uuid.Parse(req.SourceAccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source account ID: %v", err)
+	}
+	destinationID, err := uuid.Parse(req.DestinationAccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid destination account ID: %v", err)
+	}
+
+	transfer, _, err := s.transfers.CreateTransfer(ctx, sourceID, destinationID, req.Amount, req.Description, req.IdempotencyKey)
+	if err != nil {
+		return nil, transferStatusError(err)
+	}
+
+	return toProtoTransfer(transfer), nil
+}
+
+func (s *TransactionServer) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.Transfer, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transfer ID: %v", err)
+	}
+
+	transfer, err := s.transfers.GetTransfer(ctx, id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get transfer: %v", err)
+	}
+	if transfer == nil {
+		return nil, status.Errorf(codes.NotFound, "transfer %s not found", id)
+	}
+
+	return toProtoTransfer(transfer), nil
+}
+
+func toProtoTransfer(transfer *domain.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                   transfer.ID.String(),
+		SourceAccountId:      transfer.SourceAccountID.String(),
+		DestinationAccountId: transfer.DestinationAccountID.String(),
+		Amount:               transfer.Amount,
+		Description:          transfer.Description,
+		IdempotencyKey:       transfer.IdempotencyKey,
+		DebitTransactionId:   transfer.DebitTransactionID.String(),
+		CreditTransactionId:  transfer.CreditTransactionID.String(),
+		CreatedAt:            timestamppb.New(time.Unix(transfer.CreatedAt, 0)),
+	}
+}
+
+func transferStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTransferAmount), errors.Is(err, domain.ErrSameAccountTransfer):
+		return status.Errorf(codes.InvalidArgument, "invalid transfer: %v", err)
+	case errors.Is(err, domain.ErrAccountNotFound):
+		return status.Errorf(codes.NotFound, "failed to create transfer: %v", err)
+	case errors.Is(err, domain.ErrAccountInactive):
+		return status.Errorf(codes.FailedPrecondition, "failed to create transfer: %v", err)
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return status.Errorf(codes.AlreadyExists, "failed to create transfer: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to create transfer: %v", err)
+	}
 }
 
 func (s *TransactionServer) GetTransactionSummary(ctx context.Context, req *pb.GetTransactionSummaryRequest) (*pb.TransactionSummary, error) {
@@ -51,17 +446,467 @@ func (s *TransactionServer) GetTransactionSummary(ctx context.Context, req *pb.G
 		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
 	}
 
-	summary, err := s.service.GetTransactionSummary(ctx, accountID)
+	search, err := domain.ParseSearchQuery(req.Query)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get transaction summary: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+
+	if req.DetailsLimit < 0 || req.DetailsLimit > maxSummaryDetails || req.DetailsOffset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "details limit must be between 0 and %d and offset non-negative", maxSummaryDetails)
 	}
 
-	return &pb.TransactionSummary{
-		TotalBalance:  summary.TotalBalance,
-		TotalCount:    int32(summary.TotalCount),
-		AverageCredit: summary.AverageCredit,
-		AverageDebit:  summary.AverageDebit,
-	}, nil
+	opts := domain.SummaryOptions{
+		IncludePending:  req.IncludePending,
+		Search:          search,
+		Granularity:     req.Granularity,
+		CycleClosingDay: int(req.ClosingDay),
+		DetailLimit:     req.DetailsLimit,
+		DetailOffset:    req.DetailsOffset,
+	}
+	if req.From != nil {
+		opts.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		opts.To = req.To.AsTime()
+	}
+	summary, err := s.service.GetTransactionSummary(ctx, accountID, opts)
+	if err != nil {
+		return nil, summaryStatusError(err)
+	}
+
+	categories := make([]*pb.CategoryTotal, 0, len(summary.Categories))
+	for _, c := range summary.Categories {
+		categories = append(categories, &pb.CategoryTotal{
+			Category: c.Category,
+			Total:    c.Total,
+			Count:    int32(c.Count),
+		})
+	}
+
+	response := &pb.TransactionSummary{
+		TotalBalance:       summary.TotalBalance,
+		TotalCount:         int32(summary.TotalCount),
+		AverageCredit:      summary.AverageCredit,
+		AverageDebit:       summary.AverageDebit,
+		CreditCount:        int32(summary.CreditCount),
+		DebitCount:         int32(summary.DebitCount),
+		TotalCredit:        summary.TotalCredit,
+		TotalDebit:         summary.TotalDebit,
+		RefundCount:        int32(summary.RefundCount),
+		TotalRefunds:       summary.TotalRefunds,
+		NetSpend:           summary.NetSpend,
+		Categories:         categories,
+		InstallmentBalance: summary.InstallmentBalance,
+	}
+	if summary.Rewards != nil {
+		response.PointsBalance = summary.Rewards.Balance
+		response.PointsEarned = summary.Rewards.Earned
+		response.PointsRedeemed = summary.Rewards.Redeemed
+	}
+	for _, sub := range summary.Subscriptions {
+		response.Subscriptions = append(response.Subscriptions, &pb.Subscription{
+			Id:               sub.ID.String(),
+			Merchant:         sub.Merchant,
+			Category:         sub.Category,
+			Cadence:          sub.Cadence,
+			AverageAmount:    sub.AverageAmount,
+			LastAmount:       sub.LastAmount,
+			LastChargeDate:   timestamppb.New(sub.LastChargeDate),
+			NextExpectedDate: timestamppb.New(sub.NextExpectedDate),
+			ChargeCount:      int32(sub.ChargeCount),
+			Status:           sub.Status,
+		})
+	}
+	for _, b := range summary.Budgets {
+		response.Budgets = append(response.Budgets, &pb.BudgetStatus{
+			BudgetId:  b.Budget.ID.String(),
+			Category:  b.Budget.Category,
+			Amount:    b.Budget.Amount,
+			Month:     timestamppb.New(b.Month),
+			Spent:     b.Spent,
+			Remaining: b.Remaining,
+			Percent:   b.Percent,
+			Threshold: int32(b.Threshold),
+		})
+	}
+
+	// The page is spread over the months; return it newest first
+	var details []*pb.Transaction
+	for _, monthly := range summary.Monthly {
+		for i := range monthly.Transactions {
+			details = append(details, toProtoTransaction(&monthly.Transactions[i]))
+		}
+	}
+	sort.Slice(details, func(i, j int) bool {
+		a, b := details[i].InputDate.AsTime(), details[j].InputDate.AsTime()
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return details[i].Id < details[j].Id
+	})
+	response.Transactions = details
+
+	response.Granularity = summary.Granularity
+	if !summary.From.IsZero() {
+		response.From = timestamppb.New(summary.From)
+	}
+	if !summary.To.IsZero() {
+		response.To = timestamppb.New(summary.To)
+	}
+	for _, p := range summary.Periods {
+		period := &pb.SummaryPeriod{
+			Label:       p.Label,
+			Start:       timestamppb.New(p.Start),
+			End:         timestamppb.New(p.End),
+			Count:       int32(p.Count),
+			CreditCount: int32(p.CreditCount),
+			Credits:     p.Credits,
+			DebitCount:  int32(p.DebitCount),
+			Debits:      p.Debits,
+			RefundCount: int32(p.RefundCount),
+			Refunds:     p.Refunds,
+			NetSpend:    p.NetSpend,
+			Net:         p.Net,
+		}
+		if p.Change != nil {
+			period.Change = &pb.PeriodChange{
+				Count:           int32(p.Change.Count),
+				Credits:         p.Change.Credits,
+				Debits:          p.Change.Debits,
+				NetSpend:        p.Change.NetSpend,
+				Net:             p.Change.Net,
+				NetSpendPercent: p.Change.NetSpendPercent,
+			}
+		}
+		response.Periods = append(response.Periods, period)
+	}
+	return response, nil
+}
+
+func summaryStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGranularity), errors.Is(err, domain.ErrInvalidSummaryRange),
+		errors.Is(err, domain.ErrInvalidClosingDay), errors.Is(err, domain.ErrTooManyPeriods):
+		return status.Errorf(codes.InvalidArgument, "invalid summary: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to get transaction summary: %v", err)
+	}
 }
 
 // Implement other gRPC methods (GetTransaction, ListTransactions) similarly
+
+func (s *TransactionServer) OpenDispute(ctx context.Context, req *pb.OpenDisputeRequest) (*pb.Dispute, error) {
+	transactionID, err := uuid.Parse(req.TransactionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction ID: %v", err)
+	}
+
+	dispute, err := s.disputes.Open(ctx, transactionID, req.Amount, req.ReasonCode, req.Description)
+	if err != nil {
+		return nil, disputeStatusError(err)
+	}
+	return convertDisputeToPB(dispute), nil
+}
+
+func (s *TransactionServer) GetDispute(ctx context.Context, req *pb.GetDisputeRequest) (*pb.Dispute, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid dispute ID: %v", err)
+	}
+
+	dispute, err := s.disputes.Get(ctx, id)
+	if err != nil {
+		return nil, disputeStatusError(err)
+	}
+	return convertDisputeToPB(dispute), nil
+}
+
+func (s *TransactionServer) ListDisputes(ctx context.Context, req *pb.ListDisputesRequest) (*pb.DisputeList, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	disputes, err := s.disputes.ListByAccount(ctx, accountID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list disputes: %v", err)
+	}
+
+	response := &pb.DisputeList{Disputes: make([]*pb.Dispute, 0, len(disputes))}
+	for _, d := range disputes {
+		response.Disputes = append(response.Disputes, convertDisputeToPB(d))
+	}
+	return response, nil
+}
+
+func (s *TransactionServer) TransitionDispute(ctx context.Context, req *pb.TransitionDisputeRequest) (*pb.Dispute, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid dispute ID: %v", err)
+	}
+
+	change, err := s.disputes.Transition(ctx, id, req.Status, req.Note)
+	if err != nil {
+		return nil, disputeStatusError(err)
+	}
+	return convertDisputeToPB(change.Dispute), nil
+}
+
+func (s *TransactionServer) AddDisputeAttachment(ctx context.Context, req *pb.AddDisputeAttachmentRequest) (*pb.DisputeAttachment, error) {
+	id, err := uuid.Parse(req.DisputeId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid dispute ID: %v", err)
+	}
+
+	attachment, err := s.disputes.AddAttachment(ctx, id, req.FileName, req.ContentType, int64(len(req.Content)), bytes.NewReader(req.Content))
+	if err != nil {
+		return nil, disputeStatusError(err)
+	}
+	return convertDisputeAttachmentToPB(*attachment), nil
+}
+
+func convertDisputeToPB(d *domain.Dispute) *pb.Dispute {
+	dispute := &pb.Dispute{
+		Id:                   d.ID.String(),
+		AccountId:            d.AccountID.String(),
+		TransactionId:        d.TransactionID.String(),
+		Amount:               d.Amount,
+		ReasonCode:           d.ReasonCode,
+		Description:          d.Description,
+		Status:               d.Status,
+		ProvisionalCreditDue: timestamppb.New(d.ProvisionalCreditDue),
+		Deadline:             timestamppb.New(d.Deadline),
+		Attachments:          make([]*pb.DisputeAttachment, 0, len(d.Attachments)),
+		History:              make([]*pb.DisputeEvent, 0, len(d.Events)),
+		CreatedAt:            timestamppb.New(time.Unix(d.CreatedAt, 0)),
+		UpdatedAt:            timestamppb.New(time.Unix(d.UpdatedAt, 0)),
+	}
+	if d.CreditTransactionID.Valid {
+		dispute.CreditTransactionId = d.CreditTransactionID.UUID.String()
+	}
+	if d.ReversalTransactionID.Valid {
+		dispute.ReversalTransactionId = d.ReversalTransactionID.UUID.String()
+	}
+	for _, a := range d.Attachments {
+		dispute.Attachments = append(dispute.Attachments, convertDisputeAttachmentToPB(a))
+	}
+	for _, e := range d.Events {
+		event := &pb.DisputeEvent{
+			FromStatus: e.FromStatus,
+			ToStatus:   e.ToStatus,
+			Note:       e.Note,
+			CreatedAt:  timestamppb.New(time.Unix(e.CreatedAt, 0)),
+		}
+		if e.TransactionID.Valid {
+			event.TransactionId = e.TransactionID.UUID.String()
+		}
+		dispute.History = append(dispute.History, event)
+	}
+	return dispute
+}
+
+func convertDisputeAttachmentToPB(a domain.DisputeAttachment) *pb.DisputeAttachment {
+	return &pb.DisputeAttachment{
+		Id:          a.ID.String(),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   timestamppb.New(time.Unix(a.CreatedAt, 0)),
+	}
+}
+
+func disputeStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidDisputeReason), errors.Is(err, domain.ErrInvalidDisputeAmount),
+		errors.Is(err, domain.ErrInvalidDisputeAttachment), errors.Is(err, domain.ErrDisputeNotDebit):
+		return status.Errorf(codes.InvalidArgument, "invalid dispute: %v", err)
+	case errors.Is(err, domain.ErrTransactionNotFound), errors.Is(err, domain.ErrDisputeNotFound):
+		return status.Errorf(codes.NotFound, "dispute failed: %v", err)
+	case errors.Is(err, domain.ErrDisputeExists):
+		return status.Errorf(codes.AlreadyExists, "dispute failed: %v", err)
+	case errors.Is(err, domain.ErrDisputeClosed), errors.Is(err, domain.ErrInvalidDisputeTransition),
+		errors.Is(err, domain.ErrDisputeWindowClosed), errors.Is(err, domain.ErrInstallmentPlanLocked):
+		return status.Errorf(codes.FailedPrecondition, "dispute failed: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "dispute failed: %v", err)
+	}
+}
+
+func (s *TransactionServer) CreateSavingsGoal(ctx context.Context, req *pb.CreateSavingsGoalRequest) (*pb.SavingsGoal, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+	if req.TargetDate == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "target date is required")
+	}
+
+	var startDate time.Time
+	if req.StartDate != nil {
+		startDate = req.StartDate.AsTime()
+	}
+
+	progress, err := s.goals.Create(ctx, accountID, req.Name, req.Category, req.TargetAmount, req.TargetDate.AsTime(), startDate)
+	if err != nil {
+		return nil, goalStatusError(err)
+	}
+	return convertGoalProgressToPB(progress), nil
+}
+
+func (s *TransactionServer) GetSavingsGoal(ctx context.Context, req *pb.GetSavingsGoalRequest) (*pb.SavingsGoal, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid goal ID: %v", err)
+	}
+
+	progress, err := s.goals.Get(ctx, id)
+	if err != nil {
+		return nil, goalStatusError(err)
+	}
+	return convertGoalProgressToPB(progress), nil
+}
+
+func (s *TransactionServer) ListSavingsGoals(ctx context.Context, req *pb.ListSavingsGoalsRequest) (*pb.SavingsGoalList, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	progress, err := s.goals.ListByAccount(ctx, accountID)
+	if err != nil {
+		return nil, goalStatusError(err)
+	}
+
+	response := &pb.SavingsGoalList{Goals: make([]*pb.SavingsGoal, 0, len(progress))}
+	for _, p := range progress {
+		response.Goals = append(response.Goals, convertGoalProgressToPB(p))
+	}
+	return response, nil
+}
+
+func (s *TransactionServer) DeleteSavingsGoal(ctx context.Context, req *pb.DeleteSavingsGoalRequest) (*pb.DeleteSavingsGoalResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid goal ID: %v", err)
+	}
+
+	if err := s.goals.Delete(ctx, id); err != nil {
+		return nil, goalStatusError(err)
+	}
+	return &pb.DeleteSavingsGoalResponse{}, nil
+}
+
+func convertGoalProgressToPB(p domain.GoalProgress) *pb.SavingsGoal {
+	goal := &pb.SavingsGoal{
+		Id:            p.Goal.ID.String(),
+		AccountId:     p.Goal.AccountID.String(),
+		Name:          p.Goal.Name,
+		TargetAmount:  p.Goal.TargetAmount,
+		TargetDate:    timestamppb.New(p.Goal.TargetDate),
+		Category:      p.Goal.Category,
+		StartDate:     timestamppb.New(p.Goal.StartDate),
+		Saved:         p.Saved,
+		Remaining:     p.Remaining,
+		Percent:       p.Percent,
+		MonthlyRate:   p.MonthlyRate,
+		MonthlyNeeded: p.MonthlyNeeded,
+		Status:        p.Status,
+		CreatedAt:     timestamppb.New(time.Unix(p.Goal.CreatedAt, 0)),
+		UpdatedAt:     timestamppb.New(time.Unix(p.Goal.UpdatedAt, 0)),
+	}
+	if !p.ProjectedDate.IsZero() {
+		goal.ProjectedDate = timestamppb.New(p.ProjectedDate)
+	}
+	return goal
+}
+
+func goalStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSavingsGoal):
+		return status.Errorf(codes.InvalidArgument, "invalid savings goal: %v", err)
+	case errors.Is(err, domain.ErrSavingsGoalNotFound), errors.Is(err, domain.ErrAccountNotFound):
+		return status.Errorf(codes.NotFound, "savings goal failed: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "savings goal failed: %v", err)
+	}
+}
+
+// StreamBalanceSeries sends the daily balance of an account one day at a
+// time, the last 30 days when the range is unset.
+func (s *TransactionServer) StreamBalanceSeries(req *pb.StreamBalanceSeriesRequest, stream pb.TransactionService_StreamBalanceSeriesServer) error {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	from := to.AddDate(0, 0, -30)
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+
+	err = s.ledger.StreamBalanceSeries(stream.Context(), accountID, from, to, req.Intraday, func(d domain.DailyBalance) error {
+		return stream.Send(convertDailyBalanceToPB(d))
+	})
+	if err != nil {
+		return balanceStatusError(err)
+	}
+	return nil
+}
+
+func (s *TransactionServer) GetBalanceAsOf(ctx context.Context, req *pb.GetBalanceAsOfRequest) (*pb.DailyBalance, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account ID: %v", err)
+	}
+
+	date := time.Now().UTC()
+	if req.Date != nil {
+		date = req.Date.AsTime()
+	}
+
+	balance, err := s.ledger.GetBalanceAsOf(ctx, accountID, date)
+	if err != nil {
+		return nil, balanceStatusError(err)
+	}
+	return convertDailyBalanceToPB(*balance), nil
+}
+
+func convertDailyBalanceToPB(d domain.DailyBalance) *pb.DailyBalance {
+	balance := &pb.DailyBalance{
+		Date:           timestamppb.New(d.Date),
+		OpeningBalance: d.OpeningBalance,
+		Credits:        d.Credits,
+		Debits:         d.Debits,
+		ClosingBalance: d.ClosingBalance,
+		PostingCount:   int32(d.PostingCount),
+	}
+	for _, p := range d.Postings {
+		point := &pb.BalancePoint{
+			JournalEntryId: p.JournalEntryID.String(),
+			Description:    p.Description,
+			EffectiveAt:    timestamppb.New(p.EffectiveDate),
+			Amount:         p.Amount,
+			Balance:        p.RunningBalance,
+		}
+		if p.TransactionID.Valid {
+			point.TransactionId = p.TransactionID.UUID.String()
+		}
+		balance.Postings = append(balance.Postings, point)
+	}
+	return balance
+}
+
+func balanceStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBalanceRange), errors.Is(err, domain.ErrBalanceRangeTooLong):
+		return status.Errorf(codes.InvalidArgument, "invalid balance range: %v", err)
+	case errors.Is(err, domain.ErrLedgerAccountNotFound):
+		return status.Errorf(codes.NotFound, "balance failed: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "balance failed: %v", err)
+	}
+}
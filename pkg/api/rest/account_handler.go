@@ -2,6 +2,7 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/AguilaMike/Stori_Challenge_Go/internal/account/application"
@@ -29,10 +30,35 @@ func (h *AccountHandler) Manager(w http.ResponseWriter, r *http.Request) {
 }
 
 func convertAccountToDTO(account *domain.Account) AccountDTO {
-	return AccountDTO{
+	dto := AccountDTO{
 		ID:       account.ID.String(),
 		Nickname: account.Nickname,
 		Email:    account.Email,
+		Balance:  account.Balance,
+		Type:     account.Type,
+	}
+	if dto.Type == "" {
+		dto.Type = domain.AccountTypeDebit
+	}
+	if account.IsCreditCard() {
+		dto.CreditLimit = account.CreditLimit
+		dto.AvailableCredit = account.AvailableCredit()
+		dto.StatementClosingDay = account.StatementClosingDay
+		dto.PaymentDueDays = account.PaymentDueDays
+	}
+	return dto
+}
+
+func convertCardStatementToDTO(statement *domain.CardStatement) *CardStatementDTO {
+	return &CardStatementDTO{
+		PeriodStart:      statement.PeriodStart.Format(dateLayout),
+		ClosingDate:      statement.ClosingDate.Format(dateLayout),
+		DueDate:          statement.DueDate.Format(dateLayout),
+		OpeningBalance:   statement.OpeningBalance,
+		Purchases:        statement.Purchases,
+		Payments:         statement.Payments,
+		StatementBalance: statement.StatementBalance,
+		MinimumPayment:   statement.MinimumPayment,
 	}
 }
 
@@ -55,8 +81,12 @@ func (h *AccountHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
 
 func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		Nickname string `json:"nickname"`
-		Email    string `json:"email"`
+		Nickname            string  `json:"nickname"`
+		Email               string  `json:"email"`
+		Type                string  `json:"type"`
+		CreditLimit         float64 `json:"credit_limit"`
+		StatementClosingDay int     `json:"statement_closing_day"`
+		PaymentDueDays      int     `json:"payment_due_days"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
@@ -64,9 +94,22 @@ func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	account, err := h.service.CreateAccount(r.Context(), input.Nickname, input.Email)
+	var account *domain.Account
+	var err error
+	switch input.Type {
+	case "", domain.AccountTypeDebit:
+		account, err = h.service.CreateAccount(r.Context(), input.Nickname, input.Email)
+	case domain.AccountTypeCreditCard:
+		account, err = h.service.CreateCreditCardAccount(r.Context(), input.Nickname, input.Email, domain.CardTerms{
+			CreditLimit:         input.CreditLimit,
+			StatementClosingDay: input.StatementClosingDay,
+			PaymentDueDays:      input.PaymentDueDays,
+		})
+	default:
+		err = domain.ErrInvalidAccountType
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), accountErrorStatus(err))
 		return
 	}
 
@@ -95,12 +138,105 @@ func (h *AccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if account == nil {
+		http.Error(w, domain.ErrAccountNotFound.Error(), http.StatusNotFound)
+		return
+	}
 
 	// Convertir la estructura de dominio a una estructura DTO
 	accountDTO := convertAccountToDTO(account)
 
+	statement, err := h.service.GetCurrentStatement(r.Context(), account)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if statement != nil {
+		accountDTO.Statement = convertCardStatementToDTO(statement)
+	}
+
+	accountDTO.InstallmentBalance, err = h.service.GetInstallmentBalance(r.Context(), account.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(accountDTO)
 }
 
+func (h *AccountHandler) GetStatements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	cycles, err := parseIntParam(r, "cycles", 1)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statements, err := h.service.GetStatements(r.Context(), id, int(cycles))
+	if err != nil {
+		http.Error(w, err.Error(), accountErrorStatus(err))
+		return
+	}
+
+	response := make([]*CardStatementDTO, 0, len(statements))
+	for _, s := range statements {
+		response = append(response, convertCardStatementToDTO(s))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func accountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAccountType), errors.Is(err, domain.ErrInvalidCreditLimit),
+		errors.Is(err, domain.ErrInvalidClosingDay), errors.Is(err, domain.ErrInvalidPaymentDays),
+		errors.Is(err, domain.ErrInvalidCycleRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrNotCreditCard):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *AccountHandler) GetBalanceDrifts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	drifts, err := h.service.GetBalanceDrifts(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]BalanceDriftDTO, 0, len(drifts))
+	for _, d := range drifts {
+		response = append(response, BalanceDriftDTO{
+			AccountID:     d.AccountID.String(),
+			Balance:       d.Balance,
+			LedgerBalance: d.LedgerBalance,
+			Drift:         d.Drift,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // Implement other handler methods (UpdateAccount, DeleteAccount) similarly
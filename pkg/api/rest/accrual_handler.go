@@ -0,0 +1,182 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+)
+
+type AccrualHandler struct {
+	service *transaction.AccrualService
+	nats    *nats.NatsClient
+}
+
+func NewAccrualHandler(service *transaction.AccrualService, nc *nats.NatsClient) *AccrualHandler {
+	return &AccrualHandler{
+		service: service,
+		nats:    nc,
+	}
+}
+
+// RunAccruals computes the accruals of a date range. Dry runs are answered
+// with the report; otherwise the run is queued for the worker.
+func (h *AccrualHandler) RunAccruals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input struct {
+		From   string `json:"from"`
+		To     string `json:"to"`
+		DryRun bool   `json:"dry_run"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	from, err := time.Parse(dateLayout, input.From)
+	if err != nil {
+		http.Error(w, "invalid from date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	to, err := time.Parse(dateLayout, input.To)
+	if err != nil {
+		http.Error(w, "invalid to date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	if input.DryRun {
+		run, err := h.service.RunAccruals(r.Context(), from, to, true)
+		if err != nil {
+			log.Printf("Error running accruals: %v", err)
+			http.Error(w, err.Error(), accrualErrorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(convertAccrualRunToDTO(run))
+		return
+	}
+
+	if err := accountDomain.ValidateAccrualRange(from, to, time.Now().UTC().Truncate(24*time.Hour)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.nats.Publish(accountDomain.AccrualRunRequestedEvent, map[string]time.Time{
+		"from": from,
+		"to":   to,
+	})
+	if err != nil {
+		log.Printf("Error requesting accrual run: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]string{
+		"message": "Accrual run queued",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *AccrualHandler) ListAccruals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now().UTC()
+	from, err := parseDateParam(r, "from", startOfMonth(now))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := parseDateParam(r, "to", now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	accruals, err := h.service.ListAccruals(r.Context(), accountID, from, to)
+	if err != nil {
+		log.Printf("Error listing accruals: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dtos := make([]AccrualDTO, 0, len(accruals))
+	for _, a := range accruals {
+		dtos = append(dtos, convertAccrualToDTO(a))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dtos)
+}
+
+func convertAccrualToDTO(a *accountDomain.Accrual) AccrualDTO {
+	dto := AccrualDTO{
+		AccountID:     a.AccountID.String(),
+		Kind:          a.Kind,
+		AccrualDate:   a.AccrualDate.Format(dateLayout),
+		StatementDate: a.StatementDate.Format(dateLayout),
+		Basis:         a.Basis,
+		DailyRate:     a.DailyRate,
+		Amount:        a.Amount,
+	}
+	// Accruals computed by a dry run are not stored
+	if a.TransactionID != uuid.Nil {
+		dto.ID = a.ID.String()
+		dto.TransactionID = a.TransactionID.String()
+	}
+	return dto
+}
+
+func convertAccrualRunToDTO(run *accountDomain.AccrualRun) AccrualRunDTO {
+	lines := make([]AccrualLineDTO, 0, len(run.Lines))
+	for _, l := range run.Lines {
+		lines = append(lines, AccrualLineDTO{
+			AccrualDTO:   convertAccrualToDTO(l.Accrual),
+			Existing:     l.Existing,
+			PostedAmount: l.PostedAmount,
+			Mismatched:   l.Mismatched(),
+		})
+	}
+
+	return AccrualRunDTO{
+		From:       run.From.Format(dateLayout),
+		To:         run.To.Format(dateLayout),
+		DryRun:     run.DryRun,
+		Created:    run.Created,
+		Existing:   run.Existing,
+		Mismatched: run.Mismatched,
+		Lines:      lines,
+	}
+}
+
+func accrualErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, accountDomain.ErrInvalidAccrualRange):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
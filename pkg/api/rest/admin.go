@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// AdminKeyHeader carries the key of the admin endpoints.
+const AdminKeyHeader = "X-Admin-Key"
+
+// RequireAdmin serves next only to requests carrying the admin key. The
+// admin endpoints are disabled when no key is configured.
+func RequireAdmin(key string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if key == "" {
+			http.Error(w, "Admin API is disabled", http.StatusForbidden)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(AdminKeyHeader)), []byte(key)) != 1 {
+			http.Error(w, "Invalid admin key", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
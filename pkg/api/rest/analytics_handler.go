@@ -0,0 +1,209 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	analytics "github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/domain"
+)
+
+// defaultAnalyticsDays is the range of the analytics without dates.
+const defaultAnalyticsDays = 30
+
+type AnalyticsHandler struct {
+	service *analytics.AnalyticsService
+}
+
+func NewAnalyticsHandler(service *analytics.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// GetActivity returns the active accounts and the transaction volume and
+// value per day across all accounts.
+func (h *AnalyticsHandler) GetActivity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	from, to, err := parseAnalyticsRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	activity, err := h.service.GetActivity(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, err.Error(), analyticsErrorStatus(err))
+		return
+	}
+
+	dto := ActivityDTO{
+		From:             activity.From.Format(dateLayout),
+		To:               activity.To.AddDate(0, 0, -1).Format(dateLayout),
+		ActiveAccounts:   activity.ActiveAccounts,
+		TransactionCount: activity.TransactionCount,
+		TotalCredit:      activity.TotalCredit,
+		TotalDebit:       activity.TotalDebit,
+		Days:             make([]DailyActivityDTO, 0, len(activity.Days)),
+	}
+	for _, d := range activity.Days {
+		dto.Days = append(dto.Days, DailyActivityDTO{
+			Date:             d.Date.Format(dateLayout),
+			ActiveAccounts:   d.ActiveAccounts,
+			TransactionCount: d.TransactionCount,
+			Credit:           d.Credit,
+			Debit:            d.Debit,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto)
+}
+
+// GetImportStats returns the success rate and average file size of the
+// imports.
+func (h *AnalyticsHandler) GetImportStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	from, to, err := parseAnalyticsRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.service.GetImportStats(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, err.Error(), analyticsErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ImportStatsDTO{
+		From:             stats.From.Format(dateLayout),
+		To:               stats.To.AddDate(0, 0, -1).Format(dateLayout),
+		Total:            stats.Total,
+		Succeeded:        stats.Succeeded,
+		Failed:           stats.Failed,
+		SuccessRate:      stats.SuccessRate,
+		AverageFileSize:  stats.AverageFileSize,
+		TotalFileSize:    stats.TotalFileSize,
+		TransactionCount: stats.TransactionCount,
+	})
+}
+
+// GetBalanceDistribution returns how the current balances of the active
+// accounts spread.
+func (h *AnalyticsHandler) GetBalanceDistribution(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	distribution, err := h.service.GetBalanceDistribution(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), analyticsErrorStatus(err))
+		return
+	}
+
+	dto := BalanceDistributionDTO{
+		AccountCount: distribution.AccountCount,
+		Total:        distribution.Total,
+		Average:      distribution.Average,
+		Min:          distribution.Min,
+		Max:          distribution.Max,
+		Percentiles:  make([]BalancePercentileDTO, 0, len(distribution.Percentiles)),
+		Buckets:      make([]BalanceBucketDTO, 0, len(distribution.Buckets)),
+	}
+	for _, p := range distribution.Percentiles {
+		dto.Percentiles = append(dto.Percentiles, BalancePercentileDTO{
+			Percentile: p.Percentile,
+			Balance:    p.Balance,
+		})
+	}
+	for _, b := range distribution.Buckets {
+		dto.Buckets = append(dto.Buckets, BalanceBucketDTO{
+			From:         b.From,
+			To:           b.To,
+			AccountCount: b.AccountCount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto)
+}
+
+// ListTopMerchants returns the merchants with the most purchases across
+// all accounts, up to limit, 10 by default.
+func (h *AnalyticsHandler) ListTopMerchants(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	from, to, err := parseAnalyticsRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := parseIntParam(r, "limit", domain.DefaultTopMerchants)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	merchants, err := h.service.ListTopMerchants(r.Context(), from, to, int(limit))
+	if err != nil {
+		http.Error(w, err.Error(), analyticsErrorStatus(err))
+		return
+	}
+
+	dtos := make([]TopMerchantDTO, 0, len(merchants))
+	for _, m := range merchants {
+		dtos = append(dtos, TopMerchantDTO{
+			Merchant:         m.Merchant,
+			TransactionCount: m.TransactionCount,
+			AccountCount:     m.AccountCount,
+			Total:            m.Total,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dtos)
+}
+
+// parseAnalyticsRange reads the from and to dates, both included, which
+// default to the last 30 days, and returns to as the day after.
+func parseAnalyticsRange(r *http.Request) (time.Time, time.Time, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to, err := parseDateParam(r, "to", today)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	from, err := parseDateParam(r, "from", to.AddDate(0, 0, -defaultAnalyticsDays+1))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must not be before from")
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func analyticsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRange),
+		errors.Is(err, domain.ErrRangeTooLong),
+		errors.Is(err, domain.ErrInvalidTopLimit):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type AnnotationHandler struct {
+	service *transaction.AnnotationService
+}
+
+func NewAnnotationHandler(service *transaction.AnnotationService) *AnnotationHandler {
+	return &AnnotationHandler{
+		service: service,
+	}
+}
+
+func (h *AnnotationHandler) AnnotateTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	var input AnnotationDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.service.AnnotateTransaction(r.Context(), id, input.Tags, input.Note)
+	if err != nil {
+		log.Printf("Error annotating transaction: %v", err)
+		http.Error(w, err.Error(), annotationErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionToDTO(t))
+}
+
+func annotationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTag), errors.Is(err, domain.ErrTooManyTags), errors.Is(err, domain.ErrNoteTooLong):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,185 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type AnomalyHandler struct {
+	service *transaction.AnomalyService
+}
+
+func NewAnomalyHandler(service *transaction.AnomalyService) *AnomalyHandler {
+	return &AnomalyHandler{
+		service: service,
+	}
+}
+
+// ListQueue is the admin review queue: the alerts in the status given by
+// the status parameter, open by default, highest score first.
+func (h *AnomalyHandler) ListQueue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit, err := parseIntParam(r, "limit", defaultSearchLimit)
+	if err != nil || limit <= 0 || limit > maxSearchLimit {
+		http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit), http.StatusBadRequest)
+		return
+	}
+	offset, err := parseIntParam(r, "offset", 0)
+	if err != nil || offset < 0 {
+		http.Error(w, "offset must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+
+	alerts, err := h.service.ListQueue(r.Context(), r.URL.Query().Get("status"), limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), anomalyErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionAlertsToDTO(alerts))
+}
+
+func (h *AnomalyHandler) GetAlert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid alert ID", http.StatusBadRequest)
+		return
+	}
+
+	alert, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), anomalyErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionAlertToDTO(alert))
+}
+
+func (h *AnomalyHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	alerts, err := h.service.ListByAccount(r.Context(), accountID)
+	if err != nil {
+		http.Error(w, err.Error(), anomalyErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionAlertsToDTO(alerts))
+}
+
+func (h *AnomalyHandler) ReviewAlert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid alert ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Status   string `json:"status"`
+		Reviewer string `json:"reviewer"`
+		Note     string `json:"note"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if input.Reviewer == "" {
+		http.Error(w, "reviewer is required", http.StatusBadRequest)
+		return
+	}
+
+	alert, err := h.service.Review(r.Context(), id, input.Status, input.Reviewer, input.Note)
+	if err != nil {
+		log.Printf("Error reviewing transaction alert: %v", err)
+		http.Error(w, err.Error(), anomalyErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionAlertToDTO(alert))
+}
+
+func convertTransactionAlertsToDTO(alerts []*domain.TransactionAlert) []TransactionAlertDTO {
+	data := make([]TransactionAlertDTO, 0, len(alerts))
+	for _, a := range alerts {
+		data = append(data, convertTransactionAlertToDTO(a))
+	}
+	return data
+}
+
+func convertTransactionAlertToDTO(a *domain.TransactionAlert) TransactionAlertDTO {
+	dto := TransactionAlertDTO{
+		ID:         a.ID.String(),
+		AccountID:  a.AccountID.String(),
+		Score:      a.Score,
+		Reasons:    make([]AnomalyReasonDTO, 0, len(a.Reasons)),
+		Status:     a.Status,
+		ReviewedBy: a.ReviewedBy,
+		ReviewNote: a.ReviewNote,
+		CreatedAt:  time.Unix(a.CreatedAt, 0).UTC().Format(time.RFC3339),
+	}
+	for _, reason := range a.Reasons {
+		dto.Reasons = append(dto.Reasons, AnomalyReasonDTO{
+			Code:   reason.Code,
+			Weight: reason.Weight,
+			Detail: reason.Detail,
+		})
+	}
+	if a.ReviewedAt > 0 {
+		dto.ReviewedAt = time.Unix(a.ReviewedAt, 0).UTC().Format(time.RFC3339)
+	}
+	if a.Transaction != nil {
+		t := convertTransactionToDTO(a.Transaction)
+		dto.Transaction = &t
+	}
+	return dto
+}
+
+func anomalyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAlertReview):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransactionAlertNotFound), errors.Is(err, domain.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrAlertReviewed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type BudgetHandler struct {
+	service *transaction.BudgetService
+}
+
+func NewBudgetHandler(service *transaction.BudgetService) *BudgetHandler {
+	return &BudgetHandler{
+		service: service,
+	}
+}
+
+// SetBudget creates the budget of a category, or replaces its amount when
+// the account already has one. An empty category sets the overall budget.
+func (h *BudgetHandler) SetBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input struct {
+		AccountID string  `json:"account_id"`
+		Category  string  `json:"category"`
+		Amount    float64 `json:"amount"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	accountID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	budget, err := h.service.SetBudget(r.Context(), accountID, input.Category, input.Amount)
+	if err != nil {
+		log.Printf("Error setting budget: %v", err)
+		http.Error(w, err.Error(), budgetErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(convertBudgetToDTO(budget))
+}
+
+func (h *BudgetHandler) DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid budget ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), budgetErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBudgets reports the budgets of an account against the spend of the
+// month parameter, the current month by default.
+func (h *BudgetHandler) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+	month, err := parseMonthParam(r, "month", time.Now().UTC())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statuses, err := h.service.ListStatus(r.Context(), accountID, month)
+	if err != nil {
+		http.Error(w, err.Error(), budgetErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertBudgetStatusesToDTO(statuses))
+}
+
+func convertBudgetToDTO(b *domain.Budget) BudgetDTO {
+	return BudgetDTO{
+		ID:        b.ID.String(),
+		AccountID: b.AccountID.String(),
+		Category:  b.Category,
+		Amount:    b.Amount,
+		CreatedAt: time.Unix(b.CreatedAt, 0).UTC().Format(time.RFC3339),
+		UpdatedAt: time.Unix(b.UpdatedAt, 0).UTC().Format(time.RFC3339),
+	}
+}
+
+func convertBudgetStatusesToDTO(statuses []domain.BudgetStatus) []BudgetStatusDTO {
+	data := make([]BudgetStatusDTO, 0, len(statuses))
+	for _, s := range statuses {
+		data = append(data, BudgetStatusDTO{
+			Budget:    convertBudgetToDTO(s.Budget),
+			Month:     s.Month.Format("2006-01"),
+			Spent:     s.Spent,
+			Remaining: s.Remaining,
+			Percent:   s.Percent,
+			Threshold: s.Threshold,
+		})
+	}
+	return data
+}
+
+func budgetErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBudget):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrBudgetNotFound), errors.Is(err, domain.ErrAccountNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type CorrectionHandler struct {
+	service *transaction.CorrectionService
+}
+
+func NewCorrectionHandler(service *transaction.CorrectionService) *CorrectionHandler {
+	return &CorrectionHandler{
+		service: service,
+	}
+}
+
+func convertCorrectionToDTO(correction *domain.Correction) CorrectionDTO {
+	dto := CorrectionDTO{
+		ID:                  correction.ID.String(),
+		TransactionID:       correction.TransactionID.String(),
+		VersionID:           correction.VersionID.String(),
+		Kind:                correction.Kind,
+		Reason:              correction.Reason,
+		Actor:               correction.Actor,
+		PreviousAmount:      correction.PreviousAmount,
+		NewAmount:           correction.NewAmount,
+		PreviousDescription: correction.PreviousDescription,
+		NewDescription:      correction.NewDescription,
+		PreviousCategory:    correction.PreviousCategory,
+		NewCategory:         correction.NewCategory,
+		CreatedAt:           time.Unix(correction.CreatedAt, 0).UTC().Format(time.RFC3339),
+	}
+	if correction.JournalEntryID.Valid {
+		dto.JournalEntryID = correction.JournalEntryID.UUID.String()
+	}
+	return dto
+}
+
+func convertCorrectedTransactionToDTO(t *domain.Transaction, correction *domain.Correction) CorrectedTransactionDTO {
+	return CorrectedTransactionDTO{
+		Transaction: convertTransactionToDTO(t),
+		Correction:  convertCorrectionToDTO(correction),
+	}
+}
+
+func (h *CorrectionHandler) AmendTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Amount      *float64 `json:"amount"`
+		Description *string  `json:"description"`
+		Category    *string  `json:"category"`
+		Reason      string   `json:"reason"`
+		Actor       string   `json:"actor"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	amendment := domain.Amendment{
+		Amount:      input.Amount,
+		Description: input.Description,
+		Category:    input.Category,
+	}
+
+	t, correction, err := h.service.AmendTransaction(r.Context(), id, amendment, input.Reason, input.Actor)
+	if err != nil {
+		log.Printf("Error amending transaction: %v", err)
+		http.Error(w, err.Error(), correctionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertCorrectedTransactionToDTO(t, correction))
+}
+
+func (h *CorrectionHandler) VoidTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Reason string `json:"reason"`
+		Actor  string `json:"actor"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t, correction, err := h.service.VoidTransaction(r.Context(), id, input.Reason, input.Actor)
+	if err != nil {
+		log.Printf("Error voiding transaction: %v", err)
+		http.Error(w, err.Error(), correctionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertCorrectedTransactionToDTO(t, correction))
+}
+
+func (h *CorrectionHandler) GetTransactionHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	corrections, err := h.service.GetTransactionHistory(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]CorrectionDTO, 0, len(corrections))
+	for _, c := range corrections {
+		response = append(response, convertCorrectionToDTO(c))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func correctionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrCorrectionReason), errors.Is(err, domain.ErrCorrectionActor),
+		errors.Is(err, domain.ErrInvalidCorrectionAmount), errors.Is(err, domain.ErrNothingToAmend):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrTransactionVoided), errors.Is(err, domain.ErrTransferLegCorrection),
+		errors.Is(err, domain.ErrTransactionNotPosted), errors.Is(err, domain.ErrInstallmentPlanLocked),
+		errors.Is(err, domain.ErrDisputeMovementLocked):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,300 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type DisputeHandler struct {
+	service *transaction.DisputeService
+}
+
+func NewDisputeHandler(service *transaction.DisputeService) *DisputeHandler {
+	return &DisputeHandler{
+		service: service,
+	}
+}
+
+func (h *DisputeHandler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input struct {
+		TransactionID string  `json:"transaction_id"`
+		Amount        float64 `json:"amount"`
+		ReasonCode    string  `json:"reason_code"`
+		Description   string  `json:"description"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transactionID, err := uuid.Parse(input.TransactionID)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	dispute, err := h.service.Open(r.Context(), transactionID, input.Amount, input.ReasonCode, input.Description)
+	if err != nil {
+		log.Printf("Error opening dispute: %v", err)
+		http.Error(w, err.Error(), disputeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(convertDisputeToDTO(dispute))
+}
+
+func (h *DisputeHandler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid dispute ID", http.StatusBadRequest)
+		return
+	}
+
+	dispute, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), disputeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertDisputeToDTO(dispute))
+}
+
+func (h *DisputeHandler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	disputes, err := h.service.ListByAccount(r.Context(), accountID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]DisputeDTO, 0, len(disputes))
+	for _, d := range disputes {
+		response = append(response, convertDisputeToDTO(d))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *DisputeHandler) ListReasons(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := make([]DisputeReasonDTO, 0, len(domain.DisputeReasons))
+	for code, description := range domain.DisputeReasons {
+		response = append(response, DisputeReasonDTO{Code: code, Description: description})
+	}
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Code < response[j].Code
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *DisputeHandler) TransitionDispute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid dispute ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Status string `json:"status"`
+		Note   string `json:"note"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	change, err := h.service.Transition(r.Context(), id, input.Status, input.Note)
+	if err != nil {
+		log.Printf("Error updating dispute: %v", err)
+		http.Error(w, err.Error(), disputeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertDisputeToDTO(change.Dispute))
+}
+
+func (h *DisputeHandler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid dispute ID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, domain.MaxDisputeAttachmentSize+1<<20)
+	if err := r.ParseMultipartForm(domain.MaxDisputeAttachmentSize); err != nil {
+		http.Error(w, "Unable to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.AddAttachment(r.Context(), id, header.Filename, header.Header.Get("Content-Type"), header.Size, file)
+	if err != nil {
+		log.Printf("Error adding dispute attachment: %v", err)
+		http.Error(w, err.Error(), disputeErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(convertDisputeAttachmentToDTO(*attachment))
+}
+
+func (h *DisputeHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid dispute ID", http.StatusBadRequest)
+		return
+	}
+	attachmentID, err := uuid.Parse(r.PathValue("attachment_id"))
+	if err != nil {
+		http.Error(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	attachment, content, err := h.service.OpenAttachment(r.Context(), id, attachmentID)
+	if err != nil {
+		http.Error(w, err.Error(), disputeErrorStatus(err))
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("Error sending dispute attachment: %v", err)
+	}
+}
+
+func convertDisputeToDTO(d *domain.Dispute) DisputeDTO {
+	dto := DisputeDTO{
+		ID:                   d.ID.String(),
+		AccountID:            d.AccountID.String(),
+		TransactionID:        d.TransactionID.String(),
+		Amount:               d.Amount,
+		ReasonCode:           d.ReasonCode,
+		Description:          d.Description,
+		Status:               d.Status,
+		ProvisionalCreditDue: d.ProvisionalCreditDue.Format(dateLayout),
+		Deadline:             d.Deadline.Format(dateLayout),
+		Attachments:          make([]DisputeAttachmentDTO, 0, len(d.Attachments)),
+		History:              make([]DisputeEventDTO, 0, len(d.Events)),
+		CreatedAt:            time.Unix(d.CreatedAt, 0).UTC().Format(time.RFC3339),
+		UpdatedAt:            time.Unix(d.UpdatedAt, 0).UTC().Format(time.RFC3339),
+	}
+	if d.CreditTransactionID.Valid {
+		dto.CreditTransactionID = d.CreditTransactionID.UUID.String()
+	}
+	if d.ReversalTransactionID.Valid {
+		dto.ReversalTransactionID = d.ReversalTransactionID.UUID.String()
+	}
+	for _, a := range d.Attachments {
+		dto.Attachments = append(dto.Attachments, convertDisputeAttachmentToDTO(a))
+	}
+	for _, e := range d.Events {
+		event := DisputeEventDTO{
+			FromStatus: e.FromStatus,
+			ToStatus:   e.ToStatus,
+			Note:       e.Note,
+			CreatedAt:  time.Unix(e.CreatedAt, 0).UTC().Format(time.RFC3339),
+		}
+		if e.TransactionID.Valid {
+			event.TransactionID = e.TransactionID.UUID.String()
+		}
+		dto.History = append(dto.History, event)
+	}
+	return dto
+}
+
+func convertDisputeAttachmentToDTO(a domain.DisputeAttachment) DisputeAttachmentDTO {
+	return DisputeAttachmentDTO{
+		ID:          a.ID.String(),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   time.Unix(a.CreatedAt, 0).UTC().Format(time.RFC3339),
+	}
+}
+
+func disputeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidDisputeReason), errors.Is(err, domain.ErrInvalidDisputeAmount),
+		errors.Is(err, domain.ErrInvalidDisputeAttachment), errors.Is(err, domain.ErrDisputeNotDebit):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransactionNotFound), errors.Is(err, domain.ErrDisputeNotFound),
+		errors.Is(err, domain.ErrDisputeAttachmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrDisputeExists), errors.Is(err, domain.ErrDisputeClosed),
+		errors.Is(err, domain.ErrInvalidDisputeTransition), errors.Is(err, domain.ErrDisputeWindowClosed),
+		errors.Is(err, domain.ErrInstallmentPlanLocked):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,9 +1,35 @@
 package rest
 
 type AccountDTO struct {
-	ID       string `json:"id"`
-	Nickname string `json:"nickname"`
-	Email    string `json:"email"`
+	ID                  string            `json:"id"`
+	Nickname            string            `json:"nickname"`
+	Email               string            `json:"email"`
+	Balance             float64           `json:"balance"`
+	Type                string            `json:"type"`
+	CreditLimit         float64           `json:"credit_limit,omitempty"`
+	AvailableCredit     float64           `json:"available_credit,omitempty"`
+	StatementClosingDay int               `json:"statement_closing_day,omitempty"`
+	PaymentDueDays      int               `json:"payment_due_days,omitempty"`
+	InstallmentBalance  float64           `json:"installment_balance,omitempty"`
+	Statement           *CardStatementDTO `json:"statement,omitempty"`
+}
+
+type CardStatementDTO struct {
+	PeriodStart      string  `json:"period_start"`
+	ClosingDate      string  `json:"closing_date"`
+	DueDate          string  `json:"due_date"`
+	OpeningBalance   float64 `json:"opening_balance"`
+	Purchases        float64 `json:"purchases"`
+	Payments         float64 `json:"payments"`
+	StatementBalance float64 `json:"statement_balance"`
+	MinimumPayment   float64 `json:"minimum_payment"`
+}
+
+type BalanceDriftDTO struct {
+	AccountID     string  `json:"account_id"`
+	Balance       float64 `json:"balance"`
+	LedgerBalance float64 `json:"ledger_balance"`
+	Drift         float64 `json:"drift"`
 }
 
 type TransactionDTO struct {
@@ -13,29 +39,540 @@ type TransactionDTO struct {
 }
 
 type TransactionSummaryDTO struct {
-	AverageCredit float64 `json:"average_credit"`
-	AverageDebit  float64 `json:"average_debit"`
-	CreditCount   int     `json:"credit_count"`
-	DebitCount    int     `json:"debit_count"`
-	TotalBalance  float64 `json:"total_balance"`
-	TotalCount    int     `json:"total_count"`
-	TotalCredit   float64 `json:"total_credit"`
-	TotalDebit    float64 `json:"total_debit"`
+	AverageCredit      float64              `json:"average_credit"`
+	AverageDebit       float64              `json:"average_debit"`
+	CreditCount        int                  `json:"credit_count"`
+	DebitCount         int                  `json:"debit_count"`
+	TotalBalance       float64              `json:"total_balance"`
+	TotalCount         int                  `json:"total_count"`
+	TotalCredit        float64              `json:"total_credit"`
+	TotalDebit         float64              `json:"total_debit"`
+	RefundCount        int                  `json:"refund_count"`
+	TotalRefunds       float64              `json:"total_refunds"`
+	NetSpend           float64              `json:"net_spend"`
+	Categories         []CategoryTotalDTO   `json:"categories"`
+	InstallmentBalance float64              `json:"installment_balance"`
+	Rewards            *RewardSummaryDTO    `json:"rewards,omitempty"`
+	Subscriptions      []SubscriptionDTO    `json:"subscriptions"`
+	Budgets            []BudgetStatusDTO    `json:"budgets,omitempty"`
+	Goals              []SavingsGoalDTO     `json:"goals,omitempty"`
+	LookingAhead       []ForecastHorizonDTO `json:"looking_ahead,omitempty"`
+	From               string               `json:"from,omitempty"`
+	To                 string               `json:"to,omitempty"`
+	Granularity        string               `json:"granularity"`
+	Periods            []SummaryPeriodDTO   `json:"periods"`
+}
+
+// SummaryPeriodDTO is a period of a summary, from start to end, both
+// included.
+type SummaryPeriodDTO struct {
+	Label       string           `json:"label"`
+	Start       string           `json:"start"`
+	End         string           `json:"end"`
+	Count       int              `json:"total_transactions"`
+	CreditCount int              `json:"credit_count"`
+	Credits     float64          `json:"credits"`
+	DebitCount  int              `json:"debit_count"`
+	Debits      float64          `json:"debits"`
+	RefundCount int              `json:"refund_count"`
+	Refunds     float64          `json:"refunds"`
+	NetSpend    float64          `json:"net_spend"`
+	Net         float64          `json:"net"`
+	Change      *PeriodChangeDTO `json:"change,omitempty"`
+}
+
+type PeriodChangeDTO struct {
+	Count           int     `json:"total_transactions"`
+	Credits         float64 `json:"credits"`
+	Debits          float64 `json:"debits"`
+	NetSpend        float64 `json:"net_spend"`
+	Net             float64 `json:"net"`
+	NetSpendPercent float64 `json:"net_spend_percent"`
+}
+
+type RewardSummaryDTO struct {
+	Balance  int64 `json:"balance"`
+	Earned   int64 `json:"earned"`
+	Redeemed int64 `json:"redeemed"`
 }
 
 type TransactionMonthlyDTO struct {
-	Year          int                    `json:"year"`
-	Month         int                    `json:"month"`
-	Total         int                    `json:"total_transactions"`
-	Balance       float64                `json:"balance"`
-	AverageCredit float64                `json:"average_credit"`
-	AverageDebit  float64                `json:"average_debit"`
-	Transactions  []TransactionDetailDTO `json:"transactions"`
+	Year           int                    `json:"year"`
+	Month          int                    `json:"month"`
+	Total          int                    `json:"total_transactions"`
+	Balance        float64                `json:"balance"`
+	AverageCredit  float64                `json:"average_credit"`
+	AverageDebit   float64                `json:"average_debit"`
+	Refunds        float64                `json:"refunds"`
+	NetSpend       float64                `json:"net_spend"`
+	Installments   float64                `json:"installments"`
+	PointsEarned   int64                  `json:"points_earned"`
+	PointsRedeemed int64                  `json:"points_redeemed"`
+	Categories     []CategoryTotalDTO     `json:"categories"`
+	Budgets        []BudgetStatusDTO      `json:"budgets,omitempty"`
+	TopMerchants   []MerchantTotalDTO     `json:"top_merchants"`
+	Transactions   []TransactionDetailDTO `json:"transactions"`
 }
 
 type TransactionDetailDTO struct {
+	ID                string     `json:"id"`
+	Amount            float64    `json:"amount"`
+	Type              string     `json:"type"`
+	Description       string     `json:"description"`
+	Merchant          string     `json:"merchant"`
+	Category          string     `json:"category"`
+	InputDate         string     `json:"input_date"`
+	Voided            bool       `json:"voided"`
+	ReversalOf        string     `json:"reversal_of,omitempty"`
+	ReversalKind      string     `json:"reversal_kind,omitempty"`
+	Status            string     `json:"status"`
+	AuthorizedAt      string     `json:"authorized_at,omitempty"`
+	PostedAt          string     `json:"posted_at,omitempty"`
+	Splits            []SplitDTO `json:"splits,omitempty"`
+	Tags              []string   `json:"tags,omitempty"`
+	Note              string     `json:"note,omitempty"`
+	InstallmentPlanID string     `json:"installment_plan_id,omitempty"`
+	Supersedes        string     `json:"supersedes,omitempty"`
+	SupersededBy      string     `json:"superseded_by,omitempty"`
+}
+
+type AnnotationDTO struct {
+	Tags []string `json:"tags"`
+	Note string   `json:"note"`
+}
+
+type TagTotalDTO struct {
+	Tag         string  `json:"tag"`
+	Count       int     `json:"count"`
+	TotalCredit float64 `json:"total_credit"`
+	TotalDebit  float64 `json:"total_debit"`
+	Total       float64 `json:"total"`
+}
+
+type SplitDTO struct {
+	ID       string  `json:"id,omitempty"`
+	Amount   float64 `json:"amount"`
+	Category string  `json:"category"`
+	Note     string  `json:"note"`
+}
+
+type CategoryTotalDTO struct {
+	Category string  `json:"category"`
+	Total    float64 `json:"total"`
+	Count    int     `json:"count"`
+}
+
+type MerchantTotalDTO struct {
+	Merchant string  `json:"merchant"`
+	Category string  `json:"category"`
+	Total    float64 `json:"total"`
+	Count    int     `json:"count"`
+}
+
+type MerchantDTO struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Logo     string `json:"logo"`
+}
+
+type TrialBalanceDTO struct {
+	AsOf  string                `json:"as_of"`
+	Total float64               `json:"total"`
+	Lines []TrialBalanceLineDTO `json:"lines"`
+}
+
+type TrialBalanceLineDTO struct {
+	Code    string  `json:"code"`
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	Balance float64 `json:"balance"`
+}
+
+type StatementDTO struct {
+	LedgerAccount  string             `json:"ledger_account"`
+	From           string             `json:"from"`
+	To             string             `json:"to"`
+	OpeningBalance float64            `json:"opening_balance"`
+	ClosingBalance float64            `json:"closing_balance"`
+	Lines          []StatementLineDTO `json:"lines"`
+}
+
+type StatementLineDTO struct {
+	JournalEntryID string  `json:"journal_entry_id"`
+	TransactionID  string  `json:"transaction_id,omitempty"`
+	Description    string  `json:"description"`
+	EffectiveDate  string  `json:"effective_date"`
+	Amount         float64 `json:"amount"`
+	RunningBalance float64 `json:"running_balance"`
+}
+
+type BalanceSeriesDTO struct {
+	LedgerAccount  string            `json:"ledger_account"`
+	From           string            `json:"from"`
+	To             string            `json:"to"`
+	OpeningBalance float64           `json:"opening_balance"`
+	ClosingBalance float64           `json:"closing_balance"`
+	Days           []DailyBalanceDTO `json:"days"`
+}
+
+type DailyBalanceDTO struct {
+	Date           string            `json:"date"`
+	OpeningBalance float64           `json:"opening_balance"`
+	Credits        float64           `json:"credits"`
+	Debits         float64           `json:"debits"`
+	ClosingBalance float64           `json:"closing_balance"`
+	PostingCount   int               `json:"posting_count"`
+	Postings       []BalancePointDTO `json:"postings,omitempty"`
+}
+
+type BalancePointDTO struct {
+	JournalEntryID string  `json:"journal_entry_id"`
+	TransactionID  string  `json:"transaction_id,omitempty"`
+	Description    string  `json:"description"`
+	EffectiveAt    string  `json:"effective_at"`
+	Amount         float64 `json:"amount"`
+	Balance        float64 `json:"balance"`
+}
+
+type JournalEntryRequestDTO struct {
+	Description   string       `json:"description"`
+	EffectiveDate string       `json:"effective_date"`
+	Postings      []PostingDTO `json:"postings"`
+}
+
+type PostingDTO struct {
+	LedgerAccount string  `json:"ledger_account"`
+	Amount        float64 `json:"amount"`
+}
+
+type TransferDTO struct {
+	ID                   string  `json:"id"`
+	SourceAccountID      string  `json:"source_account_id"`
+	DestinationAccountID string  `json:"destination_account_id"`
+	Amount               float64 `json:"amount"`
+	Description          string  `json:"description"`
+	DebitTransactionID   string  `json:"debit_transaction_id"`
+	CreditTransactionID  string  `json:"credit_transaction_id"`
+	CreatedAt            string  `json:"created_at"`
+}
+
+type CorrectionDTO struct {
+	ID                  string  `json:"id"`
+	TransactionID       string  `json:"transaction_id"`
+	VersionID           string  `json:"version_id"`
+	Kind                string  `json:"kind"`
+	Reason              string  `json:"reason"`
+	Actor               string  `json:"actor"`
+	PreviousAmount      float64 `json:"previous_amount"`
+	NewAmount           float64 `json:"new_amount"`
+	PreviousDescription string  `json:"previous_description"`
+	NewDescription      string  `json:"new_description"`
+	PreviousCategory    string  `json:"previous_category"`
+	NewCategory         string  `json:"new_category"`
+	JournalEntryID      string  `json:"journal_entry_id,omitempty"`
+	CreatedAt           string  `json:"created_at"`
+}
+
+type CorrectedTransactionDTO struct {
+	Transaction TransactionDetailDTO `json:"transaction"`
+	Correction  CorrectionDTO        `json:"correction"`
+}
+
+type AccrualDTO struct {
+	ID            string  `json:"id,omitempty"`
+	AccountID     string  `json:"account_id"`
+	Kind          string  `json:"kind"`
+	AccrualDate   string  `json:"accrual_date"`
+	StatementDate string  `json:"statement_date"`
+	Basis         float64 `json:"basis"`
+	DailyRate     float64 `json:"daily_rate"`
+	Amount        float64 `json:"amount"`
+	TransactionID string  `json:"transaction_id,omitempty"`
+}
+
+type AccrualLineDTO struct {
+	AccrualDTO
+	Existing     bool    `json:"existing"`
+	PostedAmount float64 `json:"posted_amount"`
+	Mismatched   bool    `json:"mismatched"`
+}
+
+type AccrualRunDTO struct {
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	DryRun     bool             `json:"dry_run"`
+	Created    int              `json:"created"`
+	Existing   int              `json:"existing"`
+	Mismatched int              `json:"mismatched"`
+	Lines      []AccrualLineDTO `json:"lines"`
+}
+
+type InstallmentPlanDTO struct {
+	ID                      string           `json:"id"`
+	AccountID               string           `json:"account_id"`
+	TransactionID           string           `json:"transaction_id"`
+	ConversionTransactionID string           `json:"conversion_transaction_id"`
+	Principal               float64          `json:"principal"`
+	Installments            int              `json:"installments"`
+	AnnualRate              float64          `json:"annual_rate"`
+	InstallmentAmount       float64          `json:"installment_amount"`
+	TotalInterest           float64          `json:"total_interest"`
+	Status                  string           `json:"status"`
+	PostedInstallments      int              `json:"posted_installments"`
+	RemainingBalance        float64          `json:"remaining_balance"`
+	Schedule                []InstallmentDTO `json:"schedule"`
+	CreatedAt               string           `json:"created_at"`
+}
+
+type InstallmentDTO struct {
+	Number        int     `json:"number"`
+	DueDate       string  `json:"due_date"`
+	Principal     float64 `json:"principal"`
+	Interest      float64 `json:"interest"`
+	Amount        float64 `json:"amount"`
+	TransactionID string  `json:"transaction_id,omitempty"`
+}
+
+type RewardBalanceDTO struct {
+	AccountID string           `json:"account_id"`
+	Points    int64            `json:"points"`
+	Value     float64          `json:"value"`
+	Earned    int64            `json:"earned"`
+	Redeemed  int64            `json:"redeemed"`
+	Entries   []RewardEntryDTO `json:"entries"`
+}
+
+type RewardEntryDTO struct {
+	ID            string `json:"id"`
+	Kind          string `json:"kind"`
+	Points        int64  `json:"points"`
+	TransactionID string `json:"transaction_id"`
+	Rule          string `json:"rule,omitempty"`
+	CycleStart    string `json:"cycle_start"`
+	EffectiveDate string `json:"effective_date"`
+	Description   string `json:"description"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type DisputeDTO struct {
+	ID                    string                 `json:"id"`
+	AccountID             string                 `json:"account_id"`
+	TransactionID         string                 `json:"transaction_id"`
+	Amount                float64                `json:"amount"`
+	ReasonCode            string                 `json:"reason_code"`
+	Description           string                 `json:"description"`
+	Status                string                 `json:"status"`
+	ProvisionalCreditDue  string                 `json:"provisional_credit_due"`
+	Deadline              string                 `json:"deadline"`
+	CreditTransactionID   string                 `json:"credit_transaction_id,omitempty"`
+	ReversalTransactionID string                 `json:"reversal_transaction_id,omitempty"`
+	Attachments           []DisputeAttachmentDTO `json:"attachments"`
+	History               []DisputeEventDTO      `json:"history"`
+	CreatedAt             string                 `json:"created_at"`
+	UpdatedAt             string                 `json:"updated_at"`
+}
+
+type DisputeAttachmentDTO struct {
+	ID          string `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type DisputeEventDTO struct {
+	FromStatus    string `json:"from_status,omitempty"`
+	ToStatus      string `json:"to_status"`
+	Note          string `json:"note,omitempty"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type DisputeReasonDTO struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+type SubscriptionDTO struct {
+	ID               string  `json:"id"`
+	Merchant         string  `json:"merchant"`
+	Category         string  `json:"category,omitempty"`
+	Cadence          string  `json:"cadence"`
+	AverageAmount    float64 `json:"average_amount"`
+	MonthlyCost      float64 `json:"monthly_cost"`
+	LastAmount       float64 `json:"last_amount"`
+	LastChargeDate   string  `json:"last_charge_date"`
+	NextExpectedDate string  `json:"next_expected_date"`
+	ChargeCount      int     `json:"charge_count"`
+	Status           string  `json:"status"`
+}
+
+type SubscriptionAlertDTO struct {
+	ID             string  `json:"id"`
+	SubscriptionID string  `json:"subscription_id"`
+	Kind           string  `json:"kind"`
+	ChargeDate     string  `json:"charge_date"`
+	Amount         float64 `json:"amount"`
+	PreviousAmount float64 `json:"previous_amount"`
+	Message        string  `json:"message"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+type TransactionAlertDTO struct {
+	ID          string                `json:"id"`
+	AccountID   string                `json:"account_id"`
+	Score       float64               `json:"score"`
+	Reasons     []AnomalyReasonDTO    `json:"reasons"`
+	Status      string                `json:"status"`
+	ReviewedBy  string                `json:"reviewed_by,omitempty"`
+	ReviewNote  string                `json:"review_note,omitempty"`
+	ReviewedAt  string                `json:"reviewed_at,omitempty"`
+	Transaction *TransactionDetailDTO `json:"transaction,omitempty"`
+	CreatedAt   string                `json:"created_at"`
+}
+
+type AnomalyReasonDTO struct {
+	Code   string  `json:"code"`
+	Weight float64 `json:"weight"`
+	Detail string  `json:"detail"`
+}
+
+type BudgetDTO struct {
 	ID        string  `json:"id"`
+	AccountID string  `json:"account_id"`
+	Category  string  `json:"category"` // empty for the overall budget
 	Amount    float64 `json:"amount"`
-	Type      string  `json:"type"`
-	InputDate string  `json:"input_date"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type BudgetStatusDTO struct {
+	Budget    BudgetDTO `json:"budget"`
+	Month     string    `json:"month"`
+	Spent     float64   `json:"spent"`
+	Remaining float64   `json:"remaining"`
+	Percent   float64   `json:"percent"`
+	Threshold int       `json:"threshold"`
+}
+
+type SavingsGoalDTO struct {
+	ID            string  `json:"id"`
+	AccountID     string  `json:"account_id"`
+	Name          string  `json:"name"`
+	TargetAmount  float64 `json:"target_amount"`
+	TargetDate    string  `json:"target_date"`
+	Category      string  `json:"category"` // empty when the net flow of the account counts
+	StartDate     string  `json:"start_date"`
+	Saved         float64 `json:"saved"`
+	Remaining     float64 `json:"remaining"`
+	Percent       float64 `json:"percent"`
+	MonthlyRate   float64 `json:"monthly_rate"`
+	MonthlyNeeded float64 `json:"monthly_needed"`
+	ProjectedDate string  `json:"projected_date,omitempty"`
+	Status        string  `json:"status"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+type CashFlowForecastDTO struct {
+	AccountID       string               `json:"account_id"`
+	AsOf            string               `json:"as_of"`
+	StartingBalance float64              `json:"starting_balance"`
+	DailyIncome     float64              `json:"daily_income"`
+	DailySpend      float64              `json:"daily_spend"`
+	DailyDeviation  float64              `json:"daily_deviation"`
+	Horizons        []ForecastHorizonDTO `json:"horizons"`
+	Points          []ForecastPointDTO   `json:"points"`
+	Items           []ForecastItemDTO    `json:"recurring_items"`
+	GeneratedAt     string               `json:"generated_at"`
+}
+
+type ForecastHorizonDTO struct {
+	Days              int     `json:"days"`
+	Date              string  `json:"date"`
+	Expected          float64 `json:"expected_balance"`
+	Low               float64 `json:"low"`
+	High              float64 `json:"high"`
+	RecurringIncome   float64 `json:"recurring_income"`
+	RecurringExpenses float64 `json:"recurring_expenses"`
+	Baseline          float64 `json:"baseline"`
+}
+
+type ForecastPointDTO struct {
+	Date      string  `json:"date"`
+	Expected  float64 `json:"expected_balance"`
+	Low       float64 `json:"low"`
+	High      float64 `json:"high"`
+	Recurring float64 `json:"recurring"`
+	Baseline  float64 `json:"baseline"`
+}
+
+type ForecastItemDTO struct {
+	Date     string  `json:"date"`
+	Name     string  `json:"name"`
+	Category string  `json:"category"`
+	Cadence  string  `json:"cadence"`
+	Amount   float64 `json:"amount"`
+}
+
+// ActivityDTO is the activity of all accounts from from to to, both
+// included.
+type ActivityDTO struct {
+	From             string             `json:"from"`
+	To               string             `json:"to"`
+	ActiveAccounts   int64              `json:"active_accounts"`
+	TransactionCount int64              `json:"total_transactions"`
+	TotalCredit      float64            `json:"total_credit"`
+	TotalDebit       float64            `json:"total_debit"`
+	Days             []DailyActivityDTO `json:"days"`
+}
+
+type DailyActivityDTO struct {
+	Date             string  `json:"date"`
+	ActiveAccounts   int64   `json:"active_accounts"`
+	TransactionCount int64   `json:"total_transactions"`
+	Credit           float64 `json:"credit"`
+	Debit            float64 `json:"debit"`
+}
+
+type ImportStatsDTO struct {
+	From             string  `json:"from"`
+	To               string  `json:"to"`
+	Total            int64   `json:"total_imports"`
+	Succeeded        int64   `json:"succeeded"`
+	Failed           int64   `json:"failed"`
+	SuccessRate      float64 `json:"success_rate"`
+	AverageFileSize  float64 `json:"average_file_size"`
+	TotalFileSize    int64   `json:"total_file_size"`
+	TransactionCount int64   `json:"total_transactions"`
+}
+
+type BalanceDistributionDTO struct {
+	AccountCount int64                  `json:"account_count"`
+	Total        float64                `json:"total"`
+	Average      float64                `json:"average"`
+	Min          float64                `json:"min"`
+	Max          float64                `json:"max"`
+	Percentiles  []BalancePercentileDTO `json:"percentiles"`
+	Buckets      []BalanceBucketDTO     `json:"buckets"`
+}
+
+type BalancePercentileDTO struct {
+	Percentile float64 `json:"percentile"`
+	Balance    float64 `json:"balance"`
+}
+
+// BalanceBucketDTO counts the accounts with a balance from from, included,
+// to to, excluded; a missing bound is open.
+type BalanceBucketDTO struct {
+	From         *float64 `json:"from,omitempty"`
+	To           *float64 `json:"to,omitempty"`
+	AccountCount int64    `json:"account_count"`
+}
+
+type TopMerchantDTO struct {
+	Merchant         string  `json:"merchant"`
+	TransactionCount int64   `json:"total_transactions"`
+	AccountCount     int64   `json:"account_count"`
+	Total            float64 `json:"total"`
 }
@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type ForecastHandler struct {
+	service *transaction.ForecastService
+	nats    *nats.NatsClient
+}
+
+func NewForecastHandler(service *transaction.ForecastService, nc *nats.NatsClient) *ForecastHandler {
+	return &ForecastHandler{
+		service: service,
+		nats:    nc,
+	}
+}
+
+// GetForecast returns the latest forecast of the account computed by the
+// worker, over the days parameter, 90 by default.
+func (h *ForecastHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	days, err := parseIntParam(r, "days", domain.ForecastHorizonDays)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	forecast, err := h.service.Get(r.Context(), accountID, int(days))
+	if err != nil {
+		http.Error(w, err.Error(), forecastErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertForecastToDTO(forecast))
+}
+
+// RunForecast asks the worker to forecast the account again, for instance
+// after a correction, instead of waiting for the daily run.
+func (h *ForecastHandler) RunForecast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.nats.Publish(domain.ForecastRunRequestedEvent, map[string]uuid.UUID{"account_id": accountID})
+	if err != nil {
+		log.Printf("Error requesting forecast: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]string{
+		"message": "Forecast queued",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(data)
+}
+
+func convertForecastToDTO(f *domain.CashFlowForecast) CashFlowForecastDTO {
+	dto := CashFlowForecastDTO{
+		AccountID:       f.AccountID.String(),
+		AsOf:            f.AsOf.Format(dateLayout),
+		StartingBalance: f.StartingBalance,
+		DailyIncome:     f.DailyIncome,
+		DailySpend:      f.DailySpend,
+		DailyDeviation:  f.DailyDeviation,
+		Horizons:        convertForecastHorizonsToDTO(f.Horizons()),
+		Points:          make([]ForecastPointDTO, 0, len(f.Points)),
+		Items:           make([]ForecastItemDTO, 0, len(f.Items)),
+		GeneratedAt:     time.Unix(f.GeneratedAt, 0).UTC().Format(time.RFC3339),
+	}
+	for _, p := range f.Points {
+		dto.Points = append(dto.Points, ForecastPointDTO{
+			Date:      p.Date.Format(dateLayout),
+			Expected:  p.Expected,
+			Low:       p.Low,
+			High:      p.High,
+			Recurring: p.Recurring,
+			Baseline:  p.Baseline,
+		})
+	}
+	for _, i := range f.Items {
+		dto.Items = append(dto.Items, ForecastItemDTO{
+			Date:     i.Date.Format(dateLayout),
+			Name:     i.Name,
+			Category: i.Category,
+			Cadence:  i.Cadence,
+			Amount:   i.Amount,
+		})
+	}
+	return dto
+}
+
+func convertForecastHorizonsToDTO(horizons []domain.ForecastHorizon) []ForecastHorizonDTO {
+	dtos := make([]ForecastHorizonDTO, 0, len(horizons))
+	for _, h := range horizons {
+		dtos = append(dtos, ForecastHorizonDTO{
+			Days:              h.Days,
+			Date:              h.Date.Format(dateLayout),
+			Expected:          h.Expected,
+			Low:               h.Low,
+			High:              h.High,
+			RecurringIncome:   h.RecurringIncome,
+			RecurringExpenses: h.RecurringExpenses,
+			Baseline:          h.Baseline,
+		})
+	}
+	return dtos
+}
+
+func forecastErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidForecastHorizon):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrForecastNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
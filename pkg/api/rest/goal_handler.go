@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type GoalHandler struct {
+	service *transaction.GoalService
+}
+
+func NewGoalHandler(service *transaction.GoalService) *GoalHandler {
+	return &GoalHandler{
+		service: service,
+	}
+}
+
+func (h *GoalHandler) Manager(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		h.GetGoal(w, r)
+	} else if r.Method == http.MethodDelete {
+		h.DeleteGoal(w, r)
+	} else {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+// CreateGoal sets up a savings goal. start_date defaults to today; an
+// earlier date counts the savings already made towards the goal.
+func (h *GoalHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input struct {
+		AccountID    string  `json:"account_id"`
+		Name         string  `json:"name"`
+		TargetAmount float64 `json:"target_amount"`
+		TargetDate   string  `json:"target_date"`
+		Category     string  `json:"category"`
+		StartDate    string  `json:"start_date"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	accountID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+	targetDate, err := time.Parse(dateLayout, input.TargetDate)
+	if err != nil {
+		http.Error(w, "invalid target_date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	var startDate time.Time
+	if input.StartDate != "" {
+		if startDate, err = time.Parse(dateLayout, input.StartDate); err != nil {
+			http.Error(w, "invalid start_date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	progress, err := h.service.Create(r.Context(), accountID, input.Name, input.Category, input.TargetAmount, targetDate, startDate)
+	if err != nil {
+		log.Printf("Error creating savings goal: %v", err)
+		http.Error(w, err.Error(), goalErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(convertGoalProgressToDTO(progress))
+}
+
+func (h *GoalHandler) GetGoal(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid goal ID", http.StatusBadRequest)
+		return
+	}
+
+	progress, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), goalErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertGoalProgressToDTO(progress))
+}
+
+func (h *GoalHandler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid goal ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), goalErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *GoalHandler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	progress, err := h.service.ListByAccount(r.Context(), accountID)
+	if err != nil {
+		http.Error(w, err.Error(), goalErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertGoalProgressListToDTO(progress))
+}
+
+func convertGoalProgressToDTO(p domain.GoalProgress) SavingsGoalDTO {
+	dto := SavingsGoalDTO{
+		ID:            p.Goal.ID.String(),
+		AccountID:     p.Goal.AccountID.String(),
+		Name:          p.Goal.Name,
+		TargetAmount:  p.Goal.TargetAmount,
+		TargetDate:    p.Goal.TargetDate.Format(dateLayout),
+		Category:      p.Goal.Category,
+		StartDate:     p.Goal.StartDate.Format(dateLayout),
+		Saved:         p.Saved,
+		Remaining:     p.Remaining,
+		Percent:       p.Percent,
+		MonthlyRate:   p.MonthlyRate,
+		MonthlyNeeded: p.MonthlyNeeded,
+		Status:        p.Status,
+		CreatedAt:     time.Unix(p.Goal.CreatedAt, 0).UTC().Format(time.RFC3339),
+		UpdatedAt:     time.Unix(p.Goal.UpdatedAt, 0).UTC().Format(time.RFC3339),
+	}
+	if !p.ProjectedDate.IsZero() {
+		dto.ProjectedDate = p.ProjectedDate.Format(dateLayout)
+	}
+	return dto
+}
+
+func convertGoalProgressListToDTO(progress []domain.GoalProgress) []SavingsGoalDTO {
+	data := make([]SavingsGoalDTO, 0, len(progress))
+	for _, p := range progress {
+		data = append(data, convertGoalProgressToDTO(p))
+	}
+	return data
+}
+
+func goalErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSavingsGoal):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSavingsGoalNotFound), errors.Is(err, domain.ErrAccountNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,160 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	accountDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/account/domain"
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type InstallmentHandler struct {
+	service *transaction.InstallmentService
+}
+
+func NewInstallmentHandler(service *transaction.InstallmentService) *InstallmentHandler {
+	return &InstallmentHandler{
+		service: service,
+	}
+}
+
+func (h *InstallmentHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input struct {
+		TransactionID string  `json:"transaction_id"`
+		Installments  int     `json:"installments"`
+		AnnualRate    float64 `json:"annual_rate"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transactionID, err := uuid.Parse(input.TransactionID)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.service.CreatePlan(r.Context(), transactionID, input.Installments, input.AnnualRate)
+	if err != nil {
+		log.Printf("Error creating installment plan: %v", err)
+		http.Error(w, err.Error(), installmentErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(convertInstallmentPlanToDTO(plan))
+}
+
+func (h *InstallmentHandler) GetPlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid installment plan ID", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.service.GetPlan(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), installmentErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertInstallmentPlanToDTO(plan))
+}
+
+func (h *InstallmentHandler) ListPlans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	plans, err := h.service.ListPlans(r.Context(), accountID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]InstallmentPlanDTO, 0, len(plans))
+	for _, p := range plans {
+		response = append(response, convertInstallmentPlanToDTO(p))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func convertInstallmentPlanToDTO(plan *domain.InstallmentPlan) InstallmentPlanDTO {
+	schedule := make([]InstallmentDTO, 0, len(plan.Installments))
+	for _, i := range plan.Installments {
+		dto := InstallmentDTO{
+			Number:    i.Number,
+			DueDate:   i.DueDate.Format(dateLayout),
+			Principal: i.Principal,
+			Interest:  i.Interest,
+			Amount:    i.Amount,
+		}
+		if i.TransactionID.Valid {
+			dto.TransactionID = i.TransactionID.UUID.String()
+		}
+		schedule = append(schedule, dto)
+	}
+
+	return InstallmentPlanDTO{
+		ID:                      plan.ID.String(),
+		AccountID:               plan.AccountID.String(),
+		TransactionID:           plan.TransactionID.String(),
+		ConversionTransactionID: plan.ConversionTransactionID.String(),
+		Principal:               plan.Principal,
+		Installments:            plan.Count,
+		AnnualRate:              plan.AnnualRate,
+		InstallmentAmount:       plan.InstallmentAmount,
+		TotalInterest:           plan.TotalInterest,
+		Status:                  plan.Status,
+		PostedInstallments:      plan.PostedCount(),
+		RemainingBalance:        plan.RemainingBalance(),
+		Schedule:                schedule,
+		CreatedAt:               time.Unix(plan.CreatedAt, 0).UTC().Format(time.RFC3339),
+	}
+}
+
+func installmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInstallmentCount), errors.Is(err, domain.ErrInvalidInstallmentRate),
+		errors.Is(err, domain.ErrInstallmentTooSmall), errors.Is(err, domain.ErrInstallmentNotPurchase),
+		errors.Is(err, accountDomain.ErrNotCreditCard):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransactionNotFound), errors.Is(err, domain.ErrInstallmentPlanNotFound),
+		errors.Is(err, accountDomain.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInstallmentPlanExists), errors.Is(err, domain.ErrInstallmentPurchaseBilled),
+		errors.Is(err, domain.ErrTransactionVoided):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,303 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type LedgerHandler struct {
+	service *transaction.LedgerService
+}
+
+func NewLedgerHandler(service *transaction.LedgerService) *LedgerHandler {
+	return &LedgerHandler{
+		service: service,
+	}
+}
+
+func (h *LedgerHandler) GetTrialBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	asOf, err := parseDateParam(r, "as_of", time.Now().UTC())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	trialBalance, err := h.service.GetTrialBalance(r.Context(), endOfDay(asOf))
+	if err != nil {
+		log.Printf("Error getting trial balance: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := TrialBalanceDTO{
+		AsOf:  asOf.Format(dateLayout),
+		Total: trialBalance.Total,
+		Lines: make([]TrialBalanceLineDTO, 0, len(trialBalance.Lines)),
+	}
+	for _, l := range trialBalance.Lines {
+		data.Lines = append(data.Lines, TrialBalanceLineDTO{
+			Code:    l.Code,
+			Name:    l.Name,
+			Type:    l.Type,
+			Balance: l.Balance,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *LedgerHandler) GetAccountStatement(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	monthStart := startOfMonth(time.Now().UTC())
+	from, err := parseDateParam(r, "from", monthStart)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := parseDateParam(r, "to", monthStart.AddDate(0, 1, 0))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statement, err := h.service.GetAccountStatement(r.Context(), accountID, from, to)
+	if err != nil {
+		log.Printf("Error getting account statement: %v", err)
+		http.Error(w, err.Error(), balanceErrorStatus(err))
+		return
+	}
+
+	data := StatementDTO{
+		LedgerAccount:  statement.LedgerAccountCode,
+		From:           statement.From.Format(dateLayout),
+		To:             statement.To.Format(dateLayout),
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          make([]StatementLineDTO, 0, len(statement.Lines)),
+	}
+	for _, l := range statement.Lines {
+		line := StatementLineDTO{
+			JournalEntryID: l.JournalEntryID.String(),
+			Description:    l.Description,
+			EffectiveDate:  l.EffectiveDate.Format(dateLayout),
+			Amount:         l.Amount,
+			RunningBalance: l.RunningBalance,
+		}
+		if l.TransactionID.Valid {
+			line.TransactionID = l.TransactionID.UUID.String()
+		}
+		data.Lines = append(data.Lines, line)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetBalanceSeries returns the daily balance of an account from from to to,
+// both included, the last 30 days by default. With intraday=true every day
+// lists its postings with the running balance.
+func (h *LedgerHandler) GetBalanceSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from, err := parseDateParam(r, "from", today.AddDate(0, 0, -29))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := parseDateParam(r, "to", today)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	intraday, err := parseBoolParam(r, "intraday")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series, err := h.service.GetBalanceSeries(r.Context(), accountID, from, to.AddDate(0, 0, 1), intraday)
+	if err != nil {
+		log.Printf("Error getting balance series: %v", err)
+		http.Error(w, err.Error(), balanceErrorStatus(err))
+		return
+	}
+
+	data := BalanceSeriesDTO{
+		LedgerAccount:  series.LedgerAccountCode,
+		From:           series.From.Format(dateLayout),
+		To:             to.Format(dateLayout),
+		OpeningBalance: series.OpeningBalance,
+		ClosingBalance: series.ClosingBalance,
+		Days:           make([]DailyBalanceDTO, 0, len(series.Days)),
+	}
+	for _, d := range series.Days {
+		data.Days = append(data.Days, convertDailyBalanceToDTO(d))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// GetBalanceAsOf returns the balance of an account at the end of the date
+// parameter, today by default.
+func (h *LedgerHandler) GetBalanceAsOf(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	date, err := parseDateParam(r, "date", time.Now().UTC())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	balance, err := h.service.GetBalanceAsOf(r.Context(), accountID, date)
+	if err != nil {
+		log.Printf("Error getting balance as of %s: %v", date.Format(dateLayout), err)
+		http.Error(w, err.Error(), balanceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertDailyBalanceToDTO(*balance))
+}
+
+func convertDailyBalanceToDTO(d domain.DailyBalance) DailyBalanceDTO {
+	dto := DailyBalanceDTO{
+		Date:           d.Date.Format(dateLayout),
+		OpeningBalance: d.OpeningBalance,
+		Credits:        d.Credits,
+		Debits:         d.Debits,
+		ClosingBalance: d.ClosingBalance,
+		PostingCount:   d.PostingCount,
+	}
+	for _, p := range d.Postings {
+		point := BalancePointDTO{
+			JournalEntryID: p.JournalEntryID.String(),
+			Description:    p.Description,
+			EffectiveAt:    p.EffectiveDate.UTC().Format(time.RFC3339),
+			Amount:         p.Amount,
+			Balance:        p.RunningBalance,
+		}
+		if p.TransactionID.Valid {
+			point.TransactionID = p.TransactionID.UUID.String()
+		}
+		dto.Postings = append(dto.Postings, point)
+	}
+	return dto
+}
+
+func balanceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrLedgerAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidBalanceRange), errors.Is(err, domain.ErrBalanceRangeTooLong):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// PostJournalEntry records a manual entry, typically against the fees or
+// adjustments internal accounts.
+func (h *LedgerHandler) PostJournalEntry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input JournalEntryRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	effectiveDate := time.Now().UTC()
+	if input.EffectiveDate != "" {
+		date, err := time.Parse(dateLayout, input.EffectiveDate)
+		if err != nil {
+			http.Error(w, "Invalid effective date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		effectiveDate = date
+	}
+
+	entry := domain.NewJournalEntry(input.Description, effectiveDate)
+	for _, p := range input.Postings {
+		entry.AddPosting(p.LedgerAccount, p.Amount)
+	}
+
+	err := h.service.PostJournalEntry(r.Context(), entry)
+	if err != nil {
+		log.Printf("Error posting journal entry: %v", err)
+		status := http.StatusInternalServerError
+		switch {
+		case isEntryValidationError(err):
+			status = http.StatusBadRequest
+		case errors.Is(err, domain.ErrAccountNotFound):
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	data := map[string]string{
+		"id": entry.ID.String(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(data)
+}
+
+func isEntryValidationError(err error) bool {
+	return errors.Is(err, domain.ErrEntryTooFewPostings) ||
+		errors.Is(err, domain.ErrEntryZeroPosting) ||
+		errors.Is(err, domain.ErrEntryUnbalanced) ||
+		errors.Is(err, domain.ErrEntryNoLedgerAccount) ||
+		errors.Is(err, domain.ErrUnknownLedgerAccount)
+}
+
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.UTC)
+}
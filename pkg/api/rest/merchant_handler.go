@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	merchant "github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/domain"
+)
+
+type MerchantHandler struct {
+	service *merchant.MerchantService
+	nats    *nats.NatsClient
+}
+
+func NewMerchantHandler(service *merchant.MerchantService, nc *nats.NatsClient) *MerchantHandler {
+	return &MerchantHandler{
+		service: service,
+		nats:    nc,
+	}
+}
+
+func (h *MerchantHandler) ListMerchants(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	merchants := h.service.ListMerchants()
+	response := make([]MerchantDTO, 0, len(merchants))
+	for _, m := range merchants {
+		response = append(response, MerchantDTO{
+			Name:     m.Name,
+			Category: m.Category,
+			Logo:     m.Logo,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// NormalizeHistory queues a worker job that re-applies the merchant
+// dictionary over every stored transaction.
+func (h *MerchantHandler) NormalizeHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.nats.Publish(domain.NormalizeRequestedEvent, map[string]string{})
+	if err != nil {
+		log.Printf("Error requesting merchant normalization: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]string{
+		"message": "Merchant normalization queued",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+)
+
+// parseDateParam reads a YYYY-MM-DD query parameter, returning def when the
+// parameter is absent.
+func parseDateParam(r *http.Request, name string, def time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date, expected YYYY-MM-DD", name)
+	}
+	return date, nil
+}
+
+// parseMonthParam reads a YYYY-MM query parameter as the first day of the
+// month, returning def when the parameter is absent.
+func parseMonthParam(r *http.Request, name string, def time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s month, expected YYYY-MM", name)
+	}
+	return month, nil
+}
+
+// parseBoolParam reads a boolean query parameter, returning false when the
+// parameter is absent.
+func parseBoolParam(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s value, expected true or false", name)
+	}
+	return b, nil
+}
+
+// parseIntParam reads an integer query parameter, returning def when the
+// parameter is absent.
+func parseIntParam(r *http.Request, name string, def int64) (int64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value, expected a number", name)
+	}
+	return n, nil
+}
+
+// parseSummaryOptions reads include_pending, the q search query, e.g.
+// q=tag:reimbursable, the from and to dates, both included, the
+// granularity of the periods and the closing_day of billing cycles. With
+// details=true the summary also returns the page of its transactions set
+// by limit and offset.
+func parseSummaryOptions(r *http.Request) (domain.SummaryOptions, error) {
+	includePending, err := parseBoolParam(r, "include_pending")
+	if err != nil {
+		return domain.SummaryOptions{}, err
+	}
+	search, err := domain.ParseSearchQuery(r.URL.Query().Get("q"))
+	if err != nil {
+		return domain.SummaryOptions{}, err
+	}
+	from, err := parseDateParam(r, "from", time.Time{})
+	if err != nil {
+		return domain.SummaryOptions{}, err
+	}
+	to, err := parseDateParam(r, "to", time.Time{})
+	if err != nil {
+		return domain.SummaryOptions{}, err
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+	closingDay, err := parseIntParam(r, "closing_day", 0)
+	if err != nil {
+		return domain.SummaryOptions{}, err
+	}
+	opts := domain.SummaryOptions{
+		IncludePending:  includePending,
+		Search:          search,
+		From:            from,
+		To:              to,
+		Granularity:     r.URL.Query().Get("granularity"),
+		CycleClosingDay: int(closingDay),
+	}
+	if err := opts.Validate(); err != nil {
+		return domain.SummaryOptions{}, err
+	}
+
+	details, err := parseBoolParam(r, "details")
+	if err != nil || !details {
+		return opts, err
+	}
+	limit, err := parseIntParam(r, "limit", defaultSearchLimit)
+	if err != nil || limit <= 0 || limit > maxSearchLimit {
+		return domain.SummaryOptions{}, fmt.Errorf("limit must be between 1 and %d", maxSearchLimit)
+	}
+	offset, err := parseIntParam(r, "offset", 0)
+	if err != nil || offset < 0 {
+		return domain.SummaryOptions{}, fmt.Errorf("offset must be a non-negative number")
+	}
+	opts.DetailLimit, opts.DetailOffset = limit, offset
+	return opts, nil
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type RefundHandler struct {
+	service *transaction.RefundService
+	nats    *nats.NatsClient
+}
+
+func NewRefundHandler(service *transaction.RefundService, nc *nats.NatsClient) *RefundHandler {
+	return &RefundHandler{
+		service: service,
+		nats:    nc,
+	}
+}
+
+func (h *RefundHandler) LinkRefund(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	refundID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		OriginalID string `json:"original_id"`
+		Kind       string `json:"kind"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	originalID, err := uuid.Parse(input.OriginalID)
+	if err != nil {
+		http.Error(w, "Invalid original transaction ID", http.StatusBadRequest)
+		return
+	}
+	if input.Kind == "" {
+		input.Kind = domain.ReversalKindRefund
+	}
+
+	t, err := h.service.LinkRefund(r.Context(), refundID, originalID, input.Kind)
+	if err != nil {
+		log.Printf("Error linking refund: %v", err)
+		http.Error(w, err.Error(), refundErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionToDTO(t))
+}
+
+// MatchRefunds queues a worker job that runs the automatic refund matcher
+// over every credit that is not linked yet.
+func (h *RefundHandler) MatchRefunds(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.nats.Publish(domain.RefundMatchRequestedEvent, map[string]string{})
+	if err != nil {
+		log.Printf("Error requesting refund matching: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]string{
+		"message": "Refund matching queued",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(data)
+}
+
+func refundErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidReversalKind), errors.Is(err, domain.ErrRefundNotCredit),
+		errors.Is(err, domain.ErrRefundOriginalDebit), errors.Is(err, domain.ErrRefundAccountMismatch),
+		errors.Is(err, domain.ErrRefundReversalPartial):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrRefundAlreadyLinked), errors.Is(err, domain.ErrRefundExceedsOriginal),
+		errors.Is(err, domain.ErrTransactionVoided), errors.Is(err, domain.ErrTransactionNotPosted),
+		errors.Is(err, domain.ErrInstallmentPlanLocked):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type RewardHandler struct {
+	service *transaction.RewardService
+}
+
+func NewRewardHandler(service *transaction.RewardService) *RewardHandler {
+	return &RewardHandler{
+		service: service,
+	}
+}
+
+func (h *RewardHandler) GetProgram(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.service.Program())
+}
+
+func (h *RewardHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+	limit, err := parseIntParam(r, "limit", defaultSearchLimit)
+	if err != nil || limit <= 0 || limit > maxSearchLimit {
+		http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit), http.StatusBadRequest)
+		return
+	}
+	offset, err := parseIntParam(r, "offset", 0)
+	if err != nil || offset < 0 {
+		http.Error(w, "offset must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+
+	balance, err := h.service.GetBalance(r.Context(), accountID, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), rewardErrorStatus(err))
+		return
+	}
+
+	entries := make([]RewardEntryDTO, 0, len(balance.Entries))
+	for _, e := range balance.Entries {
+		entries = append(entries, convertRewardEntryToDTO(e))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(RewardBalanceDTO{
+		AccountID: balance.AccountID.String(),
+		Points:    balance.Points,
+		Value:     balance.Value,
+		Earned:    balance.Earned,
+		Redeemed:  balance.Redeemed,
+		Entries:   entries,
+	})
+}
+
+func (h *RewardHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Points int64 `json:"points"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.service.Redeem(r.Context(), accountID, input.Points)
+	if err != nil {
+		log.Printf("Error redeeming reward points: %v", err)
+		http.Error(w, err.Error(), rewardErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(convertRewardEntryToDTO(entry))
+}
+
+func convertRewardEntryToDTO(e *domain.RewardEntry) RewardEntryDTO {
+	return RewardEntryDTO{
+		ID:            e.ID.String(),
+		Kind:          e.Kind,
+		Points:        e.Points,
+		TransactionID: e.TransactionID.String(),
+		Rule:          e.Rule,
+		CycleStart:    e.CycleStart.Format(dateLayout),
+		EffectiveDate: e.EffectiveDate.Format(dateLayout),
+		Description:   e.Description,
+		CreatedAt:     time.Unix(e.CreatedAt, 0).UTC().Format(time.RFC3339),
+	}
+}
+
+func rewardErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRedemption):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInsufficientPoints), errors.Is(err, domain.ErrAccountInactive):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type SplitHandler struct {
+	service *transaction.SplitService
+}
+
+func NewSplitHandler(service *transaction.SplitService) *SplitHandler {
+	return &SplitHandler{
+		service: service,
+	}
+}
+
+func (h *SplitHandler) Manager(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		h.GetSplits(w, r)
+	} else if r.Method == http.MethodPut {
+		h.SplitTransaction(w, r)
+	} else if r.Method == http.MethodDelete {
+		h.RemoveSplits(w, r)
+	} else {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+}
+
+func convertSplitsToDTO(splits []domain.Split) []SplitDTO {
+	response := make([]SplitDTO, 0, len(splits))
+	for _, s := range splits {
+		response = append(response, SplitDTO{
+			ID:       s.ID.String(),
+			Amount:   s.Amount,
+			Category: s.Category,
+			Note:     s.Note,
+		})
+	}
+	return response
+}
+
+func (h *SplitHandler) GetSplits(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	splits, err := h.service.GetSplits(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertSplitsToDTO(splits))
+}
+
+func (h *SplitHandler) SplitTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		Splits []SplitDTO `json:"splits"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	parts := make([]domain.SplitPart, 0, len(input.Splits))
+	for _, s := range input.Splits {
+		parts = append(parts, domain.SplitPart{
+			Amount:   s.Amount,
+			Category: s.Category,
+			Note:     s.Note,
+		})
+	}
+
+	h.setSplits(w, r, id, parts)
+}
+
+func (h *SplitHandler) RemoveSplits(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	h.setSplits(w, r, id, nil)
+}
+
+func (h *SplitHandler) setSplits(w http.ResponseWriter, r *http.Request, id uuid.UUID, parts []domain.SplitPart) {
+	t, err := h.service.SplitTransaction(r.Context(), id, parts)
+	if err != nil {
+		log.Printf("Error splitting transaction: %v", err)
+		http.Error(w, err.Error(), splitErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionToDTO(t))
+}
+
+func splitErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrSplitTooFewParts), errors.Is(err, domain.ErrSplitCategory),
+		errors.Is(err, domain.ErrSplitInvalidAmount), errors.Is(err, domain.ErrSplitSumMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrSplitTransferOrVoid), errors.Is(err, domain.ErrTransactionNotPosted):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+type SubscriptionHandler struct {
+	service *transaction.SubscriptionService
+}
+
+func NewSubscriptionHandler(service *transaction.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		service: service,
+	}
+}
+
+func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	subscriptions, err := h.service.ListByAccount(r.Context(), accountID)
+	if err != nil {
+		http.Error(w, err.Error(), subscriptionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertSubscriptionsToDTO(subscriptions))
+}
+
+// DetectSubscriptions runs detection for the account right away instead of
+// waiting for the worker, and returns the alerts it raised.
+func (h *SubscriptionHandler) DetectSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	alerts, err := h.service.Detect(r.Context(), accountID, time.Now().UTC())
+	if err != nil {
+		log.Printf("Error detecting subscriptions: %v", err)
+		http.Error(w, err.Error(), subscriptionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertSubscriptionAlertsToDTO(alerts))
+}
+
+func (h *SubscriptionHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	alerts, err := h.service.ListAlerts(r.Context(), accountID)
+	if err != nil {
+		http.Error(w, err.Error(), subscriptionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertSubscriptionAlertsToDTO(alerts))
+}
+
+func convertSubscriptionsToDTO(subscriptions []*domain.Subscription) []SubscriptionDTO {
+	data := make([]SubscriptionDTO, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		data = append(data, SubscriptionDTO{
+			ID:               s.ID.String(),
+			Merchant:         s.Merchant,
+			Category:         s.Category,
+			Cadence:          s.Cadence,
+			AverageAmount:    s.AverageAmount,
+			MonthlyCost:      s.MonthlyCost(),
+			LastAmount:       s.LastAmount,
+			LastChargeDate:   s.LastChargeDate.Format(dateLayout),
+			NextExpectedDate: s.NextExpectedDate.Format(dateLayout),
+			ChargeCount:      s.ChargeCount,
+			Status:           s.Status,
+		})
+	}
+	return data
+}
+
+func convertSubscriptionAlertsToDTO(alerts []*domain.SubscriptionAlert) []SubscriptionAlertDTO {
+	data := make([]SubscriptionAlertDTO, 0, len(alerts))
+	for _, a := range alerts {
+		data = append(data, SubscriptionAlertDTO{
+			ID:             a.ID.String(),
+			SubscriptionID: a.SubscriptionID.String(),
+			Kind:           a.Kind,
+			ChargeDate:     a.ChargeDate.Format(dateLayout),
+			Amount:         a.Amount,
+			PreviousAmount: a.PreviousAmount,
+			Message:        a.Message,
+			CreatedAt:      time.Unix(a.CreatedAt, 0).UTC().Format(time.RFC3339),
+		})
+	}
+	return data
+}
+
+func subscriptionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrAccountNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
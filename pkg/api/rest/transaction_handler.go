@@ -2,22 +2,27 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 
 	"github.com/google/uuid"
 
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
 	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
 )
 
 type TransactionHandler struct {
 	service *transaction.TransactionService
+	nats    *nats.NatsClient
 }
 
-func NewTransactionHandler(service *transaction.TransactionService) *TransactionHandler {
+func NewTransactionHandler(service *transaction.TransactionService, nc *nats.NatsClient) *TransactionHandler {
 	return &TransactionHandler{
 		service: service,
+		nats:    nc,
 	}
 }
 
@@ -35,9 +40,15 @@ func (h *TransactionHandler) GetTransactionSummary(w http.ResponseWriter, r *htt
 		return
 	}
 
-	summary, err := h.service.GetTransactionSummary(r.Context(), accountID)
+	opts, err := parseSummaryOptions(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.service.GetTransactionSummary(r.Context(), accountID, opts)
+	if err != nil {
+		http.Error(w, err.Error(), summaryErrorStatus(err))
 		return
 	}
 
@@ -45,39 +56,78 @@ func (h *TransactionHandler) GetTransactionSummary(w http.ResponseWriter, r *htt
 	data := TransactionDTO{
 		AccounID: accountID.String(),
 		Summary: TransactionSummaryDTO{
-			AverageCredit: summary.AverageCredit,
-			AverageDebit:  summary.AverageDebit,
-			CreditCount:   summary.CreditCount,
-			DebitCount:    summary.DebitCount,
-			TotalBalance:  summary.TotalBalance,
-			TotalCount:    summary.TotalCount,
-			TotalCredit:   summary.TotalCredit,
-			TotalDebit:    summary.TotalDebit,
+			AverageCredit:      summary.AverageCredit,
+			AverageDebit:       summary.AverageDebit,
+			CreditCount:        summary.CreditCount,
+			DebitCount:         summary.DebitCount,
+			TotalBalance:       summary.TotalBalance,
+			TotalCount:         summary.TotalCount,
+			TotalCredit:        summary.TotalCredit,
+			TotalDebit:         summary.TotalDebit,
+			RefundCount:        summary.RefundCount,
+			TotalRefunds:       summary.TotalRefunds,
+			NetSpend:           summary.NetSpend,
+			Categories:         convertCategoryTotalsToDTO(summary.Categories),
+			InstallmentBalance: summary.InstallmentBalance,
+			Subscriptions:      convertSubscriptionsToDTO(summary.Subscriptions),
+			Budgets:            convertBudgetStatusesToDTO(summary.Budgets),
+			Goals:              convertGoalProgressListToDTO(summary.Goals),
+			Granularity:        summary.Granularity,
+			Periods:            convertSummaryPeriodsToDTO(summary.Periods),
 		},
 		Monthly: make(map[string]*TransactionMonthlyDTO),
 	}
+	if !summary.From.IsZero() {
+		data.Summary.From = summary.From.Format(dateLayout)
+	}
+	if !summary.To.IsZero() {
+		// The range of the request includes its last day
+		data.Summary.To = summary.To.AddDate(0, 0, -1).Format(dateLayout)
+	}
+	if summary.Forecast != nil {
+		data.Summary.LookingAhead = convertForecastHorizonsToDTO(summary.Forecast.Horizons())
+	}
+	if summary.Rewards != nil {
+		data.Summary.Rewards = &RewardSummaryDTO{
+			Balance:  summary.Rewards.Balance,
+			Earned:   summary.Rewards.Earned,
+			Redeemed: summary.Rewards.Redeemed,
+		}
+	}
 
 	for _, v := range summary.Monthly {
-		key := fmt.Sprintf("%d-%d", v.Year, v.Month)
+		key := fmt.Sprintf("%04d-%02d", v.Year, v.Month)
 		if _, ok := data.Monthly[key]; !ok {
 			data.Monthly[key] = &TransactionMonthlyDTO{
-				Year:          v.Year,
-				Month:         v.Month,
-				Total:         v.Total,
-				Balance:       v.Balance,
-				AverageCredit: v.AverageCredit,
-				AverageDebit:  v.AverageDebit,
-				Transactions:  make([]TransactionDetailDTO, 0, len(v.Transactions)),
+				Year:           v.Year,
+				Month:          v.Month,
+				Total:          v.Total,
+				Balance:        v.Balance,
+				AverageCredit:  v.AverageCredit,
+				AverageDebit:   v.AverageDebit,
+				Refunds:        v.Refunds,
+				NetSpend:       v.NetSpend,
+				Installments:   v.Installments,
+				PointsEarned:   v.PointsEarned,
+				PointsRedeemed: v.PointsRedeemed,
+				Categories:     convertCategoryTotalsToDTO(v.Categories),
+				Budgets:        convertBudgetStatusesToDTO(v.Budgets),
+				TopMerchants:   make([]MerchantTotalDTO, 0, len(v.TopMerchants)),
+				Transactions:   make([]TransactionDetailDTO, 0, len(v.Transactions)),
 			}
 
-			for _, t := range v.Transactions {
-				data.Monthly[key].Transactions = append(data.Monthly[key].Transactions, TransactionDetailDTO{
-					ID:        t.ID.String(),
-					Amount:    t.Amount,
-					Type:      t.Type,
-					InputDate: t.InputDate.Format("2006-01-02"),
+			for _, m := range v.TopMerchants {
+				data.Monthly[key].TopMerchants = append(data.Monthly[key].TopMerchants, MerchantTotalDTO{
+					Merchant: m.Merchant,
+					Category: m.Category,
+					Total:    m.Total,
+					Count:    m.Count,
 				})
 			}
+
+			for _, t := range v.Transactions {
+				data.Monthly[key].Transactions = append(data.Monthly[key].Transactions, convertTransactionToDTO(&t))
+			}
 		}
 	}
 
@@ -85,6 +135,236 @@ func (h *TransactionHandler) GetTransactionSummary(w http.ResponseWriter, r *htt
 	json.NewEncoder(w).Encode(data)
 }
 
+// RebuildSummaries queues a worker job that recomputes the monthly summary
+// projection of every account from the transactions.
+func (h *TransactionHandler) RebuildSummaries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := h.nats.Publish(domain.SummaryRebuildRequestedEvent, map[string]string{})
+	if err != nil {
+		log.Printf("Error requesting summary rebuild: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]string{
+		"message": "Summary rebuild queued",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(data)
+}
+
+func convertSummaryPeriodsToDTO(periods []domain.SummaryPeriod) []SummaryPeriodDTO {
+	dtos := make([]SummaryPeriodDTO, 0, len(periods))
+	for _, p := range periods {
+		dto := SummaryPeriodDTO{
+			Label:       p.Label,
+			Start:       p.Start.Format(dateLayout),
+			End:         p.End.AddDate(0, 0, -1).Format(dateLayout),
+			Count:       p.Count,
+			CreditCount: p.CreditCount,
+			Credits:     p.Credits,
+			DebitCount:  p.DebitCount,
+			Debits:      p.Debits,
+			RefundCount: p.RefundCount,
+			Refunds:     p.Refunds,
+			NetSpend:    p.NetSpend,
+			Net:         p.Net,
+		}
+		if p.Change != nil {
+			dto.Change = &PeriodChangeDTO{
+				Count:           p.Change.Count,
+				Credits:         p.Change.Credits,
+				Debits:          p.Change.Debits,
+				NetSpend:        p.Change.NetSpend,
+				Net:             p.Change.Net,
+				NetSpendPercent: p.Change.NetSpendPercent,
+			}
+		}
+		dtos = append(dtos, dto)
+	}
+	return dtos
+}
+
+func summaryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGranularity), errors.Is(err, domain.ErrInvalidSummaryRange),
+		errors.Is(err, domain.ErrInvalidClosingDay), errors.Is(err, domain.ErrTooManyPeriods):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func convertTransactionToDTO(t *domain.Transaction) TransactionDetailDTO {
+	dto := TransactionDetailDTO{
+		ID:           t.ID.String(),
+		Amount:       t.Amount,
+		Type:         t.Type,
+		Description:  t.Description,
+		Merchant:     t.Merchant,
+		Category:     t.Category,
+		InputDate:    t.InputDate.Format("2006-01-02"),
+		Voided:       t.Voided,
+		ReversalKind: t.ReversalKind,
+		Status:       t.Status,
+		Tags:         t.Tags,
+		Note:         t.Note,
+	}
+	if t.ReversalOf.Valid {
+		dto.ReversalOf = t.ReversalOf.UUID.String()
+	}
+	if t.InstallmentPlanID.Valid {
+		dto.InstallmentPlanID = t.InstallmentPlanID.UUID.String()
+	}
+	if t.Supersedes.Valid {
+		dto.Supersedes = t.Supersedes.UUID.String()
+	}
+	if t.SupersededBy.Valid {
+		dto.SupersededBy = t.SupersededBy.UUID.String()
+	}
+	if !t.AuthorizedAt.IsZero() {
+		dto.AuthorizedAt = t.AuthorizedAt.Format("2006-01-02")
+	}
+	if !t.PostedAt.IsZero() {
+		dto.PostedAt = t.PostedAt.Format("2006-01-02")
+	}
+	if len(t.Splits) > 0 {
+		dto.Splits = convertSplitsToDTO(t.Splits)
+	}
+	return dto
+}
+
+func convertCategoryTotalsToDTO(totals []domain.CategoryTotal) []CategoryTotalDTO {
+	response := make([]CategoryTotalDTO, 0, len(totals))
+	for _, c := range totals {
+		response = append(response, CategoryTotalDTO{
+			Category: c.Category,
+			Total:    c.Total,
+			Count:    c.Count,
+		})
+	}
+	return response
+}
+
+// ReverseAuthorization releases a pending authorization the merchant
+// cancelled.
+func (h *TransactionHandler) SearchTransactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	search, err := domain.ParseSearchQuery(r.URL.Query().Get("q"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := parseIntParam(r, "limit", defaultSearchLimit)
+	if err != nil || limit <= 0 || limit > maxSearchLimit {
+		http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit), http.StatusBadRequest)
+		return
+	}
+	offset, err := parseIntParam(r, "offset", 0)
+	if err != nil || offset < 0 {
+		http.Error(w, "offset must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+
+	transactions, err := h.service.SearchTransactions(r.Context(), accountID, search, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]TransactionDetailDTO, 0, len(transactions))
+	for _, t := range transactions {
+		response = append(response, convertTransactionToDTO(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *TransactionHandler) GetTagTotals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	opts, err := parseSummaryOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	totals, err := h.service.GetTagTotals(r.Context(), accountID, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]TagTotalDTO, 0, len(totals))
+	for _, t := range totals {
+		response = append(response, TagTotalDTO{
+			Tag:         t.Tag,
+			Count:       t.Count,
+			TotalCredit: t.TotalCredit,
+			TotalDebit:  t.TotalDebit,
+			Total:       t.Total,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *TransactionHandler) ReverseAuthorization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.service.ReverseAuthorization(r.Context(), id)
+	if err != nil {
+		log.Printf("Error reversing authorization: %v", err)
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrTransactionNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransactionToDTO(t))
+}
+
 func (h *TransactionHandler) SendEmailSummary(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
@@ -99,10 +379,16 @@ func (h *TransactionHandler) SendEmailSummary(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	summary, err := h.service.GetTransactionSummary(r.Context(), accountID)
+	opts, err := parseSummaryOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.service.GetTransactionSummary(r.Context(), accountID, opts)
 	if err != nil {
 		log.Printf("Error getting transaction summary: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), summaryErrorStatus(err))
 		return
 	}
 
@@ -0,0 +1,150 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	transaction "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type TransferHandler struct {
+	service *transaction.TransferService
+}
+
+func NewTransferHandler(service *transaction.TransferService) *TransferHandler {
+	return &TransferHandler{
+		service: service,
+	}
+}
+
+func convertTransferToDTO(transfer *domain.Transfer) TransferDTO {
+	return TransferDTO{
+		ID:                   transfer.ID.String(),
+		SourceAccountID:      transfer.SourceAccountID.String(),
+		DestinationAccountID: transfer.DestinationAccountID.String(),
+		Amount:               transfer.Amount,
+		Description:          transfer.Description,
+		DebitTransactionID:   transfer.DebitTransactionID.String(),
+		CreditTransactionID:  transfer.CreditTransactionID.String(),
+		CreatedAt:            time.Unix(transfer.CreatedAt, 0).UTC().Format(time.RFC3339),
+	}
+}
+
+func (h *TransferHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input struct {
+		SourceAccountID      string  `json:"source_account_id"`
+		DestinationAccountID string  `json:"destination_account_id"`
+		Amount               float64 `json:"amount"`
+		Description          string  `json:"description"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sourceID, err := uuid.Parse(input.SourceAccountID)
+	if err != nil {
+		http.Error(w, "Invalid source account ID", http.StatusBadRequest)
+		return
+	}
+	destinationID, err := uuid.Parse(input.DestinationAccountID)
+	if err != nil {
+		http.Error(w, "Invalid destination account ID", http.StatusBadRequest)
+		return
+	}
+
+	transfer, created, err := h.service.CreateTransfer(r.Context(), sourceID, destinationID, input.Amount, input.Description, r.Header.Get(idempotencyKeyHeader))
+	if err != nil {
+		log.Printf("Error creating transfer: %v", err)
+		http.Error(w, err.Error(), transferErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(convertTransferToDTO(transfer))
+}
+
+func (h *TransferHandler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid transfer ID", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.service.GetTransfer(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if transfer == nil {
+		http.Error(w, "Transfer not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(convertTransferToDTO(transfer))
+}
+
+func (h *TransferHandler) ListTransfers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID, err := uuid.Parse(r.PathValue("account_id"))
+	if err != nil {
+		http.Error(w, "Invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	transfers, err := h.service.GetTransfersByAccount(r.Context(), accountID, 100, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]TransferDTO, 0, len(transfers))
+	for _, t := range transfers {
+		response = append(response, convertTransferToDTO(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTransferAmount), errors.Is(err, domain.ErrSameAccountTransfer):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrAccountInactive):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
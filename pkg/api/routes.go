@@ -4,7 +4,11 @@ import (
 	"net/http"
 
 	appAccount "github.com/AguilaMike/Stori_Challenge_Go/internal/account/application"
+	appAnalytics "github.com/AguilaMike/Stori_Challenge_Go/internal/analytics/application"
+	"github.com/AguilaMike/Stori_Challenge_Go/internal/common/nats"
+	appMerchant "github.com/AguilaMike/Stori_Challenge_Go/internal/merchant/application"
 	appTran "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/application"
+	tranDomain "github.com/AguilaMike/Stori_Challenge_Go/internal/transaction/domain"
 	"github.com/AguilaMike/Stori_Challenge_Go/pkg/api/api_grpc"
 	"github.com/AguilaMike/Stori_Challenge_Go/pkg/api/rest"
 	pbAccount "github.com/AguilaMike/Stori_Challenge_Go/pkg/proto"
@@ -12,28 +16,145 @@ import (
 )
 
 func SetupHTTPRoutes(
-	accountService *appAccount.AccountService, transactionService *appTran.TransactionService) *http.ServeMux {
+	accountService *appAccount.AccountService, transactionService *appTran.TransactionService,
+	ledgerService *appTran.LedgerService, transferService *appTran.TransferService,
+	correctionService *appTran.CorrectionService, refundService *appTran.RefundService,
+	splitService *appTran.SplitService, annotationService *appTran.AnnotationService, accrualService *appTran.AccrualService,
+	installmentService *appTran.InstallmentService, rewardService *appTran.RewardService,
+	disputeService *appTran.DisputeService, subscriptionService *appTran.SubscriptionService,
+	anomalyService *appTran.AnomalyService, budgetService *appTran.BudgetService,
+	goalService *appTran.GoalService, forecastService *appTran.ForecastService,
+	merchantService *appMerchant.MerchantService, analyticsService *appAnalytics.AnalyticsService,
+	adminKey string, nc *nats.NatsClient) *http.ServeMux {
 	router := http.NewServeMux()
 
 	accountHandler := rest.NewAccountHandler(accountService)
-	transactionHandler := rest.NewTransactionHandler(transactionService)
+	transactionHandler := rest.NewTransactionHandler(transactionService, nc)
+	ledgerHandler := rest.NewLedgerHandler(ledgerService)
+	transferHandler := rest.NewTransferHandler(transferService)
+	correctionHandler := rest.NewCorrectionHandler(correctionService)
+	refundHandler := rest.NewRefundHandler(refundService, nc)
+	splitHandler := rest.NewSplitHandler(splitService)
+	annotationHandler := rest.NewAnnotationHandler(annotationService)
+	accrualHandler := rest.NewAccrualHandler(accrualService, nc)
+	installmentHandler := rest.NewInstallmentHandler(installmentService)
+	rewardHandler := rest.NewRewardHandler(rewardService)
+	disputeHandler := rest.NewDisputeHandler(disputeService)
+	subscriptionHandler := rest.NewSubscriptionHandler(subscriptionService)
+	anomalyHandler := rest.NewAnomalyHandler(anomalyService)
+	budgetHandler := rest.NewBudgetHandler(budgetService)
+	goalHandler := rest.NewGoalHandler(goalService)
+	forecastHandler := rest.NewForecastHandler(forecastService, nc)
+	merchantHandler := rest.NewMerchantHandler(merchantService, nc)
+	analyticsHandler := rest.NewAnalyticsHandler(analyticsService)
 
 	// Account routes
 	router.HandleFunc("/accounts", accountHandler.Manager)
 	router.HandleFunc("/accounts/{id}", accountHandler.GetAccount)
+	router.HandleFunc("/accounts/balance-drift", accountHandler.GetBalanceDrifts)
+	router.HandleFunc("/accounts/statements/{id}", accountHandler.GetStatements)
 
 	// Transaction routes
 	router.HandleFunc("/transactions/summary/{account_id}", transactionHandler.GetTransactionSummary)
+	router.HandleFunc("/transactions/summary/rebuild", transactionHandler.RebuildSummaries)
 	router.HandleFunc("/transactions/send-sumamry/{account_id}", transactionHandler.SendEmailSummary)
+	router.HandleFunc("/transactions/amend/{id}", correctionHandler.AmendTransaction)
+	router.HandleFunc("/transactions/void/{id}", correctionHandler.VoidTransaction)
+	router.HandleFunc("/transactions/history/{id}", correctionHandler.GetTransactionHistory)
+	router.HandleFunc("/transactions/link-refund/{id}", refundHandler.LinkRefund)
+	router.HandleFunc("/transactions/refunds/match", refundHandler.MatchRefunds)
+	router.HandleFunc("/transactions/reverse-authorization/{id}", transactionHandler.ReverseAuthorization)
+	router.HandleFunc("/transactions/splits/{id}", splitHandler.Manager)
+	router.HandleFunc("/transactions/annotations/{id}", annotationHandler.AnnotateTransaction)
+	router.HandleFunc("/transactions/search/{account_id}", transactionHandler.SearchTransactions)
+	router.HandleFunc("/transactions/tags/{account_id}", transactionHandler.GetTagTotals)
+
+	// Ledger routes
+	router.HandleFunc("/ledger/entries", ledgerHandler.PostJournalEntry)
+	router.HandleFunc("/ledger/trial-balance", ledgerHandler.GetTrialBalance)
+	router.HandleFunc("/ledger/statements/{account_id}", ledgerHandler.GetAccountStatement)
+	router.HandleFunc("/ledger/balances/{account_id}", ledgerHandler.GetBalanceSeries)
+	router.HandleFunc("/ledger/balances/{account_id}/as-of", ledgerHandler.GetBalanceAsOf)
+
+	// Transfer routes
+	router.HandleFunc("/transfers", transferHandler.CreateTransfer)
+	router.HandleFunc("/transfers/{id}", transferHandler.GetTransfer)
+	router.HandleFunc("/transfers/account/{account_id}", transferHandler.ListTransfers)
+
+	// Accrual routes
+	router.HandleFunc("/accruals/run", accrualHandler.RunAccruals)
+	router.HandleFunc("/accruals/{account_id}", accrualHandler.ListAccruals)
+
+	// Installment plan routes
+	router.HandleFunc("/installments", installmentHandler.CreatePlan)
+	router.HandleFunc("/installments/{id}", installmentHandler.GetPlan)
+	router.HandleFunc("/installments/account/{account_id}", installmentHandler.ListPlans)
+
+	// Rewards routes
+	router.HandleFunc("/rewards/program", rewardHandler.GetProgram)
+	router.HandleFunc("/rewards/{account_id}", rewardHandler.GetBalance)
+	router.HandleFunc("/rewards/redeem/{account_id}", rewardHandler.Redeem)
+
+	// Dispute routes
+	router.HandleFunc("/disputes", disputeHandler.OpenDispute)
+	router.HandleFunc("/disputes/reasons", disputeHandler.ListReasons)
+	router.HandleFunc("/disputes/{id}", disputeHandler.GetDispute)
+	router.HandleFunc("/disputes/account/{account_id}", disputeHandler.ListDisputes)
+	router.HandleFunc("/disputes/transition/{id}", disputeHandler.TransitionDispute)
+	router.HandleFunc("/disputes/attachments/{id}", disputeHandler.AddAttachment)
+	router.HandleFunc("/disputes/attachments/{id}/{attachment_id}", disputeHandler.GetAttachment)
+
+	// Subscription routes
+	router.HandleFunc("/subscriptions/{account_id}", subscriptionHandler.ListSubscriptions)
+	router.HandleFunc("/subscriptions/detect/{account_id}", subscriptionHandler.DetectSubscriptions)
+	router.HandleFunc("/subscriptions/alerts/{account_id}", subscriptionHandler.ListAlerts)
+
+	// Suspicious activity routes
+	router.HandleFunc("/alerts/review", rest.RequireAdmin(adminKey, anomalyHandler.ListQueue))
+	router.HandleFunc("/alerts/review/{id}", rest.RequireAdmin(adminKey, anomalyHandler.ReviewAlert))
+	router.HandleFunc("/alerts/{id}", anomalyHandler.GetAlert)
+	router.HandleFunc("/alerts/account/{account_id}", anomalyHandler.ListAlerts)
+
+	// Budget routes
+	router.HandleFunc("/budgets", budgetHandler.SetBudget)
+	router.HandleFunc("/budgets/{id}", budgetHandler.DeleteBudget)
+	router.HandleFunc("/budgets/account/{account_id}", budgetHandler.ListBudgets)
+
+	// Savings goal routes
+	router.HandleFunc("/goals", goalHandler.CreateGoal)
+	router.HandleFunc("/goals/{id}", goalHandler.Manager)
+	router.HandleFunc("/goals/account/{account_id}", goalHandler.ListGoals)
+
+	// Forecast routes
+	router.HandleFunc("/forecasts/{account_id}", forecastHandler.GetForecast)
+	router.HandleFunc("/forecasts/run/{account_id}", forecastHandler.RunForecast)
+
+	// Merchant routes
+	router.HandleFunc("/merchants", merchantHandler.ListMerchants)
+	router.HandleFunc("/merchants/normalize", merchantHandler.NormalizeHistory)
+
+	// Admin analytics routes
+	router.HandleFunc("/admin/analytics/activity", rest.RequireAdmin(adminKey, analyticsHandler.GetActivity))
+	router.HandleFunc("/admin/analytics/imports", rest.RequireAdmin(adminKey, analyticsHandler.GetImportStats))
+	router.HandleFunc("/admin/analytics/balances", rest.RequireAdmin(adminKey, analyticsHandler.GetBalanceDistribution))
+	router.HandleFunc("/admin/analytics/merchants", rest.RequireAdmin(adminKey, analyticsHandler.ListTopMerchants))
 
 	return router
 }
 
-func SetupGRPCServer(accountService *appAccount.AccountService, transactionService *appTran.TransactionService) *grpc.Server {
-	grpcServer := grpc.NewServer()
+func SetupGRPCServer(
+	accountService *appAccount.AccountService, transactionService *appTran.TransactionService,
+	transferService *appTran.TransferService, correctionService *appTran.CorrectionService,
+	refundService *appTran.RefundService, splitService *appTran.SplitService,
+	annotationService *appTran.AnnotationService, disputeService *appTran.DisputeService,
+	goalService *appTran.GoalService, ledgerService *appTran.LedgerService,
+	analyticsService *appAnalytics.AnalyticsService, adminKey string) *grpc.Server {
+	// Leave room for dispute attachments, which are sent inline
+	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(tranDomain.MaxDisputeAttachmentSize + 1<<20))
 
 	pbAccount.RegisterAccountServiceServer(grpcServer, api_grpc.NewAccountServer(accountService))
-	pbAccount.RegisterTransactionServiceServer(grpcServer, api_grpc.NewTransactionServer(transactionService))
+	pbAccount.RegisterTransactionServiceServer(grpcServer, api_grpc.NewTransactionServer(transactionService, transferService, correctionService, refundService, splitService, annotationService, disputeService, goalService, ledgerService))
+	pbAccount.RegisterAnalyticsServiceServer(grpcServer, api_grpc.NewAnalyticsServer(analyticsService, adminKey))
 
 	return grpcServer
 }
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v5.27.2
+// source: pkg/proto/analytics.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnalyticsServiceClient interface {
+	GetActivity(ctx context.Context, in *AnalyticsRangeRequest, opts ...grpc.CallOption) (*Activity, error)
+	GetImportStats(ctx context.Context, in *AnalyticsRangeRequest, opts ...grpc.CallOption) (*ImportStats, error)
+	GetBalanceDistribution(ctx context.Context, in *GetBalanceDistributionRequest, opts ...grpc.CallOption) (*BalanceDistribution, error)
+	ListTopMerchants(ctx context.Context, in *ListTopMerchantsRequest, opts ...grpc.CallOption) (*ListTopMerchantsResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetActivity(ctx context.Context, in *AnalyticsRangeRequest, opts ...grpc.CallOption) (*Activity, error) {
+	out := new(Activity)
+	err := c.cc.Invoke(ctx, "/stori.AnalyticsService/GetActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetImportStats(ctx context.Context, in *AnalyticsRangeRequest, opts ...grpc.CallOption) (*ImportStats, error) {
+	out := new(ImportStats)
+	err := c.cc.Invoke(ctx, "/stori.AnalyticsService/GetImportStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetBalanceDistribution(ctx context.Context, in *GetBalanceDistributionRequest, opts ...grpc.CallOption) (*BalanceDistribution, error) {
+	out := new(BalanceDistribution)
+	err := c.cc.Invoke(ctx, "/stori.AnalyticsService/GetBalanceDistribution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ListTopMerchants(ctx context.Context, in *ListTopMerchantsRequest, opts ...grpc.CallOption) (*ListTopMerchantsResponse, error) {
+	out := new(ListTopMerchantsResponse)
+	err := c.cc.Invoke(ctx, "/stori.AnalyticsService/ListTopMerchants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility
+type AnalyticsServiceServer interface {
+	GetActivity(context.Context, *AnalyticsRangeRequest) (*Activity, error)
+	GetImportStats(context.Context, *AnalyticsRangeRequest) (*ImportStats, error)
+	GetBalanceDistribution(context.Context, *GetBalanceDistributionRequest) (*BalanceDistribution, error)
+	ListTopMerchants(context.Context, *ListTopMerchantsRequest) (*ListTopMerchantsResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAnalyticsServiceServer struct {
+}
+
+func (UnimplementedAnalyticsServiceServer) GetActivity(context.Context, *AnalyticsRangeRequest) (*Activity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivity not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetImportStats(context.Context, *AnalyticsRangeRequest) (*ImportStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportStats not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetBalanceDistribution(context.Context, *GetBalanceDistributionRequest) (*BalanceDistribution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceDistribution not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ListTopMerchants(context.Context, *ListTopMerchantsRequest) (*ListTopMerchantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopMerchants not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.AnalyticsService/GetActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetActivity(ctx, req.(*AnalyticsRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetImportStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetImportStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.AnalyticsService/GetImportStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetImportStats(ctx, req.(*AnalyticsRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetBalanceDistribution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceDistributionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetBalanceDistribution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.AnalyticsService/GetBalanceDistribution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetBalanceDistribution(ctx, req.(*GetBalanceDistributionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ListTopMerchants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopMerchantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ListTopMerchants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.AnalyticsService/ListTopMerchants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ListTopMerchants(ctx, req.(*ListTopMerchantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stori.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetActivity",
+			Handler:    _AnalyticsService_GetActivity_Handler,
+		},
+		{
+			MethodName: "GetImportStats",
+			Handler:    _AnalyticsService_GetImportStats_Handler,
+		},
+		{
+			MethodName: "GetBalanceDistribution",
+			Handler:    _AnalyticsService_GetBalanceDistribution_Handler,
+		},
+		{
+			MethodName: "ListTopMerchants",
+			Handler:    _AnalyticsService_ListTopMerchants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/proto/analytics.proto",
+}
@@ -24,6 +24,28 @@ const _ = grpc.SupportPackageIsVersion7
 type TransactionServiceClient interface {
 	CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
 	GetTransactionSummary(ctx context.Context, in *GetTransactionSummaryRequest, opts ...grpc.CallOption) (*TransactionSummary, error)
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	AmendTransaction(ctx context.Context, in *AmendTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	VoidTransaction(ctx context.Context, in *VoidTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	GetTransactionHistory(ctx context.Context, in *GetTransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistory, error)
+	LinkRefund(ctx context.Context, in *LinkRefundRequest, opts ...grpc.CallOption) (*Transaction, error)
+	ReverseAuthorization(ctx context.Context, in *ReverseAuthorizationRequest, opts ...grpc.CallOption) (*Transaction, error)
+	SplitTransaction(ctx context.Context, in *SplitTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	AnnotateTransaction(ctx context.Context, in *AnnotateTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	SearchTransactions(ctx context.Context, in *SearchTransactionsRequest, opts ...grpc.CallOption) (*TransactionList, error)
+	GetTagTotals(ctx context.Context, in *GetTagTotalsRequest, opts ...grpc.CallOption) (*TagTotals, error)
+	OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	GetDispute(ctx context.Context, in *GetDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	ListDisputes(ctx context.Context, in *ListDisputesRequest, opts ...grpc.CallOption) (*DisputeList, error)
+	TransitionDispute(ctx context.Context, in *TransitionDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	AddDisputeAttachment(ctx context.Context, in *AddDisputeAttachmentRequest, opts ...grpc.CallOption) (*DisputeAttachment, error)
+	CreateSavingsGoal(ctx context.Context, in *CreateSavingsGoalRequest, opts ...grpc.CallOption) (*SavingsGoal, error)
+	GetSavingsGoal(ctx context.Context, in *GetSavingsGoalRequest, opts ...grpc.CallOption) (*SavingsGoal, error)
+	ListSavingsGoals(ctx context.Context, in *ListSavingsGoalsRequest, opts ...grpc.CallOption) (*SavingsGoalList, error)
+	DeleteSavingsGoal(ctx context.Context, in *DeleteSavingsGoalRequest, opts ...grpc.CallOption) (*DeleteSavingsGoalResponse, error)
+	StreamBalanceSeries(ctx context.Context, in *StreamBalanceSeriesRequest, opts ...grpc.CallOption) (TransactionService_StreamBalanceSeriesClient, error)
+	GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*DailyBalance, error)
 }
 
 type transactionServiceClient struct {
@@ -52,12 +74,255 @@ func (c *transactionServiceClient) GetTransactionSummary(ctx context.Context, in
 	return out, nil
 }
 
+func (c *transactionServiceClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	out := new(Transfer)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/CreateTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	out := new(Transfer)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/GetTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) AmendTransaction(ctx context.Context, in *AmendTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/AmendTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) VoidTransaction(ctx context.Context, in *VoidTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/VoidTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) GetTransactionHistory(ctx context.Context, in *GetTransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistory, error) {
+	out := new(TransactionHistory)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/GetTransactionHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) LinkRefund(ctx context.Context, in *LinkRefundRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/LinkRefund", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ReverseAuthorization(ctx context.Context, in *ReverseAuthorizationRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/ReverseAuthorization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) SplitTransaction(ctx context.Context, in *SplitTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/SplitTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) AnnotateTransaction(ctx context.Context, in *AnnotateTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/AnnotateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) SearchTransactions(ctx context.Context, in *SearchTransactionsRequest, opts ...grpc.CallOption) (*TransactionList, error) {
+	out := new(TransactionList)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/SearchTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) GetTagTotals(ctx context.Context, in *GetTagTotalsRequest, opts ...grpc.CallOption) (*TagTotals, error) {
+	out := new(TagTotals)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/GetTagTotals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/OpenDispute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) GetDispute(ctx context.Context, in *GetDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/GetDispute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ListDisputes(ctx context.Context, in *ListDisputesRequest, opts ...grpc.CallOption) (*DisputeList, error) {
+	out := new(DisputeList)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/ListDisputes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) TransitionDispute(ctx context.Context, in *TransitionDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/TransitionDispute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) AddDisputeAttachment(ctx context.Context, in *AddDisputeAttachmentRequest, opts ...grpc.CallOption) (*DisputeAttachment, error) {
+	out := new(DisputeAttachment)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/AddDisputeAttachment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) CreateSavingsGoal(ctx context.Context, in *CreateSavingsGoalRequest, opts ...grpc.CallOption) (*SavingsGoal, error) {
+	out := new(SavingsGoal)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/CreateSavingsGoal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) GetSavingsGoal(ctx context.Context, in *GetSavingsGoalRequest, opts ...grpc.CallOption) (*SavingsGoal, error) {
+	out := new(SavingsGoal)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/GetSavingsGoal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ListSavingsGoals(ctx context.Context, in *ListSavingsGoalsRequest, opts ...grpc.CallOption) (*SavingsGoalList, error) {
+	out := new(SavingsGoalList)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/ListSavingsGoals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) DeleteSavingsGoal(ctx context.Context, in *DeleteSavingsGoalRequest, opts ...grpc.CallOption) (*DeleteSavingsGoalResponse, error) {
+	out := new(DeleteSavingsGoalResponse)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/DeleteSavingsGoal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) StreamBalanceSeries(ctx context.Context, in *StreamBalanceSeriesRequest, opts ...grpc.CallOption) (TransactionService_StreamBalanceSeriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TransactionService_ServiceDesc.Streams[0], "/stori.TransactionService/StreamBalanceSeries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &transactionServiceStreamBalanceSeriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TransactionService_StreamBalanceSeriesClient interface {
+	Recv() (*DailyBalance, error)
+	grpc.ClientStream
+}
+
+type transactionServiceStreamBalanceSeriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *transactionServiceStreamBalanceSeriesClient) Recv() (*DailyBalance, error) {
+	m := new(DailyBalance)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *transactionServiceClient) GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*DailyBalance, error) {
+	out := new(DailyBalance)
+	err := c.cc.Invoke(ctx, "/stori.TransactionService/GetBalanceAsOf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionServiceServer is the server API for TransactionService service.
 // All implementations must embed UnimplementedTransactionServiceServer
 // for forward compatibility
 type TransactionServiceServer interface {
 	CreateTransaction(context.Context, *CreateTransactionRequest) (*Transaction, error)
 	GetTransactionSummary(context.Context, *GetTransactionSummaryRequest) (*TransactionSummary, error)
+	CreateTransfer(context.Context, *CreateTransferRequest) (*Transfer, error)
+	GetTransfer(context.Context, *GetTransferRequest) (*Transfer, error)
+	AmendTransaction(context.Context, *AmendTransactionRequest) (*Transaction, error)
+	VoidTransaction(context.Context, *VoidTransactionRequest) (*Transaction, error)
+	GetTransactionHistory(context.Context, *GetTransactionHistoryRequest) (*TransactionHistory, error)
+	LinkRefund(context.Context, *LinkRefundRequest) (*Transaction, error)
+	ReverseAuthorization(context.Context, *ReverseAuthorizationRequest) (*Transaction, error)
+	SplitTransaction(context.Context, *SplitTransactionRequest) (*Transaction, error)
+	AnnotateTransaction(context.Context, *AnnotateTransactionRequest) (*Transaction, error)
+	SearchTransactions(context.Context, *SearchTransactionsRequest) (*TransactionList, error)
+	GetTagTotals(context.Context, *GetTagTotalsRequest) (*TagTotals, error)
+	OpenDispute(context.Context, *OpenDisputeRequest) (*Dispute, error)
+	GetDispute(context.Context, *GetDisputeRequest) (*Dispute, error)
+	ListDisputes(context.Context, *ListDisputesRequest) (*DisputeList, error)
+	TransitionDispute(context.Context, *TransitionDisputeRequest) (*Dispute, error)
+	AddDisputeAttachment(context.Context, *AddDisputeAttachmentRequest) (*DisputeAttachment, error)
+	CreateSavingsGoal(context.Context, *CreateSavingsGoalRequest) (*SavingsGoal, error)
+	GetSavingsGoal(context.Context, *GetSavingsGoalRequest) (*SavingsGoal, error)
+	ListSavingsGoals(context.Context, *ListSavingsGoalsRequest) (*SavingsGoalList, error)
+	DeleteSavingsGoal(context.Context, *DeleteSavingsGoalRequest) (*DeleteSavingsGoalResponse, error)
+	StreamBalanceSeries(*StreamBalanceSeriesRequest, TransactionService_StreamBalanceSeriesServer) error
+	GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*DailyBalance, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
 
@@ -71,6 +336,72 @@ func (UnimplementedTransactionServiceServer) CreateTransaction(context.Context,
 func (UnimplementedTransactionServiceServer) GetTransactionSummary(context.Context, *GetTransactionSummaryRequest) (*TransactionSummary, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionSummary not implemented")
 }
+func (UnimplementedTransactionServiceServer) CreateTransfer(context.Context, *CreateTransferRequest) (*Transfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfer not implemented")
+}
+func (UnimplementedTransactionServiceServer) GetTransfer(context.Context, *GetTransferRequest) (*Transfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransfer not implemented")
+}
+func (UnimplementedTransactionServiceServer) AmendTransaction(context.Context, *AmendTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AmendTransaction not implemented")
+}
+func (UnimplementedTransactionServiceServer) VoidTransaction(context.Context, *VoidTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoidTransaction not implemented")
+}
+func (UnimplementedTransactionServiceServer) GetTransactionHistory(context.Context, *GetTransactionHistoryRequest) (*TransactionHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionHistory not implemented")
+}
+func (UnimplementedTransactionServiceServer) LinkRefund(context.Context, *LinkRefundRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkRefund not implemented")
+}
+func (UnimplementedTransactionServiceServer) ReverseAuthorization(context.Context, *ReverseAuthorizationRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseAuthorization not implemented")
+}
+func (UnimplementedTransactionServiceServer) SplitTransaction(context.Context, *SplitTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SplitTransaction not implemented")
+}
+func (UnimplementedTransactionServiceServer) AnnotateTransaction(context.Context, *AnnotateTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnotateTransaction not implemented")
+}
+func (UnimplementedTransactionServiceServer) SearchTransactions(context.Context, *SearchTransactionsRequest) (*TransactionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTransactions not implemented")
+}
+func (UnimplementedTransactionServiceServer) GetTagTotals(context.Context, *GetTagTotalsRequest) (*TagTotals, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTagTotals not implemented")
+}
+func (UnimplementedTransactionServiceServer) OpenDispute(context.Context, *OpenDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenDispute not implemented")
+}
+func (UnimplementedTransactionServiceServer) GetDispute(context.Context, *GetDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDispute not implemented")
+}
+func (UnimplementedTransactionServiceServer) ListDisputes(context.Context, *ListDisputesRequest) (*DisputeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisputes not implemented")
+}
+func (UnimplementedTransactionServiceServer) TransitionDispute(context.Context, *TransitionDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionDispute not implemented")
+}
+func (UnimplementedTransactionServiceServer) AddDisputeAttachment(context.Context, *AddDisputeAttachmentRequest) (*DisputeAttachment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDisputeAttachment not implemented")
+}
+func (UnimplementedTransactionServiceServer) CreateSavingsGoal(context.Context, *CreateSavingsGoalRequest) (*SavingsGoal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSavingsGoal not implemented")
+}
+func (UnimplementedTransactionServiceServer) GetSavingsGoal(context.Context, *GetSavingsGoalRequest) (*SavingsGoal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSavingsGoal not implemented")
+}
+func (UnimplementedTransactionServiceServer) ListSavingsGoals(context.Context, *ListSavingsGoalsRequest) (*SavingsGoalList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavingsGoals not implemented")
+}
+func (UnimplementedTransactionServiceServer) DeleteSavingsGoal(context.Context, *DeleteSavingsGoalRequest) (*DeleteSavingsGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavingsGoal not implemented")
+}
+func (UnimplementedTransactionServiceServer) StreamBalanceSeries(*StreamBalanceSeriesRequest, TransactionService_StreamBalanceSeriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBalanceSeries not implemented")
+}
+func (UnimplementedTransactionServiceServer) GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*DailyBalance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAsOf not implemented")
+}
 func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
 
 // UnsafeTransactionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +451,405 @@ func _TransactionService_GetTransactionSummary_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/CreateTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_GetTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).GetTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/GetTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).GetTransfer(ctx, req.(*GetTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_AmendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).AmendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/AmendTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).AmendTransaction(ctx, req.(*AmendTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_VoidTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).VoidTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/VoidTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).VoidTransaction(ctx, req.(*VoidTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_GetTransactionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).GetTransactionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/GetTransactionHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).GetTransactionHistory(ctx, req.(*GetTransactionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_LinkRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkRefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).LinkRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/LinkRefund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).LinkRefund(ctx, req.(*LinkRefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ReverseAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ReverseAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/ReverseAuthorization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ReverseAuthorization(ctx, req.(*ReverseAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_SplitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).SplitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/SplitTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).SplitTransaction(ctx, req.(*SplitTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_AnnotateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnotateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).AnnotateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/AnnotateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).AnnotateTransaction(ctx, req.(*AnnotateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_SearchTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).SearchTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/SearchTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).SearchTransactions(ctx, req.(*SearchTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_GetTagTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagTotalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).GetTagTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/GetTagTotals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).GetTagTotals(ctx, req.(*GetTagTotalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_OpenDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).OpenDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/OpenDispute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).OpenDispute(ctx, req.(*OpenDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_GetDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).GetDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/GetDispute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).GetDispute(ctx, req.(*GetDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ListDisputes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisputesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListDisputes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/ListDisputes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListDisputes(ctx, req.(*ListDisputesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_TransitionDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).TransitionDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/TransitionDispute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).TransitionDispute(ctx, req.(*TransitionDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_AddDisputeAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDisputeAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).AddDisputeAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/AddDisputeAttachment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).AddDisputeAttachment(ctx, req.(*AddDisputeAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_CreateSavingsGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavingsGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).CreateSavingsGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/CreateSavingsGoal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).CreateSavingsGoal(ctx, req.(*CreateSavingsGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_GetSavingsGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSavingsGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).GetSavingsGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/GetSavingsGoal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).GetSavingsGoal(ctx, req.(*GetSavingsGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ListSavingsGoals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavingsGoalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListSavingsGoals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/ListSavingsGoals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListSavingsGoals(ctx, req.(*ListSavingsGoalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_DeleteSavingsGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavingsGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).DeleteSavingsGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/DeleteSavingsGoal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).DeleteSavingsGoal(ctx, req.(*DeleteSavingsGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_StreamBalanceSeries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBalanceSeriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransactionServiceServer).StreamBalanceSeries(m, &transactionServiceStreamBalanceSeriesServer{stream})
+}
+
+type TransactionService_StreamBalanceSeriesServer interface {
+	Send(*DailyBalance) error
+	grpc.ServerStream
+}
+
+type transactionServiceStreamBalanceSeriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *transactionServiceStreamBalanceSeriesServer) Send(m *DailyBalance) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TransactionService_GetBalanceAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).GetBalanceAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stori.TransactionService/GetBalanceAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).GetBalanceAsOf(ctx, req.(*GetBalanceAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,7 +865,97 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionSummary",
 			Handler:    _TransactionService_GetTransactionSummary_Handler,
 		},
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _TransactionService_CreateTransfer_Handler,
+		},
+		{
+			MethodName: "GetTransfer",
+			Handler:    _TransactionService_GetTransfer_Handler,
+		},
+		{
+			MethodName: "AmendTransaction",
+			Handler:    _TransactionService_AmendTransaction_Handler,
+		},
+		{
+			MethodName: "VoidTransaction",
+			Handler:    _TransactionService_VoidTransaction_Handler,
+		},
+		{
+			MethodName: "GetTransactionHistory",
+			Handler:    _TransactionService_GetTransactionHistory_Handler,
+		},
+		{
+			MethodName: "LinkRefund",
+			Handler:    _TransactionService_LinkRefund_Handler,
+		},
+		{
+			MethodName: "ReverseAuthorization",
+			Handler:    _TransactionService_ReverseAuthorization_Handler,
+		},
+		{
+			MethodName: "SplitTransaction",
+			Handler:    _TransactionService_SplitTransaction_Handler,
+		},
+		{
+			MethodName: "AnnotateTransaction",
+			Handler:    _TransactionService_AnnotateTransaction_Handler,
+		},
+		{
+			MethodName: "SearchTransactions",
+			Handler:    _TransactionService_SearchTransactions_Handler,
+		},
+		{
+			MethodName: "GetTagTotals",
+			Handler:    _TransactionService_GetTagTotals_Handler,
+		},
+		{
+			MethodName: "OpenDispute",
+			Handler:    _TransactionService_OpenDispute_Handler,
+		},
+		{
+			MethodName: "GetDispute",
+			Handler:    _TransactionService_GetDispute_Handler,
+		},
+		{
+			MethodName: "ListDisputes",
+			Handler:    _TransactionService_ListDisputes_Handler,
+		},
+		{
+			MethodName: "TransitionDispute",
+			Handler:    _TransactionService_TransitionDispute_Handler,
+		},
+		{
+			MethodName: "AddDisputeAttachment",
+			Handler:    _TransactionService_AddDisputeAttachment_Handler,
+		},
+		{
+			MethodName: "CreateSavingsGoal",
+			Handler:    _TransactionService_CreateSavingsGoal_Handler,
+		},
+		{
+			MethodName: "GetSavingsGoal",
+			Handler:    _TransactionService_GetSavingsGoal_Handler,
+		},
+		{
+			MethodName: "ListSavingsGoals",
+			Handler:    _TransactionService_ListSavingsGoals_Handler,
+		},
+		{
+			MethodName: "DeleteSavingsGoal",
+			Handler:    _TransactionService_DeleteSavingsGoal_Handler,
+		},
+		{
+			MethodName: "GetBalanceAsOf",
+			Handler:    _TransactionService_GetBalanceAsOf_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBalanceSeries",
+			Handler:       _TransactionService_StreamBalanceSeries_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/proto/transaction.proto",
 }
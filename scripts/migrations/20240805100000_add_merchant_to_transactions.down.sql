@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_merchant;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS merchant,
+    DROP COLUMN IF EXISTS description;
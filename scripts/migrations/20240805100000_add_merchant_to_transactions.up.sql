@@ -0,0 +1,6 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS merchant TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant ON transactions(merchant);
@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id UUID PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    account_id UUID REFERENCES accounts(id),
+    created_at BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY,
+    transaction_id UUID REFERENCES transactions(id),
+    description TEXT NOT NULL,
+    effective_date TIMESTAMP NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id UUID PRIMARY KEY,
+    journal_entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    ledger_account_id UUID NOT NULL REFERENCES ledger_accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_transaction_id ON journal_entries(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_postings_journal_entry_id ON postings(journal_entry_id);
+CREATE INDEX IF NOT EXISTS idx_postings_ledger_account_id ON postings(ledger_account_id);
+
+-- Internal accounts
+INSERT INTO ledger_accounts (id, code, name, type, created_at) VALUES
+    (gen_random_uuid(), 'merchant_settlement', 'Merchant settlement', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT),
+    (gen_random_uuid(), 'customer_funding', 'Customer funding', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT),
+    (gen_random_uuid(), 'fees', 'Fees', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT),
+    (gen_random_uuid(), 'adjustments', 'Adjustments', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT)
+ON CONFLICT (code) DO NOTHING;
+
+-- Backfill one journal entry per existing transaction
+INSERT INTO ledger_accounts (id, code, name, type, account_id, created_at)
+SELECT gen_random_uuid(), 'customer:' || a.id, a.nickname, 'customer', a.id, a.created_at
+FROM accounts a
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO journal_entries (id, transaction_id, description, effective_date, created_at)
+SELECT gen_random_uuid(), t.id, t.description, t.input_date, t.created_at
+FROM transactions t;
+
+INSERT INTO postings (id, journal_entry_id, ledger_account_id, amount, created_at)
+SELECT gen_random_uuid(), je.id, la.id, t.amount, t.created_at
+FROM journal_entries je
+JOIN transactions t ON t.id = je.transaction_id
+JOIN ledger_accounts la ON la.account_id = t.account_id;
+
+INSERT INTO postings (id, journal_entry_id, ledger_account_id, amount, created_at)
+SELECT gen_random_uuid(), je.id, la.id, -t.amount, t.created_at
+FROM journal_entries je
+JOIN transactions t ON t.id = je.transaction_id
+JOIN ledger_accounts la ON la.code = CASE WHEN t.amount < 0 THEN 'merchant_settlement' ELSE 'customer_funding' END;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
+DROP TABLE IF EXISTS transfers;
+DELETE FROM ledger_accounts WHERE code = 'transfers_clearing';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS transfers (
+    id UUID PRIMARY KEY,
+    source_account_id UUID NOT NULL REFERENCES accounts(id),
+    destination_account_id UUID NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    description TEXT NOT NULL DEFAULT '',
+    idempotency_key TEXT UNIQUE,
+    debit_transaction_id UUID NOT NULL,
+    credit_transaction_id UUID NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS transfer_id UUID REFERENCES transfers(id);
+
+INSERT INTO ledger_accounts (id, code, name, type, created_at) VALUES
+    (gen_random_uuid(), 'transfers_clearing', 'Transfers clearing', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT)
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS transaction_corrections;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS voided,
+    DROP COLUMN IF EXISTS updated_at;
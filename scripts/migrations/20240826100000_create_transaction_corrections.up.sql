@@ -0,0 +1,23 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS voided BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS updated_at BIGINT NOT NULL DEFAULT 0;
+
+UPDATE transactions SET updated_at = created_at WHERE updated_at = 0;
+
+CREATE TABLE IF NOT EXISTS transaction_corrections (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('amend', 'void')),
+    reason TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    previous_amount DECIMAL(15, 2) NOT NULL,
+    new_amount DECIMAL(15, 2) NOT NULL,
+    previous_description TEXT NOT NULL,
+    new_description TEXT NOT NULL,
+    previous_category TEXT NOT NULL,
+    new_category TEXT NOT NULL,
+    journal_entry_id UUID REFERENCES journal_entries(id),
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_corrections_transaction_id ON transaction_corrections(transaction_id);
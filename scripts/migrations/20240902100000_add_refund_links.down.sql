@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_account_merchant;
+DROP INDEX IF EXISTS idx_transactions_reversal_of;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS reversal_kind,
+    DROP COLUMN IF EXISTS reversal_of;
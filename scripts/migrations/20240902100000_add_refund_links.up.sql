@@ -0,0 +1,6 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS reversal_of UUID REFERENCES transactions(id),
+    ADD COLUMN IF NOT EXISTS reversal_kind VARCHAR(10) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_reversal_of ON transactions(reversal_of);
+CREATE INDEX IF NOT EXISTS idx_transactions_account_merchant ON transactions(account_id, merchant, input_date);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_pending;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS posted_at,
+    DROP COLUMN IF EXISTS authorized_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'posted'
+        CHECK (status IN ('pending', 'posted', 'reversed', 'expired')),
+    ADD COLUMN IF NOT EXISTS authorized_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS posted_at TIMESTAMP;
+
+UPDATE transactions SET authorized_at = input_date, posted_at = input_date WHERE authorized_at IS NULL;
+
+ALTER TABLE transactions ALTER COLUMN authorized_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_pending ON transactions(account_id, authorized_at) WHERE status = 'pending';
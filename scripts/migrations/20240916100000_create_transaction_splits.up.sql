@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS transaction_splits (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    position INTEGER NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    category TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL,
+    UNIQUE (transaction_id, position)
+);
@@ -0,0 +1,11 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS note TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS transaction_tags (
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    tag VARCHAR(50) NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (transaction_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_tags_tag ON transaction_tags(tag);
@@ -0,0 +1,5 @@
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS payment_due_days,
+    DROP COLUMN IF EXISTS statement_closing_day,
+    DROP COLUMN IF EXISTS credit_limit,
+    DROP COLUMN IF EXISTS type;
@@ -0,0 +1,6 @@
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS type VARCHAR(20) NOT NULL DEFAULT 'debit'
+        CHECK (type IN ('debit', 'credit_card')),
+    ADD COLUMN IF NOT EXISTS credit_limit DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS statement_closing_day INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS payment_due_days INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS card_accruals (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('interest', 'late_fee')),
+    accrual_date DATE NOT NULL,
+    statement_date DATE NOT NULL,
+    basis DECIMAL(15, 2) NOT NULL,
+    daily_rate DECIMAL(14, 10) NOT NULL DEFAULT 0,
+    amount DECIMAL(15, 2) NOT NULL,
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    created_at BIGINT NOT NULL,
+    UNIQUE (account_id, kind, accrual_date)
+);
+
+INSERT INTO ledger_accounts (id, code, name, type, created_at) VALUES
+    (gen_random_uuid(), 'interest_income', 'Interest income', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT)
+ON CONFLICT (code) DO NOTHING;
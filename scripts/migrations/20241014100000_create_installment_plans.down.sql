@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS installments;
+DROP TABLE IF EXISTS installment_plans;
+ALTER TABLE transactions DROP COLUMN IF EXISTS installment_plan_id;
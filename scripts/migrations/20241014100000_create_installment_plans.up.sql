@@ -0,0 +1,36 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_plan_id UUID;
+
+CREATE TABLE IF NOT EXISTS installment_plans (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id),
+    conversion_transaction_id UUID NOT NULL REFERENCES transactions(id),
+    principal DECIMAL(15, 2) NOT NULL,
+    installment_count INT NOT NULL CHECK (installment_count BETWEEN 2 AND 48),
+    annual_rate DECIMAL(8, 6) NOT NULL DEFAULT 0,
+    installment_amount DECIMAL(15, 2) NOT NULL,
+    total_interest DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed')),
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_installment_plans_account_id ON installment_plans(account_id);
+
+CREATE TABLE IF NOT EXISTS installments (
+    id UUID PRIMARY KEY,
+    plan_id UUID NOT NULL REFERENCES installment_plans(id),
+    number INT NOT NULL,
+    due_date DATE NOT NULL,
+    principal DECIMAL(15, 2) NOT NULL,
+    interest DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    amount DECIMAL(15, 2) NOT NULL,
+    transaction_id UUID REFERENCES transactions(id),
+    UNIQUE (plan_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_installments_due ON installments(due_date) WHERE transaction_id IS NULL;
+
+INSERT INTO ledger_accounts (id, code, name, type, created_at) VALUES
+    (gen_random_uuid(), 'installments_receivable', 'Installments receivable', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT)
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS reward_entries (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('earn', 'redeem', 'reversal')),
+    points BIGINT NOT NULL,
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    rule VARCHAR(100) NOT NULL DEFAULT '',
+    cycle_start DATE NOT NULL,
+    effective_date DATE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL,
+    UNIQUE (transaction_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reward_entries_account_cycle ON reward_entries(account_id, cycle_start);
+
+INSERT INTO ledger_accounts (id, code, name, type, created_at) VALUES
+    (gen_random_uuid(), 'rewards_expense', 'Rewards expense', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT)
+ON CONFLICT (code) DO NOTHING;
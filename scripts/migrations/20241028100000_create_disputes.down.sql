@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dispute_attachments;
+DROP TABLE IF EXISTS dispute_events;
+DROP TABLE IF EXISTS disputes;
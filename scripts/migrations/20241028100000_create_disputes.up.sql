@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS disputes (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    reason_code VARCHAR(30) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'opened' CHECK (status IN ('opened', 'under_review', 'provisional_credit', 'won', 'lost')),
+    provisional_credit_due DATE NOT NULL,
+    deadline DATE NOT NULL,
+    credit_transaction_id UUID REFERENCES transactions(id),
+    reversal_transaction_id UUID REFERENCES transactions(id),
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_disputes_account_id ON disputes(account_id);
+-- A transaction can only have one dispute in progress at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disputes_open_transaction ON disputes(transaction_id) WHERE status NOT IN ('won', 'lost');
+
+CREATE TABLE IF NOT EXISTS dispute_events (
+    id UUID PRIMARY KEY,
+    dispute_id UUID NOT NULL REFERENCES disputes(id),
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    transaction_id UUID REFERENCES transactions(id),
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispute_events_dispute_id ON dispute_events(dispute_id);
+
+CREATE TABLE IF NOT EXISTS dispute_attachments (
+    id UUID PRIMARY KEY,
+    dispute_id UUID NOT NULL REFERENCES disputes(id),
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    blob_key TEXT NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispute_attachments_dispute_id ON dispute_attachments(dispute_id);
+
+INSERT INTO ledger_accounts (id, code, name, type, created_at) VALUES
+    (gen_random_uuid(), 'chargebacks_receivable', 'Chargebacks receivable', 'internal', EXTRACT(EPOCH FROM NOW())::BIGINT)
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    merchant VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    cadence VARCHAR(10) NOT NULL CHECK (cadence IN ('weekly', 'monthly', 'annual')),
+    average_amount DECIMAL(15, 2) NOT NULL,
+    last_amount DECIMAL(15, 2) NOT NULL,
+    last_charge_date DATE NOT NULL,
+    next_expected_date DATE NOT NULL,
+    charge_count INT NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('active', 'missed', 'inactive')),
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    UNIQUE (account_id, merchant)
+);
+
+CREATE TABLE IF NOT EXISTS subscription_alerts (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('missing_charge', 'price_increase')),
+    charge_date DATE NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    previous_amount DECIMAL(15, 2) NOT NULL,
+    message TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    -- One alert per expected or observed charge
+    UNIQUE (subscription_id, kind, charge_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_alerts_account_id ON subscription_alerts(account_id, created_at);
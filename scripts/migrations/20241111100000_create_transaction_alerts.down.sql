@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_account_authorized_at;
+DROP TABLE IF EXISTS transaction_alert_reasons;
+DROP TABLE IF EXISTS transaction_alerts;
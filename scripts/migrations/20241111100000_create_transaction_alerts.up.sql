@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS transaction_alerts (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    score DECIMAL(5, 2) NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('open', 'confirmed', 'dismissed')),
+    reviewed_by VARCHAR(100) NOT NULL DEFAULT '',
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_alerts_status ON transaction_alerts(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_transaction_alerts_account_id ON transaction_alerts(account_id, created_at);
+
+CREATE TABLE IF NOT EXISTS transaction_alert_reasons (
+    alert_id UUID NOT NULL REFERENCES transaction_alerts(id),
+    code VARCHAR(30) NOT NULL,
+    weight DECIMAL(5, 2) NOT NULL,
+    detail TEXT NOT NULL,
+    PRIMARY KEY (alert_id, code)
+);
+
+-- Speeds up the history lookups of the anomaly detector
+CREATE INDEX IF NOT EXISTS idx_transactions_account_authorized_at ON transactions(account_id, authorized_at);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    -- An empty category is the overall budget of the account
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    UNIQUE (account_id, category)
+);
+
+CREATE TABLE IF NOT EXISTS budget_alerts (
+    id UUID PRIMARY KEY,
+    budget_id UUID NOT NULL REFERENCES budgets(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    month DATE NOT NULL,
+    threshold INT NOT NULL,
+    spent DECIMAL(15, 2) NOT NULL,
+    created_at BIGINT NOT NULL,
+    -- One alert per threshold and month
+    UNIQUE (budget_id, month, threshold)
+);
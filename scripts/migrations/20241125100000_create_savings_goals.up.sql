@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS savings_goals (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    name VARCHAR(100) NOT NULL,
+    target_amount DECIMAL(15, 2) NOT NULL CHECK (target_amount > 0),
+    target_date DATE NOT NULL,
+    -- Contributions are the transactions of the category, or the net flow
+    -- of the account when it is empty
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    start_date DATE NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CHECK (target_date > start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goals_account_id ON savings_goals(account_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS monthly_merchant_summary;
+DROP TABLE IF EXISTS monthly_category_summary;
+DROP TABLE IF EXISTS monthly_account_summary;
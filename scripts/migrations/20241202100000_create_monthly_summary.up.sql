@@ -0,0 +1,90 @@
+-- Projection of the posted, non-voided transactions of every account and
+-- month, kept up to date by the worker from the transaction events. Each
+-- month is recomputed as a whole, so refreshing it is idempotent.
+CREATE TABLE IF NOT EXISTS monthly_account_summary (
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    month DATE NOT NULL,
+    total_count INT NOT NULL DEFAULT 0,
+    amount DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    credit_count INT NOT NULL DEFAULT 0,
+    credits DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    debit_count INT NOT NULL DEFAULT 0,
+    debits DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    refund_count INT NOT NULL DEFAULT 0,
+    refunds DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    installments DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    refreshed_at BIGINT NOT NULL,
+    PRIMARY KEY (account_id, month)
+);
+
+CREATE TABLE IF NOT EXISTS monthly_category_summary (
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    month DATE NOT NULL,
+    category TEXT NOT NULL,
+    total DECIMAL(18, 2) NOT NULL,
+    count INT NOT NULL,
+    PRIMARY KEY (account_id, month, category)
+);
+
+CREATE TABLE IF NOT EXISTS monthly_merchant_summary (
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    month DATE NOT NULL,
+    merchant TEXT NOT NULL,
+    category TEXT NOT NULL,
+    total DECIMAL(18, 2) NOT NULL,
+    count INT NOT NULL,
+    PRIMARY KEY (account_id, month, merchant)
+);
+
+-- Build the projection of the existing transactions
+INSERT INTO monthly_account_summary (account_id, month, total_count, amount, credit_count, credits,
+                                     debit_count, debits, refund_count, refunds, installments, refreshed_at)
+SELECT t.account_id,
+       date_trunc('month', t.input_date)::date,
+       COUNT(*),
+       COALESCE(SUM(t.amount), 0),
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NULL),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NULL), 0),
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount <= 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount <= 0), 0),
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NOT NULL),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NOT NULL), 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'installment'), 0),
+       EXTRACT(EPOCH FROM NOW())::BIGINT
+FROM transactions t
+WHERE t.voided = false AND t.status = 'posted'
+GROUP BY 1, 2;
+
+INSERT INTO monthly_category_summary (account_id, month, category, total, count)
+SELECT t.account_id,
+       date_trunc('month', t.input_date)::date,
+       CASE WHEN COALESCE(s.category, t.category) = '' THEN 'uncategorized'
+            ELSE COALESCE(s.category, t.category) END,
+       SUM(-COALESCE(s.amount, t.amount)),
+       COUNT(DISTINCT t.id)
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.voided = false AND t.status = 'posted'
+  AND t.amount < 0
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 1, 2, 3;
+
+INSERT INTO monthly_merchant_summary (account_id, month, merchant, category, total, count)
+SELECT t.account_id,
+       date_trunc('month', t.input_date)::date,
+       t.merchant,
+       (array_agg(t.category ORDER BY t.input_date, t.id))[1],
+       SUM(-t.amount),
+       COUNT(*)
+FROM transactions t
+WHERE t.voided = false AND t.status = 'posted'
+  AND t.amount < 0
+  AND t.merchant <> ''
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 1, 2, 3;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_journal_entries_effective_date;
+DROP TABLE IF EXISTS daily_balances;
@@ -0,0 +1,14 @@
+-- Cache of the daily balances of the customer ledger accounts, filled when
+-- a balance series is read. Posting to an account deletes the days from the
+-- effective date of the entry on, since every later closing balance moves.
+CREATE TABLE IF NOT EXISTS daily_balances (
+    ledger_account_id UUID NOT NULL REFERENCES ledger_accounts(id),
+    day DATE NOT NULL,
+    credits DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    debits DECIMAL(18, 2) NOT NULL DEFAULT 0,
+    closing_balance DECIMAL(18, 2) NOT NULL,
+    posting_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (ledger_account_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_effective_date ON journal_entries(effective_date);
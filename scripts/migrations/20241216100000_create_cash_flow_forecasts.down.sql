@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS cash_flow_forecast_items;
+DROP TABLE IF EXISTS cash_flow_forecast_points;
+DROP TABLE IF EXISTS cash_flow_forecasts;
@@ -0,0 +1,34 @@
+-- Latest cash-flow forecast of every account, replaced as a whole by the
+-- worker each time it runs.
+CREATE TABLE IF NOT EXISTS cash_flow_forecasts (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id),
+    as_of DATE NOT NULL,
+    starting_balance DECIMAL(18, 2) NOT NULL,
+    daily_income DECIMAL(18, 2) NOT NULL,
+    daily_spend DECIMAL(18, 2) NOT NULL,
+    daily_deviation DECIMAL(18, 2) NOT NULL,
+    generated_at BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cash_flow_forecast_points (
+    account_id UUID NOT NULL REFERENCES cash_flow_forecasts(account_id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    expected DECIMAL(18, 2) NOT NULL,
+    low DECIMAL(18, 2) NOT NULL,
+    high DECIMAL(18, 2) NOT NULL,
+    recurring DECIMAL(18, 2) NOT NULL,
+    baseline DECIMAL(18, 2) NOT NULL,
+    PRIMARY KEY (account_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS cash_flow_forecast_items (
+    id BIGSERIAL PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES cash_flow_forecasts(account_id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    name TEXT NOT NULL,
+    category TEXT NOT NULL,
+    cadence TEXT NOT NULL,
+    amount DECIMAL(18, 2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_flow_forecast_items_account_id ON cash_flow_forecast_items(account_id, day);
@@ -0,0 +1,7 @@
+ALTER TABLE transaction_corrections
+    DROP COLUMN IF EXISTS version_id;
+
+DROP INDEX IF EXISTS idx_transactions_supersedes;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS superseded_by,
+    DROP COLUMN IF EXISTS supersedes;
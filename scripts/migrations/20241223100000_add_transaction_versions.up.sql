@@ -0,0 +1,8 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS supersedes UUID REFERENCES transactions(id),
+    ADD COLUMN IF NOT EXISTS superseded_by UUID REFERENCES transactions(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_supersedes ON transactions(supersedes);
+
+ALTER TABLE transaction_corrections
+    ADD COLUMN IF NOT EXISTS version_id UUID REFERENCES transactions(id);
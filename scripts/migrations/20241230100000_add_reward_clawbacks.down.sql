@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_reward_entries_reversals;
+DROP INDEX IF EXISTS idx_reward_entries_transaction_kind;
+
+ALTER TABLE reward_entries
+    ADD CONSTRAINT reward_entries_transaction_id_kind_key UNIQUE (transaction_id, kind);
+
+ALTER TABLE reward_entries
+    DROP COLUMN IF EXISTS basis;
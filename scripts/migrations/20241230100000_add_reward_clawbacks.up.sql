@@ -0,0 +1,17 @@
+-- The purchase amount the points were earned on, so that refunds and
+-- amendments take back their share of them
+ALTER TABLE reward_entries
+    ADD COLUMN IF NOT EXISTS basis DECIMAL(15, 2) NOT NULL DEFAULT 0;
+
+UPDATE reward_entries e
+SET basis = -t.amount
+FROM transactions t
+WHERE t.id = e.transaction_id AND e.kind = 'earn' AND t.amount < 0;
+
+-- A purchase is reversed a share at a time, so only earns and redemptions
+-- stay unique per transaction
+ALTER TABLE reward_entries
+    DROP CONSTRAINT IF EXISTS reward_entries_transaction_id_kind_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reward_entries_transaction_kind ON reward_entries(transaction_id, kind) WHERE kind <> 'reversal';
+CREATE INDEX IF NOT EXISTS idx_reward_entries_reversals ON reward_entries(transaction_id) WHERE kind = 'reversal';
@@ -0,0 +1,22 @@
+-- Months of the summary projection waiting for a refresh. Every write to
+-- the transactions of a month queues it in the same database transaction,
+-- so that a worker restart loses none; version changes on every write so
+-- that a refresh only dequeues the month if nothing was written since.
+CREATE TABLE IF NOT EXISTS stale_summary_months (
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    month DATE NOT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    marked_at BIGINT NOT NULL,
+    PRIMARY KEY (account_id, month)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stale_summary_months_marked_at ON stale_summary_months(marked_at);
+
+-- Queue what changed since the months were last projected
+INSERT INTO stale_summary_months (account_id, month, marked_at)
+SELECT DISTINCT t.account_id, date_trunc('month', t.input_date)::date, EXTRACT(EPOCH FROM NOW())::BIGINT
+FROM transactions t
+LEFT JOIN monthly_account_summary p
+    ON p.account_id = t.account_id AND p.month = date_trunc('month', t.input_date)::date
+WHERE p.refreshed_at IS NULL OR t.updated_at >= p.refreshed_at
+ON CONFLICT (account_id, month) DO NOTHING;
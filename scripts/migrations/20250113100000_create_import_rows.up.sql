@@ -0,0 +1,10 @@
+-- Rows of uploaded files already imported, keyed by the hash of the file
+-- and the line of the row, so that a file sent again adds nothing twice.
+-- A row settling an authorization points at the authorization it settled.
+CREATE TABLE IF NOT EXISTS import_rows (
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    import_key TEXT NOT NULL,
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (account_id, import_key)
+);
@@ -1,12 +1,25 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (id, nickname, email, balance, created_at, updated_at, active)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO accounts (id, nickname, email, balance, created_at, updated_at, active,
+    type, credit_limit, statement_closing_day, payment_due_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 RETURNING *;
 
 -- name: GetAccount :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountForUpdate :one
+SELECT * FROM accounts
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: LockAccountForShare :exec
+-- Waits for the balance changes of the account in flight and holds off the
+-- next ones.
+SELECT id FROM accounts
+WHERE id = $1
+FOR SHARE;
+
 -- name: ListAccounts :many
 SELECT * FROM accounts
 WHERE active = true
@@ -23,3 +36,46 @@ RETURNING *;
 UPDATE accounts
 SET active = false
 WHERE id = $1;
+
+-- name: AdjustAccountBalance :one
+UPDATE accounts
+SET balance = balance + $2, updated_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ReconcileAccountBalance :one
+-- Sets the balance to the sum of the account ledger postings in a single
+-- statement; no row is returned when they already agree.
+UPDATE accounts a
+SET balance = ledger.balance, updated_at = sqlc.arg(updated_at)
+FROM (
+    SELECT COALESCE(SUM(p.amount), 0)::numeric AS balance
+    FROM ledger_accounts la
+    JOIN postings p ON p.ledger_account_id = la.id
+    WHERE la.account_id = sqlc.arg(id)
+) ledger
+WHERE a.id = sqlc.arg(id) AND a.balance <> ledger.balance
+RETURNING a.*;
+
+-- name: ListAccountBalanceDrifts :many
+SELECT
+    a.id,
+    a.balance,
+    COALESCE(SUM(p.amount), 0)::numeric AS ledger_balance
+FROM accounts a
+LEFT JOIN ledger_accounts la ON la.account_id = a.id
+LEFT JOIN postings p ON p.ledger_account_id = la.id
+GROUP BY a.id, a.balance
+HAVING a.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY a.id;
+
+-- name: GetAccountStatementActivity :one
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE input_date < sqlc.arg('period_start')), 0)::numeric AS opening_balance,
+    COALESCE(SUM(amount) FILTER (WHERE input_date >= sqlc.arg('period_start') AND amount < 0), 0)::numeric AS debits,
+    COALESCE(SUM(amount) FILTER (WHERE input_date >= sqlc.arg('period_start') AND amount > 0), 0)::numeric AS credits
+FROM transactions
+WHERE account_id = sqlc.arg('account_id')
+    AND status = 'posted'
+    AND NOT voided AND superseded_by IS NULL
+    AND input_date < sqlc.arg('period_end');
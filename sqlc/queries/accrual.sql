@@ -0,0 +1,29 @@
+-- name: ListCreditCardAccounts :many
+SELECT * FROM accounts
+WHERE type = 'credit_card' AND active = true
+ORDER BY id;
+
+-- name: ListAccountDailyActivity :many
+SELECT
+    date_trunc('day', input_date)::timestamp AS day,
+    COALESCE(SUM(amount) FILTER (WHERE amount < 0), 0)::numeric AS debits,
+    COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0)::numeric AS credits
+FROM transactions
+WHERE account_id = $1
+    AND status = 'posted'
+    AND NOT voided AND superseded_by IS NULL
+    AND input_date < $2
+GROUP BY 1
+ORDER BY 1;
+
+-- name: CreateCardAccrual :one
+INSERT INTO card_accruals (id, account_id, kind, accrual_date, statement_date, basis, daily_rate, amount, transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (account_id, kind, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: ListCardAccruals :many
+SELECT * FROM card_accruals
+WHERE account_id = $1
+    AND accrual_date BETWEEN sqlc.arg('from_date') AND sqlc.arg('to_date')
+ORDER BY accrual_date, kind;
@@ -0,0 +1,80 @@
+-- name: GetAnomalyAmountStats :one
+SELECT COUNT(*)::bigint AS debit_count,
+       COALESCE(AVG(-amount), 0)::float8 AS mean,
+       COALESCE(STDDEV_POP(-amount), 0)::float8 AS stddev
+FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND type = 'debit'
+  AND amount < 0
+  AND voided = false AND superseded_by IS NULL
+  AND status <> 'expired'
+  AND authorized_at >= sqlc.arg(since)
+  AND authorized_at < sqlc.arg(before);
+
+-- name: CountMerchantHistory :one
+SELECT COUNT(*)::bigint FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND merchant = sqlc.arg(merchant)
+  AND voided = false AND superseded_by IS NULL
+  AND authorized_at < sqlc.arg(before);
+
+-- name: CountDebitsBetween :one
+SELECT COUNT(*)::bigint FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND type = 'debit'
+  AND amount < 0
+  AND voided = false AND superseded_by IS NULL
+  AND id <> sqlc.arg(exclude_id)
+  AND authorized_at BETWEEN sqlc.arg(since) AND sqlc.arg(until);
+
+-- name: ListDuplicateCharges :many
+SELECT id FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND amount = sqlc.arg(amount)
+  AND merchant = sqlc.arg(merchant)
+  AND description = sqlc.arg(description)
+  AND voided = false AND superseded_by IS NULL
+  AND id <> sqlc.arg(exclude_id)
+  AND authorized_at BETWEEN sqlc.arg(since) AND sqlc.arg(until)
+ORDER BY authorized_at, id;
+
+-- name: CreateTransactionAlert :one
+INSERT INTO transaction_alerts (id, transaction_id, account_id, score, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (transaction_id) DO NOTHING
+RETURNING *;
+
+-- name: CreateTransactionAlertReason :exec
+INSERT INTO transaction_alert_reasons (alert_id, code, weight, detail)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetTransactionAlert :one
+SELECT * FROM transaction_alerts
+WHERE id = $1;
+
+-- name: GetTransactionAlertForUpdate :one
+SELECT * FROM transaction_alerts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListTransactionAlertsByStatus :many
+SELECT * FROM transaction_alerts
+WHERE status = sqlc.arg(status)
+ORDER BY score DESC, created_at, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListTransactionAlertsByAccount :many
+SELECT * FROM transaction_alerts
+WHERE account_id = $1
+ORDER BY created_at DESC, id;
+
+-- name: ListTransactionAlertReasons :many
+SELECT * FROM transaction_alert_reasons
+WHERE alert_id = $1
+ORDER BY weight DESC, code;
+
+-- name: ReviewTransactionAlert :one
+UPDATE transaction_alerts
+SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = $5, updated_at = $6
+WHERE id = $1
+RETURNING *;
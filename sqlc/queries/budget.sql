@@ -0,0 +1,48 @@
+-- name: UpsertBudget :one
+INSERT INTO budgets (id, account_id, category, amount, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (account_id, category) DO UPDATE
+SET amount = EXCLUDED.amount,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetBudget :one
+SELECT * FROM budgets
+WHERE id = $1;
+
+-- name: ListBudgetsByAccount :many
+SELECT * FROM budgets
+WHERE account_id = $1
+ORDER BY category;
+
+-- name: DeleteBudgetAlerts :exec
+DELETE FROM budget_alerts
+WHERE budget_id = $1;
+
+-- name: DeleteBudget :exec
+DELETE FROM budgets
+WHERE id = $1;
+
+-- name: ListCategorySpend :many
+-- Spend per category as the summary counts it: posted purchases and
+-- charges, split across their categories, without installment movements.
+SELECT (CASE WHEN COALESCE(s.category, t.category) = '' THEN 'uncategorized'
+             ELSE COALESCE(s.category, t.category) END)::text AS category,
+       SUM(-COALESCE(s.amount, t.amount))::float8 AS spent
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = sqlc.arg(account_id)
+  AND t.amount < 0
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.type NOT IN ('installment', 'installment_conversion')
+  AND t.input_date >= sqlc.arg(month_start)
+  AND t.input_date < sqlc.arg(month_end)
+GROUP BY 1
+ORDER BY 1;
+
+-- name: CreateBudgetAlert :one
+INSERT INTO budget_alerts (id, budget_id, account_id, month, threshold, spent, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (budget_id, month, threshold) DO NOTHING
+RETURNING *;
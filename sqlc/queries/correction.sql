@@ -0,0 +1,50 @@
+-- name: CreateTransactionCorrection :one
+INSERT INTO transaction_corrections (id, transaction_id, kind, reason, actor, previous_amount, new_amount, previous_description, new_description, previous_category, new_category, journal_entry_id, created_at, version_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING *;
+
+-- name: ListTransactionCorrections :many
+SELECT * FROM transaction_corrections
+WHERE transaction_id = $1
+ORDER BY created_at, id;
+
+-- name: CreateTransactionVersion :one
+INSERT INTO transactions (id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, voided, updated_at, reversal_of, reversal_kind, status, authorized_at, posted_at, note, installment_plan_id, supersedes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+RETURNING *;
+
+-- name: MarkTransactionSuperseded :execrows
+-- The only change ever made to a corrected row.
+UPDATE transactions
+SET superseded_by = sqlc.arg(superseded_by)
+WHERE id = sqlc.arg(id) AND superseded_by IS NULL;
+
+-- name: CopyTransactionSplits :exec
+INSERT INTO transaction_splits (id, transaction_id, position, amount, category, note, created_at)
+SELECT gen_random_uuid(), sqlc.arg(to_id), s.position, s.amount, s.category, s.note, s.created_at
+FROM transaction_splits s
+WHERE s.transaction_id = sqlc.arg(from_id);
+
+-- name: CopyTransactionTags :exec
+INSERT INTO transaction_tags (transaction_id, tag, created_at)
+SELECT sqlc.arg(to_id), t.tag, t.created_at
+FROM transaction_tags t
+WHERE t.transaction_id = sqlc.arg(from_id);
+
+-- name: MoveTransactionReversals :many
+-- Refunds and dispute movements follow the current version of what they
+-- reverse.
+UPDATE transactions
+SET reversal_of = sqlc.arg(to_id)
+WHERE reversal_of = sqlc.arg(from_id)
+RETURNING *;
+
+-- name: MoveTransactionDisputes :exec
+UPDATE disputes
+SET transaction_id = sqlc.arg(to_id)
+WHERE transaction_id = sqlc.arg(from_id);
+
+-- name: MoveTransactionRewardEntries :exec
+UPDATE reward_entries
+SET transaction_id = sqlc.arg(to_id)
+WHERE transaction_id = sqlc.arg(from_id);
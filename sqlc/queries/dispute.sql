@@ -0,0 +1,63 @@
+-- name: CreateDispute :one
+INSERT INTO disputes (id, account_id, transaction_id, amount, reason_code, description, status, provisional_credit_due, deadline, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetDispute :one
+SELECT * FROM disputes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetDisputeForUpdate :one
+SELECT * FROM disputes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetOpenDisputeByTransaction :one
+SELECT * FROM disputes
+WHERE transaction_id = $1 AND status NOT IN ('won', 'lost')
+LIMIT 1;
+
+-- name: ListDisputesByAccount :many
+SELECT * FROM disputes
+WHERE account_id = $1
+ORDER BY created_at DESC, id;
+
+-- name: UpdateDispute :exec
+UPDATE disputes
+SET status = $2, credit_transaction_id = $3, reversal_transaction_id = $4, updated_at = $5
+WHERE id = $1;
+
+-- name: ListDisputesDueForCredit :many
+SELECT * FROM disputes
+WHERE status IN ('opened', 'under_review') AND provisional_credit_due <= $1
+ORDER BY provisional_credit_due, id
+LIMIT $2;
+
+-- name: ListDisputesPastDeadline :many
+SELECT * FROM disputes
+WHERE status NOT IN ('won', 'lost') AND deadline < $1
+ORDER BY deadline, id
+LIMIT $2;
+
+-- name: CreateDisputeEvent :exec
+INSERT INTO dispute_events (id, dispute_id, from_status, to_status, note, transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListDisputeEvents :many
+SELECT * FROM dispute_events
+WHERE dispute_id = $1
+ORDER BY created_at, id;
+
+-- name: CreateDisputeAttachment :one
+INSERT INTO dispute_attachments (id, dispute_id, file_name, content_type, size, blob_key, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetDisputeAttachment :one
+SELECT * FROM dispute_attachments
+WHERE id = $1 AND dispute_id = $2 LIMIT 1;
+
+-- name: ListDisputeAttachments :many
+SELECT * FROM dispute_attachments
+WHERE dispute_id = $1
+ORDER BY created_at, id;
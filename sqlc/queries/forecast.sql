@@ -0,0 +1,54 @@
+-- name: ListForecastHistory :many
+SELECT * FROM transactions
+WHERE account_id = $1
+  AND status = 'posted'
+  AND voided = false AND superseded_by IS NULL
+  AND input_date >= $2
+ORDER BY input_date, created_at, id;
+
+-- name: ListForecastAccounts :many
+SELECT DISTINCT t.account_id FROM transactions t
+JOIN accounts a ON a.id = t.account_id
+WHERE a.active = true AND t.status = 'posted' AND t.voided = false AND t.superseded_by IS NULL AND t.input_date >= $1
+ORDER BY t.account_id;
+
+-- name: UpsertForecast :exec
+INSERT INTO cash_flow_forecasts (account_id, as_of, starting_balance, daily_income, daily_spend, daily_deviation, generated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id) DO UPDATE
+SET as_of = EXCLUDED.as_of,
+    starting_balance = EXCLUDED.starting_balance,
+    daily_income = EXCLUDED.daily_income,
+    daily_spend = EXCLUDED.daily_spend,
+    daily_deviation = EXCLUDED.daily_deviation,
+    generated_at = EXCLUDED.generated_at;
+
+-- name: DeleteForecastPoints :exec
+DELETE FROM cash_flow_forecast_points
+WHERE account_id = $1;
+
+-- name: CreateForecastPoint :exec
+INSERT INTO cash_flow_forecast_points (account_id, day, expected, low, high, recurring, baseline)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: DeleteForecastItems :exec
+DELETE FROM cash_flow_forecast_items
+WHERE account_id = $1;
+
+-- name: CreateForecastItem :exec
+INSERT INTO cash_flow_forecast_items (account_id, day, name, category, cadence, amount)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetForecast :one
+SELECT * FROM cash_flow_forecasts
+WHERE account_id = $1;
+
+-- name: ListForecastPoints :many
+SELECT * FROM cash_flow_forecast_points
+WHERE account_id = $1
+ORDER BY day;
+
+-- name: ListForecastItems :many
+SELECT * FROM cash_flow_forecast_items
+WHERE account_id = $1
+ORDER BY day, id;
@@ -0,0 +1,41 @@
+-- name: CreateSavingsGoal :one
+INSERT INTO savings_goals (id, account_id, name, target_amount, target_date, category, start_date, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetSavingsGoal :one
+SELECT * FROM savings_goals
+WHERE id = $1;
+
+-- name: ListSavingsGoalsByAccount :many
+SELECT * FROM savings_goals
+WHERE account_id = $1
+ORDER BY target_date, name;
+
+-- name: DeleteSavingsGoal :exec
+DELETE FROM savings_goals
+WHERE id = $1;
+
+-- name: GetGoalNetSavings :one
+-- Net flow of the account since a goal started, in total and since the
+-- start of the window its contribution rate is measured over.
+SELECT COALESCE(SUM(amount), 0)::float8 AS saved,
+       COALESCE(SUM(amount) FILTER (WHERE input_date >= sqlc.arg(recent_since)), 0)::float8 AS recent
+FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND status = 'posted'
+  AND voided = false AND superseded_by IS NULL
+  AND input_date >= sqlc.arg(since);
+
+-- name: GetGoalCategoryContributions :one
+-- Money moved into a goal through its category: debits of the category,
+-- split across categories, less the credits taken back out of it.
+SELECT COALESCE(SUM(-COALESCE(s.amount, t.amount)), 0)::float8 AS saved,
+       COALESCE(SUM(-COALESCE(s.amount, t.amount)) FILTER (WHERE t.input_date >= sqlc.arg(recent_since)), 0)::float8 AS recent
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = sqlc.arg(account_id)
+  AND COALESCE(s.category, t.category) = sqlc.arg(category)
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.input_date >= sqlc.arg(since);
@@ -0,0 +1,55 @@
+-- name: CreateInstallmentPlan :one
+INSERT INTO installment_plans (id, account_id, transaction_id, conversion_transaction_id, principal, installment_count, annual_rate, installment_amount, total_interest, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: CreateInstallment :exec
+INSERT INTO installments (id, plan_id, number, due_date, principal, interest, amount)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetInstallmentPlan :one
+SELECT * FROM installment_plans
+WHERE id = $1 LIMIT 1;
+
+-- name: GetInstallmentPlanForUpdate :one
+SELECT * FROM installment_plans
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListInstallmentPlansByAccount :many
+SELECT * FROM installment_plans
+WHERE account_id = $1
+ORDER BY created_at DESC, id;
+
+-- name: ListPlanInstallments :many
+SELECT * FROM installments
+WHERE plan_id = $1
+ORDER BY number;
+
+-- name: ListDueInstallments :many
+SELECT * FROM installments
+WHERE transaction_id IS NULL AND due_date <= $1
+ORDER BY due_date, plan_id, number
+LIMIT $2;
+
+-- name: MarkInstallmentPosted :execrows
+UPDATE installments
+SET transaction_id = $2
+WHERE id = $1 AND transaction_id IS NULL;
+
+-- name: UpdateInstallmentPlanStatus :exec
+UPDATE installment_plans
+SET status = $2, updated_at = $3
+WHERE id = $1;
+
+-- name: SetTransactionInstallmentPlan :one
+UPDATE transactions
+SET installment_plan_id = $2, updated_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: GetAccountInstallmentBalance :one
+SELECT COALESCE(SUM(i.amount), 0)::numeric AS remaining
+FROM installments i
+JOIN installment_plans p ON p.id = i.plan_id
+WHERE p.account_id = $1 AND i.transaction_id IS NULL;
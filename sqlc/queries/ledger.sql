@@ -0,0 +1,101 @@
+-- name: EnsureLedgerAccount :one
+INSERT INTO ledger_accounts (id, code, name, type, account_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (code) DO UPDATE SET code = EXCLUDED.code
+RETURNING *;
+
+-- name: GetLedgerAccountByCode :one
+SELECT * FROM ledger_accounts
+WHERE code = $1 LIMIT 1;
+
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (id, transaction_id, description, effective_date, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: CreatePosting :one
+INSERT INTO postings (id, journal_entry_id, ledger_account_id, amount, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetTrialBalance :many
+SELECT
+    la.id,
+    la.code,
+    la.name,
+    la.type,
+    COALESCE(SUM(p.amount) FILTER (WHERE je.effective_date <= sqlc.arg(as_of)::timestamp), 0)::numeric AS balance
+FROM ledger_accounts la
+LEFT JOIN postings p ON p.ledger_account_id = la.id
+LEFT JOIN journal_entries je ON je.id = p.journal_entry_id
+GROUP BY la.id, la.code, la.name, la.type
+ORDER BY la.code;
+
+-- name: GetLedgerBalanceBefore :one
+SELECT COALESCE(SUM(p.amount), 0)::numeric AS balance
+FROM postings p
+JOIN journal_entries je ON je.id = p.journal_entry_id
+WHERE p.ledger_account_id = $1 AND je.effective_date < $2;
+
+-- name: ListLedgerPostings :many
+SELECT
+    p.id,
+    p.journal_entry_id,
+    je.transaction_id,
+    je.description,
+    je.effective_date,
+    p.amount
+FROM postings p
+JOIN journal_entries je ON je.id = p.journal_entry_id
+WHERE p.ledger_account_id = $1
+    AND je.effective_date >= sqlc.arg(from_date)
+    AND je.effective_date < sqlc.arg(to_date)
+ORDER BY je.effective_date, p.created_at, p.id;
+
+-- name: LockLedgerAccount :exec
+-- Serializes the filling of the cached daily balances of an internal ledger
+-- account.
+SELECT id FROM ledger_accounts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListLedgerDailyTotals :many
+SELECT
+    date_trunc('day', je.effective_date)::timestamp AS day,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0)::float8 AS credits,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.amount < 0), 0)::float8 AS debits,
+    COUNT(*) AS posting_count
+FROM postings p
+JOIN journal_entries je ON je.id = p.journal_entry_id
+WHERE p.ledger_account_id = sqlc.arg(ledger_account_id)
+    AND je.effective_date >= sqlc.arg(from_date)
+    AND je.effective_date < sqlc.arg(to_date)
+GROUP BY 1
+ORDER BY 1;
+
+-- name: ListDailyBalances :many
+SELECT
+    day,
+    credits::float8 AS credits,
+    debits::float8 AS debits,
+    closing_balance::float8 AS closing_balance,
+    posting_count
+FROM daily_balances
+WHERE ledger_account_id = sqlc.arg(ledger_account_id)
+    AND day >= sqlc.arg(from_date)::date
+    AND day < sqlc.arg(to_date)::date
+ORDER BY day;
+
+-- name: UpsertDailyBalance :exec
+INSERT INTO daily_balances (ledger_account_id, day, credits, debits, closing_balance, posting_count)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (ledger_account_id, day) DO UPDATE
+SET credits = EXCLUDED.credits,
+    debits = EXCLUDED.debits,
+    closing_balance = EXCLUDED.closing_balance,
+    posting_count = EXCLUDED.posting_count;
+
+-- name: DeleteDailyBalancesFrom :exec
+DELETE FROM daily_balances
+WHERE ledger_account_id = sqlc.arg(ledger_account_id)
+    AND day >= sqlc.arg(from_date)::date;
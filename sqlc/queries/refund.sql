@@ -0,0 +1,41 @@
+-- name: LinkTransactionReversal :one
+UPDATE transactions
+SET reversal_of = $2, reversal_kind = $3, updated_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: SumTransactionRefunds :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS refunded
+FROM transactions
+WHERE reversal_of = $1 AND voided = false AND superseded_by IS NULL;
+
+-- name: ListRefundCandidates :many
+SELECT sqlc.embed(t), COALESCE(r.refunded, 0)::numeric AS refunded
+FROM transactions t
+LEFT JOIN (
+    SELECT reversal_of, SUM(amount) AS refunded
+    FROM transactions
+    WHERE reversal_of IS NOT NULL AND voided = false AND superseded_by IS NULL
+    GROUP BY reversal_of
+) r ON r.reversal_of = t.id
+WHERE t.account_id = sqlc.arg(account_id)
+  AND t.merchant = sqlc.arg(merchant)
+  AND t.type = 'debit'
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.transfer_id IS NULL
+  AND t.installment_plan_id IS NULL
+  AND t.input_date BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+ORDER BY t.input_date DESC, t.created_at DESC;
+
+-- name: ListUnlinkedCredits :many
+SELECT * FROM transactions
+WHERE type = 'credit'
+  AND status = 'posted'
+  AND merchant <> ''
+  AND reversal_of IS NULL
+  AND transfer_id IS NULL
+  AND voided = false AND superseded_by IS NULL
+  AND (created_at, id) > (sqlc.arg(after_created_at)::bigint, sqlc.arg(after_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,89 @@
+-- name: CreateRewardEntry :one
+INSERT INTO reward_entries (id, account_id, kind, points, transaction_id, rule, cycle_start, effective_date, description, created_at, basis)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (transaction_id, kind) WHERE kind <> 'reversal' DO NOTHING
+RETURNING *;
+
+-- name: ListRewardCycleTotals :many
+SELECT rule, COALESCE(SUM(points), 0)::bigint AS points
+FROM reward_entries
+WHERE account_id = $1 AND cycle_start = $2 AND kind = 'earn'
+GROUP BY rule;
+
+-- name: GetRewardBalance :one
+SELECT
+    COALESCE(SUM(points), 0)::bigint AS balance,
+    COALESCE(SUM(points) FILTER (WHERE kind IN ('earn', 'reversal')), 0)::bigint AS earned,
+    COALESCE(-SUM(points) FILTER (WHERE kind = 'redeem'), 0)::bigint AS redeemed
+FROM reward_entries
+WHERE account_id = $1;
+
+-- name: ListRewardEntries :many
+SELECT * FROM reward_entries
+WHERE account_id = $1
+ORDER BY effective_date DESC, created_at DESC, id
+LIMIT $2 OFFSET $3;
+
+-- name: ListRewardMonths :many
+SELECT
+    date_trunc('month', effective_date)::timestamp AS month,
+    COALESCE(SUM(points) FILTER (WHERE kind IN ('earn', 'reversal')), 0)::bigint AS earned,
+    COALESCE(-SUM(points) FILTER (WHERE kind = 'redeem'), 0)::bigint AS redeemed
+FROM reward_entries
+WHERE account_id = $1
+GROUP BY 1
+ORDER BY 1;
+
+-- name: ListUnrewardedDebits :many
+SELECT t.* FROM transactions t
+WHERE t.type = 'debit'
+  AND t.status = 'posted'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.transfer_id IS NULL
+  AND t.input_date >= sqlc.arg(start_date)
+  AND NOT EXISTS (
+      SELECT 1 FROM reward_entries e
+      WHERE e.transaction_id = t.id AND e.kind = 'earn'
+  )
+  AND (t.created_at, t.id) > (sqlc.arg(after_created_at)::bigint, sqlc.arg(after_id)::uuid)
+ORDER BY t.created_at, t.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListRewardClawbacks :many
+-- Earned points of purchases whose standing spend changed since: voided,
+-- amended, refunded or won in a dispute. Dispute credits only count once the
+-- dispute is won. The points kept are floored as RewardClawback.Reversal
+-- does, so that a purchase drops out of the list once reversed.
+WITH standing AS (
+    SELECT
+        e.id,
+        CASE WHEN t.voided THEN 0 ELSE GREATEST(0, LEAST(e.basis, -t.amount - COALESCE((
+            SELECT SUM(r.amount) FROM transactions r
+            WHERE r.reversal_of = t.id AND r.voided = false AND r.superseded_by IS NULL
+              AND (r.reversal_kind <> 'dispute' OR EXISTS (
+                  SELECT 1 FROM disputes d
+                  WHERE d.credit_transaction_id = r.id AND d.status = 'won'
+              ))
+        ), 0))) END::numeric AS retained,
+        COALESCE((
+            SELECT SUM(x.points) FROM reward_entries x
+            WHERE x.transaction_id = e.transaction_id AND x.kind = 'reversal'
+        ), 0)::bigint AS reversed
+    FROM reward_entries e
+    JOIN transactions t ON t.id = e.transaction_id
+    WHERE e.kind = 'earn'
+      AND e.points > 0
+      AND e.basis > 0
+      AND (sqlc.narg(entry_id)::uuid IS NULL OR e.id = sqlc.narg(entry_id))
+)
+SELECT sqlc.embed(e), s.retained, s.reversed
+FROM standing s
+JOIN reward_entries e ON e.id = s.id
+WHERE e.points * ROUND(s.retained * 100)::bigint / ROUND(e.basis * 100)::bigint - e.points <> s.reversed
+ORDER BY e.created_at, e.id
+LIMIT sqlc.arg('limit');
+
+-- name: LockRewardEntry :exec
+SELECT id FROM reward_entries
+WHERE id = $1
+FOR UPDATE;
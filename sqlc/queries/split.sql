@@ -0,0 +1,13 @@
+-- name: CreateTransactionSplit :one
+INSERT INTO transaction_splits (id, transaction_id, position, amount, category, note, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: DeleteTransactionSplits :exec
+DELETE FROM transaction_splits
+WHERE transaction_id = $1;
+
+-- name: ListTransactionSplits :many
+SELECT * FROM transaction_splits
+WHERE transaction_id = $1
+ORDER BY position;
@@ -0,0 +1,46 @@
+-- name: ListSubscriptionCandidates :many
+SELECT * FROM transactions
+WHERE account_id = $1
+  AND type = 'debit'
+  AND status = 'posted'
+  AND voided = false AND superseded_by IS NULL
+  AND transfer_id IS NULL
+  AND input_date >= $2
+ORDER BY input_date, created_at, id;
+
+-- name: ListSubscriptionAccounts :many
+SELECT DISTINCT account_id FROM transactions
+WHERE type = 'debit' AND status = 'posted' AND voided = false AND superseded_by IS NULL AND input_date >= $1
+ORDER BY account_id;
+
+-- name: UpsertSubscription :one
+INSERT INTO subscriptions (id, account_id, merchant, category, cadence, average_amount, last_amount, last_charge_date, next_expected_date, charge_count, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (account_id, merchant) DO UPDATE
+SET category = EXCLUDED.category,
+    cadence = EXCLUDED.cadence,
+    average_amount = EXCLUDED.average_amount,
+    last_amount = EXCLUDED.last_amount,
+    last_charge_date = EXCLUDED.last_charge_date,
+    next_expected_date = EXCLUDED.next_expected_date,
+    charge_count = EXCLUDED.charge_count,
+    status = EXCLUDED.status,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: ListSubscriptionsByAccount :many
+SELECT * FROM subscriptions
+WHERE account_id = $1
+ORDER BY status, next_expected_date, merchant;
+
+-- name: CreateSubscriptionAlert :one
+INSERT INTO subscription_alerts (id, subscription_id, account_id, kind, charge_date, amount, previous_amount, message, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (subscription_id, kind, charge_date) DO NOTHING
+RETURNING *;
+
+-- name: ListSubscriptionAlerts :many
+SELECT * FROM subscription_alerts
+WHERE account_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2;
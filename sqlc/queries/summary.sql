@@ -0,0 +1,136 @@
+-- name: ListSummaryDays :many
+-- Totals per day of the transactions a summary covers: those of the
+-- account in the range, neither voided nor superseded by a correction,
+-- posted or optionally pending, carrying every tag of the search and
+-- containing its text in the description, merchant, category or note.
+-- Every summary query selects them the same way. Installment movements
+-- only count towards the balance and the installments billed, as the
+-- purchase already counts as spend; refunds give back spend instead of
+-- counting as credits.
+SELECT date_trunc('day', t.input_date)::timestamp AS day,
+       COUNT(*) AS total,
+       COALESCE(SUM(t.amount), 0)::float8 AS amount,
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NULL) AS credit_count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NULL), 0)::float8 AS credits,
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount <= 0) AS debit_count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount <= 0), 0)::float8 AS debits,
+       COUNT(*) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NOT NULL) AS refund_count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                          AND t.amount > 0 AND t.reversal_of IS NOT NULL), 0)::float8 AS refunds,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'installment'), 0)::float8 AS installments
+FROM transactions t
+WHERE t.account_id = sqlc.arg(account_id)
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND (sqlc.narg(from_date)::timestamp IS NULL OR t.input_date >= sqlc.narg(from_date)::timestamp)
+  AND (sqlc.narg(to_date)::timestamp IS NULL OR t.input_date < sqlc.narg(to_date)::timestamp)
+  AND (t.status = 'posted' OR (sqlc.arg(include_pending)::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> sqlc.arg(tags)::text[]
+  AND (sqlc.arg(text)::text = ''
+       OR strpos(lower(t.description), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.merchant), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.category), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.note), lower(sqlc.arg(text)::text)) > 0)
+GROUP BY 1
+ORDER BY 1;
+
+-- name: ListSummaryCategories :many
+-- Spend per month and category, split across the categories of split
+-- transactions.
+SELECT date_trunc('month', t.input_date)::timestamp AS month,
+       (CASE WHEN COALESCE(s.category, t.category) = '' THEN 'uncategorized'
+             ELSE COALESCE(s.category, t.category) END)::text AS category,
+       SUM(-COALESCE(s.amount, t.amount))::float8 AS total,
+       COUNT(DISTINCT t.id) AS count
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = sqlc.arg(account_id)
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND (sqlc.narg(from_date)::timestamp IS NULL OR t.input_date >= sqlc.narg(from_date)::timestamp)
+  AND (sqlc.narg(to_date)::timestamp IS NULL OR t.input_date < sqlc.narg(to_date)::timestamp)
+  AND (t.status = 'posted' OR (sqlc.arg(include_pending)::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> sqlc.arg(tags)::text[]
+  AND (sqlc.arg(text)::text = ''
+       OR strpos(lower(t.description), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.merchant), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.category), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.note), lower(sqlc.arg(text)::text)) > 0)
+  AND t.amount < 0
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 1, 2
+ORDER BY 1, 3 DESC, 2;
+
+-- name: ListSummaryMerchants :many
+-- The merchants with the most spend of every month, up to merchant_limit
+-- per month.
+SELECT m.month, m.merchant, m.category, m.total, m.count
+FROM (
+    SELECT date_trunc('month', t.input_date)::timestamp AS month,
+           t.merchant,
+           (array_agg(t.category ORDER BY t.input_date, t.id))[1]::text AS category,
+           SUM(-t.amount)::float8 AS total,
+           COUNT(*) AS count,
+           ROW_NUMBER() OVER (PARTITION BY date_trunc('month', t.input_date)::timestamp
+                              ORDER BY SUM(-t.amount) DESC, t.merchant) AS rank
+    FROM transactions t
+    WHERE t.account_id = sqlc.arg(account_id)
+      AND t.voided = false AND t.superseded_by IS NULL
+      AND (sqlc.narg(from_date)::timestamp IS NULL OR t.input_date >= sqlc.narg(from_date)::timestamp)
+      AND (sqlc.narg(to_date)::timestamp IS NULL OR t.input_date < sqlc.narg(to_date)::timestamp)
+      AND (t.status = 'posted' OR (sqlc.arg(include_pending)::boolean AND t.status = 'pending'))
+      AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> sqlc.arg(tags)::text[]
+      AND (sqlc.arg(text)::text = ''
+           OR strpos(lower(t.description), lower(sqlc.arg(text)::text)) > 0
+           OR strpos(lower(t.merchant), lower(sqlc.arg(text)::text)) > 0
+           OR strpos(lower(t.category), lower(sqlc.arg(text)::text)) > 0
+           OR strpos(lower(t.note), lower(sqlc.arg(text)::text)) > 0)
+      AND t.amount < 0
+      AND t.merchant <> ''
+      AND t.type NOT IN ('installment', 'installment_conversion')
+    GROUP BY 1, 2
+) m
+WHERE m.rank <= sqlc.arg(merchant_limit)::bigint
+ORDER BY m.month, m.rank;
+
+-- name: ListSummaryTagTotals :many
+SELECT g.tag,
+       COUNT(*) AS count,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.amount > 0), 0)::float8 AS total_credit,
+       COALESCE(SUM(t.amount) FILTER (WHERE t.amount <= 0), 0)::float8 AS total_debit,
+       SUM(t.amount)::float8 AS total
+FROM transactions t
+JOIN transaction_tags g ON g.transaction_id = t.id
+WHERE t.account_id = sqlc.arg(account_id)
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND (sqlc.narg(from_date)::timestamp IS NULL OR t.input_date >= sqlc.narg(from_date)::timestamp)
+  AND (sqlc.narg(to_date)::timestamp IS NULL OR t.input_date < sqlc.narg(to_date)::timestamp)
+  AND (t.status = 'posted' OR (sqlc.arg(include_pending)::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> sqlc.arg(tags)::text[]
+  AND (sqlc.arg(text)::text = ''
+       OR strpos(lower(t.description), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.merchant), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.category), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.note), lower(sqlc.arg(text)::text)) > 0)
+GROUP BY g.tag
+ORDER BY total_debit, g.tag;
+
+-- name: ListSummaryTransactions :many
+-- A page of the transactions of a summary, newest first.
+SELECT t.* FROM transactions t
+WHERE t.account_id = sqlc.arg(account_id)
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND (sqlc.narg(from_date)::timestamp IS NULL OR t.input_date >= sqlc.narg(from_date)::timestamp)
+  AND (sqlc.narg(to_date)::timestamp IS NULL OR t.input_date < sqlc.narg(to_date)::timestamp)
+  AND (t.status = 'posted' OR (sqlc.arg(include_pending)::boolean AND t.status = 'pending'))
+  AND ARRAY(SELECT g.tag FROM transaction_tags g WHERE g.transaction_id = t.id)::text[] @> sqlc.arg(tags)::text[]
+  AND (sqlc.arg(text)::text = ''
+       OR strpos(lower(t.description), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.merchant), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.category), lower(sqlc.arg(text)::text)) > 0
+       OR strpos(lower(t.note), lower(sqlc.arg(text)::text)) > 0)
+ORDER BY t.input_date DESC, t.id
+LIMIT sqlc.arg(row_limit)::bigint OFFSET sqlc.arg(row_offset)::bigint;
@@ -0,0 +1,166 @@
+-- name: RefreshMonthlyAccountSummary :exec
+-- Recomputes the totals of an account and month from its posted,
+-- current, non-voided transactions, the same way ListSummaryDays does. A month left
+-- without transactions keeps a row of zeros.
+INSERT INTO monthly_account_summary (account_id, month, total_count, amount, credit_count, credits,
+                                     debit_count, debits, refund_count, refunds, installments, refreshed_at)
+SELECT sqlc.arg(account_id)::uuid,
+       sqlc.arg(month)::date,
+       COUNT(t.id),
+       COALESCE(SUM(t.amount), 0),
+       COUNT(t.id) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NULL),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NULL), 0),
+       COUNT(t.id) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount <= 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount <= 0), 0),
+       COUNT(t.id) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NOT NULL),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type NOT IN ('installment', 'installment_conversion')
+                             AND t.amount > 0 AND t.reversal_of IS NOT NULL), 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'installment'), 0),
+       sqlc.arg(refreshed_at)::bigint
+FROM transactions t
+WHERE t.account_id = sqlc.arg(account_id)::uuid
+  AND t.input_date >= sqlc.arg(month)::date
+  AND t.input_date < sqlc.arg(month)::date + INTERVAL '1 month'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.status = 'posted'
+ON CONFLICT (account_id, month) DO UPDATE
+SET total_count = EXCLUDED.total_count,
+    amount = EXCLUDED.amount,
+    credit_count = EXCLUDED.credit_count,
+    credits = EXCLUDED.credits,
+    debit_count = EXCLUDED.debit_count,
+    debits = EXCLUDED.debits,
+    refund_count = EXCLUDED.refund_count,
+    refunds = EXCLUDED.refunds,
+    installments = EXCLUDED.installments,
+    refreshed_at = EXCLUDED.refreshed_at;
+
+-- name: DeleteMonthlyCategorySummary :exec
+DELETE FROM monthly_category_summary
+WHERE account_id = sqlc.arg(account_id) AND month = sqlc.arg(month);
+
+-- name: InsertMonthlyCategorySummary :exec
+-- Recomputes the spend per category of an account and month, as
+-- ListSummaryCategories does.
+INSERT INTO monthly_category_summary (account_id, month, category, total, count)
+SELECT sqlc.arg(account_id)::uuid,
+       sqlc.arg(month)::date,
+       CASE WHEN COALESCE(s.category, t.category) = '' THEN 'uncategorized'
+            ELSE COALESCE(s.category, t.category) END,
+       SUM(-COALESCE(s.amount, t.amount)),
+       COUNT(DISTINCT t.id)
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+WHERE t.account_id = sqlc.arg(account_id)::uuid
+  AND t.input_date >= sqlc.arg(month)::date
+  AND t.input_date < sqlc.arg(month)::date + INTERVAL '1 month'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.status = 'posted'
+  AND t.amount < 0
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 3;
+
+-- name: DeleteMonthlyMerchantSummary :exec
+DELETE FROM monthly_merchant_summary
+WHERE account_id = sqlc.arg(account_id) AND month = sqlc.arg(month);
+
+-- name: InsertMonthlyMerchantSummary :exec
+-- Recomputes the spend per merchant of an account and month, as
+-- ListSummaryMerchants does.
+INSERT INTO monthly_merchant_summary (account_id, month, merchant, category, total, count)
+SELECT sqlc.arg(account_id)::uuid,
+       sqlc.arg(month)::date,
+       t.merchant,
+       (array_agg(t.category ORDER BY t.input_date, t.id))[1],
+       SUM(-t.amount),
+       COUNT(*)
+FROM transactions t
+WHERE t.account_id = sqlc.arg(account_id)::uuid
+  AND t.input_date >= sqlc.arg(month)::date
+  AND t.input_date < sqlc.arg(month)::date + INTERVAL '1 month'
+  AND t.voided = false AND t.superseded_by IS NULL
+  AND t.status = 'posted'
+  AND t.amount < 0
+  AND t.merchant <> ''
+  AND t.type NOT IN ('installment', 'installment_conversion')
+GROUP BY 3;
+
+-- name: ListSummaryProjectionMonths :many
+-- Every account and month with transactions or already projected, to
+-- rebuild the projection.
+SELECT t.account_id, date_trunc('month', t.input_date)::date AS month
+FROM transactions t
+UNION
+SELECT p.account_id, p.month
+FROM monthly_account_summary p
+ORDER BY 1, 2;
+
+-- name: ListProjectedMonths :many
+SELECT month,
+       total_count,
+       amount::float8 AS amount,
+       credit_count,
+       credits::float8 AS credits,
+       debit_count,
+       debits::float8 AS debits,
+       refund_count,
+       refunds::float8 AS refunds,
+       installments::float8 AS installments
+FROM monthly_account_summary
+WHERE account_id = sqlc.arg(account_id)
+  AND total_count > 0
+  AND (sqlc.narg(from_date)::date IS NULL OR month >= sqlc.narg(from_date)::date)
+  AND (sqlc.narg(to_date)::date IS NULL OR month < sqlc.narg(to_date)::date)
+ORDER BY month;
+
+-- name: ListProjectedCategories :many
+SELECT month, category, total::float8 AS total, count
+FROM monthly_category_summary
+WHERE account_id = sqlc.arg(account_id)
+  AND (sqlc.narg(from_date)::date IS NULL OR month >= sqlc.narg(from_date)::date)
+  AND (sqlc.narg(to_date)::date IS NULL OR month < sqlc.narg(to_date)::date)
+ORDER BY month, total DESC, category;
+
+-- name: ListProjectedMerchants :many
+-- The merchants with the most spend of every month, up to merchant_limit
+-- per month.
+SELECT m.month, m.merchant, m.category, m.total, m.count
+FROM (
+    SELECT month, merchant, category, total::float8 AS total, count,
+           ROW_NUMBER() OVER (PARTITION BY month ORDER BY total DESC, merchant) AS rank
+    FROM monthly_merchant_summary
+    WHERE account_id = sqlc.arg(account_id)
+      AND (sqlc.narg(from_date)::date IS NULL OR month >= sqlc.narg(from_date)::date)
+      AND (sqlc.narg(to_date)::date IS NULL OR month < sqlc.narg(to_date)::date)
+) m
+WHERE m.rank <= sqlc.arg(merchant_limit)::bigint
+ORDER BY m.month, m.rank;
+
+-- name: MarkSummaryMonthStale :exec
+-- Queues a month for the next flush, in the database transaction of the
+-- write that changed it.
+INSERT INTO stale_summary_months (account_id, month, marked_at)
+VALUES (sqlc.arg(account_id), sqlc.arg(month), sqlc.arg(marked_at))
+ON CONFLICT (account_id, month) DO UPDATE
+SET version = stale_summary_months.version + 1,
+    marked_at = EXCLUDED.marked_at;
+
+-- name: GetStaleSummaryMonthVersion :one
+SELECT version FROM stale_summary_months
+WHERE account_id = sqlc.arg(account_id) AND month = sqlc.arg(month);
+
+-- name: DeleteStaleSummaryMonth :exec
+-- Dequeues a refreshed month unless it was written to since the refresh
+-- read its version.
+DELETE FROM stale_summary_months
+WHERE account_id = sqlc.arg(account_id) AND month = sqlc.arg(month) AND version = sqlc.arg(version);
+
+-- name: ListStaleSummaryMonths :many
+SELECT account_id, month FROM stale_summary_months
+ORDER BY marked_at, account_id, month
+LIMIT sqlc.arg('limit')::bigint;
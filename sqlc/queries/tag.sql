@@ -0,0 +1,18 @@
+-- name: UpdateTransactionNote :exec
+UPDATE transactions
+SET note = $2, updated_at = $3
+WHERE id = $1;
+
+-- name: AddTransactionTag :exec
+INSERT INTO transaction_tags (transaction_id, tag, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (transaction_id, tag) DO NOTHING;
+
+-- name: DeleteTransactionTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id = $1;
+
+-- name: ListTransactionTags :many
+SELECT tag FROM transaction_tags
+WHERE transaction_id = $1
+ORDER BY tag;
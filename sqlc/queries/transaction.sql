@@ -1,6 +1,6 @@
 -- name: CreateTransaction :one
-INSERT INTO transactions (id, account_id, amount, type, input_file_id, input_date, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO transactions (id, account_id, amount, type, input_file_id, input_date, created_at, description, merchant, category, transfer_id, updated_at, status, authorized_at, posted_at, installment_plan_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 RETURNING *;
 
 -- name: GetTransaction :one
@@ -9,15 +9,56 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListTransactionsByAccount :many
 SELECT * FROM transactions
-WHERE account_id = $1
+WHERE account_id = $1 AND superseded_by IS NULL
 ORDER BY created_at
 LIMIT $2 OFFSET $3;
 
--- name: GetTransactionSummary :one
-SELECT
-    SUM(CASE WHEN type = 'credit' THEN amount ELSE -amount END) as total_balance,
-    COUNT(*) as transaction_count,
-    AVG(CASE WHEN type = 'credit' THEN amount ELSE NULL END) as average_credit,
-    AVG(CASE WHEN type = 'debit' THEN amount ELSE NULL END) as average_debit
-FROM transactions
-WHERE account_id = $1;
+-- name: ListTransactions :many
+SELECT * FROM transactions
+WHERE superseded_by IS NULL
+ORDER BY created_at, id
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateTransactionMerchant :exec
+UPDATE transactions
+SET merchant = $2, category = $3
+WHERE id = $1;
+
+-- name: GetTransactionForUpdate :one
+SELECT * FROM transactions
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListPendingAuthorizations :many
+SELECT * FROM transactions
+WHERE account_id = sqlc.arg(account_id)
+  AND status = 'pending'
+  AND authorized_at BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+ORDER BY authorized_at, id;
+
+-- name: CreateImportRow :exec
+INSERT INTO import_rows (account_id, import_key, transaction_id, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListImportedKeys :many
+-- The keys among import_keys of rows the account already imported.
+SELECT import_key FROM import_rows
+WHERE account_id = sqlc.arg(account_id) AND import_key = ANY(sqlc.arg(import_keys)::text[]);
+
+-- name: SettleTransaction :one
+UPDATE transactions
+SET amount = $2, type = $3, input_date = $4, posted_at = $5, status = 'posted', updated_at = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateTransactionStatus :one
+UPDATE transactions
+SET status = $2, updated_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ExpirePendingTransactions :many
+UPDATE transactions
+SET status = 'expired', updated_at = sqlc.arg(updated_at)
+WHERE status = 'pending' AND authorized_at < sqlc.arg(authorized_before)
+RETURNING *;
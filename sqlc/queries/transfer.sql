@@ -0,0 +1,13 @@
+-- name: CreateTransfer :one
+INSERT INTO transfers (id, source_account_id, destination_account_id, amount, description, idempotency_key, debit_transaction_id, credit_transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (idempotency_key) DO NOTHING
+RETURNING *;
+
+-- name: GetTransfer :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferByIdempotencyKey :one
+SELECT * FROM transfers
+WHERE idempotency_key = $1 LIMIT 1;
@@ -105,6 +105,11 @@ document.addEventListener('DOMContentLoaded', () => {
                         <p>Operaciones: $${data.total_transactions}</p>
                         <p>Promedio Credito: $${data.average_credit.toFixed(2)}</p>
                         <p>Promedio Debito: $${data.average_debit.toFixed(2)}</p>
+                        ${data.top_merchants && data.top_merchants.length ? `
+                        <h4>Principales Comercios</h4>
+                        <ul>
+                            ${data.top_merchants.map(m => `<li>${m.merchant}: $${m.total.toFixed(2)} (${m.count})</li>`).join('')}
+                        </ul>` : ''}
                         <ul>
                             ${data.transactions.map(t => `<li>$${t.amount} (${new Date(t.input_date).toLocaleDateString()})</li>`).join('')}
                         </ul>
@@ -239,12 +244,19 @@ document.addEventListener('DOMContentLoaded', () => {
     }
 
     // Conexión al servidor de WebSockets
-    const ws = new WebSocket(`ws://${window.location.host}/ws/?userID=${getOrCreateUserId()}`);
+    const socket = new WebSocket(`ws://${window.location.host}/ws/?userID=${getOrCreateUserId()}`);
 
     socket.onmessage = function(event) {
         const data = JSON.parse(event.data);
-        if (data.type === 'transaction_update') {
+        if (data.type === 'import_summary') {
+            const fileImport = data.summary.Import;
+            showNotification(`${fileImport.FileName}: ${fileImport.TransactionCount} importadas, ${fileImport.RejectedCount} rechazadas, ${fileImport.DuplicateCount} duplicadas`, 'info');
             updateTransactionUI(data.summary);
+        } else if (data.type === 'transfer_completed') {
+            showNotification(`Transferencia de $${data.transfer.Amount.toFixed(2)} completada`, 'success');
+        } else if (data.type === 'transaction_status') {
+            const amount = data.change.Transaction.Amount.toFixed(2);
+            showNotification(`Transacción de $${amount}: ${data.change.From} → ${data.change.To}`, 'info');
         }
     };
 
@@ -252,7 +264,7 @@ document.addEventListener('DOMContentLoaded', () => {
         console.log('WebSocket connection closed:', event);
     };
 
-    function updateTransactionUI(summary) {
+    function updateTransactionUI(importSummary) {
 
     }
 